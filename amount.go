@@ -0,0 +1,219 @@
+package xrplsale
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// dropsPerXRP is the number of drops in one XRP
+const dropsPerXRP = 1_000_000
+
+// maxAmountExponent bounds the exponent accepted by ParseAmount
+const maxAmountExponent = 64
+
+// Amount is an exact decimal quantity of XRP or tokens. The API sends
+// amounts as strings or JSON numbers; Amount decodes both without going
+// through float64 and always encodes as a string. The zero value is zero.
+type Amount struct {
+	r *big.Rat
+}
+
+// ParseAmount parses a decimal amount such as "123456789.123456" or "1e-6"
+func ParseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	// big.Rat also takes fractions, base prefixes such as 0x and underscores
+	if s == "" || strings.IndexFunc(s, notDecimal) >= 0 {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	// Bound exponents so input such as "1e1000000000" cannot exhaust memory
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil || exp > maxAmountExponent || exp < -maxAmountExponent {
+			return Amount{}, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || !terminates(r) {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	return Amount{r: r}, nil
+}
+
+// MustParseAmount is like ParseAmount but panics on invalid input. It is
+// meant for constants in code and tests.
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// AmountFromDrops returns the XRP amount of drops
+func AmountFromDrops(drops int64) Amount {
+	return Amount{r: big.NewRat(drops, dropsPerXRP)}
+}
+
+// rat returns a's value; callers must not modify it
+func (a Amount) rat() *big.Rat {
+	if a.r == nil {
+		return new(big.Rat)
+	}
+	return a.r
+}
+
+// XRP returns the amount as a decimal string of XRP
+func (a Amount) XRP() string {
+	return a.String()
+}
+
+// Drops returns the amount in drops. It fails if the amount has a fraction
+// of a drop or does not fit in an int64.
+func (a Amount) Drops() (int64, error) {
+	drops := new(big.Rat).Mul(a.rat(), big.NewRat(dropsPerXRP, 1))
+	if !drops.IsInt() || !drops.Num().IsInt64() {
+		return 0, fmt.Errorf("amount %s is not a whole number of drops", a)
+	}
+	return drops.Num().Int64(), nil
+}
+
+// Rat returns a copy of the amount as a big.Rat
+func (a Amount) Rat() *big.Rat {
+	return new(big.Rat).Set(a.rat())
+}
+
+// Add returns a + b
+func (a Amount) Add(b Amount) Amount {
+	return Amount{r: new(big.Rat).Add(a.rat(), b.rat())}
+}
+
+// Sub returns a - b
+func (a Amount) Sub(b Amount) Amount {
+	return Amount{r: new(big.Rat).Sub(a.rat(), b.rat())}
+}
+
+// Mul returns a * b, e.g. a token count times a price
+func (a Amount) Mul(b Amount) Amount {
+	return Amount{r: new(big.Rat).Mul(a.rat(), b.rat())}
+}
+
+// Cmp returns -1, 0 or 1 as a is less than, equal to or greater than b
+func (a Amount) Cmp(b Amount) int {
+	return a.rat().Cmp(b.rat())
+}
+
+// Equal reports whether a and b are the same amount
+func (a Amount) Equal(b Amount) bool {
+	return a.Cmp(b) == 0
+}
+
+// Sign returns -1, 0 or 1 as a is negative, zero or positive
+func (a Amount) Sign() int {
+	return a.rat().Sign()
+}
+
+// IsZero reports whether a is zero
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// String returns the exact decimal representation without trailing zeros
+func (a Amount) String() string {
+	r := a.rat()
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	return r.FloatString(decimalPlaces(r.Denom()))
+}
+
+// MarshalJSON implements json.Marshaler, always encoding a string
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a string, a number or null
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*a = Amount{}
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return a.unmarshalString(s)
+	}
+	parsed, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// unmarshalString decodes an amount sent as a string, "" being zero
+func (a *Amount) unmarshalString(s string) error {
+	if s == "" {
+		*a = Amount{}
+		return nil
+	}
+	parsed, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (a *Amount) UnmarshalText(text []byte) error {
+	parsed, err := ParseAmount(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// notDecimal reports whether r cannot appear in a decimal amount
+func notDecimal(r rune) bool {
+	return !strings.ContainsRune("0123456789.+-eE", r)
+}
+
+// terminates reports whether r has a finite decimal expansion
+func terminates(r *big.Rat) bool {
+	return decimalPlaces(r.Denom()) >= 0
+}
+
+// decimalPlaces returns the number of decimal places needed to write 1/denom
+// exactly, or -1 when the expansion does not terminate
+func decimalPlaces(denom *big.Int) int {
+	twos := denom.TrailingZeroBits()
+	d := new(big.Int).Rsh(denom, twos)
+	
+	five, rem := big.NewInt(5), new(big.Int)
+	fives := 0
+	for {
+		q, m := new(big.Int).QuoRem(d, five, rem)
+		if m.Sign() != 0 {
+			break
+		}
+		d = q
+		fives++
+	}
+	if !d.IsInt64() || d.Int64() != 1 {
+		return -1
+	}
+	return max(int(twos), fives)
+}
\ No newline at end of file
@@ -0,0 +1,117 @@
+package xrplsale_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestAmountJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		want      string
+		wantDrops int64
+		dropsErr  bool
+	}{
+		{"string", `"123456789.123456"`, "123456789.123456", 123456789123456, false},
+		{"number", `123456789.123456`, "123456789.123456", 123456789123456, false},
+		{"max XRP supply", `"100000000000"`, "100000000000", 100000000000000000, false},
+		{"max XRP supply as number", `100000000000.000000`, "100000000000", 100000000000000000, false},
+		{"one drop", `"0.000001"`, "0.000001", 1, false},
+		{"exponent", `1e-6`, "0.000001", 1, false},
+		{"trailing zeros", `"10.500000"`, "10.5", 10500000, false},
+		{"negative", `"-2.25"`, "-2.25", -2250000, false},
+		{"fraction of a drop", `"0.0000001"`, "0.0000001", 0, true},
+		{"beyond int64 drops", `"10000000000000"`, "10000000000000", 0, true},
+		{"token amount", `"0.333333333333333333"`, "0.333333333333333333", 0, true},
+		{"empty string", `""`, "0", 0, false},
+		{"null", `null`, "0", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a xrplsale.Amount
+			if err := json.Unmarshal([]byte(tt.in), &a); err != nil {
+				t.Fatal(err)
+			}
+			if a.String() != tt.want || a.XRP() != tt.want {
+				t.Fatalf("decoded %s as %s, want %s", tt.in, a, tt.want)
+			}
+			out, err := json.Marshal(a)
+			if err != nil || string(out) != `"`+tt.want+`"` {
+				t.Fatalf("Marshal() = %s, %v; want the string %q", out, err, tt.want)
+			}
+			var again xrplsale.Amount
+			if err := json.Unmarshal(out, &again); err != nil || !again.Equal(a) {
+				t.Fatalf("round trip gave %s, %v", again, err)
+			}
+			drops, err := a.Drops()
+			if (err != nil) != tt.dropsErr || drops != tt.wantDrops {
+				t.Fatalf("Drops() = %d, %v; want %d, error %v", drops, err, tt.wantDrops, tt.dropsErr)
+			}
+			if err == nil && !xrplsale.AmountFromDrops(drops).Equal(a) {
+				t.Fatalf("AmountFromDrops(%d) = %s, want %s", drops, xrplsale.AmountFromDrops(drops), a)
+			}
+		})
+	}
+}
+
+func TestParseAmountInvalid(t *testing.T) {
+	for _, in := range []string{"", " ", "abc", "1/3", "1e1000000000", "1e-65", "0x10", "0b101", "0o17", "1_000", "0x1p-2", "1.2.3", "NaN", "Inf"} {
+		if a, err := xrplsale.ParseAmount(in); err == nil {
+			t.Errorf("ParseAmount(%q) = %s, want an error", in, a)
+		}
+	}
+	for _, in := range []string{`"abc"`, `true`, `{}`, `[1]`} {
+		var a xrplsale.Amount
+		if err := json.Unmarshal([]byte(in), &a); err == nil {
+			t.Errorf("Unmarshal(%s) = %s, want an error", in, a)
+		}
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a, b := xrplsale.MustParseAmount("0.1"), xrplsale.MustParseAmount("0.2")
+	tests := []struct {
+		name string
+		got  xrplsale.Amount
+		want string
+	}{
+		// Exact where float64 gives 0.30000000000000004
+		{"add", a.Add(b), "0.3"},
+		{"sub", a.Sub(b), "-0.1"},
+		{"mul", xrplsale.MustParseAmount("1500").Mul(xrplsale.MustParseAmount("0.000333")), "0.4995"},
+		{"zero value", xrplsale.Amount{}.Add(a), "0.1"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+	
+	if a.Cmp(b) != -1 || b.Cmp(a) != 1 || a.Cmp(xrplsale.MustParseAmount("0.100")) != 0 {
+		t.Error("Cmp() disagrees with the decimal order")
+	}
+	if !(xrplsale.Amount{}).IsZero() || a.Sub(a).Sign() != 0 || a.Sub(b).Sign() != -1 || a.Sign() != 1 {
+		t.Error("Sign() or IsZero() disagrees with the value")
+	}
+	
+	// Rat returns a copy the caller may modify
+	r := a.Rat()
+	r.SetInt64(7)
+	if a.String() != "0.1" {
+		t.Fatalf("modifying Rat() changed the amount to %s", a)
+	}
+}
+
+func TestInvestmentAmounts(t *testing.T) {
+	var investment xrplsale.Investment
+	err := json.Unmarshal([]byte(`{"id":"inv_1","amount_xrp":99999999999.999999,"token_amount":"123456789.123456"}`), &investment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if investment.AmountXRP.String() != "99999999999.999999" || investment.TokenAmount.String() != "123456789.123456" {
+		t.Fatalf("decoded amounts %s and %s, want them exact", investment.AmountXRP, investment.TokenAmount)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,40 @@
+package xrplsale
+
+import "time"
+
+// Announcement is an update post shown on a project's sale page
+type Announcement struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"project_id"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"` // Markdown
+	Pinned    bool      `json:"pinned"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	
+	// NotifiedSubscribers is the number of investors the platform will notify
+	// about this announcement; zero unless it was created with Notify
+	NotifiedSubscribers int `json:"notified_subscribers"`
+}
+
+// CreateAnnouncementRequest describes an announcement to publish
+type CreateAnnouncementRequest struct {
+	Title  string `json:"title"`
+	Body   string `json:"body"` // Markdown
+	Pinned bool   `json:"pinned,omitempty"`
+	
+	// Notify sends the platform's investor notification for this announcement
+	Notify bool `json:"notify,omitempty"`
+}
+
+// ListAnnouncementsOptions represents options for listing announcements
+type ListAnnouncementsOptions struct {
+	Pinned *bool `url:"pinned"`
+	Page   int   `url:"page,positive"`
+	Limit  int   `url:"limit,positive"`
+}
+
+// params returns the options as query parameters
+func (o *ListAnnouncementsOptions) params() map[string]string {
+	return encodeQuery(o)
+}
\ No newline at end of file
@@ -0,0 +1,153 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+type announcementCall struct {
+	method, path, query string
+	body                map[string]interface{}
+}
+
+// announcementServer records each request and answers with response
+func announcementServer(t *testing.T, response string) (*xrplsale.Client, *announcementCall) {
+	t.Helper()
+	last := &announcementCall{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		*last = announcementCall{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery}
+		if len(raw) > 0 {
+			json.Unmarshal(raw, &last.body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	return xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL}), last
+}
+
+func TestCreateAnnouncement(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  xrplsale.CreateAnnouncementRequest
+		response string
+		wantBody map[string]interface{}
+		wantSent int
+	}{
+		{"plain",
+			xrplsale.CreateAnnouncementRequest{Title: "AMA", Body: "**Friday**"},
+			`{"id":"ann_1","project_id":"proj_1","title":"AMA","body":"**Friday**"}`,
+			map[string]interface{}{"title": "AMA", "body": "**Friday**"}, 0},
+		{"pinned with notification",
+			xrplsale.CreateAnnouncementRequest{Title: "Tier 2", Body: "Open now", Pinned: true, Notify: true},
+			`{"id":"ann_2","project_id":"proj_1","title":"Tier 2","body":"Open now","pinned":true,"notified_subscribers":42}`,
+			map[string]interface{}{"title": "Tier 2", "body": "Open now", "pinned": true, "notify": true}, 42},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, last := announcementServer(t, tt.response)
+			announcement, err := client.Projects.CreateAnnouncement(context.Background(), "proj_1", &tt.request)
+			if err != nil {
+				t.Fatalf("CreateAnnouncement: %v", err)
+			}
+			if last.method != http.MethodPost || last.path != "/projects/proj_1/announcements" {
+				t.Errorf("sent %s %s, want POST /projects/proj_1/announcements", last.method, last.path)
+			}
+			if len(last.body) != len(tt.wantBody) {
+				t.Errorf("body = %v, want %v", last.body, tt.wantBody)
+			}
+			for key, want := range tt.wantBody {
+				if last.body[key] != want {
+					t.Errorf("body[%q] = %v, want %v", key, last.body[key], want)
+				}
+			}
+			if announcement.Pinned != tt.request.Pinned || announcement.NotifiedSubscribers != tt.wantSent {
+				t.Errorf("got pinned %t, %d notified; want %t, %d", announcement.Pinned, announcement.NotifiedSubscribers, tt.request.Pinned, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestAnnouncementRequests(t *testing.T) {
+	ctx := context.Background()
+	pinned := true
+	page := `{"data":[{"id":"ann_1","pinned":true}],"pagination":{"page":2,"limit":10,"total":11,"total_pages":2}}`
+	
+	tests := []struct {
+		name      string
+		response  string
+		call      func(*xrplsale.Client) error
+		wantCall  announcementCall
+		checkBody bool
+	}{
+		{"ListAnnouncements", page, func(client *xrplsale.Client) error {
+			result, err := client.Projects.ListAnnouncements(ctx, "proj_1", &xrplsale.ListAnnouncementsOptions{Pinned: &pinned, Page: 2, Limit: 10})
+			if err == nil && (len(result.Data) != 1 || result.Data[0].ID != "ann_1") {
+				t.Errorf("ListAnnouncements decoded %+v", result.Data)
+			}
+			return err
+		}, announcementCall{method: "GET", path: "/projects/proj_1/announcements", query: "limit=10&page=2&pinned=true"}, false},
+		{"ListAnnouncements without options", page, func(client *xrplsale.Client) error {
+			_, err := client.Projects.ListAnnouncements(ctx, "proj_1", nil)
+			return err
+		}, announcementCall{method: "GET", path: "/projects/proj_1/announcements"}, false},
+		{"UpdateAnnouncement", `{"id":"ann_1","pinned":false}`, func(client *xrplsale.Client) error {
+			result, err := client.Projects.UpdateAnnouncement(ctx, "proj_1", "ann_1", map[string]interface{}{"pinned": false})
+			if err == nil && result.ID != "ann_1" {
+				t.Errorf("UpdateAnnouncement decoded %+v", result)
+			}
+			return err
+		}, announcementCall{method: "PATCH", path: "/projects/proj_1/announcements/ann_1", body: map[string]interface{}{"pinned": false}}, true},
+		{"DeleteAnnouncement", `{}`, func(client *xrplsale.Client) error {
+			return client.Projects.DeleteAnnouncement(ctx, "proj_1", "ann_1")
+		}, announcementCall{method: "DELETE", path: "/projects/proj_1/announcements/ann_1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, last := announcementServer(t, tt.response)
+			if err := tt.call(client); err != nil {
+				t.Fatal(err)
+			}
+			if last.method != tt.wantCall.method || last.path != tt.wantCall.path || last.query != tt.wantCall.query {
+				t.Errorf("sent %s %s?%s, want %s %s?%s", last.method, last.path, last.query, tt.wantCall.method, tt.wantCall.path, tt.wantCall.query)
+			}
+			if tt.checkBody && (len(last.body) != 1 || last.body["pinned"] != false) {
+				t.Errorf("body = %v, want %v", last.body, tt.wantCall.body)
+			}
+		})
+	}
+}
+
+func TestAnnouncementPublishedEvent(t *testing.T) {
+	const secret = "whsec_test"
+	payload := []byte(`{"id":"evt_1","type":"project.announcement_published","created_at":"2024-05-01T12:00:00Z","data":{"project_id":"proj_1","announcement_id":"ann_2","title":"Tier 2","pinned":true,"notified_subscribers":42}}`)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", WebhookSecret: secret})
+	
+	var got xrplsale.AnnouncementPublishedPayload
+	handler := client.WebhookHandler(func(_ context.Context, event *xrplsale.WebhookEvent) error {
+		if event.Type != xrplsale.EventProjectAnnouncementPublished {
+			t.Errorf("Type = %q, want %q", event.Type, xrplsale.EventProjectAnnouncementPublished)
+		}
+		return event.DecodeData(&got)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(payload))
+	req.Header.Set(xrplsale.WebhookSignatureHeader, sign(secret, payload))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	want := xrplsale.AnnouncementPublishedPayload{ProjectID: "proj_1", AnnouncementID: "ann_2", Title: "Tier 2", Pinned: true, NotifiedSubscribers: 42}
+	if got != want {
+		t.Errorf("payload = %+v, want %+v", got, want)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,143 @@
+package xrplsale
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// The service interfaces list every method of the matching service, so code
+// that depends on them can be tested with the implementations in the mocks
+// package. Run go generate after changing them. The range-over-func
+// iterators need Go 1.23 and are declared in range_iter.go instead.
+
+//go:generate go run ./internal/mockgen -source api.go -out mocks/api.go
+
+// ProjectsAPI is implemented by ProjectsService
+type ProjectsAPI interface {
+	projectIterators
+	
+	List(ctx context.Context, opts *ListProjectsOptions, reqOpts ...RequestOption) (*PaginatedResponse[Project], error)
+	ListAll(ctx context.Context, opts *ListProjectsOptions, reqOpts ...RequestOption) ([]Project, error)
+	ForEach(ctx context.Context, opts *ListProjectsOptions, fn func(Project) error, reqOpts ...RequestOption) error
+	GetActive(ctx context.Context, page, limit int, reqOpts ...RequestOption) (*PaginatedResponse[Project], error)
+	Get(ctx context.Context, projectID string, reqOpts ...RequestOption) (*Project, error)
+	GetBySlug(ctx context.Context, slug string, reqOpts ...RequestOption) (*Project, error)
+	Search(ctx context.Context, query string, opts *ListProjectsOptions, reqOpts ...RequestOption) (*PaginatedResponse[Project], error)
+	Exists(ctx context.Context, projectID string, reqOpts ...RequestOption) (bool, error)
+	Create(ctx context.Context, project *CreateProjectRequest, reqOpts ...RequestOption) (*Project, error)
+	Update(ctx context.Context, projectID string, updates map[string]interface{}, reqOpts ...RequestOption) (*Project, error)
+	Launch(ctx context.Context, projectID string, reqOpts ...RequestOption) (*Project, error)
+	Provision(ctx context.Context, spec *ProjectSpec, reqOpts ...RequestOption) (*ProvisionResult, error)
+	GetStats(ctx context.Context, projectID string, reqOpts ...RequestOption) (*ProjectStats, error)
+	GetStatsHistory(ctx context.Context, projectID string, period Period, granularity Granularity, reqOpts ...RequestOption) (*StatsHistory, error)
+	BuildDistributionReport(ctx context.Context, projectID string, reqOpts ...RequestOption) (*DistributionReport, error)
+	WatchStats(ctx context.Context, projectID string, opts *WatchOptions) *Subscription[ProjectStats]
+	GetTokenDetails(ctx context.Context, projectID string, reqOpts ...RequestOption) (*TokenDetails, error)
+	
+	ListAnnouncements(ctx context.Context, projectID string, opts *ListAnnouncementsOptions, reqOpts ...RequestOption) (*PaginatedResponse[Announcement], error)
+	CreateAnnouncement(ctx context.Context, projectID string, announcement *CreateAnnouncementRequest, reqOpts ...RequestOption) (*Announcement, error)
+	UpdateAnnouncement(ctx context.Context, projectID, announcementID string, updates map[string]interface{}, reqOpts ...RequestOption) (*Announcement, error)
+	DeleteAnnouncement(ctx context.Context, projectID, announcementID string, reqOpts ...RequestOption) error
+	
+	GetMyPermissions(ctx context.Context, projectID string, reqOpts ...RequestOption) (*ProjectPermissions, error)
+	ListCollaborators(ctx context.Context, projectID string, reqOpts ...RequestOption) (*Collaborators, error)
+	InviteCollaborator(ctx context.Context, projectID string, invite *InviteCollaboratorRequest, reqOpts ...RequestOption) (*CollaboratorInvitation, error)
+	UpdateCollaboratorRole(ctx context.Context, projectID, collaboratorID string, role CollaboratorRole, reqOpts ...RequestOption) (*Collaborator, error)
+	RemoveCollaborator(ctx context.Context, projectID, collaboratorID string, reqOpts ...RequestOption) error
+	
+	UploadDocument(ctx context.Context, projectID, filename string, r io.Reader, docType DocumentType, reqOpts ...RequestOption) (*ProjectDocument, error)
+}
+
+// InvestmentsAPI is implemented by InvestmentsService
+type InvestmentsAPI interface {
+	investmentIterators
+	
+	Create(ctx context.Context, investment *CreateInvestmentRequest, reqOpts ...RequestOption) (*Investment, error)
+	CreateManual(ctx context.Context, investment *CreateManualInvestmentRequest, reqOpts ...RequestOption) (*Investment, error)
+	Get(ctx context.Context, investmentID string, reqOpts ...RequestOption) (*Investment, error)
+	GetByProject(ctx context.Context, projectID string, page, limit int, reqOpts ...RequestOption) (*PaginatedResponse[Investment], error)
+	IterateAllByProject(ctx context.Context, projectID string, reqOpts ...RequestOption) *Iterator[Investment]
+	ForEachByProject(ctx context.Context, projectID string, fn func(Investment) error, reqOpts ...RequestOption) error
+	ExportCSV(ctx context.Context, projectID string, w io.Writer, reqOpts ...RequestOption) (*InvestmentCSVExport, error)
+	StreamByProject(ctx context.Context, projectID string, opts *StreamOptions, reqOpts ...RequestOption) (*Stream[Investment], error)
+	WatchInvestment(ctx context.Context, investmentID string, opts *WatchOptions) *Subscription[Investment]
+	WaitForConfirmation(ctx context.Context, investmentID string, opts *ConfirmationOptions, reqOpts ...RequestOption) (*Investment, error)
+	GetInvestorSummary(ctx context.Context, investorAccount string, reqOpts ...RequestOption) (*InvestorSummary, error)
+	Simulate(ctx context.Context, simulation *SimulateInvestmentRequest, reqOpts ...RequestOption) (*SimulationResult, error)
+	CheckSpendableBalance(ctx context.Context, account string, amount CurrencyAmount, reqOpts ...RequestOption) (*BalanceCheck, error)
+	ReconcilePayments(ctx context.Context, projectID string, external []ExternalPayment, opts *PaymentReconciliationOptions, reqOpts ...RequestOption) (*ReconciliationReport, error)
+	
+	FlagDispute(ctx context.Context, investmentID string, dispute *DisputeRequest, reqOpts ...RequestOption) (*Dispute, error)
+	GetDispute(ctx context.Context, disputeID string, reqOpts ...RequestOption) (*Dispute, error)
+	ListDisputes(ctx context.Context, projectID string, opts *ListDisputesOptions, reqOpts ...RequestOption) (*PaginatedResponse[Dispute], error)
+	AttachEvidence(ctx context.Context, disputeID, filename string, r io.Reader, description string, reqOpts ...RequestOption) (*DisputeEvidence, error)
+}
+
+// AnalyticsAPI is implemented by AnalyticsService
+type AnalyticsAPI interface {
+	GetPlatformAnalytics(ctx context.Context, reqOpts ...RequestOption) (*PlatformAnalytics, error)
+	GetProjectAnalytics(ctx context.Context, projectID string, startDate, endDate time.Time, reqOpts ...RequestOption) (*ProjectAnalytics, error)
+	GetGeoDistribution(ctx context.Context, projectID string, dateRange *DateRange, reqOpts ...RequestOption) (*GeoDistribution, error)
+	GetTrends(ctx context.Context, period Period, reqOpts ...RequestOption) (*MarketTrends, error)
+	GetMarketTrends(ctx context.Context, period string, reqOpts ...RequestOption) (*MarketTrends, error)
+	ExportData(ctx context.Context, exportReq *ExportDataRequest, reqOpts ...RequestOption) (*ExportResult, error)
+	DownloadExport(ctx context.Context, exportID string, w io.Writer, reqOpts ...RequestOption) (int64, error)
+}
+
+// AuthAPI is implemented by AuthService
+type AuthAPI interface {
+	GenerateChallenge(ctx context.Context, walletAddress string, reqOpts ...RequestOption) (*AuthChallenge, error)
+	Authenticate(ctx context.Context, authReq *AuthRequest, reqOpts ...RequestOption) (*AuthResponse, error)
+	AuthenticateWithSigner(ctx context.Context, signer ChallengeSigner, reqOpts ...RequestOption) (*AuthResponse, error)
+	AuthenticateWithSeed(ctx context.Context, walletAddress, familySeed string, reqOpts ...RequestOption) (*AuthResponse, error)
+	AuthenticateWithPrivateKey(ctx context.Context, walletAddress, privateKey string, reqOpts ...RequestOption) (*AuthResponse, error)
+	AuthenticateServiceAccount(ctx context.Context, clientID, clientSecret string, reqOpts ...RequestOption) (*AuthResponse, error)
+	CreateXamanSignIn(ctx context.Context, reqOpts ...RequestOption) (*XamanSignInRequest, error)
+	GetXamanSignInStatus(ctx context.Context, uuid string, reqOpts ...RequestOption) (*XamanSignInStatus, error)
+	CompleteXamanSignIn(ctx context.Context, uuid string, reqOpts ...RequestOption) (*AuthResponse, error)
+	WaitForXamanSignIn(ctx context.Context, uuid string, pollInterval time.Duration, reqOpts ...RequestOption) (*AuthResponse, error)
+	Refresh(ctx context.Context, refreshToken string, reqOpts ...RequestOption) (*AuthResponse, error)
+	Logout(ctx context.Context, reqOpts ...RequestOption) error
+	VerifySession(ctx context.Context, reqOpts ...RequestOption) (*SessionStatus, error)
+	CreateStreamToken(ctx context.Context, reqOpts ...RequestOption) (*StreamToken, error)
+	ListSessions(ctx context.Context, reqOpts ...RequestOption) ([]*Session, error)
+	RevokeSession(ctx context.Context, sessionID string, reqOpts ...RequestOption) error
+	RevokeAllSessions(ctx context.Context, exceptCurrent bool, reqOpts ...RequestOption) error
+	Enable2FA(ctx context.Context, reqOpts ...RequestOption) (*TwoFactorSetup, error)
+	Confirm2FA(ctx context.Context, code string, reqOpts ...RequestOption) error
+	Disable2FA(ctx context.Context, code string, reqOpts ...RequestOption) error
+	Complete2FA(ctx context.Context, challengeToken, code string, reqOpts ...RequestOption) (*AuthResponse, error)
+	GetProfile(ctx context.Context, reqOpts ...RequestOption) (*UserProfile, error)
+	UpdateProfile(ctx context.Context, update *UpdateProfileRequest, reqOpts ...RequestOption) (*UserProfile, error)
+	GetNotificationPreferences(ctx context.Context, reqOpts ...RequestOption) (*NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, update *NotificationPreferencesUpdate, reqOpts ...RequestOption) (*NotificationPreferences, error)
+	HasScope(scope string) bool
+	Scopes() []string
+	LoadScopes(ctx context.Context, reqOpts ...RequestOption) ([]string, error)
+}
+
+// WebhooksAPI is implemented by WebhooksService
+type WebhooksAPI interface {
+	Register(ctx context.Context, webhook *RegisterWebhookRequest, reqOpts ...RequestOption) (*Webhook, error)
+	EnsureRegistered(ctx context.Context, req RegisterWebhookRequest, reqOpts ...RequestOption) (*Webhook, error)
+	Reconcile(ctx context.Context, desired []RegisterWebhookRequest, opts *ReconcileOptions, reqOpts ...RequestOption) (*ReconcileResult, error)
+	List(ctx context.Context, reqOpts ...RequestOption) ([]*Webhook, error)
+	ListPaged(ctx context.Context, page, limit int, reqOpts ...RequestOption) (*PaginatedResponse[Webhook], error)
+	Get(ctx context.Context, webhookID string, reqOpts ...RequestOption) (*Webhook, error)
+	Exists(ctx context.Context, webhookID string, reqOpts ...RequestOption) (bool, error)
+	Update(ctx context.Context, webhookID string, updates map[string]interface{}, reqOpts ...RequestOption) (*Webhook, error)
+	Delete(ctx context.Context, webhookID string, reqOpts ...RequestOption) error
+	Test(ctx context.Context, webhookID string, reqOpts ...RequestOption) error
+	GetDeliveries(ctx context.Context, webhookID string, page, limit int, reqOpts ...RequestOption) (*PaginatedResponse[WebhookDelivery], error)
+	StreamDeliveries(ctx context.Context, webhookID string, opts *StreamOptions, reqOpts ...RequestOption) (*Stream[WebhookDelivery], error)
+	PurgeDeliveries(ctx context.Context, webhookID string, olderThan time.Time, opts *PurgeOptions, reqOpts ...RequestOption) (*PurgeResult, error)
+}
+
+var (
+	_ ProjectsAPI    = (*ProjectsService)(nil)
+	_ InvestmentsAPI = (*InvestmentsService)(nil)
+	_ AnalyticsAPI   = (*AnalyticsService)(nil)
+	_ AuthAPI        = (*AuthService)(nil)
+	_ WebhooksAPI    = (*WebhooksService)(nil)
+)
\ No newline at end of file
@@ -0,0 +1,256 @@
+package xrplsale
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrSessionExpired is matched by the error Refresh returns when the API
+// rejects the refresh token, so the session must be re-established with
+// Authenticate. The error also matches ErrUnauthorized and the AuthError.
+var ErrSessionExpired = errors.New("session expired")
+
+// refreshFailureTTL is how long a failed refresh is remembered, so callers
+// retrying in a loop do not hammer the auth endpoint
+const refreshFailureTTL = 5 * time.Second
+
+// tokenRefresher serializes refreshes of one session's tokens; it is shared
+// by a client and the clones that inherited its session. Concurrent
+// refreshes share a single request, and since the API invalidates a refresh
+// token on first use, a refresh with a token that was already rotated
+// returns the rotation's result instead of being sent.
+type tokenRefresher struct {
+	mu       sync.Mutex
+	inflight *refreshCall
+	
+	// rotatedFrom is the refresh token consumed by the last successful
+	// refresh, whose result is last
+	rotatedFrom string
+	last        *AuthResponse
+	
+	// failedToken's refresh failed with failedErr at failedAt
+	failedToken string
+	failedErr   error
+	failedAt    time.Time
+}
+
+// refreshCall is a refresh in flight; done is closed once resp and err are set
+type refreshCall struct {
+	token string
+	done  chan struct{}
+	resp  *AuthResponse
+	err   error
+}
+
+// refresh exchanges token by calling do, unless an equivalent refresh is in
+// flight or has just completed. do runs detached from ctx so one caller
+// giving up does not fail the others waiting on it.
+func (r *tokenRefresher) refresh(ctx context.Context, token string, do func(context.Context) (*AuthResponse, error)) (*AuthResponse, error) {
+	r.mu.Lock()
+	for r.inflight != nil {
+		call := r.inflight
+		r.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if call.token == token {
+			return call.resp, call.err
+		}
+		r.mu.Lock()
+	}
+	
+	if token == r.rotatedFrom && r.last != nil {
+		resp := r.last
+		r.mu.Unlock()
+		return resp, nil
+	}
+	if token == r.failedToken && time.Since(r.failedAt) < refreshFailureTTL {
+		err := r.failedErr
+		r.mu.Unlock()
+		return nil, err
+	}
+	
+	call := &refreshCall{token: token, done: make(chan struct{})}
+	r.inflight = call
+	r.mu.Unlock()
+	
+	call.resp, call.err = do(context.WithoutCancel(ctx))
+	
+	r.mu.Lock()
+	if call.resp != nil {
+		// The token was rotated even if the caller's callback failed
+		r.rotatedFrom, r.last = token, call.resp
+		r.failedToken, r.failedErr = "", nil
+	} else {
+		r.failedToken, r.failedErr, r.failedAt = token, call.err, time.Now()
+	}
+	r.inflight = nil
+	r.mu.Unlock()
+	close(call.done)
+	
+	return call.resp, call.err
+}
+
+// refreshShared refreshes through c's refresher. When the tokens were
+// obtained by another client sharing it, or by an earlier refresh, do does
+// not run and c adopts them.
+func (c *Client) refreshShared(ctx context.Context, key string, do func(context.Context) (*AuthResponse, error)) (*AuthResponse, error) {
+	ran := false
+	resp, err := c.refresher.refresh(ctx, key, func(ctx context.Context) (*AuthResponse, error) {
+		ran = true
+		return do(ctx)
+	})
+	if !ran && err == nil && resp != nil && resp.Token != "" {
+		c.setTokens(resp.tokenSet())
+	}
+	return resp, err
+}
+
+// refreshError marks the rejection of a refresh token as ErrSessionExpired
+func refreshError(err error) error {
+	if errors.Is(err, ErrUnauthorized) {
+		return fmt.Errorf("%w: %w", ErrSessionExpired, err)
+	}
+	return err
+}
+
+// reset forgets past refreshes, so a logged out session's tokens are never
+// handed out again. A refresh in flight completes.
+func (r *tokenRefresher) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rotatedFrom, r.last = "", nil
+	r.failedToken, r.failedErr = "", nil
+}
+
+// DefaultRefreshSkew is how long before its expiry Config.AutoRefresh
+// refreshes the auth token by default
+const DefaultRefreshSkew = 30 * time.Second
+
+// autoRefreshKey marks the context of a request that must not trigger
+// AutoRefresh: a refresh started by AutoRefresh, which must not refresh
+// again, or a VerifySession, which must not change the session
+type autoRefreshKey struct{}
+
+// refreshIfExpiring refreshes the auth token when Config.AutoRefresh is set,
+// or the client authenticated as a service account, and the token is about
+// to expire. Failures go to Config.OnAuthError; the request then goes out
+// with the old token and gets the API's 401.
+func (c *Client) refreshIfExpiring(ctx context.Context) {
+	if ctx.Value(autoRefreshKey{}) != nil {
+		return
+	}
+	creds := c.credentials()
+	if !c.config.AutoRefresh && creds.serviceAccount == nil {
+		return
+	}
+	skew := c.config.RefreshSkew
+	if skew <= 0 {
+		skew = DefaultRefreshSkew
+	}
+	if creds.refreshToken == "" && creds.serviceAccount == nil {
+		return
+	}
+	if creds.expiresAt.IsZero() || time.Until(creds.expiresAt) > skew {
+		return
+	}
+	
+	if _, err := c.services.auth.Refresh(context.WithValue(ctx, autoRefreshKey{}, true), ""); err != nil {
+		c.authFailed("automatic token refresh failed", err)
+	}
+}
+
+// MaxReplayBodyBytes is the largest streamed request body buffered so the
+// request can be replayed under Config.RetryOnUnauthorized
+const MaxReplayBodyBytes = 1 << 20
+
+// unauthorizedRetryKey marks the context of a request replayed after a 401,
+// which is not replayed again
+type unauthorizedRetryKey struct{}
+
+// unauthorizedRetry reports whether ctx is a replay after a 401
+func unauthorizedRetry(ctx context.Context) bool {
+	return ctx.Value(unauthorizedRetryKey{}) != nil
+}
+
+// replayableBody buffers an io.Reader body of up to MaxReplayBodyBytes so
+// it can be sent again. A larger body is returned unbuffered with ok false;
+// any other body is marshaled anew for each send.
+func replayableBody(body interface{}) (replayBody interface{}, ok bool, err error) {
+	r, isReader := body.(io.Reader)
+	if !isReader {
+		return body, true, nil
+	}
+	buf, err := io.ReadAll(io.LimitReader(r, MaxReplayBodyBytes+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("reading request body: %w", err)
+	}
+	if len(buf) > MaxReplayBodyBytes {
+		return io.MultiReader(bytes.NewReader(buf), r), false, nil
+	}
+	return buf, true, nil
+}
+
+// renewRejectedToken renews the auth token a request was sent with after
+// the API rejected it with 401. It refreshes with Config.RetryOnUnauthorized
+// and re-exchanges a service account's credentials. It reports whether the
+// client now holds a different token to replay the request with.
+func (c *Client) renewRejectedToken(ctx context.Context, sent Secret, err error) bool {
+	if sent == "" || ctx.Value(autoRefreshKey{}) != nil || unauthorizedRetry(ctx) || !errors.Is(err, ErrUnauthorized) {
+		return false
+	}
+	creds := c.credentials()
+	if creds.authToken != sent {
+		// Another request renewed it meanwhile
+		return creds.authToken != ""
+	}
+	if !c.config.RetryOnUnauthorized && creds.serviceAccount == nil {
+		return false
+	}
+	if creds.refreshToken == "" && creds.serviceAccount == nil {
+		return false
+	}
+	if _, err := c.services.auth.Refresh(context.WithValue(ctx, autoRefreshKey{}, true), ""); err != nil {
+		c.authFailed("refreshing rejected token failed", err)
+		return false
+	}
+	return true
+}
+
+// authFailed reports a failed automatic refresh or sign-in to the logger
+// and Config.OnAuthError
+func (c *Client) authFailed(what string, err error) {
+	c.logger.Warnf("%s: %v", what, err)
+	if c.config.OnAuthError != nil {
+		c.config.OnAuthError(err)
+	}
+}
+
+// errNoRefreshToken is returned by Refresh when there is no token to exchange
+var errNoRefreshToken = errors.New("no refresh token: authenticate first or pass one")
+
+// storeTokens makes tokens the client's credentials, writes them to the
+// token store and hands them to Config.OnTokenRefresh. It runs before
+// waiting refreshes are released, so the rotated refresh token is persisted
+// before anyone can use it.
+func (c *Client) storeTokens(ctx context.Context, tokens *AuthResponse) error {
+	c.setTokens(tokens.tokenSet())
+	c.emitTokenRefreshed(tokens)
+	if err := c.saveTokens(ctx); err != nil {
+		return err
+	}
+	if c.config.OnTokenRefresh != nil {
+		if err := c.config.OnTokenRefresh(ctx, tokens); err != nil {
+			return fmt.Errorf("token refresh callback: %w", err)
+		}
+	}
+	c.notifyTokenRefresh(c.tokenSet())
+	return nil
+}
\ No newline at end of file
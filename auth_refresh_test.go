@@ -0,0 +1,158 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestConcurrentRefresh(t *testing.T) {
+	const goroutines = 100
+	getProfile := func(client *xrplsale.Client) error {
+		_, err := client.Auth.GetProfile(context.Background())
+		return err
+	}
+	refresh := func(client *xrplsale.Client) error {
+		_, err := client.Auth.Refresh(context.Background(), "")
+		return err
+	}
+	retryOnUnauthorized := func(c *xrplsale.Config) { c.RetryOnUnauthorized = true }
+	tests := []struct {
+		name      string
+		configure func(*xrplsale.Config)
+		// expiring issues tokens within the refresh skew of their expiry;
+		// expired makes the server reject the signed-in token
+		expiring, expired bool
+		call              func(*xrplsale.Client) error
+		// waits is set when every call waits on the refresh itself, rather
+		// than replaying with the tokens it stored
+		waits bool
+		fail  bool
+	}{
+		{"rejected token", retryOnUnauthorized, false, true, getProfile, false, false},
+		{"expiring token", func(c *xrplsale.Config) { c.AutoRefresh = true }, true, false, getProfile, false, false},
+		{"explicit refresh", func(*xrplsale.Config) {}, false, false, refresh, true, false},
+		{"failed refresh", retryOnUnauthorized, false, true, getProfile, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var persisted atomic.Int32
+			var authErrors atomic.Int32
+			srv, client, hits := newCountingClient(t, tt.configure, func(c *xrplsale.Config) {
+				c.OnTokenRefresh = func(context.Context, *xrplsale.AuthResponse) error {
+					// Waiters must not be released before the new tokens are saved
+					time.Sleep(20 * time.Millisecond)
+					persisted.Add(1)
+					return nil
+				}
+				c.OnAuthError = func(error) { authErrors.Add(1) }
+			})
+			if tt.expiring {
+				srv.SetTokenTTL(xrplsale.DefaultRefreshSkew / 2)
+			}
+			signIn(t, client)
+			if tt.expired {
+				srv.ExpireTokens()
+			}
+			if tt.fail {
+				srv.Fail(http.MethodPost, "/auth/refresh", xrplsaletest.Failure{Status: http.StatusUnauthorized})
+			}
+			
+			start := make(chan struct{})
+			errs := make(chan error, goroutines)
+			var wg sync.WaitGroup
+			for i := 0; i < goroutines; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					err := tt.call(client)
+					if err == nil && tt.waits && persisted.Load() == 0 {
+						err = errors.New("call returned before the refreshed tokens were persisted")
+					}
+					errs <- err
+				}()
+			}
+			close(start)
+			wg.Wait()
+			close(errs)
+			
+			if n := hits.count(http.MethodPost, "/auth/refresh"); n != 1 {
+				t.Fatalf("%d refresh calls from %d goroutines, want 1", n, goroutines)
+			}
+			failed := 0
+			for err := range errs {
+				if err != nil {
+					if !tt.fail {
+						t.Fatalf("GetProfile() = %v", err)
+					}
+					failed++
+				}
+			}
+			if tt.fail {
+				if failed != goroutines || persisted.Load() != 0 || authErrors.Load() == 0 {
+					t.Fatalf("%d calls failed, %d refreshes persisted, %d auth errors; want every call failed and none persisted", failed, persisted.Load(), authErrors.Load())
+				}
+				return
+			}
+			if persisted.Load() != 1 {
+				t.Fatalf("OnTokenRefresh ran %d times, want 1", persisted.Load())
+			}
+		})
+	}
+}
+func TestRefreshSessionExpired(t *testing.T) {
+	tests := []struct {
+		name         string
+		signIn       bool
+		refreshToken string
+		// spent has another client refresh first, so the refresh token was
+		// used; the client itself would replay that rotation
+		spent       bool
+		status      int
+		wantExpired bool
+	}{
+		{"unknown refresh token", true, "bogus", false, 0, true},
+		{"spent refresh token", true, "", true, 0, true},
+		{"server error", true, "", false, http.StatusInternalServerError, false},
+		{"no refresh token", false, "", false, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, _ := newCountingClient(t)
+			refreshToken := tt.refreshToken
+			if tt.signIn {
+				first := signIn(t, client)
+				if refreshToken == "" {
+					refreshToken = first.RefreshToken
+				}
+			}
+			if tt.spent {
+				if _, err := xrplsaletest.NewClient(srv).Auth.Refresh(context.Background(), refreshToken); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.status != 0 {
+				srv.Fail(http.MethodPost, "/auth/refresh", xrplsaletest.Failure{Status: tt.status})
+			}
+			
+			_, err := client.Auth.Refresh(context.Background(), refreshToken, xrplsale.WithNoRetry())
+			if err == nil {
+				t.Fatal("Refresh() succeeded, want an error")
+			}
+			if got := errors.Is(err, xrplsale.ErrSessionExpired); got != tt.wantExpired {
+				t.Fatalf("Refresh() = %v, matches ErrSessionExpired %v, want %v", err, got, tt.wantExpired)
+			}
+			if tt.wantExpired && !errors.Is(err, xrplsale.ErrUnauthorized) {
+				t.Errorf("Refresh() = %v, want it to match ErrUnauthorized too", err)
+			}
+		})
+	}
+}
\ No newline at end of file
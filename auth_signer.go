@@ -0,0 +1,132 @@
+package xrplsale
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xrplsale/go-sdk/internal/keypairs"
+)
+
+// ChallengeSigner signs sign-in challenges for
+// AuthService.AuthenticateWithSigner. Implement it to keep the key in an HSM
+// or a wallet app; WalletSigner holds it in memory.
+type ChallengeSigner interface {
+	// Address returns the classic address of the account signing in
+	Address() string
+	
+	// SignChallenge returns the hex signature of ChallengeMessage(challenge)
+	SignChallenge(ctx context.Context, challenge string) (string, error)
+}
+
+// ChallengeMessage returns the bytes a challenge signature covers: the
+// challenge text as UTF-8. As everywhere on the XRP Ledger, ed25519 keys sign
+// them directly and secp256k1 keys sign their SHA-512 half, with a canonical
+// DER signature.
+func ChallengeMessage(challenge string) []byte {
+	return []byte(challenge)
+}
+
+// WalletSigner is a ChallengeSigner holding an account's key in memory
+type WalletSigner struct {
+	keys    *keypairs.KeyPair
+	address string
+}
+
+// NewSeedSigner returns a signer for the master key of a family seed
+// ("s..." for secp256k1, "sEd..." for ed25519). Its address is the one the
+// key is the master key of.
+func NewSeedSigner(familySeed string) (*WalletSigner, error) {
+	keys, err := keypairs.FromSeed(familySeed)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletSigner{keys: keys, address: keys.Address()}, nil
+}
+
+// NewPrivateKeySigner returns a signer for a hex private key as printed by
+// XRPL tools: "ED" and 32 bytes for ed25519, or 32 bytes, optionally with a
+// "00" prefix, for secp256k1
+func NewPrivateKeySigner(privateKey string) (*WalletSigner, error) {
+	keys, err := keypairs.FromPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletSigner{keys: keys, address: keys.Address()}, nil
+}
+
+// ForAccount returns a copy of the signer signing in as address, for a key
+// set as the account's regular key
+func (s *WalletSigner) ForAccount(address string) (*WalletSigner, error) {
+	if !keypairs.ValidAddress(address) {
+		return nil, fmt.Errorf("invalid wallet address %q", address)
+	}
+	return &WalletSigner{keys: s.keys, address: address}, nil
+}
+
+// Address implements ChallengeSigner
+func (s *WalletSigner) Address() string { return s.address }
+
+// PublicKey returns the signer's public key in hex
+func (s *WalletSigner) PublicKey() string {
+	return strings.ToUpper(hex.EncodeToString(s.keys.PublicKey()))
+}
+
+// SignChallenge implements ChallengeSigner
+func (s *WalletSigner) SignChallenge(_ context.Context, challenge string) (string, error) {
+	return strings.ToUpper(hex.EncodeToString(s.keys.Sign(ChallengeMessage(challenge)))), nil
+}
+
+// AuthenticateWithSigner signs in as signer's account: it requests a
+// challenge, has signer sign it and authenticates with the signature
+func (as *AuthService) AuthenticateWithSigner(ctx context.Context, signer ChallengeSigner, reqOpts ...RequestOption) (*AuthResponse, error) {
+	address := signer.Address()
+	challenge, err := as.GenerateChallenge(ctx, address, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.Challenge == "" {
+		return nil, errors.New("auth challenge is empty")
+	}
+	signature, err := signer.SignChallenge(ctx, challenge.Challenge)
+	if err != nil {
+		return nil, fmt.Errorf("signing auth challenge: %w", err)
+	}
+	return as.Authenticate(ctx, &AuthRequest{
+		WalletAddress: address,
+		Signature:     signature,
+		Timestamp:     challenge.Timestamp,
+	}, reqOpts...)
+}
+
+// AuthenticateWithSeed signs in as walletAddress with the key of a family
+// seed, signing the challenge locally. walletAddress differs from the seed's
+// own address when the key is the account's regular key.
+func (as *AuthService) AuthenticateWithSeed(ctx context.Context, walletAddress, familySeed string, reqOpts ...RequestOption) (*AuthResponse, error) {
+	signer, err := NewSeedSigner(familySeed)
+	if err != nil {
+		return nil, err
+	}
+	return as.authenticateAs(ctx, walletAddress, signer, reqOpts)
+}
+
+// AuthenticateWithPrivateKey signs in as walletAddress with a hex private
+// key, see NewPrivateKeySigner and AuthenticateWithSeed
+func (as *AuthService) AuthenticateWithPrivateKey(ctx context.Context, walletAddress, privateKey string, reqOpts ...RequestOption) (*AuthResponse, error) {
+	signer, err := NewPrivateKeySigner(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return as.authenticateAs(ctx, walletAddress, signer, reqOpts)
+}
+
+// authenticateAs signs in as walletAddress with signer's key
+func (as *AuthService) authenticateAs(ctx context.Context, walletAddress string, signer *WalletSigner, reqOpts []RequestOption) (*AuthResponse, error) {
+	signer, err := signer.ForAccount(walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	return as.AuthenticateWithSigner(ctx, signer, reqOpts...)
+}
\ No newline at end of file
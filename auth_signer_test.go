@@ -0,0 +1,163 @@
+package xrplsale_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// Key vectors of the XRP Ledger's reference implementation
+const (
+	secpSeed    = "sp5fghtJtpUorTwvof1NpDXAzNwf5"
+	secpKey     = "00D78B9735C3F26501C7337B8A5727FD53A6EFDBC6AA55984F098488561F985E23"
+	secpAddress = "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1"
+	edSeed      = "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r"
+	edKey       = "EDB4C4E046826BD26190D09715FC31F4E6A728204EADD112905B08B14B7F15C4F3"
+	edPublicKey = "ED01FA53FA5A7E77798F882ECE20B1ABC00BB358A9E55A202D0D0676BD0CE37A63"
+	edAddress   = "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD"
+)
+
+const testChallenge = "xrpl.sale sign-in 1700000000"
+
+// challengeServer issues testChallenge and records the last sign-in request
+func challengeServer(t *testing.T) (*httptest.Server, func() xrplsale.AuthRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var last xrplsale.AuthRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth/challenge":
+			w.Write([]byte(`{"challenge":"` + testChallenge + `","timestamp":1700000000}`))
+		case "/auth/wallet":
+			mu.Lock()
+			json.NewDecoder(r.Body).Decode(&last)
+			mu.Unlock()
+			w.Write([]byte(`{"token":"token","refresh_token":"refresh"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() xrplsale.AuthRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return last
+	}
+}
+
+func TestAuthenticateWithKey(t *testing.T) {
+	// regularKeyOf is an account the vector keys are set as the regular key of
+	const regularKeyOf = secpAddress
+	tests := []struct {
+		name string
+		auth func(xrplsale.AuthAPI) (*xrplsale.AuthResponse, error)
+		// wantAddress is empty when signing in must fail before any request
+		wantAddress string
+		ed25519     bool
+	}{
+		{"secp256k1 seed", func(as xrplsale.AuthAPI) (*xrplsale.AuthResponse, error) {
+			return as.AuthenticateWithSeed(context.Background(), secpAddress, secpSeed)
+		}, secpAddress, false},
+		{"ed25519 seed", func(as xrplsale.AuthAPI) (*xrplsale.AuthResponse, error) {
+			return as.AuthenticateWithSeed(context.Background(), edAddress, edSeed)
+		}, edAddress, true},
+		{"secp256k1 private key", func(as xrplsale.AuthAPI) (*xrplsale.AuthResponse, error) {
+			return as.AuthenticateWithPrivateKey(context.Background(), secpAddress, secpKey)
+		}, secpAddress, false},
+		{"ed25519 private key", func(as xrplsale.AuthAPI) (*xrplsale.AuthResponse, error) {
+			return as.AuthenticateWithPrivateKey(context.Background(), edAddress, edKey)
+		}, edAddress, true},
+		{"regular key", func(as xrplsale.AuthAPI) (*xrplsale.AuthResponse, error) {
+			return as.AuthenticateWithSeed(context.Background(), regularKeyOf, edSeed)
+		}, regularKeyOf, true},
+		{"invalid seed", func(as xrplsale.AuthAPI) (*xrplsale.AuthResponse, error) {
+			return as.AuthenticateWithSeed(context.Background(), edAddress, "sNotASeed")
+		}, "", false},
+		{"invalid address", func(as xrplsale.AuthAPI) (*xrplsale.AuthResponse, error) {
+			return as.AuthenticateWithSeed(context.Background(), "rNotAnAddress", edSeed)
+		}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, last := challengeServer(t)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			response, err := tt.auth(client.Auth)
+			if tt.wantAddress == "" {
+				if err == nil || last().WalletAddress != "" {
+					t.Fatalf("signing in = %v with request %+v, want an error and no request", err, last())
+				}
+				return
+			}
+			if err != nil || response.Token != "token" {
+				t.Fatalf("signing in = %+v, %v", response, err)
+			}
+			req := last()
+			if req.WalletAddress != tt.wantAddress || req.Timestamp != 1700000000 {
+				t.Errorf("signed in with %+v, want %s and the challenge's timestamp", req, tt.wantAddress)
+			}
+			signature, err := hex.DecodeString(req.Signature)
+			if err != nil || strings.ToUpper(req.Signature) != req.Signature {
+				t.Fatalf("signature %q is not upper case hex", req.Signature)
+			}
+			if !tt.ed25519 {
+				// A DER sequence of two integers
+				if len(signature) < 8 || signature[0] != 0x30 || int(signature[1]) != len(signature)-2 {
+					t.Errorf("secp256k1 signature %X is not DER encoded", signature)
+				}
+				return
+			}
+			public, _ := hex.DecodeString(edPublicKey[2:])
+			if !ed25519.Verify(public, xrplsale.ChallengeMessage(testChallenge), signature) {
+				t.Errorf("ed25519 signature %X does not verify", signature)
+			}
+		})
+	}
+}
+
+func TestWalletSigner(t *testing.T) {
+	tests := []struct {
+		name        string
+		signer      func() (*xrplsale.WalletSigner, error)
+		wantAddress string
+	}{
+		{"secp256k1 seed", func() (*xrplsale.WalletSigner, error) { return xrplsale.NewSeedSigner(secpSeed) }, secpAddress},
+		{"secp256k1 private key", func() (*xrplsale.WalletSigner, error) { return xrplsale.NewPrivateKeySigner(secpKey) }, secpAddress},
+		{"unprefixed secp256k1 private key", func() (*xrplsale.WalletSigner, error) { return xrplsale.NewPrivateKeySigner(secpKey[2:]) }, secpAddress},
+		{"ed25519 seed", func() (*xrplsale.WalletSigner, error) { return xrplsale.NewSeedSigner(edSeed) }, edAddress},
+		{"ed25519 private key", func() (*xrplsale.WalletSigner, error) { return xrplsale.NewPrivateKeySigner(edKey) }, edAddress},
+		{"malformed private key", func() (*xrplsale.WalletSigner, error) { return xrplsale.NewPrivateKeySigner("ED00") }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := tt.signer()
+			if tt.wantAddress == "" {
+				if err == nil {
+					t.Fatal("want an error for a malformed key")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signer.Address() != tt.wantAddress {
+				t.Errorf("Address() = %s, want %s", signer.Address(), tt.wantAddress)
+			}
+			// Signing is deterministic for both algorithms
+			first, _ := signer.SignChallenge(context.Background(), testChallenge)
+			second, _ := signer.SignChallenge(context.Background(), testChallenge)
+			if first == "" || first != second {
+				t.Errorf("signatures %s and %s of one challenge differ", first, second)
+			}
+		})
+	}
+}
\ No newline at end of file
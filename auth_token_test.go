@@ -0,0 +1,99 @@
+package xrplsale_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestSetAuthTokenConcurrent(t *testing.T) {
+	const readers, writes = 16, 200
+	var mu sync.Mutex
+	seen := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		for _, value := range r.Header.Values("Authorization") {
+			seen[value]++
+		}
+		if n := len(r.Header.Values("Authorization")); n != 1 {
+			seen[fmt.Sprintf("%d Authorization headers", n)]++
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	
+	tests := []struct {
+		name   string
+		mutate func(client *xrplsale.Client, i int)
+		// own reports whether the requests carry the tokens set by mutate
+		own bool
+	}{
+		{"SetAuthToken", func(client *xrplsale.Client, i int) { client.SetAuthToken(fmt.Sprintf("tok-%d", i)) }, true},
+		{"clones", func(client *xrplsale.Client, i int) {
+			clone := client.Clone(xrplsale.WithAuthToken(fmt.Sprintf("clone-%d", i)))
+			clone.SetAuthToken(fmt.Sprintf("clone-%d-b", i))
+			clone.IsAuthenticated()
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			clear(seen)
+			mu.Unlock()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			client.SetAuthToken("tok-start")
+			
+			done := make(chan struct{})
+			errs := make(chan error, readers)
+			var wg sync.WaitGroup
+			for r := 0; r < readers; r++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						select {
+						case <-done:
+							errs <- nil
+							return
+						default:
+						}
+						if err := client.Get(context.Background(), "/projects", nil, nil); err != nil {
+							errs <- err
+							return
+						}
+					}
+				}()
+			}
+			for i := 0; i < writes; i++ {
+				tt.mutate(client, i)
+			}
+			close(done)
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			
+			mu.Lock()
+			defer mu.Unlock()
+			for header := range seen {
+				switch {
+				case header == "Bearer tok-start":
+				case tt.own && strings.HasPrefix(header, "Bearer tok-"):
+				default:
+					t.Errorf("a request was sent with %q", header)
+				}
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,102 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// authCapture is a transport recording the Authorization header of the
+// last request to each path
+type authCapture struct {
+	next http.RoundTripper
+	mu   sync.Mutex
+	last map[string]string
+}
+
+func (ac *authCapture) RoundTrip(req *http.Request) (*http.Response, error) {
+	ac.mu.Lock()
+	if ac.last == nil {
+		ac.last = make(map[string]string)
+	}
+	ac.last[req.URL.Path] = req.Header.Get("Authorization")
+	ac.mu.Unlock()
+	return ac.next.RoundTrip(req)
+}
+
+func (ac *authCapture) token(path string) string {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.last[path]
+}
+
+func TestAutoRefresh(t *testing.T) {
+	const ttl = time.Second
+	tests := []struct {
+		name         string
+		autoRefresh  bool
+		failRefresh  bool
+		wantNewToken bool
+		wantRefresh  bool
+	}{
+		{"refreshes before the request", true, false, true, true},
+		{"off", false, false, false, false},
+		{"failed refresh falls back to 401", true, true, false, true},
+	}
+	type setup struct {
+		client     *xrplsale.Client
+		hits       *hitCounter
+		auth       *authCapture
+		first      string
+		authErrors *atomic.Int32
+	}
+	// Every client signs in first, so a single wait expires all their tokens
+	setups := make([]setup, len(tests))
+	for i, tt := range tests {
+		s := setup{auth: &authCapture{}, authErrors: &atomic.Int32{}}
+		srv, client, hits := newCountingClient(t, func(c *xrplsale.Config) {
+			c.AutoRefresh = tt.autoRefresh
+			c.RefreshSkew = 200 * time.Millisecond
+			c.OnAuthError = func(error) { s.authErrors.Add(1) }
+			s.auth.next = c.Transport
+			c.Transport = s.auth
+		})
+		srv.SetTokenTTL(ttl)
+		signIn(t, client)
+		if _, err := client.Auth.GetProfile(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if tt.failRefresh {
+			srv.Fail(http.MethodPost, "/auth/refresh", xrplsaletest.Failure{Status: http.StatusUnauthorized})
+		}
+		s.client, s.hits, s.first = client, hits, s.auth.token("/auth/profile")
+		setups[i] = s
+	}
+	time.Sleep(ttl + 100*time.Millisecond)
+	
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := setups[i]
+			_, err := s.client.Auth.GetProfile(context.Background())
+			if tt.wantNewToken && err != nil || !tt.wantNewToken && !errors.Is(err, xrplsale.ErrUnauthorized) {
+				t.Fatalf("GetProfile() after expiry = %v", err)
+			}
+			if changed := s.auth.token("/auth/profile") != s.first; changed != tt.wantNewToken {
+				t.Errorf("token changed %v, want %v", changed, tt.wantNewToken)
+			}
+			if refreshed := s.hits.count(http.MethodPost, "/auth/refresh") > 0; refreshed != tt.wantRefresh {
+				t.Errorf("refresh attempted %v, want %v", refreshed, tt.wantRefresh)
+			}
+			if failed := s.authErrors.Load() > 0; failed != tt.failRefresh {
+				t.Errorf("OnAuthError called %d times, want a call %v", s.authErrors.Load(), tt.failRefresh)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,138 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// ErrInsufficientSpendable is matched by an InsufficientSpendableError
+var ErrInsufficientSpendable = errors.New("insufficient spendable balance")
+
+// CurrencyAmount is an amount of XRP or of an issued currency
+type CurrencyAmount struct {
+	Currency string `json:"currency"`
+	Issuer   string `json:"issuer,omitempty"` // empty for XRP
+	Value    string `json:"value"`
+}
+
+// XRPAmount returns value as an XRP CurrencyAmount
+func XRPAmount(value string) CurrencyAmount {
+	return CurrencyAmount{Currency: "XRP", Value: value}
+}
+
+// BalanceCheck reports what an account can spend once its XRPL reserves are
+// held back. It is advisory only: the balance may change between CheckedAt
+// and the payment being submitted.
+type BalanceCheck struct {
+	Account string         `json:"account"`
+	Amount  CurrencyAmount `json:"amount"`
+	
+	// Balance is the account's current balance in Amount's currency
+	Balance string `json:"balance"`
+	
+	// BaseReserve, OwnerReserve and TrustlineReserve are the XRP amounts the
+	// ledger will not let the account spend. TrustlineReserve is non-zero when
+	// receiving the project's tokens requires a new trust line.
+	BaseReserve       string `json:"base_reserve"`
+	OwnerReserve      string `json:"owner_reserve"`
+	TrustlineReserve  string `json:"trustline_reserve"`
+	TrustlineRequired bool   `json:"trustline_required"`
+	
+	// Spendable is Balance less the reserves
+	Spendable string `json:"spendable"`
+	
+	// Sufficient and Shortfall compare Spendable against Amount
+	Sufficient bool   `json:"-"`
+	Shortfall  string `json:"-"`
+	
+	LedgerIndex uint32    `json:"ledger_index"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+// InsufficientSpendableError is returned by a pre-checked investment the
+// investor's wallet cannot cover
+type InsufficientSpendableError struct {
+	Check *BalanceCheck
+}
+
+// Error implements the error interface
+func (e *InsufficientSpendableError) Error() string {
+	return fmt.Sprintf("%s: %s short of %s %s (checked at %s)",
+		ErrInsufficientSpendable, e.Check.Shortfall, e.Check.Amount.Value, e.Check.Amount.Currency,
+		e.Check.CheckedAt.Format(time.RFC3339))
+}
+
+// Is matches ErrInsufficientSpendable
+func (e *InsufficientSpendableError) Is(target error) bool {
+	return target == ErrInsufficientSpendable
+}
+
+// WithBalancePrecheck makes Investments.Create check the investor's spendable
+// balance first and fail with ErrInsufficientSpendable instead of submitting
+// an investment the wallet cannot cover
+func WithBalancePrecheck() RequestOption {
+	return func(ro *requestOptions) {
+		ro.balancePrecheck = true
+	}
+}
+
+// CheckSpendableBalance reports whether account can spend amount after the
+// XRPL base, owner and trust line reserves
+func (is *InvestmentsService) CheckSpendableBalance(ctx context.Context, account string, amount CurrencyAmount, reqOpts ...RequestOption) (*BalanceCheck, error) {
+	want, ok := new(big.Rat).SetString(amount.Value)
+	if !ok || want.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount: %q", amount.Value)
+	}
+	
+	params := map[string]string{
+		"currency": amount.Currency,
+		"value":    amount.Value,
+	}
+	if amount.Issuer != "" {
+		params["issuer"] = amount.Issuer
+	}
+	
+	var check BalanceCheck
+	if err := is.client.Get(ctx, fmt.Sprintf("/accounts/%s/spendable", account), params, &check, reqOpts...); err != nil {
+		return nil, err
+	}
+	
+	spendable, ok := new(big.Rat).SetString(check.Spendable)
+	if !ok {
+		return nil, fmt.Errorf("invalid spendable balance in response: %q", check.Spendable)
+	}
+	check.Amount = amount
+	check.Sufficient = spendable.Cmp(want) >= 0
+	if !check.Sufficient {
+		check.Shortfall = formatDecimal(new(big.Rat).Sub(want, spendable))
+	}
+	if check.CheckedAt.IsZero() {
+		check.CheckedAt = time.Now().UTC()
+	}
+	return &check, nil
+}
+
+// precheckBalance fails with an InsufficientSpendableError when the investor
+// cannot cover the investment
+func (is *InvestmentsService) precheckBalance(ctx context.Context, investment *CreateInvestmentRequest, reqOpts []RequestOption) error {
+	check, err := is.CheckSpendableBalance(ctx, investment.InvestorAccount, XRPAmount(investment.AmountXRP), reqOpts...)
+	if err != nil {
+		return fmt.Errorf("balance precheck: %w", err)
+	}
+	if !check.Sufficient {
+		return &InsufficientSpendableError{Check: check}
+	}
+	return nil
+}
+
+// formatDecimal formats r without trailing zeros, to the 15 significant
+// decimals an XRPL amount can carry
+func formatDecimal(r *big.Rat) string {
+	s := r.FloatString(15)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
\ No newline at end of file
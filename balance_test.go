@@ -0,0 +1,143 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// spendableServer answers balance checks with spendable and accepts
+// investments, recording the calls it receives
+type spendableServer struct {
+	spendable string
+	
+	mu    sync.Mutex
+	calls []string
+	query map[string]string
+}
+
+func (s *spendableServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.calls = append(s.calls, r.Method+" "+r.URL.Path)
+	s.query = map[string]string{}
+	for key := range r.URL.Query() {
+		s.query[key] = r.URL.Query().Get(key)
+	}
+	s.mu.Unlock()
+	
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/investments":
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"inv_1","status":"pending"}`))
+	default:
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"account":            "rInvestor",
+			"balance":            "100",
+			"base_reserve":       "10",
+			"owner_reserve":      "2",
+			"spendable":          s.spendable,
+			"ledger_index":       90000000,
+			"checked_at":         "2026-10-17T09:00:00Z",
+			"trustline_required": false,
+		})
+	}
+}
+
+func TestCheckSpendableBalance(t *testing.T) {
+	tests := []struct {
+		name           string
+		spendable      string
+		amount         xrplsale.CurrencyAmount
+		wantSufficient bool
+		wantShortfall  string
+		wantErr        bool
+	}{
+		{"covered", "88", xrplsale.XRPAmount("50"), true, "", false},
+		{"exactly covered", "88", xrplsale.XRPAmount("88"), true, "", false},
+		{"short", "88", xrplsale.XRPAmount("100.5"), false, "12.5", false},
+		{"short by a drop", "88", xrplsale.XRPAmount("88.000001"), false, "0.000001", false},
+		{"issued currency", "5", xrplsale.CurrencyAmount{Currency: "USD", Issuer: "rIssuer", Value: "7"}, false, "2", false},
+		{"invalid amount", "88", xrplsale.XRPAmount("lots"), false, "", true},
+		{"negative amount", "88", xrplsale.XRPAmount("-1"), false, "", true},
+		{"invalid response", "n/a", xrplsale.XRPAmount("1"), false, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &spendableServer{spendable: tt.spendable}
+			srv := httptest.NewServer(api)
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			check, err := client.Investments.CheckSpendableBalance(context.Background(), "rInvestor", tt.amount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CheckSpendableBalance() = %+v, want an error", check)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckSpendableBalance() = %v", err)
+			}
+			if check.Sufficient != tt.wantSufficient || check.Shortfall != tt.wantShortfall {
+				t.Fatalf("Sufficient = %t, Shortfall = %q; want %t, %q", check.Sufficient, check.Shortfall, tt.wantSufficient, tt.wantShortfall)
+			}
+			if check.CheckedAt.IsZero() || check.Amount != tt.amount {
+				t.Fatalf("check = %+v, want it timestamped and carrying the amount", check)
+			}
+			if api.query["currency"] != tt.amount.Currency || api.query["value"] != tt.amount.Value || api.query["issuer"] != tt.amount.Issuer {
+				t.Fatalf("query = %v, want the amount", api.query)
+			}
+		})
+	}
+}
+
+func TestCreateWithBalancePrecheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		spendable string
+		opts      []xrplsale.RequestOption
+		wantCalls []string
+		wantShort bool
+	}{
+		{"without precheck", "1", nil, []string{"POST /investments"}, false},
+		{"covered", "500", []xrplsale.RequestOption{xrplsale.WithBalancePrecheck()}, []string{"GET /accounts/rInvestor/spendable", "POST /investments"}, false},
+		{"short", "99", []xrplsale.RequestOption{xrplsale.WithBalancePrecheck()}, []string{"GET /accounts/rInvestor/spendable"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &spendableServer{spendable: tt.spendable}
+			srv := httptest.NewServer(api)
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			_, err := client.Investments.Create(context.Background(), &xrplsale.CreateInvestmentRequest{
+				ProjectID:       "proj_1",
+				AmountXRP:       "100",
+				InvestorAccount: "rInvestor",
+			}, tt.opts...)
+			if !equalStrings(api.calls, tt.wantCalls) {
+				t.Errorf("calls = %q, want %q", api.calls, tt.wantCalls)
+			}
+			if !tt.wantShort {
+				if err != nil {
+					t.Fatalf("Create() = %v", err)
+				}
+				return
+			}
+			var short *xrplsale.InsufficientSpendableError
+			if !errors.As(err, &short) || !errors.Is(err, xrplsale.ErrInsufficientSpendable) {
+				t.Fatalf("Create() = %v, want ErrInsufficientSpendable", err)
+			}
+			if short.Check.Shortfall != "1" {
+				t.Fatalf("Shortfall = %q, want 1", short.Check.Shortfall)
+			}
+		})
+	}
+}
\ No newline at end of file
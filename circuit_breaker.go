@@ -0,0 +1,191 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the API while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open: API considered unavailable")
+
+// CircuitState is the state of a client's circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreakerConfig configures the client's circuit breaker
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive 5xx or network failures that opens the circuit
+	FailureThreshold int
+	
+	// OpenDuration is how long calls fail fast before probing the API again
+	OpenDuration time.Duration
+	
+	// HalfOpenProbes is the number of probe requests let through after the
+	// cool-down; all must succeed for the circuit to close again
+	HalfOpenProbes int
+}
+
+// circuitBreaker tracks consecutive failures for a client
+type circuitBreaker struct {
+	mu     sync.Mutex
+	config CircuitBreakerConfig
+	
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probes    int
+	successes int
+	
+	// onChange, when set, is called with the new state after the circuit
+	// opens or closes. It is called with the breaker locked and must not block.
+	onChange func(CircuitState)
+}
+
+// newCircuitBreaker creates a closed breaker, filling in defaults
+func newCircuitBreaker(config CircuitBreakerConfig) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.OpenDuration <= 0 {
+		config.OpenDuration = 30 * time.Second
+	}
+	if config.HalfOpenProbes <= 0 {
+		config.HalfOpenProbes = 1
+	}
+	return &circuitBreaker{config: config, state: CircuitClosed}
+}
+
+// State returns the current state, moving an expired open circuit to half-open
+func (cb *circuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.advance()
+	return cb.state
+}
+
+// advance moves an open circuit to half-open once the cool-down has elapsed
+func (cb *circuitBreaker) advance() {
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.config.OpenDuration {
+		cb.state = CircuitHalfOpen
+		cb.probes = 0
+		cb.successes = 0
+	}
+}
+
+// allow reports whether a request may be sent
+func (cb *circuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	
+	cb.advance()
+	switch cb.state {
+	case CircuitOpen:
+		return false
+	case CircuitHalfOpen:
+		if cb.probes >= cb.config.HalfOpenProbes {
+			return false
+		}
+		cb.probes++
+	}
+	return true
+}
+
+// record updates the breaker with the outcome of a request
+func (cb *circuitBreaker) record(failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	
+	if failed {
+		cb.failures++
+		if cb.state == CircuitHalfOpen || cb.failures >= cb.config.FailureThreshold {
+			wasOpen := cb.state == CircuitOpen
+			cb.state = CircuitOpen
+			cb.openedAt = time.Now()
+			if !wasOpen {
+				cb.changed()
+			}
+		}
+		return
+	}
+	
+	cb.failures = 0
+	if cb.state == CircuitHalfOpen {
+		cb.successes++
+		if cb.successes >= cb.config.HalfOpenProbes {
+			cb.state = CircuitClosed
+			cb.changed()
+		}
+	}
+}
+
+// release gives back the slot of a half-open probe whose outcome is not
+// recorded, so the next request can probe instead
+func (cb *circuitBreaker) release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitHalfOpen && cb.probes > 0 {
+		cb.probes--
+	}
+}
+
+// changed reports the current state to onChange
+func (cb *circuitBreaker) changed() {
+	if cb.onChange != nil {
+		cb.onChange(cb.state)
+	}
+}
+
+// breakerTransport consults the circuit breaker before every attempt and
+// records its outcome
+type breakerTransport struct {
+	base    http.RoundTripper
+	breaker *circuitBreaker
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+	
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		// A caller giving up or a request never sent says nothing about
+		// the API's health
+		if errors.Is(err, context.Canceled) || errors.Is(err, ErrDryRun) {
+			t.breaker.release()
+		} else {
+			t.breaker.record(true)
+		}
+		return resp, err
+	}
+	
+	t.breaker.record(resp.StatusCode >= 500)
+	return resp, nil
+}
+
+// circuitChanged emits the lifecycle event for a circuit breaker transition
+func (c *clientCore) circuitChanged(state CircuitState) {
+	kind := ClientEventCircuitClosed
+	if state == CircuitOpen {
+		kind = ClientEventCircuitOpened
+	}
+	c.events.emit(kind, map[string]string{"state": string(state)})
+}
+
+// CircuitState returns the state of the client's circuit breaker, for use in
+// health checks. It is always CircuitClosed when no breaker is configured.
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.State()
+}
\ No newline at end of file
@@ -0,0 +1,149 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// breakerServer answers with status, or blocks until the request is
+// canceled when status is zero
+type breakerServer struct {
+	*httptest.Server
+	status atomic.Int32
+	hits   atomic.Int32
+}
+
+func newBreakerServer(t *testing.T) *breakerServer {
+	t.Helper()
+	srv := &breakerServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.hits.Add(1)
+		status := int(srv.status.Load())
+		if status == 0 {
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+const breakerCoolDown = 50 * time.Millisecond
+
+// newBreakerClient returns a client opening its circuit after two
+// failures, for breakerCoolDown
+func newBreakerClient(srv *breakerServer) *xrplsale.Client {
+	return xrplsale.NewClientWithConfig(&xrplsale.Config{
+		APIKey:  "key",
+		BaseURL: srv.URL,
+		CircuitBreaker: &xrplsale.CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenDuration:     breakerCoolDown,
+			HalfOpenProbes:   1,
+		},
+	})
+}
+
+// get makes a GET with ctx and returns its error
+func get(ctx context.Context, client *xrplsale.Client) error {
+	var out map[string]interface{}
+	return client.Get(ctx, "/projects", nil, &out, xrplsale.WithNoRetry())
+}
+
+// openCircuit fails requests until client's circuit opens, then waits
+// for it to go half-open
+func openCircuit(t *testing.T, srv *breakerServer, client *xrplsale.Client) {
+	t.Helper()
+	srv.status.Store(http.StatusServiceUnavailable)
+	for i := 0; i < 2; i++ {
+		get(context.Background(), client)
+	}
+	if state := client.CircuitState(); state != xrplsale.CircuitOpen {
+		t.Fatalf("CircuitState() = %s after two failures, want open", state)
+	}
+	time.Sleep(breakerCoolDown + 10*time.Millisecond)
+	if state := client.CircuitState(); state != xrplsale.CircuitHalfOpen {
+		t.Fatalf("CircuitState() = %s after the cool-down, want half-open", state)
+	}
+}
+
+func TestCircuitBreakerCycle(t *testing.T) {
+	srv := newBreakerServer(t)
+	client := newBreakerClient(srv)
+	if state := client.CircuitState(); state != xrplsale.CircuitClosed {
+		t.Fatalf("CircuitState() = %s, want closed", state)
+	}
+	
+	openCircuit(t, srv, client)
+	
+	// A failed probe opens the circuit again and calls fail fast
+	if err := get(context.Background(), client); errors.Is(err, xrplsale.ErrCircuitOpen) {
+		t.Fatalf("half-open probe was not sent: %v", err)
+	}
+	hits := srv.hits.Load()
+	if err := get(context.Background(), client); !errors.Is(err, xrplsale.ErrCircuitOpen) {
+		t.Fatalf("get() with an open circuit = %v, want ErrCircuitOpen", err)
+	}
+	if srv.hits.Load() != hits {
+		t.Error("a request reached the server while the circuit was open")
+	}
+	
+	// A successful probe closes it
+	time.Sleep(breakerCoolDown + 10*time.Millisecond)
+	srv.status.Store(http.StatusOK)
+	if err := get(context.Background(), client); err != nil {
+		t.Fatalf("half-open probe = %v", err)
+	}
+	if state := client.CircuitState(); state != xrplsale.CircuitClosed {
+		t.Errorf("CircuitState() = %s after a successful probe, want closed", state)
+	}
+}
+
+func TestCircuitBreakerReleasesUnrecordedProbe(t *testing.T) {
+	tests := []struct {
+		name  string
+		probe func(client *xrplsale.Client) error
+	}{
+		{"canceled probe", func(client *xrplsale.Client) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+			return get(ctx, client)
+		}},
+		{"dry-run probe", func(client *xrplsale.Client) error {
+			return client.Post(context.Background(), "/projects", map[string]string{}, nil, xrplsale.WithDryRun(), xrplsale.WithNoRetry())
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newBreakerServer(t)
+			client := newBreakerClient(srv)
+			openCircuit(t, srv, client)
+			
+			srv.status.Store(0)
+			if err := tt.probe(client); err == nil || errors.Is(err, xrplsale.ErrCircuitOpen) {
+				t.Fatalf("probe = %v, want it sent and unrecorded", err)
+			}
+			if state := client.CircuitState(); state != xrplsale.CircuitHalfOpen {
+				t.Fatalf("CircuitState() = %s after the probe, want half-open", state)
+			}
+			
+			srv.status.Store(http.StatusOK)
+			if err := get(context.Background(), client); err != nil {
+				t.Fatalf("next probe = %v, want its slot back", err)
+			}
+			if state := client.CircuitState(); state != xrplsale.CircuitClosed {
+				t.Errorf("CircuitState() = %s, want closed", state)
+			}
+		})
+	}
+}
\ No newline at end of file
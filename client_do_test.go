@@ -0,0 +1,132 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// echoServer answers /echo with the request's method, query and body, and
+// other paths with the fixed responses the Do tests need
+func echoServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		switch r.URL.Path {
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"method": r.Method,
+				"query":  r.URL.Query().Get("q"),
+				"body":   string(body),
+			})
+		case "/invalid":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error":{"code":"validation_error","message":"name is required"}}`))
+		case "/trailing":
+			w.Write([]byte(`{"method":"GET"} {}`))
+		case "/extra":
+			w.Write([]byte(`{"method":"GET","surprise":true}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+type echoed struct {
+	Method string `json:"method"`
+	Query  string `json:"query"`
+	Body   string `json:"body"`
+}
+
+func TestDo(t *testing.T) {
+	srv, requests := echoServer(t)
+	tests := []struct {
+		name       string
+		method     string
+		endpoint   string
+		params     map[string]string
+		body       interface{}
+		strict     bool
+		wantStatus int
+		want       echoed
+		wantAPIErr bool
+		wantDecode string
+	}{
+		{"GET with params", http.MethodGet, "/echo", map[string]string{"q": "tokens"}, nil, false, http.StatusOK, echoed{Method: "GET", Query: "tokens"}, false, ""},
+		{"POST with body", http.MethodPost, "/echo", nil, map[string]string{"name": "x"}, false, http.StatusOK, echoed{Method: "POST", Body: `{"name":"x"}`}, false, ""},
+		{"DELETE", http.MethodDelete, "/echo", nil, nil, false, http.StatusOK, echoed{Method: "DELETE"}, false, ""},
+		{"error response", http.MethodGet, "/invalid", nil, nil, false, http.StatusUnprocessableEntity, echoed{}, true, ""},
+		{"trailing data", http.MethodGet, "/trailing", nil, nil, false, http.StatusOK, echoed{}, false, "unexpected data"},
+		{"unknown field", http.MethodGet, "/extra", nil, nil, false, http.StatusOK, echoed{Method: "GET"}, false, ""},
+		{"unknown field strict", http.MethodGet, "/extra", nil, nil, true, http.StatusOK, echoed{}, false, "surprise"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, StrictDecoding: tt.strict})
+			resp, err := client.Do(context.Background(), tt.method, tt.endpoint, tt.params, tt.body, xrplsale.WithNoRetry())
+			if resp == nil || resp.StatusCode != tt.wantStatus || resp.Header.Get("X-RateLimit-Remaining") != "41" {
+				t.Fatalf("Do() = %+v, want status %d with the response headers", resp, tt.wantStatus)
+			}
+			
+			if tt.wantAPIErr {
+				var apiErr *xrplsale.APIError
+				if !errors.As(err, &apiErr) || !errors.Is(err, xrplsale.ErrValidation) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("Do() = %v, want a validation APIError", err)
+				}
+				if len(resp.Body) == 0 {
+					t.Fatal("error response has no body")
+				}
+				// The typed helpers fail the same way
+				if err := client.Get(context.Background(), tt.endpoint, tt.params, nil, xrplsale.WithNoRetry()); !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("Get() = %v, want the same APIError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Do() = %v", err)
+			}
+			
+			var got echoed
+			err = resp.Decode(&got)
+			if tt.wantDecode != "" {
+				var decodeErr *xrplsale.DecodeError
+				if !errors.As(err, &decodeErr) || decodeErr.Endpoint != tt.endpoint {
+					t.Fatalf("Decode() = %v, want a DecodeError", err)
+				}
+				if !strings.Contains(decodeErr.Error(), tt.wantDecode) {
+					t.Fatalf("Decode() = %v, want it to mention %q", err, tt.wantDecode)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("Decode() = %+v, %v; want %+v", got, err, tt.want)
+			}
+			
+			// The typed helpers decode the same response
+			var typed echoed
+			if err := client.Request(context.Background(), tt.method, tt.endpoint, tt.body, &typed); err != nil || typed.Method != got.Method || typed.Body != got.Body {
+				t.Fatalf("Request() = %+v, %v; want %+v", typed, err, got)
+			}
+		})
+	}
+	
+	before := requests.Load()
+	if _, err := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL}).Do(context.Background(), "TRACE", "/echo", nil, nil); err == nil {
+		t.Fatal("Do() with TRACE succeeded")
+	}
+	if requests.Load() != before {
+		t.Fatal("an unsupported method reached the server")
+	}
+}
\ No newline at end of file
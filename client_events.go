@@ -0,0 +1,161 @@
+package xrplsale
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultEventBufferSize is the number of undelivered events Events keeps by default
+const DefaultEventBufferSize = 256
+
+// ClientEventKind identifies a lifecycle event
+type ClientEventKind string
+
+// Client lifecycle events
+const (
+	// ClientEventTokenRefreshed follows a successful token refresh
+	ClientEventTokenRefreshed ClientEventKind = "token_refreshed"
+	
+	// ClientEventCircuitOpened and ClientEventCircuitClosed follow circuit breaker transitions
+	ClientEventCircuitOpened ClientEventKind = "circuit_opened"
+	ClientEventCircuitClosed ClientEventKind = "circuit_closed"
+	
+	// ClientEventRateLimitExhausted is emitted when the client-side rate
+	// limit starts delaying requests; it is not repeated until the limiter
+	// has recovered
+	ClientEventRateLimitExhausted ClientEventKind = "rate_limit_exhausted"
+	
+	// ClientEventDeprecation is emitted the first time a route answers with
+	// Deprecation or Sunset headers, or in a deprecated shape
+	ClientEventDeprecation ClientEventKind = "deprecation"
+	
+	// ClientEventFailover is emitted when a request fails over from one
+	// base URL to the next
+	ClientEventFailover ClientEventKind = "failover"
+	
+	// ClientEventCacheCorrupted is emitted when Config.TokenStore holds
+	// tokens that cannot be decoded; it is not repeated until the store has
+	// been read successfully again
+	ClientEventCacheCorrupted ClientEventKind = "cache_corrupted"
+	
+	// ClientEventSDKVersion is emitted once when the API first reports this
+	// SDK as outdated and once when it reports it as unsupported
+	ClientEventSDKVersion ClientEventKind = "sdk_version"
+)
+
+// ClientEvent is a notable event in the life of a client
+type ClientEvent struct {
+	Kind    ClientEventKind
+	Time    time.Time
+	Details map[string]string
+}
+
+// eventLog buffers lifecycle events for the subscriber of Events. Events
+// are only kept once someone has subscribed.
+type eventLog struct {
+	mu      sync.Mutex
+	ch      chan ClientEvent
+	size    int
+	dropped atomic.Uint64
+	
+	// deprecations holds the routes a deprecation has been reported for
+	deprecations sync.Map
+}
+
+// newEventLog creates an event log holding at most size undelivered events
+func newEventLog(size int) *eventLog {
+	if size <= 0 {
+		size = DefaultEventBufferSize
+	}
+	return &eventLog{size: size}
+}
+
+// subscribe returns the event channel, creating it on first use
+func (l *eventLog) subscribe() <-chan ClientEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.ch == nil {
+		l.ch = make(chan ClientEvent, l.size)
+	}
+	return l.ch
+}
+
+// emit queues an event without blocking. When the buffer is full the
+// oldest event is dropped to make room.
+func (l *eventLog) emit(kind ClientEventKind, details map[string]string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.ch == nil {
+		return
+	}
+	
+	event := ClientEvent{Kind: kind, Time: time.Now(), Details: details}
+	for {
+		select {
+		case l.ch <- event:
+			return
+		default:
+		}
+		select {
+		case <-l.ch:
+			l.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// deprecation emits a deprecation event for route unless one was already emitted
+func (l *eventLog) deprecation(route string, details map[string]string) {
+	if _, seen := l.deprecations.LoadOrStore(route, struct{}{}); seen {
+		return
+	}
+	details["route"] = route
+	l.emit(ClientEventDeprecation, details)
+}
+
+// checkDeprecation reports a route answering with Deprecation or Sunset headers
+func (l *eventLog) checkDeprecation(method, endpoint string, header http.Header) {
+	deprecation, sunset := header.Get("Deprecation"), header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return
+	}
+	details := map[string]string{"method": method}
+	if deprecation != "" {
+		details["deprecation"] = deprecation
+	}
+	if sunset != "" {
+		details["sunset"] = sunset
+	}
+	if link := header.Get("Link"); link != "" {
+		details["link"] = link
+	}
+	l.deprecation(method+" "+routeTemplate(endpoint), details)
+}
+
+// Events subscribes to the client's lifecycle events: token refreshes,
+// circuit breaker transitions, rate limit exhaustion and deprecations.
+// Delivery is best-effort and never blocks API calls: at most
+// Config.EventBufferSize undelivered events are kept, and the oldest are
+// dropped first (see DroppedEvents). Events are only recorded once Events
+// has been called. Every call returns the same channel, which is shared
+// with clients derived from this one.
+func (c *Client) Events() <-chan ClientEvent {
+	return c.events.subscribe()
+}
+
+// DroppedEvents returns the number of events dropped because the subscriber
+// of Events fell behind
+func (c *Client) DroppedEvents() uint64 {
+	return c.events.dropped.Load()
+}
+
+// emitTokenRefreshed reports a successful token refresh
+func (c *Client) emitTokenRefreshed(tokens *AuthResponse) {
+	c.events.emit(ClientEventTokenRefreshed, map[string]string{
+		"expires_in":      strconv.Itoa(tokens.ExpiresIn),
+		"refresh_rotated": strconv.FormatBool(tokens.RefreshToken != ""),
+	})
+}
\ No newline at end of file
@@ -0,0 +1,133 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// drain returns the events buffered on ch without waiting for more
+func drain(ch <-chan xrplsale.ClientEvent) []xrplsale.ClientEvent {
+	var events []xrplsale.ClientEvent
+	for len(ch) > 0 {
+		events = append(events, <-ch)
+	}
+	return events
+}
+
+func TestEventsSlowConsumer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, EventBufferSize: 2})
+	events := client.Events()
+	
+	// Nobody reads the events while ten routes report a deprecation
+	routes := []string{"/alpha", "/bravo", "/charlie", "/delta", "/echo", "/foxtrot", "/golf", "/hotel", "/india", "/juliet"}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, route := range routes {
+			if err := client.Get(context.Background(), route, nil, nil); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("requests blocked on an unread event channel")
+	}
+	
+	if got := client.DroppedEvents(); got != 8 {
+		t.Fatalf("DroppedEvents() = %d, want 8", got)
+	}
+	got := drain(events)
+	if len(got) != 2 || got[0].Details["route"] != "GET /india" || got[1].Details["route"] != "GET /juliet" {
+		t.Fatalf("buffered events %+v, want the two newest", got)
+	}
+}
+
+func TestEventsFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer up.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: down.URL, FallbackBaseURLs: []string{up.URL}})
+	events := client.Events()
+	
+	if err := client.Get(context.Background(), "/projects", nil, nil, xrplsale.WithNoRetry()); err != nil {
+		t.Fatal(err)
+	}
+	got := drain(events)
+	if len(got) != 1 || got[0].Kind != xrplsale.ClientEventFailover || got[0].Details["from"] != down.URL || got[0].Details["to"] != up.URL {
+		t.Fatalf("events %+v, want one failover from %s to %s", got, down.URL, up.URL)
+	}
+}
+
+func TestEventsCacheCorrupted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	store := xrplsale.NewFileTokenStore(path)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, TokenStore: store})
+	events := client.Events()
+	get := func() {
+		t.Helper()
+		if err := client.Get(context.Background(), "/projects", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	corrupted := func() int {
+		n := 0
+		for _, event := range drain(events) {
+			if event.Kind == xrplsale.ClientEventCacheCorrupted && event.Details["cache"] == "token_store" {
+				n++
+			}
+		}
+		return n
+	}
+	
+	tests := []struct {
+		name     string
+		contents string
+		want     int
+	}{
+		{"valid", `{"token":"tok"}`, 0},
+		{"corrupt", `{"token":`, 1},
+		{"still corrupt", `{"token":`, 0},
+		{"repaired", `{"token":"tok2"}`, 0},
+		{"corrupt again", `not json`, 1},
+	}
+	for _, tt := range tests {
+		if err := os.WriteFile(path, []byte(tt.contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		get()
+		get()
+		if got := corrupted(); got != tt.want {
+			t.Fatalf("%s: %d cache_corrupted events, want %d", tt.name, got, tt.want)
+		}
+	}
+	if _, err := store.Get(context.Background()); !errors.Is(err, xrplsale.ErrTokenStoreCorrupt) {
+		t.Fatalf("Get() on a corrupt file = %v, want ErrTokenStoreCorrupt", err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,69 @@
+package xrplsale
+
+// TenantHeader is the header WithTenant sets to select a launchpad tenant
+const TenantHeader = "X-Tenant-ID"
+
+// ClientOption customizes a client returned by Clone
+type ClientOption func(*Client)
+
+// WithAPIKey sets the clone's API key
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Client) {
+		c.creds.apiKey = Secret(apiKey)
+	}
+}
+
+// WithAuthToken sets the clone's auth token. The refresh token inherited
+// from the parent is cleared and Config.TokenStore no longer applies, since
+// they belong to the parent's session.
+func WithAuthToken(token string) ClientOption {
+	return func(c *Client) {
+		c.creds.authToken = Secret(token)
+		c.creds.refreshToken = ""
+		c.creds.expiresAt = tokenExpiry(token)
+		c.creds.scopes = nil
+		c.creds.serviceAccount = nil
+		c.refresher = &tokenRefresher{}
+		c.tokenStore = nil
+	}
+}
+
+// WithDefaultHeader sets a header on every request made by the clone
+func WithDefaultHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(map[string]string)
+		}
+		c.headers[key] = value
+	}
+}
+
+// WithTenant scopes every request made by the clone to a launchpad tenant
+func WithTenant(tenantID string) ClientOption {
+	return WithDefaultHeader(TenantHeader, tenantID)
+}
+
+// WithAPIVersion pins the clone to another API version than the one in
+// Config.APIVersion
+func WithAPIVersion(version string) ClientOption {
+	return WithDefaultHeader(APIVersionHeader, version)
+}
+
+// Clone returns a client sharing c's configuration, connection pool, caches
+// and hooks but owning its own credentials and default headers, which start
+// as copies of c's. Changing the clone's credentials, e.g. with
+// SetAuthToken or by authenticating, never affects c, and vice versa.
+//
+// Until it is given its own auth token, the clone shares c's session: a
+// refresh made by either one rotates the refresh token for both, and the
+// other adopts the new tokens when it next refreshes instead of sending the
+// spent refresh token.
+func (c *Client) Clone(opts ...ClientOption) *Client {
+	clone := c.derive()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(clone)
+		}
+	}
+	return clone
+}
\ No newline at end of file
@@ -0,0 +1,207 @@
+package xrplsale_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestCloneConcurrentAuthorization(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]map[string]bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tenant := r.Header.Get(xrplsale.TenantHeader)
+		if seen[tenant] == nil {
+			seen[tenant] = make(map[string]bool)
+		}
+		seen[tenant][r.Header.Get("Authorization")] = true
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	
+	parent := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	parent.SetAuthToken("parent-token")
+	tenants := map[string]*xrplsale.Client{
+		"tenant_a": parent.Clone(xrplsale.WithTenant("tenant_a"), xrplsale.WithAuthToken("token-a")),
+		"tenant_b": parent.Clone(xrplsale.WithTenant("tenant_b"), xrplsale.WithAuthToken("token-b")),
+	}
+	
+	var wg sync.WaitGroup
+	for _, client := range tenants {
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(client *xrplsale.Client) {
+				defer wg.Done()
+				var out map[string]interface{}
+				if err := client.Get(context.Background(), "/projects", nil, &out); err != nil {
+					t.Error(err)
+				}
+			}(client)
+		}
+	}
+	wg.Wait()
+	
+	want := map[string]string{"tenant_a": "Bearer token-a", "tenant_b": "Bearer token-b"}
+	for tenant, auth := range want {
+		if len(seen[tenant]) != 1 || !seen[tenant][auth] {
+			t.Errorf("%s sent Authorization %v, want only %q", tenant, seen[tenant], auth)
+		}
+	}
+	if len(seen) != len(want) {
+		t.Errorf("requests came from tenants %v, want only %v", seen, want)
+	}
+}
+
+func TestCloneSharesSession(t *testing.T) {
+	tests := []struct {
+		name   string
+		derive func(*xrplsale.Client) *xrplsale.Client
+	}{
+		{"Clone", func(c *xrplsale.Client) *xrplsale.Client { return c.Clone() }},
+		{"Clone with tenant", func(c *xrplsale.Client) *xrplsale.Client { return c.Clone(xrplsale.WithTenant("tenant_a")) }},
+		{"AsUser", func(c *xrplsale.Client) *xrplsale.Client { return c.AsUser(fixtures.Address()) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"/sequential", func(t *testing.T) {
+			_, parent, hits := newCountingClient(t)
+			signIn(t, parent)
+			clone := tt.derive(parent)
+			
+			// The parent spends the refresh token the clone also holds
+			rotated, err := parent.Auth.Refresh(context.Background(), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			adopted, err := clone.Auth.Refresh(context.Background(), "")
+			if err != nil {
+				t.Fatalf("clone Refresh() = %v, want the parent's rotation", err)
+			}
+			if adopted.Token != rotated.Token {
+				t.Errorf("clone got token %q, want %q", adopted.Token, rotated.Token)
+			}
+			if got := hits.count(http.MethodPost, "/auth/refresh"); got != 1 {
+				t.Errorf("refresh requests = %d, want 1", got)
+			}
+			if _, err := clone.Projects.List(context.Background(), nil); err != nil {
+				t.Errorf("clone request after the rotation: %v", err)
+			}
+		})
+		
+		t.Run(tt.name+"/concurrent", func(t *testing.T) {
+			_, parent, _ := newCountingClient(t)
+			signIn(t, parent)
+			clients := []*xrplsale.Client{parent, tt.derive(parent), tt.derive(parent)}
+			
+			start := make(chan struct{})
+			var wg sync.WaitGroup
+			for i := 0; i < 30; i++ {
+				client := clients[i%len(clients)]
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					if _, err := client.Auth.Refresh(context.Background(), ""); err != nil {
+						t.Errorf("Refresh() = %v", err)
+					}
+				}()
+			}
+			close(start)
+			wg.Wait()
+			
+			// Whoever refreshed last, every client can still refresh
+			for i, client := range clients {
+				if _, err := client.Auth.Refresh(context.Background(), ""); err != nil {
+					t.Errorf("client %d: Refresh() after the race = %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCloneWithOwnTokenRefreshesApart(t *testing.T) {
+	srv, parent, _ := newCountingClient(t)
+	signIn(t, parent)
+	other := signIn(t, xrplsaletest.NewClient(srv))
+	
+	clone := parent.Clone(xrplsale.WithAuthToken(other.Token))
+	if _, err := clone.Auth.Refresh(context.Background(), ""); err == nil {
+		t.Error("clone with its own token refreshed with the parent's refresh token")
+	}
+	if _, err := clone.Auth.Refresh(context.Background(), other.RefreshToken); err != nil {
+		t.Errorf("clone Refresh(own token) = %v", err)
+	}
+	if _, err := parent.Auth.Refresh(context.Background(), ""); err != nil {
+		t.Errorf("parent Refresh() = %v", err)
+	}
+	if !parent.IsAuthenticated() || !clone.IsAuthenticated() {
+		t.Error("a client lost its session")
+	}
+}
+func TestCloneThousandConcurrent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"authorization":%q,"tenant":%q}`, r.Header.Get("Authorization"), r.Header.Get(xrplsale.TenantHeader))
+	}))
+	defer srv.Close()
+	parent := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, MaxIdleConnsPerHost: 1000})
+	parent.SetAuthToken("parent-token")
+	
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tenant := fmt.Sprintf("tenant_%d", i)
+			clone := parent.Clone(xrplsale.WithTenant(tenant), xrplsale.WithAuthToken("token-"+tenant))
+			var out struct{ Authorization, Tenant string }
+			if err := clone.Get(context.Background(), "/projects", nil, &out); err != nil {
+				t.Error(err)
+				return
+			}
+			if out.Authorization != "Bearer token-"+tenant || out.Tenant != tenant {
+				t.Errorf("clone %d sent Authorization %q for tenant %q", i, out.Authorization, out.Tenant)
+			}
+		}(i)
+	}
+	wg.Wait()
+	
+	var out struct{ Authorization, Tenant string }
+	if err := parent.Get(context.Background(), "/projects", nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Authorization != "Bearer parent-token" || out.Tenant != "" {
+		t.Fatalf("parent sent Authorization %q for tenant %q after cloning", out.Authorization, out.Tenant)
+	}
+}
+
+func TestCloneAllocations(t *testing.T) {
+	parent := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key"})
+	parent.SetAuthToken("parent-token")
+	allocs := testing.AllocsPerRun(100, func() {
+		if parent.Clone().Projects == nil {
+			t.Fatal("clone has no Projects service")
+		}
+	})
+	if allocs > 1 {
+		t.Fatalf("Clone() allocates %v times, want one Client", allocs)
+	}
+}
+
+func BenchmarkClone(b *testing.B) {
+	parent := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key"})
+	parent.SetAuthToken("parent-token")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = parent.Clone()
+	}
+}
\ No newline at end of file
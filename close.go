@@ -0,0 +1,33 @@
+package xrplsale
+
+import "errors"
+
+// ErrClientClosed is returned by calls made after Client.Close
+var ErrClientClosed = errors.New("client is closed")
+
+// Close releases the client's resources: it ends every watch subscription
+// with ErrClientClosed and closes idle connections. Requests in flight
+// complete; later calls fail with ErrClientClosed. Clients derived with
+// Clone share these resources, so closing any of them closes all. Close is
+// safe to call concurrently and more than once.
+func (c *Client) Close() error {
+	if !c.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	c.watches.closeAll()
+	if t, ok := c.transport.(interface{ CloseIdleConnections() }); ok {
+		t.CloseIdleConnections()
+	}
+	return nil
+}
+
+// usable returns the error failing every request of the client, if any
+func (core *clientCore) usable() error {
+	if core.configErr != nil {
+		return core.configErr
+	}
+	if core.closed.Load() {
+		return ErrClientClosed
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,123 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestClose(t *testing.T) {
+	verifyNoLeaks := checkGoroutineLeaks(t)
+	var hits atomic.Int32
+	arrived := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path == "/slow" {
+			arrived <- struct{}{}
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"inv_1"}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, DeduplicateGETs: true})
+	ctx := context.Background()
+	
+	// Watch loops and a request in flight when the client closes
+	subs := []*xrplsale.Subscription[xrplsale.Investment]{
+		client.Investments.WatchInvestment(ctx, "inv_1", &xrplsale.WatchOptions{Interval: 5 * time.Millisecond}),
+		client.Investments.WatchInvestment(ctx, "inv_2", &xrplsale.WatchOptions{Interval: time.Hour}),
+	}
+	inFlight := make(chan error, 1)
+	go func() { inFlight <- client.Get(ctx, "/slow", nil, nil) }()
+	<-arrived
+	
+	// Closing is idempotent and safe to race
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Close(); err != nil {
+				t.Errorf("Close() = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(release)
+	if err := <-inFlight; err != nil {
+		t.Fatalf("request in flight during Close() = %v, want it to complete", err)
+	}
+	for i, sub := range subs {
+		for update := range sub.C {
+			if update.Err != nil && !errors.Is(update.Err, xrplsale.ErrClientClosed) {
+				t.Errorf("subscription %d got %v", i, update.Err)
+			}
+		}
+	}
+	
+	sent := hits.Load()
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Get", func() error { return client.Get(ctx, "/projects", nil, nil) }},
+		{"service call", func() error { _, err := client.Projects.Get(ctx, "proj_1"); return err }},
+		{"Head", func() error { _, err := client.Head(ctx, "/projects/proj_1"); return err }},
+		{"Download", func() error { _, err := client.Download(ctx, "/exports/1", io.Discard); return err }},
+		{"Upload", func() error {
+			return client.Upload(ctx, "/uploads", map[string]io.Reader{"a.txt": strings.NewReader("a")}, nil, nil)
+		}},
+		{"cloned client", func() error { return client.Clone(xrplsale.WithTenant("t1")).Get(ctx, "/projects", nil, nil) }},
+		{"Close again", func() error {
+			if err := client.Close(); err != nil {
+				return err
+			}
+			return xrplsale.ErrClientClosed
+		}},
+	}
+	for _, tt := range tests {
+		if err := tt.call(); !errors.Is(err, xrplsale.ErrClientClosed) {
+			t.Errorf("%s after Close() = %v, want ErrClientClosed", tt.name, err)
+		}
+	}
+	if hits.Load() != sent {
+		t.Errorf("%d requests sent after Close()", hits.Load()-sent)
+	}
+	verifyNoLeaks()
+}
+
+// TestCloseReleasesConnections checks clients built and closed in a loop,
+// as a service with one client per tenant does, leave nothing behind
+func TestCloseReleasesConnections(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	verifyNoLeaks := checkGoroutineLeaks(t)
+	
+	for i := 0; i < 20; i++ {
+		client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+		if err := client.Get(context.Background(), "/projects", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if _, err := client.Download(context.Background(), "/exports/1", &out); err != nil {
+			t.Fatal(err)
+		}
+		client.Close()
+	}
+	verifyNoLeaks()
+}
\ No newline at end of file
@@ -0,0 +1,97 @@
+package xrplsale
+
+import (
+	"slices"
+	"time"
+)
+
+// CollaboratorRole is a collaborator's role on a project
+type CollaboratorRole string
+
+// Collaborator roles
+const (
+	RoleOwner   CollaboratorRole = "owner"
+	RoleEditor  CollaboratorRole = "editor"
+	RoleViewer  CollaboratorRole = "viewer"
+	RoleFinance CollaboratorRole = "finance"
+)
+
+// Capabilities known to this SDK version. The API may grant others; they
+// are reported in ProjectPermissions as-is.
+const (
+	CapabilityViewProject         = "project.view"
+	CapabilityEditProject         = "project.edit"
+	CapabilityLaunchProject       = "project.launch"
+	CapabilityManageTiers         = "tiers.manage"
+	CapabilityViewInvestments     = "investments.view"
+	CapabilityRecordInvestments   = "investments.record"
+	CapabilityManageDisputes      = "disputes.manage"
+	CapabilityExportData          = "data.export"
+	CapabilityManageCollaborators = "collaborators.manage"
+	CapabilityManageWebhooks      = "webhooks.manage"
+)
+
+// ProjectPermissions lists what the current token may do on a project
+type ProjectPermissions struct {
+	ProjectID    string           `json:"project_id"`
+	Role         CollaboratorRole `json:"role"`
+	Capabilities []string         `json:"capabilities"`
+}
+
+// Can reports whether capability was granted
+func (p *ProjectPermissions) Can(capability string) bool {
+	return slices.Contains(p.Capabilities, capability)
+}
+
+// Collaborator is a user with an active role on a project
+type Collaborator struct {
+	ID            string           `json:"id"`
+	ProjectID     string           `json:"project_id"`
+	UserID        string           `json:"user_id"`
+	WalletAddress string           `json:"wallet_address,omitempty"`
+	Email         string           `json:"email,omitempty"`
+	Role          CollaboratorRole `json:"role"`
+	JoinedAt      time.Time        `json:"joined_at"`
+}
+
+// Invitation statuses
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationExpired  = "expired"
+)
+
+// CollaboratorInvitation is an invitation the invitee has not necessarily
+// accepted yet. Until it is accepted the invitee has no access to the project.
+type CollaboratorInvitation struct {
+	ID            string           `json:"id"`
+	ProjectID     string           `json:"project_id"`
+	WalletAddress string           `json:"wallet_address,omitempty"`
+	Email         string           `json:"email,omitempty"`
+	Role          CollaboratorRole `json:"role"`
+	Status        string           `json:"status"`
+	InvitedAt     time.Time        `json:"invited_at"`
+	ExpiresAt     time.Time        `json:"expires_at"`
+	
+	// Collaborator is set once the invitation has been accepted, which may
+	// happen immediately for invitees who already have an account
+	Collaborator *Collaborator `json:"collaborator,omitempty"`
+}
+
+// Pending reports whether the invitation is still awaiting the invitee
+func (i *CollaboratorInvitation) Pending() bool {
+	return i.Status == InvitationPending
+}
+
+// InviteCollaboratorRequest invites a user to a project by wallet address or email
+type InviteCollaboratorRequest struct {
+	WalletAddress string           `json:"wallet_address,omitempty"`
+	Email         string           `json:"email,omitempty"`
+	Role          CollaboratorRole `json:"role"`
+}
+
+// Collaborators lists a project's active collaborators and pending invitations
+type Collaborators struct {
+	Active  []Collaborator           `json:"collaborators"`
+	Pending []CollaboratorInvitation `json:"pending_invitations"`
+}
\ No newline at end of file
@@ -0,0 +1,119 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestGetMyPermissions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/proj_1/permissions/me" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"project_id":"proj_1","role":"finance","capabilities":["project.view","data.export","payouts.schedule"]}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	permissions, err := client.Projects.GetMyPermissions(context.Background(), "proj_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if permissions.Role != xrplsale.RoleFinance {
+		t.Errorf("Role = %q, want finance", permissions.Role)
+	}
+	for capability, want := range map[string]bool{
+		xrplsale.CapabilityViewProject: true,
+		xrplsale.CapabilityExportData:  true,
+		xrplsale.CapabilityEditProject: false,
+		// Capabilities unknown to the SDK are kept
+		"payouts.schedule": true,
+	} {
+		if got := permissions.Can(capability); got != want {
+			t.Errorf("Can(%q) = %v, want %v", capability, got, want)
+		}
+	}
+}
+
+func TestCollaborators(t *testing.T) {
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sent = append(sent, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{
+				"collaborators": [{"id":"col_1","project_id":"proj_1","user_id":"usr_1","role":"owner"}],
+				"pending_invitations": [{"id":"inv_1","project_id":"proj_1","email":"a@example.com","role":"viewer","status":"pending"}]
+			}`))
+		case r.Method == http.MethodPost && strings.Contains(string(body), "known@example.com"):
+			w.Write([]byte(`{"id":"inv_2","role":"editor","status":"accepted","collaborator":{"id":"col_2","role":"editor"}}`))
+		case r.Method == http.MethodPost:
+			w.Write([]byte(`{"id":"inv_3","role":"editor","status":"pending"}`))
+		case r.Method == http.MethodPatch:
+			var update map[string]string
+			json.Unmarshal(body, &update)
+			w.Write([]byte(`{"id":"col_1","role":"` + update["role"] + `"}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	ctx := context.Background()
+	
+	list, err := client.Projects.ListCollaborators(ctx, "proj_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Active) != 1 || list.Active[0].Role != xrplsale.RoleOwner || len(list.Pending) != 1 || !list.Pending[0].Pending() {
+		t.Fatalf("ListCollaborators() = %+v, want one owner and one pending invitation", list)
+	}
+	
+	tests := []struct {
+		email            string
+		wantPending      bool
+		wantCollaborator bool
+	}{
+		{"new@example.com", true, false},
+		{"known@example.com", false, true},
+	}
+	for _, tt := range tests {
+		invitation, err := client.Projects.InviteCollaborator(ctx, "proj_1", &xrplsale.InviteCollaboratorRequest{Email: tt.email, Role: xrplsale.RoleEditor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if invitation.Pending() != tt.wantPending || (invitation.Collaborator != nil) != tt.wantCollaborator {
+			t.Fatalf("InviteCollaborator(%s) = %+v, want pending %v", tt.email, invitation, tt.wantPending)
+		}
+	}
+	
+	collaborator, err := client.Projects.UpdateCollaboratorRole(ctx, "proj_1", "col_1", xrplsale.RoleViewer)
+	if err != nil || collaborator.Role != xrplsale.RoleViewer {
+		t.Fatalf("UpdateCollaboratorRole() = %+v, %v", collaborator, err)
+	}
+	if err := client.Projects.RemoveCollaborator(ctx, "proj_1", "col_1"); err != nil {
+		t.Fatal(err)
+	}
+	
+	want := []string{
+		"GET /projects/proj_1/collaborators",
+		`POST /projects/proj_1/collaborators {"email":"new@example.com","role":"editor"}`,
+		`POST /projects/proj_1/collaborators {"email":"known@example.com","role":"editor"}`,
+		`PATCH /projects/proj_1/collaborators/col_1 {"role":"viewer"}`,
+		"DELETE /projects/proj_1/collaborators/col_1",
+	}
+	if !equalStrings(sent, want) {
+		t.Fatalf("sent %q\nwant %q", sent, want)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,62 @@
+package xrplsale
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultCompressionThreshold is the smallest request body gzipped when
+// Config.CompressRequests is set
+const DefaultCompressionThreshold = 1024
+
+// compressTransport gzips request bodies of at least threshold bytes. It
+// sits below the retry loop, so every attempt compresses its own copy of
+// the body. Multipart uploads are left alone: they are streamed, and their
+// files are mostly compressed already.
+type compressTransport struct {
+	base      http.RoundTripper
+	threshold int
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *compressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.Header.Get("Content-Encoding") != "" ||
+		strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		return t.base.RoundTrip(req)
+	}
+	if req.ContentLength >= 0 && req.ContentLength < int64(t.threshold) {
+		return t.base.RoundTrip(req)
+	}
+	
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	
+	out := req.Clone(req.Context())
+	if len(body) < t.threshold {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		return t.base.RoundTrip(out)
+	}
+	
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	
+	out.Body = io.NopCloser(bytes.NewReader(compressed.Bytes()))
+	out.ContentLength = int64(compressed.Len())
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed.Bytes())), nil
+	}
+	out.Header.Set("Content-Encoding", "gzip")
+	return t.base.RoundTrip(out)
+}
\ No newline at end of file
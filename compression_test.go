@@ -0,0 +1,117 @@
+package xrplsale_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+)
+
+func TestGzipResponse(t *testing.T) {
+	project := fixtures.Project()
+	page := fixtures.Page(fixtures.Investments(project, 200), 1, 200)
+	var acceptEncoding string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		json.NewEncoder(zw).Encode(page)
+		zw.Close()
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	got, err := client.Investments.GetByProject(context.Background(), project.ID, 1, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(acceptEncoding, "gzip") {
+		t.Errorf("Accept-Encoding = %q, want gzip", acceptEncoding)
+	}
+	if len(got.Data) != 200 || got.Data[199].ID != page.Data[199].ID || !got.Data[0].AmountXRP.Equal(page.Data[0].AmountXRP) {
+		t.Fatalf("decoded %d investments, want the 200 sent", len(got.Data))
+	}
+}
+
+func TestCompressRequests(t *testing.T) {
+	large := map[string]string{"description": strings.Repeat("tokens ", 500)}
+	small := map[string]string{"name": "x"}
+	tests := []struct {
+		name         string
+		compress     bool
+		threshold    int
+		body         interface{}
+		failures     int
+		wantEncoding string
+	}{
+		{"off", false, 0, large, 0, ""},
+		{"large body", true, 0, large, 0, "gzip"},
+		{"small body", true, 0, small, 0, ""},
+		{"custom threshold", true, 8, small, 0, "gzip"},
+		{"retried", true, 0, large, 2, "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, _ := json.Marshal(tt.body)
+			var mu sync.Mutex
+			var attempts int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				attempts++
+				attempt := attempts
+				mu.Unlock()
+				
+				if got := r.Header.Get("Content-Encoding"); got != tt.wantEncoding {
+					t.Errorf("attempt %d Content-Encoding = %q, want %q", attempt, got, tt.wantEncoding)
+				}
+				body := io.Reader(r.Body)
+				if tt.wantEncoding == "gzip" {
+					zr, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Errorf("attempt %d body is not gzip: %v", attempt, err)
+						return
+					}
+					body = zr
+				}
+				got, _ := io.ReadAll(body)
+				if strings.TrimSpace(string(got)) != string(want) {
+					t.Errorf("attempt %d body = %.60q, want %.60q", attempt, got, want)
+				}
+				
+				w.Header().Set("Content-Type", "application/json")
+				if attempt <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					w.Write([]byte(`{"error":{"code":"unavailable","message":"try again"}}`))
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey:               "key",
+				BaseURL:              srv.URL,
+				CompressRequests:     tt.compress,
+				CompressionThreshold: tt.threshold,
+				RetryWaitTime:        time.Millisecond,
+			})
+			
+			var out map[string]interface{}
+			if err := client.Post(context.Background(), "/projects", tt.body, &out, xrplsale.WithRetryNonIdempotent()); err != nil {
+				t.Fatal(err)
+			}
+			if attempts != tt.failures+1 {
+				t.Fatalf("%d attempts, want %d", attempts, tt.failures+1)
+			}
+		})
+	}
+}
\ No newline at end of file
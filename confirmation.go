@@ -0,0 +1,132 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Investment statuses
+const (
+	InvestmentPending   = "pending"
+	InvestmentConfirmed = "confirmed"
+	InvestmentFailed    = "failed"
+)
+
+// ErrInvestmentFailed is returned by WaitForConfirmation when the payment
+// was rejected or expired
+var ErrInvestmentFailed = errors.New("investment failed")
+
+// ConfirmationOptions tunes the polling schedule of WaitForConfirmation.
+// Zero fields take the defaults noted.
+type ConfirmationOptions struct {
+	// LedgerCloseInterval is the expected time between ledger closes; 4s
+	LedgerCloseInterval time.Duration
+	
+	// CloseWindow is how close to an expected ledger close polls are made
+	// at FastPollInterval; 1s
+	CloseWindow time.Duration
+	
+	// FastPollInterval is the polling interval around expected closes; 500ms
+	FastPollInterval time.Duration
+	
+	// MaxPollInterval caps every wait, so a revised estimate is picked up; 10s
+	MaxPollInterval time.Duration
+	
+	// InitialBackoff is the first wait when the API gave no estimate. It
+	// doubles after every poll up to MaxPollInterval; 1s
+	InitialBackoff time.Duration
+}
+
+// withDefaults returns o with the defaults filled in
+func (o *ConfirmationOptions) withDefaults() ConfirmationOptions {
+	var opts ConfirmationOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.LedgerCloseInterval <= 0 {
+		opts.LedgerCloseInterval = 4 * time.Second
+	}
+	if opts.CloseWindow <= 0 {
+		opts.CloseWindow = time.Second
+	}
+	if opts.FastPollInterval <= 0 {
+		opts.FastPollInterval = 500 * time.Millisecond
+	}
+	if opts.MaxPollInterval <= 0 {
+		opts.MaxPollInterval = 10 * time.Second
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = time.Second
+	}
+	return opts
+}
+
+// WaitForConfirmation polls an investment until its payment is confirmed on
+// the ledger or fails. When the API estimates the confirmation time, on the
+// investment or its PaymentInstructions, polls
+// are concentrated around the expected ledger closes; otherwise they back
+// off exponentially. The confirmed investment carries the ledger index it
+// was validated in.
+func (is *InvestmentsService) WaitForConfirmation(ctx context.Context, investmentID string, opts *ConfirmationOptions, reqOpts ...RequestOption) (*Investment, error) {
+	schedule := opts.withDefaults()
+	
+	for attempt := 0; ; attempt++ {
+		investment, err := is.Get(ctx, investmentID, reqOpts...)
+		if err != nil {
+			return nil, err
+		}
+		switch investment.Status {
+		case InvestmentConfirmed:
+			return investment, nil
+		case InvestmentFailed:
+			return investment, fmt.Errorf("%w: %s", ErrInvestmentFailed, investmentID)
+		}
+		
+		timer := time.NewTimer(schedule.nextPoll(time.Now(), investment.estimatedConfirmation(), attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return investment, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// estimatedConfirmation returns when the investment's payment is expected to
+// be validated: the investment's own estimate, else its payment
+// instructions', or nil when the API gave none
+func (inv *Investment) estimatedConfirmation() *Timestamp {
+	if inv.EstimatedConfirmation != nil || inv.PaymentInstructions == nil {
+		return inv.EstimatedConfirmation
+	}
+	return inv.PaymentInstructions.EstimatedConfirmation
+}
+
+// nextPoll returns how long to wait before the next poll at now. estimate is
+// the expected confirmation time, nil when unknown; attempt counts the polls
+// made so far, starting at 0.
+func (o ConfirmationOptions) nextPoll(now time.Time, estimate *Timestamp, attempt int) time.Duration {
+	if estimate == nil || estimate.IsZero() {
+		wait := o.InitialBackoff
+		for i := 0; i < attempt && wait < o.MaxPollInterval; i++ {
+			wait *= 2
+		}
+		return min(wait, o.MaxPollInterval)
+	}
+	
+	// Aim at the first expected close not yet passed: the estimate itself,
+	// or a later close if the payment missed it
+	target := estimate.Time
+	if late := now.Sub(target) - o.CloseWindow; late > 0 {
+		closes := late/o.LedgerCloseInterval + 1
+		target = target.Add(closes * o.LedgerCloseInterval)
+	}
+	
+	untilWindow := target.Sub(now) - o.CloseWindow
+	if untilWindow <= 0 {
+		return o.FastPollInterval
+	}
+	return min(untilWindow, o.MaxPollInterval)
+}
\ No newline at end of file
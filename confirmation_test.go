@@ -0,0 +1,118 @@
+package xrplsale
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNextPoll(t *testing.T) {
+	opts := (&ConfirmationOptions{}).withDefaults()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *Timestamp { return &Timestamp{Time: now.Add(d)} }
+	
+	tests := []struct {
+		name     string
+		estimate *Timestamp
+		attempt  int
+		want     time.Duration
+	}{
+		{"no estimate, first poll", nil, 0, time.Second},
+		{"no estimate, backs off", nil, 3, 8 * time.Second},
+		{"no estimate, capped", nil, 10, 10 * time.Second},
+		{"zero estimate backs off", &Timestamp{}, 1, 2 * time.Second},
+		{"estimate far ahead is capped", at(time.Minute), 0, 10 * time.Second},
+		{"sleep until the close window", at(6 * time.Second), 0, 5 * time.Second},
+		{"inside the window polls fast", at(500 * time.Millisecond), 0, 500 * time.Millisecond},
+		{"just after the estimate polls fast", at(-500 * time.Millisecond), 0, 500 * time.Millisecond},
+		// Missed the estimated close by 2s: the next close is 2s away, so
+		// wait 1s to reach its window
+		{"missed close aims at the next", at(-2 * time.Second), 0, time.Second},
+		{"missed several closes", at(-9 * time.Second), 0, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := opts.nextPoll(now, tt.estimate, tt.attempt); got != tt.want {
+				t.Fatalf("nextPoll() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextPollScheduleShape(t *testing.T) {
+	// Simulate polling from 10s before an estimate to 10s after: waits are
+	// long away from ledger closes and fast within their windows
+	opts := (&ConfirmationOptions{}).withDefaults()
+	start := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	estimate := &Timestamp{Time: start.Add(10 * time.Second)}
+	
+	now := start
+	var polls []time.Duration
+	for now.Before(start.Add(20 * time.Second)) {
+		wait := opts.nextPoll(now, estimate, len(polls))
+		now = now.Add(wait)
+		polls = append(polls, now.Sub(start))
+	}
+	for _, poll := range polls {
+		// Distance to the nearest expected close: 10s, 14s, 18s, 22s
+		offset := (poll - 10*time.Second) % opts.LedgerCloseInterval
+		if offset < 0 {
+			offset += opts.LedgerCloseInterval
+		}
+		// The last fast poll of a window may land one interval past it
+		distance := min(offset, opts.LedgerCloseInterval-offset)
+		if limit := opts.CloseWindow + opts.FastPollInterval; distance > limit {
+			t.Errorf("poll at +%s is %s from any expected close, want within %s", poll, distance, limit)
+		}
+	}
+	if len(polls) > 20 {
+		t.Errorf("%d polls in 20s, want them concentrated near closes", len(polls))
+	}
+}
+
+func TestWaitForConfirmationPaymentInstructions(t *testing.T) {
+	var mu sync.Mutex
+	var polls []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls = append(polls, time.Now())
+		n := len(polls)
+		mu.Unlock()
+		
+		investment := Investment{ID: "inv_1", Status: InvestmentPending}
+		if n == 1 {
+			// Only the payment instructions carry the estimate
+			investment.PaymentInstructions = &PaymentInstructions{
+				Destination:           "rDestination",
+				DestinationTag:        42,
+				AmountXRP:             MustParseAmount("100"),
+				EstimatedConfirmation: &Timestamp{Time: time.Now().Add(150 * time.Millisecond)},
+			}
+		} else {
+			investment.Status, investment.LedgerIndex = InvestmentConfirmed, 90000001
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(investment)
+	}))
+	defer srv.Close()
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL})
+	
+	// Without the estimate the first wait would be InitialBackoff, 5s
+	opts := &ConfirmationOptions{CloseWindow: 50 * time.Millisecond, FastPollInterval: 10 * time.Millisecond, InitialBackoff: 5 * time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	investment, err := client.Investments.WaitForConfirmation(ctx, "inv_1", opts)
+	if err != nil {
+		t.Fatalf("WaitForConfirmation() = %v", err)
+	}
+	if investment.LedgerIndex != 90000001 {
+		t.Fatalf("LedgerIndex = %d, want the validated ledger", investment.LedgerIndex)
+	}
+	if wait := polls[1].Sub(polls[0]); wait < 50*time.Millisecond || wait > time.Second {
+		t.Fatalf("second poll after %s, want it near the estimated close", wait)
+	}
+}
\ No newline at end of file
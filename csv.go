@@ -0,0 +1,20 @@
+package xrplsale
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// writeCSV streams a header and the records produced by rows to w as CSV.
+// rows calls write once per record, so callers never build the full table in memory.
+func writeCSV(w io.Writer, header []string, rows func(write func(record []string) error) error) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := rows(cw.Write); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
\ No newline at end of file
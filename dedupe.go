@@ -0,0 +1,128 @@
+package xrplsale
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// sharedFlightKey marks the context of the request a flight is waiting on
+type sharedFlightKey struct{}
+
+// flightGroup tracks the GET requests in flight when Config.DeduplicateGETs
+// is set, by request key
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a GET request shared by concurrent identical calls
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	
+	resp *Response
+	err  error
+	meta ResponseMeta
+}
+
+// doShared sends a GET request, or waits for an identical one already in
+// flight. The request is cancelled only once every waiter has given up.
+func (c *Client) doShared(ctx context.Context, endpoint string, params map[string]string, opts []RequestOption) (*Response, error) {
+	ro := newRequestOptions(opts)
+	key := c.flightKey(endpoint, params, ro)
+	g := c.flights
+	
+	g.mu.Lock()
+	f, ok := g.flights[key]
+	if !ok {
+		// The request outlives the caller that started it, keeping its values
+		flightCtx, cancel := context.WithCancel(context.WithValue(context.WithoutCancel(ctx), sharedFlightKey{}, true))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		if g.flights == nil {
+			g.flights = make(map[string]*flight)
+		}
+		g.flights[key] = f
+		flightOpts := append(opts[:len(opts):len(opts)], WithResponseMeta(&f.meta))
+		go func() {
+			defer cancel()
+			f.resp, f.err = c.Do(flightCtx, http.MethodGet, endpoint, params, nil, flightOpts...)
+			g.mu.Lock()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+	
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+	
+	if ro.meta != nil {
+		*ro.meta = f.meta
+		ro.meta.Header = f.meta.Header.Clone()
+	}
+	return f.resp.clone(), f.err
+}
+
+// flightKey identifies identical GET requests: same endpoint, query
+// parameters, credentials and headers
+func (c *Client) flightKey(endpoint string, params map[string]string, ro *requestOptions) string {
+	if len(ro.params) > 0 {
+		params = maps.Clone(params)
+		if params == nil {
+			params = make(map[string]string, len(ro.params))
+		}
+		maps.Copy(params, ro.params)
+	}
+	var b strings.Builder
+	b.WriteString(requestCacheKeyFor(endpoint, params))
+	b.WriteString("\x00" + c.identity())
+	keys := make([]string, 0, len(ro.headers))
+	for key := range ro.headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		b.WriteString("\x00" + key + ":" + ro.headers[key])
+	}
+	return b.String()
+}
+
+// sharedFlight reports whether ctx belongs to a request a flight waits on
+func sharedFlight(ctx context.Context) bool {
+	shared, _ := ctx.Value(sharedFlightKey{}).(bool)
+	return shared
+}
+
+// clone returns a copy of r that shares no memory with it, so waiters of a
+// flight cannot see each other's changes
+func (r *Response) clone() *Response {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	cp.Header = r.Header.Clone()
+	cp.Body = bytes.Clone(r.Body)
+	return &cp
+}
\ No newline at end of file
@@ -0,0 +1,179 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// gatedServer holds every request until released, and counts them by path
+type gatedServer struct {
+	*httptest.Server
+	release   chan struct{}
+	arrived   chan struct{}
+	cancelled atomic.Int32
+	mu        sync.Mutex
+	hits      map[string]int
+}
+
+func newGatedServer(t *testing.T) *gatedServer {
+	t.Helper()
+	gs := &gatedServer{release: make(chan struct{}), arrived: make(chan struct{}, 100), hits: map[string]int{}}
+	gs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gs.mu.Lock()
+		gs.hits[r.URL.Path]++
+		gs.mu.Unlock()
+		gs.arrived <- struct{}{}
+		select {
+		case <-gs.release:
+		case <-r.Context().Done():
+			gs.cancelled.Add(1)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/broken") {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":{"code":"internal","message":"boom"}}`))
+			return
+		}
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		w.Write([]byte(`{"id":"` + id + `","name":"Shared","tiers":[{"tier":1,"price_per_token":"0.01","total_tokens":"100"}]}`))
+	}))
+	t.Cleanup(gs.Close)
+	return gs
+}
+
+func (gs *gatedServer) count(path string) int {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	return gs.hits[path]
+}
+
+// waitForJoin waits for the first request to reach the server, then gives
+// the other callers time to join its flight
+func (gs *gatedServer) waitForJoin() {
+	<-gs.arrived
+	time.Sleep(100 * time.Millisecond)
+}
+
+func TestDeduplicateGETs(t *testing.T) {
+	const callers = 50
+	tests := []struct {
+		name      string
+		id        string
+		dedupe    bool
+		wantHits  int
+		wantError bool
+	}{
+		{"shared", "proj_1", true, 1, false},
+		{"errors reach every waiter", "broken", true, 1, true},
+		{"off by default", "proj_1", false, callers, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newGatedServer(t)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, DeduplicateGETs: tt.dedupe})
+			
+			projects := make([]*xrplsale.Project, callers)
+			errs := make([]error, callers)
+			var wg sync.WaitGroup
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					projects[i], errs[i] = client.Projects.Get(context.Background(), tt.id, xrplsale.WithNoRetry())
+				}(i)
+			}
+			srv.waitForJoin()
+			close(srv.release)
+			wg.Wait()
+			
+			if got := srv.count("/projects/" + tt.id); got != tt.wantHits {
+				t.Fatalf("server saw %d requests for %d callers, want %d", got, callers, tt.wantHits)
+			}
+			for i, err := range errs {
+				var apiErr *xrplsale.APIError
+				if tt.wantError && (!errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError) || !tt.wantError && err != nil {
+					t.Fatalf("caller %d got %v, want error %v", i, err, tt.wantError)
+				}
+			}
+			if tt.wantError {
+				return
+			}
+			
+			// Every caller decoded its own copy
+			projects[0].Name = "changed"
+			projects[0].Tiers[0].Tier = 99
+			for i, project := range projects[1:] {
+				if project == projects[0] || project.Name != "Shared" || project.Tiers[0].Tier != 1 {
+					t.Fatalf("caller %d shares its result with caller 0: %+v", i+1, project)
+				}
+			}
+		})
+	}
+}
+
+func TestDeduplicateGETsCancellation(t *testing.T) {
+	srv := newGatedServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, DeduplicateGETs: true})
+	
+	// The caller that started the flight bails; the others still get the result
+	first, cancelFirst := context.WithCancel(context.Background())
+	results := make(chan error, 3)
+	go func() {
+		_, err := client.Projects.Get(first, "proj_1", xrplsale.WithNoRetry())
+		results <- err
+	}()
+	<-srv.arrived
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := client.Projects.Get(context.Background(), "proj_1", xrplsale.WithNoRetry())
+			results <- err
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	cancelFirst()
+	if err := <-results; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled caller got %v, want context.Canceled", err)
+	}
+	close(srv.release)
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Fatalf("remaining caller got %v", err)
+		}
+	}
+	if srv.count("/projects/proj_1") != 1 || srv.cancelled.Load() != 0 {
+		t.Fatalf("%d requests, %d cancelled; want one uncancelled request", srv.count("/projects/proj_1"), srv.cancelled.Load())
+	}
+	
+	// Once every waiter has given up, the shared request is cancelled
+	srv = newGatedServer(t)
+	client = xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, DeduplicateGETs: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < 3; i++ {
+		go func() {
+			_, err := client.Projects.Get(ctx, "proj_1", xrplsale.WithNoRetry())
+			results <- err
+		}()
+	}
+	srv.waitForJoin()
+	cancel()
+	for i := 0; i < 3; i++ {
+		if err := <-results; !errors.Is(err, context.Canceled) {
+			t.Fatalf("caller got %v, want context.Canceled", err)
+		}
+	}
+	for deadline := time.Now().Add(2 * time.Second); srv.cancelled.Load() == 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("shared request not cancelled after every waiter gave up")
+		}
+	}
+}
\ No newline at end of file
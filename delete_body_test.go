@@ -0,0 +1,71 @@
+package xrplsale_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+type captured struct {
+	method, query, contentType, body string
+}
+
+// captureServer records the last request's method, raw query, content type and body
+func captureServer(t *testing.T) (*httptest.Server, *captured) {
+	t.Helper()
+	last := &captured{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*last = captured{r.Method, r.URL.RawQuery, r.Header.Get("Content-Type"), string(body)}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, last
+}
+
+func TestDeleteWithBodyAndQueryParams(t *testing.T) {
+	srv, last := captureServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	ctx := context.Background()
+	params := map[string]string{"status": "active", "limit": "10"}
+	
+	tests := []struct {
+		name string
+		call func() error
+		want captured
+	}{
+		{"Delete", func() error {
+			return client.Delete(ctx, "/webhooks/wh_1", nil)
+		}, captured{method: "DELETE"}},
+		{"DeleteWithBody and WithQueryParam", func() error {
+			return client.DeleteWithBody(ctx, "/projects/proj_1/whitelist", []string{"rA", "rB"}, nil, xrplsale.WithQueryParam("dry_run", "true"))
+		}, captured{"DELETE", "dry_run=true", "application/json", `["rA","rB"]`}},
+		{"Request with WithQueryParam", func() error {
+			return client.Request(ctx, http.MethodPost, "/projects", map[string]string{"name": "X"}, nil, xrplsale.WithQueryParam("validate_only", "1"))
+		}, captured{"POST", "validate_only=1", "application/json", `{"name":"X"}`}},
+		{"WithQueryParam overrides the method's params", func() error {
+			return client.Get(ctx, "/projects", params, nil, xrplsale.WithQueryParam("limit", "50"), xrplsale.WithQueryParam("page", "2"))
+		}, captured{method: "GET", query: "limit=50&page=2&status=active"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatal(err)
+			}
+			if tt.want.contentType == "" {
+				last.contentType = ""
+			}
+			if *last != tt.want {
+				t.Errorf("server saw %+v, want %+v", *last, tt.want)
+			}
+		})
+	}
+	if len(params) != 2 || params["limit"] != "10" || params["page"] != "" {
+		t.Errorf("caller's params modified: %v", params)
+	}
+}
\ No newline at end of file
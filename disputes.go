@@ -0,0 +1,74 @@
+package xrplsale
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvestmentNotConfirmed is returned by FlagDispute when the investment
+// has not been confirmed on the ledger yet; there is nothing to dispute until it is
+var ErrInvestmentNotConfirmed = errors.New("investment not confirmed")
+
+// DisputeStatus is the lifecycle state of a dispute
+type DisputeStatus string
+
+// Dispute statuses
+const (
+	DisputeOpen           DisputeStatus = "open"
+	DisputeUnderReview    DisputeStatus = "under_review"
+	DisputeResolvedRefund DisputeStatus = "resolved_refund"
+	DisputeResolvedUpheld DisputeStatus = "resolved_upheld"
+)
+
+// Resolved reports whether the dispute has reached a final state
+func (s DisputeStatus) Resolved() bool {
+	return s == DisputeResolvedRefund || s == DisputeResolvedUpheld
+}
+
+// Dispute reasons
+const (
+	DisputeTokensNotReceived = "tokens_not_received"
+	DisputeWrongAmount       = "wrong_amount"
+	DisputeOther             = "other"
+)
+
+// DisputeEvidence is a document supporting a dispute, referenced by URL in
+// a DisputeRequest or uploaded with AttachEvidence
+type DisputeEvidence struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// DisputeRequest describes a dispute raised against an investment
+type DisputeRequest struct {
+	Reason      string            `json:"reason"`
+	Description string            `json:"description"`
+	Evidence    []DisputeEvidence `json:"evidence,omitempty"`
+}
+
+// Dispute represents a payment dispute on an investment
+type Dispute struct {
+	ID           string            `json:"id"`
+	InvestmentID string            `json:"investment_id"`
+	ProjectID    string            `json:"project_id"`
+	Status       DisputeStatus     `json:"status"`
+	Reason       string            `json:"reason"`
+	Description  string            `json:"description"`
+	Evidence     []DisputeEvidence `json:"evidence"`
+	Resolution   string            `json:"resolution,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	ResolvedAt   *time.Time        `json:"resolved_at,omitempty"`
+}
+
+// ListDisputesOptions represents options for listing a project's disputes
+type ListDisputesOptions struct {
+	Status DisputeStatus `url:"status,omitempty"`
+	Page   int           `url:"page,positive"`
+	Limit  int           `url:"limit,positive"`
+}
+
+// params returns the options as query parameters
+func (o *ListDisputesOptions) params() map[string]string {
+	return encodeQuery(o)
+}
\ No newline at end of file
@@ -0,0 +1,188 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+)
+
+// disputeServer serves the given investments and the dispute endpoints,
+// recording every request as "METHOD path?query"
+type disputeServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []string
+}
+
+func newDisputeServer(t *testing.T, investments ...*xrplsale.Investment) *disputeServer {
+	t.Helper()
+	ds := &disputeServer{}
+	ds.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ds.mu.Lock()
+		ds.requests = append(ds.requests, r.Method+" "+r.URL.RequestURI())
+		ds.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "investments":
+			for _, inv := range investments {
+				if inv.ID == parts[1] {
+					enc.Encode(inv)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"not_found","message":"no such investment"}}`))
+		case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "disputes":
+			var req xrplsale.DisputeRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			enc.Encode(xrplsale.Dispute{ID: "dsp_1", InvestmentID: parts[1], Status: xrplsale.DisputeOpen, Reason: req.Reason, Evidence: req.Evidence})
+		case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "disputes":
+			enc.Encode(xrplsale.Dispute{ID: parts[1], Status: xrplsale.DisputeUnderReview})
+		case r.Method == http.MethodGet && len(parts) == 3 && parts[0] == "projects":
+			enc.Encode(fixtures.Page([]xrplsale.Dispute{{ID: "dsp_1", ProjectID: parts[1], Status: xrplsale.DisputeOpen}}, 1, 20))
+		case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "evidence":
+			file, header, err := r.FormFile(xrplsale.UploadFieldName)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content, _ := io.ReadAll(file)
+			enc.Encode(xrplsale.DisputeEvidence{
+				URL:         "https://files.example/" + parts[1] + "/" + header.Filename + "?" + header.Header.Get("Content-Type") + "&" + string(content),
+				Description: r.FormValue("description"),
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ds.Close)
+	return ds
+}
+
+func (ds *disputeServer) sent() []string {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	return append([]string(nil), ds.requests...)
+}
+
+func TestFlagDispute(t *testing.T) {
+	confirmed := fixtures.Investment(fixtures.WithInvestmentStatus(xrplsale.InvestmentConfirmed))
+	pending := fixtures.Investment(fixtures.WithInvestmentStatus(xrplsale.InvestmentPending))
+	tests := []struct {
+		name         string
+		investmentID string
+		wantErr      error
+		wantSent     []string
+	}{
+		{"confirmed", confirmed.ID, nil, []string{"GET /investments/" + confirmed.ID, "POST /investments/" + confirmed.ID + "/disputes"}},
+		{"unconfirmed", pending.ID, xrplsale.ErrInvestmentNotConfirmed, []string{"GET /investments/" + pending.ID}},
+		{"unknown", "inv_missing", xrplsale.ErrNotFound, []string{"GET /investments/inv_missing"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newDisputeServer(t, confirmed, pending)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			dispute, err := client.Investments.FlagDispute(context.Background(), tt.investmentID, &xrplsale.DisputeRequest{
+				Reason:      xrplsale.DisputeTokensNotReceived,
+				Description: "no tokens",
+				Evidence:    []xrplsale.DisputeEvidence{{URL: "https://example.com/receipt.pdf"}},
+			}, xrplsale.WithNoRetry())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FlagDispute() = %v, want %v", err, tt.wantErr)
+			}
+			if !equalStrings(srv.sent(), tt.wantSent) {
+				t.Fatalf("sent %q, want %q", srv.sent(), tt.wantSent)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if dispute.InvestmentID != tt.investmentID || dispute.Status != xrplsale.DisputeOpen || dispute.Reason != xrplsale.DisputeTokensNotReceived || len(dispute.Evidence) != 1 {
+				t.Fatalf("FlagDispute() = %+v", dispute)
+			}
+		})
+	}
+}
+
+func TestDisputeQueries(t *testing.T) {
+	srv := newDisputeServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	ctx := context.Background()
+	
+	dispute, err := client.Investments.GetDispute(ctx, "dsp_7")
+	if err != nil || dispute.ID != "dsp_7" || dispute.Status != xrplsale.DisputeUnderReview || dispute.Status.Resolved() {
+		t.Fatalf("GetDispute() = %+v, %v", dispute, err)
+	}
+	if _, err := client.Investments.ListDisputes(ctx, "proj_1", nil); err != nil {
+		t.Fatal(err)
+	}
+	page, err := client.Investments.ListDisputes(ctx, "proj_1", &xrplsale.ListDisputesOptions{Status: xrplsale.DisputeOpen, Page: 2, Limit: 20})
+	if err != nil || len(page.Data) != 1 || page.Data[0].ProjectID != "proj_1" {
+		t.Fatalf("ListDisputes() = %+v, %v", page, err)
+	}
+	want := []string{"GET /disputes/dsp_7", "GET /projects/proj_1/disputes", "GET /projects/proj_1/disputes?limit=20&page=2&status=open"}
+	if !equalStrings(srv.sent(), want) {
+		t.Fatalf("sent %q, want %q", srv.sent(), want)
+	}
+	
+	for status, resolved := range map[xrplsale.DisputeStatus]bool{
+		xrplsale.DisputeOpen: false, xrplsale.DisputeUnderReview: false,
+		xrplsale.DisputeResolvedRefund: true, xrplsale.DisputeResolvedUpheld: true,
+	} {
+		if status.Resolved() != resolved {
+			t.Errorf("%s.Resolved() = %v, want %v", status, !resolved, resolved)
+		}
+	}
+}
+
+func TestAttachEvidence(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		description string
+		wantURL     string
+	}{
+		{"pdf with description", "receipt.pdf", "withdrawal receipt", "https://files.example/dsp_1/receipt.pdf?application/pdf&evidence"},
+		{"path without description", "/tmp/shots/screen.png", "", "https://files.example/dsp_1/screen.png?image/png&evidence"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newDisputeServer(t)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			evidence, err := client.Investments.AttachEvidence(context.Background(), "dsp_1", tt.filename, strings.NewReader("evidence"), tt.description)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if evidence.URL != tt.wantURL || evidence.Description != tt.description {
+				t.Fatalf("AttachEvidence() = %+v, want URL %q and description %q", evidence, tt.wantURL, tt.description)
+			}
+		})
+	}
+}
+
+func TestDisputeUpdatedPayload(t *testing.T) {
+	event := fixtures.WebhookEvent(xrplsale.EventDisputeUpdated)
+	event.Data = map[string]interface{}{
+		"dispute_id":      "dsp_1",
+		"investment_id":   "inv_1",
+		"status":          "resolved_refund",
+		"previous_status": "under_review",
+	}
+	var payload xrplsale.DisputeUpdatedPayload
+	if err := event.DecodeData(&payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.DisputeID != "dsp_1" || payload.Status != xrplsale.DisputeResolvedRefund || payload.PreviousStatus != xrplsale.DisputeUnderReview || !payload.Status.Resolved() {
+		t.Fatalf("DecodeData() = %+v", payload)
+	}
+}
\ No newline at end of file
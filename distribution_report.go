@@ -0,0 +1,123 @@
+package xrplsale
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// InvestorAllocation is one investor's line of a DistributionReport
+type InvestorAllocation struct {
+	InvestorAccount string
+	AmountXRP       Amount
+	TokenAmount     Amount
+	Investments     int
+	
+	// Share is TokenAmount as a percentage of the report's TotalTokens
+	Share float64
+}
+
+// DistributionReport is the token allocation of a project's confirmed
+// investments
+type DistributionReport struct {
+	Project *Project
+	Stats   *ProjectStats
+	
+	// Investors are sorted by TokenAmount, largest first, then by account
+	Investors []InvestorAllocation
+	
+	// TokensByTier totals the allocated tokens of each tier
+	TokensByTier map[int]Amount
+	
+	TotalXRP    Amount
+	TotalTokens Amount
+	
+	// InvestmentsRead counts the investments read, confirmed or not.
+	// Partial is set when the walk over them stopped early, e.g. at the
+	// deadline of WithOperationTimeout; the totals then only cover the
+	// investments read.
+	InvestmentsRead int
+	Partial         bool
+}
+
+// BuildDistributionReport totals the confirmed investments of a project by
+// investor and by tier. It reads the project, its statistics and every
+// page of investments; run it under ContextWithRequestCache alongside
+// other helpers to fetch the project only once. If reading the
+// investments fails, the partial report is returned alongside the error.
+func (ps *ProjectsService) BuildDistributionReport(ctx context.Context, projectID string, reqOpts ...RequestOption) (*DistributionReport, error) {
+	ctx, cancel := operationContext(ctx, reqOpts)
+	defer cancel()
+	
+	project, err := ps.Get(ctx, projectID, reqOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("building distribution report: %w", err)
+	}
+	stats, err := ps.GetStats(ctx, projectID, reqOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("building distribution report: %w", err)
+	}
+	
+	report := &DistributionReport{Project: project, Stats: stats, TokensByTier: make(map[int]Amount)}
+	byAccount := make(map[string]*InvestorAllocation)
+	err = ps.client.services.investments.ForEachByProject(ctx, projectID, func(investment Investment) error {
+		report.InvestmentsRead++
+		if investment.Status != InvestmentConfirmed {
+			return nil
+		}
+		allocation := byAccount[investment.InvestorAccount]
+		if allocation == nil {
+			allocation = &InvestorAllocation{InvestorAccount: investment.InvestorAccount}
+			byAccount[investment.InvestorAccount] = allocation
+		}
+		allocation.AmountXRP = allocation.AmountXRP.Add(investment.AmountXRP)
+		allocation.TokenAmount = allocation.TokenAmount.Add(investment.TokenAmount)
+		allocation.Investments++
+		report.TokensByTier[investment.Tier] = report.TokensByTier[investment.Tier].Add(investment.TokenAmount)
+		report.TotalXRP = report.TotalXRP.Add(investment.AmountXRP)
+		report.TotalTokens = report.TotalTokens.Add(investment.TokenAmount)
+		return nil
+	}, reqOpts...)
+	report.Partial = err != nil
+	report.allocate(byAccount)
+	if err != nil {
+		return report, fmt.Errorf("building distribution report: %w", err)
+	}
+	return report, nil
+}
+
+// allocate fills the report's investors, sorted, from the allocations by account
+func (report *DistributionReport) allocate(byAccount map[string]*InvestorAllocation) {
+	report.Investors = make([]InvestorAllocation, 0, len(byAccount))
+	for _, allocation := range byAccount {
+		if report.TotalTokens.Sign() > 0 {
+			share := new(big.Rat).Quo(allocation.TokenAmount.Rat(), report.TotalTokens.Rat())
+			allocation.Share, _ = share.Mul(share, big.NewRat(100, 1)).Float64()
+		}
+		report.Investors = append(report.Investors, *allocation)
+	}
+	sort.Slice(report.Investors, func(i, j int) bool {
+		a, b := report.Investors[i], report.Investors[j]
+		if c := a.TokenAmount.Cmp(b.TokenAmount); c != 0 {
+			return c > 0
+		}
+		return a.InvestorAccount < b.InvestorAccount
+	})
+}
+
+// WriteCSV writes one row per investor to w as CSV
+func (r *DistributionReport) WriteCSV(w io.Writer) error {
+	header := []string{"investor_account", "amount_xrp", "token_amount", "investments", "share_percent"}
+	return writeCSV(w, header, func(write func([]string) error) error {
+		for _, a := range r.Investors {
+			err := write([]string{a.InvestorAccount, a.AmountXRP.String(), a.TokenAmount.String(), strconv.Itoa(a.Investments), strconv.FormatFloat(a.Share, 'f', 4, 64)})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
\ No newline at end of file
@@ -0,0 +1,112 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestBuildDistributionReport(t *testing.T) {
+	srv, client, hits := newCountingClient(t)
+	gen := fixtures.New(7)
+	project := gen.Project(fixtures.WithTierCount(2))
+	srv.AddProjects(project)
+	whale, minnow := gen.Address(), gen.Address()
+	srv.AddInvestments(
+		*gen.Investment(fixtures.ForProject(project), fixtures.WithInvestor(whale), fixtures.WithAmountXRP(5000)),
+		*gen.Investment(fixtures.ForProject(project), fixtures.WithInvestor(whale), fixtures.WithAmountXRP(3000)),
+		*gen.Investment(fixtures.ForProject(project), fixtures.WithInvestor(minnow), fixtures.WithAmountXRP(10)),
+		*gen.Investment(fixtures.ForProject(project), fixtures.WithInvestor(minnow), fixtures.WithAmountXRP(900), fixtures.WithInvestmentStatus(xrplsale.InvestmentFailed)),
+	)
+	
+	ctx := xrplsale.ContextWithRequestCache(context.Background())
+	if _, err := client.Projects.Get(ctx, project.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Projects.GetStats(ctx, project.ID); err != nil {
+		t.Fatal(err)
+	}
+	report, err := client.Projects.BuildDistributionReport(ctx, project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hits.count(http.MethodGet, "/projects/"+project.ID) + hits.count(http.MethodGet, "/projects/"+project.ID+"/stats"); got != 2 {
+		t.Errorf("project and stats fetched %d times under one request cache, want 2", got)
+	}
+	
+	if len(report.Investors) != 2 || report.Investors[0].InvestorAccount != whale || report.Investors[1].InvestorAccount != minnow {
+		t.Fatalf("Investors = %+v, want the whale then the minnow", report.Investors)
+	}
+	if w := report.Investors[0]; w.Investments != 2 || !w.AmountXRP.Equal(xrplsale.MustParseAmount("8000")) {
+		t.Errorf("whale = %+v, want 2 investments of 8000 XRP", w)
+	}
+	if m := report.Investors[1]; m.Investments != 1 || !m.AmountXRP.Equal(xrplsale.MustParseAmount("10")) {
+		t.Errorf("minnow = %+v, want the failed investment left out", m)
+	}
+	if !report.TotalXRP.Equal(xrplsale.MustParseAmount("8010")) {
+		t.Errorf("TotalXRP = %s, want 8010", report.TotalXRP)
+	}
+	var tierTotal xrplsale.Amount
+	for _, tokens := range report.TokensByTier {
+		tierTotal = tierTotal.Add(tokens)
+	}
+	if !tierTotal.Equal(report.TotalTokens) {
+		t.Errorf("tiers total %s tokens, want %s", tierTotal, report.TotalTokens)
+	}
+	if share := report.Investors[0].Share + report.Investors[1].Share; share < 99.999 || share > 100.001 {
+		t.Errorf("shares add up to %v%%, want 100%%", share)
+	}
+	
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], whale+",8000,") {
+		t.Fatalf("CSV = %q, want a header and the whale first", lines)
+	}
+}
+
+func TestBuildDistributionReportMissingProject(t *testing.T) {
+	_, client, _ := newCountingClient(t)
+	if _, err := client.Projects.BuildDistributionReport(context.Background(), "proj_missing"); !errors.Is(err, xrplsale.ErrNotFound) {
+		t.Fatalf("BuildDistributionReport() = %v, want ErrNotFound", err)
+	}
+}
+func TestBuildDistributionReportPartial(t *testing.T) {
+	srv := xrplsaletest.NewServer()
+	defer srv.Close()
+	gen := fixtures.New(5)
+	project := gen.Project()
+	srv.AddProjects(project)
+	srv.AddInvestments(gen.Investments(project, 150)...)
+	// The project, its stats and the first page of investments are answered
+	client := xrplsaletest.NewClient(srv, func(c *xrplsale.Config) { c.Transport = &stallTransport{after: 3} })
+	
+	start := time.Now()
+	report, err := client.Projects.BuildDistributionReport(context.Background(), project.ID, xrplsale.WithOperationTimeout(100*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("BuildDistributionReport() = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("returned after %s, want the operation timeout to bound it", elapsed)
+	}
+	if report == nil || !report.Partial || report.InvestmentsRead != xrplsale.DefaultIteratePageSize {
+		t.Fatalf("report = %+v, want a partial report of the first page", report)
+	}
+	var total xrplsale.Amount
+	for _, investor := range report.Investors {
+		total = total.Add(investor.AmountXRP)
+	}
+	if !total.Equal(report.TotalXRP) {
+		t.Fatalf("investors total %s XRP, want the report's %s", total, report.TotalXRP)
+	}
+}
\ No newline at end of file
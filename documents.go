@@ -0,0 +1,26 @@
+package xrplsale
+
+import "time"
+
+// DocumentType is the kind of a project document
+type DocumentType string
+
+// Document types
+const (
+	DocumentLogo       DocumentType = "logo"
+	DocumentWhitepaper DocumentType = "whitepaper"
+	DocumentKYC        DocumentType = "kyc"
+	DocumentOther      DocumentType = "other"
+)
+
+// ProjectDocument is a file uploaded to a project
+type ProjectDocument struct {
+	ID          string       `json:"id"`
+	ProjectID   string       `json:"project_id"`
+	Type        DocumentType `json:"type"`
+	Filename    string       `json:"filename"`
+	ContentType string       `json:"content_type"`
+	Size        int64        `json:"size"`
+	URL         string       `json:"url"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
\ No newline at end of file
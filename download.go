@@ -0,0 +1,297 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// downloadChunkSize is the size of the reads Download copies with
+const downloadChunkSize = 32 << 10
+
+// ErrDownloadChanged is returned when a download is resumed and the server
+// no longer serves the same content
+var ErrDownloadChanged = errors.New("download changed on the server while resuming")
+
+// WithDownloadProgress makes Download call fn after every chunk written,
+// with the bytes written so far and the total size, or -1 when the server
+// did not report it
+func WithDownloadProgress(fn func(written, total int64)) RequestOption {
+	return func(ro *requestOptions) {
+		ro.downloadProgress = fn
+	}
+}
+
+// Download streams the body at rawURL into w and returns the number of
+// bytes written. rawURL is an endpoint relative to the API, or an absolute
+// URL such as ExportResult.DownloadURL; credentials are only sent to the
+// API's own host, and never follow a redirect elsewhere.
+//
+// The body is never held in memory and Config.Timeout does not apply, so
+// bound large downloads with ctx or WithRequestTimeout. Config.MaxStreamBytes
+// or WithMaxResponseBytes caps the size. When the connection
+// drops mid-stream, the download resumes where it stopped with a Range
+// request, up to Config.MaxRetries times. Request hooks and client-side
+// rate limiting are not applied.
+func (c *Client) Download(ctx context.Context, rawURL string, w io.Writer, reqOpts ...RequestOption) (int64, error) {
+	if err := c.usable(); err != nil {
+		return 0, err
+	}
+	c.prepareAuth(ctx)
+	ro := newRequestOptions(reqOpts)
+	d := &download{client: c, ro: ro, total: -1, limit: c.streamLimit(ro)}
+	// The limit applies to the whole download, across resumed requests,
+	// so it is enforced while copying rather than on each response body
+	ro.maxResponseBytes = unlimitedResponse
+	ctx, cancel := ro.context(ctx)
+	defer cancel()
+	
+	var err error
+	if d.url, err = c.resolveURL(rawURL); err != nil {
+		return 0, err
+	}
+	
+	var written int64
+	for resumes := 0; ; resumes++ {
+		body, err := d.open(ctx, written)
+		if err != nil {
+			return written, err
+		}
+		n, err := d.copy(ctx, w, body, written)
+		body.Close()
+		written += n
+		
+		var interrupted *interruptedError
+		if !errors.As(err, &interrupted) {
+			return written, err
+		}
+		if resumes >= c.config.MaxRetries {
+			return written, interrupted.err
+		}
+		c.logger.Warnf("download of %s interrupted after %d bytes, resuming: %v", d.url.Redacted(), written, interrupted.err)
+		select {
+		case <-time.After(c.config.RetryWaitTime):
+		case <-ctx.Done():
+			return written, ctx.Err()
+		}
+	}
+}
+
+// DownloadExport streams the file of a finished export into w, see
+// Client.Download
+func (as *AnalyticsService) DownloadExport(ctx context.Context, exportID string, w io.Writer, reqOpts ...RequestOption) (int64, error) {
+	return as.client.Download(ctx, fmt.Sprintf("/analytics/exports/%s/download", exportID), w, reqOpts...)
+}
+
+// interruptedError wraps a read error after which a download can resume
+type interruptedError struct {
+	err error
+}
+
+// Error implements the error interface
+func (e *interruptedError) Error() string { return e.err.Error() }
+
+// download is the state of a Download call shared by its attempts
+type download struct {
+	client *Client
+	ro     *requestOptions
+	url    *url.URL
+	limit  int64
+	
+	// total is the full size, or -1 when unknown; validator is the strong
+	// ETag or Last-Modified of the first response, used with If-Range
+	total     int64
+	validator string
+}
+
+// resolveURL returns rawURL as an absolute URL, relative to the base URL
+// unless it already is one
+func (c *Client) resolveURL(rawURL string) (*url.URL, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid download URL: %w", err)
+	}
+	if target.IsAbs() {
+		return target, nil
+	}
+	base, err := url.Parse(strings.TrimSuffix(c.config.BaseURL, "/") + "/")
+	if err != nil {
+		return nil, err
+	}
+	return base.ResolveReference(&url.URL{Path: strings.TrimPrefix(target.Path, "/"), RawQuery: target.RawQuery}), nil
+}
+
+// apiHost reports whether u is served by the API, at its base URL or a
+// fallback, so credentials may be sent to it
+func (c *Client) apiHost(u *url.URL) bool {
+	for _, raw := range append([]string{c.config.BaseURL}, c.config.FallbackBaseURLs...) {
+		base, err := url.Parse(raw)
+		if err == nil && strings.EqualFold(base.Host, u.Host) && base.Scheme == u.Scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// newRawRequest builds a request sent without resty, for bodies that must
+// be streamed. Credentials and client headers are only set for the API's
+// own host.
+func (c *Client) newRawRequest(ctx context.Context, method string, u *url.URL, body io.Reader, ro *requestOptions) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = c.httpClient.Header.Clone()
+	req.Header.Del("Content-Type")
+	if c.apiHost(u) {
+		creds := c.credentials()
+		if creds.apiKey != "" {
+			req.Header.Set("X-API-Key", creds.apiKey.Reveal())
+		}
+		if creds.authToken != "" {
+			req.Header.Set("Authorization", "Bearer "+creds.authToken.Reveal())
+		}
+		for key, value := range c.headers {
+			req.Header.Set(key, value)
+		}
+		if ro.idempotencyKey != "" {
+			req.Header.Set(IdempotencyKeyHeader, ro.idempotencyKey)
+		}
+		if c.config.Tracer != nil {
+			c.config.Tracer.Inject(ctx, req.Header)
+		}
+	}
+	for key, value := range ro.headers {
+		req.Header.Set(key, value)
+	}
+	return req, nil
+}
+
+// rawHTTPClient sends raw requests through the client's transport chain,
+// without Config.Timeout. Credentials are dropped on redirects leaving the
+// API's host.
+func (c *Client) rawHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: c.rawTransport,
+		CheckRedirect: func(next *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			if !c.apiHost(next.URL) {
+				next.Header.Del("X-API-Key")
+				next.Header.Del("Authorization")
+			}
+			return nil
+		},
+	}
+}
+
+// open requests the body from offset on
+func (d *download) open(ctx context.Context, offset int64) (io.ReadCloser, error) {
+	c := d.client
+	req, err := c.newRawRequest(ctx, http.MethodGet, d.url, nil, d.ro)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if d.validator != "" {
+			req.Header.Set("If-Range", d.validator)
+		}
+	}
+	
+	resp, err := c.rawHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxStreamErrorBody))
+		return nil, newStatusError(resp.StatusCode, resp.Header, raw, nil)
+	}
+	
+	if offset == 0 {
+		if d.limit > 0 && resp.ContentLength > d.limit {
+			resp.Body.Close()
+			return nil, &ResponseTooLargeError{Method: http.MethodGet, Endpoint: d.url.Redacted(), Limit: d.limit}
+		}
+		d.total = resp.ContentLength
+		if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			d.validator = etag
+		} else {
+			d.validator = resp.Header.Get("Last-Modified")
+		}
+		return resp.Body, nil
+	}
+	return d.resumed(resp, offset)
+}
+
+// resumed checks that a response to a Range request continues at offset
+func (d *download) resumed(resp *http.Response, offset int64) (io.ReadCloser, error) {
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), "bytes "+strconv.FormatInt(offset, 10)+"-") {
+			resp.Body.Close()
+			return nil, fmt.Errorf("resuming download: unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+		return resp.Body, nil
+	case http.StatusOK:
+		if d.validator != "" {
+			// If-Range did not match: the file is not the one being written
+			resp.Body.Close()
+			return nil, ErrDownloadChanged
+		}
+		// Ranges are not supported; skip what was already written
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("resuming download: %w", err)
+		}
+		return resp.Body, nil
+	}
+	resp.Body.Close()
+	return nil, fmt.Errorf("resuming download: unexpected HTTP status %d", resp.StatusCode)
+}
+
+// copy copies body into w, starting at offset of the whole download. A
+// read error after which the download can resume is an interruptedError.
+func (d *download) copy(ctx context.Context, w io.Writer, body io.Reader, offset int64) (int64, error) {
+	buf := make([]byte, downloadChunkSize)
+	var n int64
+	for {
+		read, err := body.Read(buf)
+		if d.limit > 0 && offset+n+int64(read) > d.limit {
+			keep := d.limit - offset - n
+			if _, werr := w.Write(buf[:keep]); werr != nil {
+				return n, werr
+			}
+			return n + keep, &ResponseTooLargeError{Method: http.MethodGet, Endpoint: d.url.Redacted(), Limit: d.limit, Read: offset + n + int64(read)}
+		}
+		if read > 0 {
+			if _, werr := w.Write(buf[:read]); werr != nil {
+				return n, werr
+			}
+			n += int64(read)
+			if d.ro.downloadProgress != nil {
+				d.ro.downloadProgress(offset+n, d.total)
+			}
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return n, ctxErr
+		}
+		if err == io.EOF {
+			if d.total >= 0 && offset+n < d.total {
+				return n, &interruptedError{err: io.ErrUnexpectedEOF}
+			}
+			return n, nil
+		}
+		if err != nil {
+			return n, &interruptedError{err: err}
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,172 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// downloadContent is several chunks long, and not a multiple of the chunk size
+var downloadContent = func() []byte {
+	content := make([]byte, 1<<20+123)
+	for i := range content {
+		content[i] = byte(i * 7 % 251)
+	}
+	return content
+}()
+
+// serveDownload serves downloadContent with Range support
+func serveDownload(etag string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(downloadContent))
+	}
+}
+
+// abortDownload announces downloadContent, then drops the connection after cut bytes
+func abortDownload(etag string, cut int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(downloadContent)))
+		w.Write(downloadContent[:cut])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// ignoreRange serves downloadContent whole, whatever the request's Range
+func ignoreRange(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Length", strconv.Itoa(len(downloadContent)))
+	w.Write(downloadContent)
+}
+
+func TestDownload(t *testing.T) {
+	const cut = 300_000
+	tests := []struct {
+		name        string
+		attempts    []http.HandlerFunc
+		wantErr     error
+		wantWritten int
+		wantRange   string
+	}{
+		{"streams in chunks", []http.HandlerFunc{serveDownload(`"v1"`)}, nil, len(downloadContent), ""},
+		{"resumes with Range", []http.HandlerFunc{abortDownload(`"v1"`, cut), serveDownload(`"v1"`)}, nil, len(downloadContent), "bytes=300000-"},
+		{"resumes without Range support", []http.HandlerFunc{abortDownload("", cut), ignoreRange}, nil, len(downloadContent), "bytes=300000-"},
+		{"changed while resuming", []http.HandlerFunc{abortDownload(`"v1"`, cut), serveDownload(`"v2"`)}, xrplsale.ErrDownloadChanged, cut, "bytes=300000-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []*http.Request
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				attempt := len(requests)
+				requests = append(requests, r.Clone(context.Background()))
+				mu.Unlock()
+				if attempt >= len(tt.attempts) {
+					t.Errorf("unexpected request %d", attempt+1)
+					return
+				}
+				tt.attempts[attempt](w, r)
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, RetryWaitTime: time.Millisecond})
+			
+			var out bytes.Buffer
+			var last, total int64
+			progress := xrplsale.WithDownloadProgress(func(written, size int64) {
+				if written <= last {
+					t.Errorf("progress went from %d to %d", last, written)
+				}
+				last, total = written, size
+			})
+			written, err := client.Analytics.DownloadExport(context.Background(), "exp_1", &out, progress)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DownloadExport() = %v, want %v", err, tt.wantErr)
+			}
+			if written != int64(tt.wantWritten) || !bytes.Equal(out.Bytes(), downloadContent[:tt.wantWritten]) {
+				t.Fatalf("wrote %d bytes (%d buffered), want the first %d of the content", written, out.Len(), tt.wantWritten)
+			}
+			if last != written || total != int64(len(downloadContent)) {
+				t.Errorf("last progress %d/%d, want %d/%d", last, total, written, len(downloadContent))
+			}
+			
+			if len(requests) != len(tt.attempts) {
+				t.Fatalf("%d requests, want %d", len(requests), len(tt.attempts))
+			}
+			for i, r := range requests {
+				if r.URL.Path != "/analytics/exports/exp_1/download" || r.Header.Get("X-API-Key") != "key" {
+					t.Errorf("request %d to %s with key %q", i+1, r.URL.Path, r.Header.Get("X-API-Key"))
+				}
+			}
+			if got := requests[len(requests)-1].Header.Get("Range"); got != tt.wantRange {
+				t.Errorf("last request Range %q, want %q", got, tt.wantRange)
+			}
+		})
+	}
+}
+
+func TestDownloadCancelledMidStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(downloadContent)))
+		w.Write(downloadContent[:128<<10])
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, RetryWaitTime: time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	
+	var out bytes.Buffer
+	done := make(chan struct{})
+	var written int64
+	var err error
+	go func() {
+		defer close(done)
+		written, err = client.Download(ctx, "/exports/big.csv", &out, xrplsale.WithDownloadProgress(func(written, total int64) {
+			if written >= 64<<10 {
+				cancel()
+			}
+		}))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Download() did not return after its context was cancelled")
+	}
+	if !errors.Is(err, context.Canceled) || written < 64<<10 || written > 128<<10 || int64(out.Len()) != written {
+		t.Fatalf("Download() = %d, %v with %d buffered; want a partial download and context.Canceled", written, err, out.Len())
+	}
+}
+
+func TestDownloadCredentialsStayOnAPIHost(t *testing.T) {
+	var got http.Header
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte("csv"))
+	}))
+	defer storage.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: "http://127.0.0.1:1"})
+	client.SetAuthToken("token")
+	
+	var out bytes.Buffer
+	if n, err := client.Download(context.Background(), storage.URL+"/signed/export.csv?sig=abc", &out); err != nil || n != 3 || out.String() != "csv" {
+		t.Fatalf("Download() = %d, %v; want the 3 byte file", n, err)
+	}
+	if got.Get("X-API-Key") != "" || got.Get("Authorization") != "" {
+		t.Errorf("credentials sent to another host: %v", got)
+	}
+}
\ No newline at end of file
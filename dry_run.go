@@ -0,0 +1,112 @@
+package xrplsale
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrDryRun is matched by the error returned for a mutating request made in
+// dry-run mode, which was prepared but not sent
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// DryRunRequest is a request dry-run mode kept from being sent, exactly as
+// it would have gone on the wire
+type DryRunRequest struct {
+	Method string
+	URL    string
+	
+	// Header holds the request headers, with credentials masked
+	Header http.Header
+	
+	// Body is the request body, decompressed if it was gzipped; nil when
+	// there is none
+	Body []byte
+}
+
+// WithDryRun prepares this request without sending it if it is a POST, PUT,
+// PATCH or DELETE, as Config.DryRun does for every request
+func WithDryRun() RequestOption {
+	return func(ro *requestOptions) {
+		ro.dryRun = true
+	}
+}
+
+// dryRunKey marks a request context whose request must not be sent
+type dryRunKey struct{}
+
+// isMutating reports whether a request with method changes state on the API
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// dryRunLog collects the requests kept from being sent
+type dryRunLog struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+// dryRunTransport intercepts dry-run requests below signing and compression,
+// so what it records is what would have been sent
+type dryRunTransport struct {
+	base http.RoundTripper
+	log  *dryRunLog
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if dryRun, _ := req.Context().Value(dryRunKey{}).(bool); !dryRun {
+		return t.base.RoundTrip(req)
+	}
+	
+	recorded := DryRunRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+	}
+	redactHeader(recorded.Header)
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if req.Header.Get("Content-Encoding") == "gzip" {
+			if zr, err := gzip.NewReader(bytes.NewReader(body)); err == nil {
+				if plain, err := io.ReadAll(zr); err == nil {
+					body = plain
+				}
+			}
+		}
+		recorded.Body = body
+	}
+	
+	t.log.mu.Lock()
+	t.log.requests = append(t.log.requests, recorded)
+	t.log.mu.Unlock()
+	return nil, ErrDryRun
+}
+
+// DryRunRequests returns the requests dry-run mode kept from being sent, in
+// the order they were made, including those of derived clients
+func (c *Client) DryRunRequests() []DryRunRequest {
+	c.dryRuns.mu.Lock()
+	defer c.dryRuns.mu.Unlock()
+	return append([]DryRunRequest(nil), c.dryRuns.requests...)
+}
+
+// withDryRun marks ctx when a request made with it must not be sent
+func (c *Client) withDryRun(ctx context.Context, method string, ro *requestOptions) context.Context {
+	if (c.config.DryRun || ro.dryRun) && isMutating(method) {
+		return context.WithValue(ctx, dryRunKey{}, true)
+	}
+	return ctx
+}
\ No newline at end of file
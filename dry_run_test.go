@@ -0,0 +1,76 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestDryRun(t *testing.T) {
+	project := &xrplsale.CreateProjectRequest{Name: "Dry Run", TokenSymbol: "DRY", TotalSupply: "1000"}
+	tests := []struct {
+		name     string
+		config   func(*xrplsale.Config)
+		opts     []xrplsale.RequestOption
+		wantErr  bool
+		wantHeld bool
+	}{
+		{"Config.DryRun", func(c *xrplsale.Config) { c.DryRun = true }, nil, true, true},
+		{"DryRunEmptyResult", func(c *xrplsale.Config) { c.DryRun, c.DryRunEmptyResult = true, true }, nil, false, true},
+		{"WithDryRun", func(*xrplsale.Config) {}, []xrplsale.RequestOption{xrplsale.WithDryRun()}, true, true},
+		{"off", func(*xrplsale.Config) {}, nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client, hits := newCountingClient(t, tt.config)
+			var hooked atomic.Int32
+			client.OnRequest(func(ctx context.Context, info *xrplsale.RequestInfo) error {
+				hooked.Add(1)
+				return nil
+			})
+			ctx := context.Background()
+			
+			_, err := client.Projects.Create(ctx, project, tt.opts...)
+			if got := errors.Is(err, xrplsale.ErrDryRun); got != tt.wantErr || !tt.wantErr && err != nil {
+				t.Fatalf("Create() = %v, want ErrDryRun %v", err, tt.wantErr)
+			}
+			if hooked.Load() != 1 {
+				t.Errorf("request hook ran %d times, want 1", hooked.Load())
+			}
+			
+			requests := client.DryRunRequests()
+			if !tt.wantHeld {
+				if len(requests) != 0 || hits.count(http.MethodPost, "/projects") != 1 {
+					t.Fatalf("%d dry-run requests and %d sent, want the request sent", len(requests), hits.total())
+				}
+				return
+			}
+			// Nothing reached the wire, and the request was not retried
+			if hits.total() != 0 || len(requests) != 1 {
+				t.Fatalf("%d requests sent and %d recorded, want 0 and 1", hits.total(), len(requests))
+			}
+			var body xrplsale.CreateProjectRequest
+			if err := json.Unmarshal(requests[0].Body, &body); err != nil || body.Name != "Dry Run" {
+				t.Errorf("recorded body %s, want the project", requests[0].Body)
+			}
+			if r := requests[0]; r.Method != http.MethodPost || !strings.HasSuffix(r.URL, "/projects") {
+				t.Errorf("recorded %s %s, want POST /projects", r.Method, r.URL)
+			}
+			if key := requests[0].Header.Get("X-API-Key"); key == "" || key == xrplsaletest.APIKey {
+				t.Errorf("recorded X-API-Key %q, want it masked", key)
+			}
+			
+			// Reads still go out
+			if _, err := client.Projects.List(ctx, nil); err != nil || hits.count(http.MethodGet, "/projects") != 1 {
+				t.Errorf("List() = %v after %d GETs, want it sent", err, hits.count(http.MethodGet, "/projects"))
+			}
+		})
+	}
+}
\ No newline at end of file
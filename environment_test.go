@@ -0,0 +1,63 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestEnvironmentBaseURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment xrplsale.Environment
+		baseURL     string
+		wantURL     string
+		wantErr     error
+	}{
+		{"default", "", "", xrplsale.ProductionBaseURL, nil},
+		{"production", xrplsale.Production, "", xrplsale.ProductionBaseURL, nil},
+		{"testnet", xrplsale.Testnet, "", xrplsale.TestnetBaseURL, nil},
+		{"staging", xrplsale.Staging, "", xrplsale.StagingBaseURL, nil},
+		{"devnet", xrplsale.Devnet, "", xrplsale.DevnetBaseURL, nil},
+		{"BaseURL overrides", xrplsale.Staging, "https://staging.internal/v1", "https://staging.internal/v1", nil},
+		{"unknown", "prod", "", "", xrplsale.ErrUnknownEnvironment},
+		{"unknown with BaseURL", "Staging", "https://staging.internal/v1", "", xrplsale.ErrUnknownEnvironment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				sent = append(sent, req.URL.String())
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+					Request:    req,
+				}, nil
+			})
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", Environment: tt.environment, BaseURL: tt.baseURL, Transport: transport})
+			
+			err := client.Get(context.Background(), "/projects", nil, nil)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), string(tt.environment)) {
+					t.Fatalf("Get() = %v, want %v naming %q", err, tt.wantErr, tt.environment)
+				}
+				if len(sent) != 0 {
+					t.Fatalf("sent %q, want nothing sent", sent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sent) != 1 || sent[0] != tt.wantURL+"/projects" {
+				t.Fatalf("sent %q, want %s/projects", sent, tt.wantURL)
+			}
+		})
+	}
+}
\ No newline at end of file
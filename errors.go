@@ -0,0 +1,274 @@
+package xrplsale
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Sentinel errors matched by the typed API errors via errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrConflict     = errors.New("conflict")
+)
+
+// APIError represents an error returned by the XRPL.Sale API
+type APIError struct {
+	Message string                 `json:"message"`
+	Code    string                 `json:"code,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	
+	// StatusCode is the HTTP status of the response
+	StatusCode int `json:"-"`
+	
+	// RequestID is the X-Request-ID the API assigned, to quote to support
+	RequestID string `json:"-"`
+	
+	// RawBody is the unparsed response body, kept even when it is not JSON
+	RawBody []byte `json:"-"`
+	
+	// IdempotencyKey is the Idempotency-Key the failed call was sent with
+	IdempotencyKey string `json:"-"`
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := e.Message
+	if e.StatusCode != 0 {
+		msg = fmt.Sprintf("%d: %s", e.StatusCode, msg)
+	}
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s (code: %s)", msg, e.Code)
+	}
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s [request %s]", msg, e.RequestID)
+	}
+	return msg
+}
+
+// NotFoundError is returned when the requested resource does not exist (404)
+type NotFoundError struct {
+	*APIError
+}
+
+// Is reports whether target is ErrNotFound
+func (e *NotFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// Unwrap returns the underlying API error
+func (e *NotFoundError) Unwrap() error { return e.APIError }
+
+// RateLimitError is returned when the client is being throttled (429)
+type RateLimitError struct {
+	*APIError
+	
+	// RetryAfter is how long the API asked the client to wait, zero if unspecified
+	RetryAfter time.Duration
+}
+
+// Is reports whether target is ErrRateLimited
+func (e *RateLimitError) Is(target error) bool { return target == ErrRateLimited }
+
+// Unwrap returns the underlying API error
+func (e *RateLimitError) Unwrap() error { return e.APIError }
+
+// ConflictError is returned when the request conflicts with the resource's
+// current state (409), e.g. registering a webhook URL that already exists
+type ConflictError struct {
+	*APIError
+}
+
+// Is reports whether target is ErrConflict
+func (e *ConflictError) Is(target error) bool { return target == ErrConflict }
+
+// Unwrap returns the underlying API error
+func (e *ConflictError) Unwrap() error { return e.APIError }
+
+// FieldError describes a validation failure for a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when the API rejects the request payload (400, 422)
+type ValidationError struct {
+	*APIError
+	
+	// Fields lists the per-field errors reported by the API
+	Fields []FieldError
+}
+
+// Is reports whether target is ErrValidation
+func (e *ValidationError) Is(target error) bool { return target == ErrValidation }
+
+// Unwrap returns the underlying API error
+func (e *ValidationError) Unwrap() error { return e.APIError }
+
+// AuthError is returned when the request is not authenticated (401) or not permitted (403)
+type AuthError struct {
+	*APIError
+}
+
+// Is reports whether target is ErrUnauthorized or, for 403 responses, ErrForbidden
+func (e *AuthError) Is(target error) bool {
+	if e.StatusCode == http.StatusForbidden {
+		return target == ErrForbidden
+	}
+	return target == ErrUnauthorized
+}
+
+// Unwrap returns the underlying API error
+func (e *AuthError) Unwrap() error { return e.APIError }
+
+// PermissionError is returned when the API rejects a request with 403 Forbidden
+type PermissionError struct {
+	*AuthError
+	
+	// RequiredScope names the permission the caller is missing, when the API reports it
+	RequiredScope string
+}
+
+// Error implements the error interface
+func (e *PermissionError) Error() string {
+	if e.RequiredScope != "" {
+		return fmt.Sprintf("%s (requires scope %s)", e.APIError.Error(), e.RequiredScope)
+	}
+	return e.APIError.Error()
+}
+
+// Unwrap returns the underlying auth error
+func (e *PermissionError) Unwrap() error { return e.AuthError }
+
+// errorBody holds the parts of an error response beyond the APIError fields
+type errorBody struct {
+	RequiredScope string          `json:"required_scope"`
+	Errors        json.RawMessage `json:"errors"`
+}
+
+// newResponseError converts an error response into the typed error for its
+// status code. requestID is used when the response carries no request ID.
+func newResponseError(resp *resty.Response, requestID string) error {
+	err := newStatusError(resp.StatusCode(), resp.Header(), resp.Body(), nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RequestID == "" {
+		apiErr.RequestID = requestID
+	}
+	return err
+}
+
+// newStatusError builds the typed error for an error status from its parts.
+// When apiErr is nil the body is decoded into a fresh APIError.
+func newStatusError(status int, header http.Header, rawBody []byte, apiErr *APIError) error {
+	jsonErrBody := jsonBody(header, rawBody)
+	if apiErr == nil {
+		apiErr = &APIError{}
+		_ = json.Unmarshal(jsonErrBody, apiErr)
+	}
+	apiErr.StatusCode = status
+	apiErr.RequestID = header.Get(RequestIDHeader)
+	apiErr.RawBody = rawBody
+	if apiErr.Message == "" {
+		apiErr.Message = fmt.Sprintf("API error: %s", http.StatusText(status))
+		// A proxy or load balancer error page is the only clue to what failed
+		if !json.Valid(jsonErrBody) {
+			if snippet := bodySnippet(rawBody); snippet != "" {
+				apiErr.Message += ": " + snippet
+			}
+		}
+	}
+	
+	var body errorBody
+	_ = json.Unmarshal(jsonErrBody, &body)
+	
+	switch status {
+	case http.StatusNotFound:
+		return &NotFoundError{APIError: apiErr}
+	case http.StatusConflict:
+		return &ConflictError{APIError: apiErr}
+	case http.StatusTooManyRequests:
+		return &RateLimitError{APIError: apiErr, RetryAfter: parseRetryAfter(header.Get("Retry-After"))}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &ValidationError{APIError: apiErr, Fields: parseFieldErrors(body.Errors)}
+	case http.StatusUnauthorized:
+		return &AuthError{APIError: apiErr}
+	case http.StatusForbidden:
+		scope := body.RequiredScope
+		if scope == "" {
+			scope, _ = apiErr.Details["required_scope"].(string)
+		}
+		return &PermissionError{
+			AuthError:     &AuthError{APIError: apiErr},
+			RequiredScope: scope,
+		}
+	}
+	return apiErr
+}
+
+// maxErrorSnippet bounds how much of a non-JSON error body goes into an error message
+const maxErrorSnippet = 200
+
+// bodySnippet returns the start of body on a single line
+func bodySnippet(body []byte) string {
+	if len(body) > 4*maxErrorSnippet {
+		body = body[:4*maxErrorSnippet]
+	}
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if len(snippet) <= maxErrorSnippet {
+		return snippet
+	}
+	cut := maxErrorSnippet
+	for cut > 0 && !utf8.RuneStart(snippet[cut]) {
+		cut--
+	}
+	return snippet[:cut] + "..."
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// parseFieldErrors accepts both a list of field errors and a map of field to messages
+func parseFieldErrors(raw json.RawMessage) []FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+	
+	var list []FieldError
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	
+	var byField map[string][]string
+	if err := json.Unmarshal(raw, &byField); err == nil {
+		fields := make([]FieldError, 0, len(byField))
+		for field, messages := range byField {
+			for _, message := range messages {
+				fields = append(fields, FieldError{Field: field, Message: message})
+			}
+		}
+		return fields
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,183 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		sentinel    error
+		wantCode    string
+		wantMessage string
+	}{
+		{"structured body", http.StatusConflict, "application/json", `{"message":"slug taken","code":"slug_conflict"}`, xrplsale.ErrConflict, "slug_conflict", "slug taken"},
+		{"HTML error page", http.StatusBadGateway, "text/html", "<html>\n<body><h1>502 Bad Gateway</h1></body>\n</html>", nil, "", "<html> <body><h1>502 Bad Gateway</h1></body> </html>"},
+		{"not found", http.StatusNotFound, "application/json", `{"message":"no such project"}`, xrplsale.ErrNotFound, "", "no such project"},
+		{"unauthorized", http.StatusUnauthorized, "application/json", `{}`, xrplsale.ErrUnauthorized, "", "Unauthorized"},
+		{"forbidden", http.StatusForbidden, "application/json", `{"message":"nope","required_scope":"projects:write"}`, xrplsale.ErrForbidden, "", "requires scope projects:write"},
+		{"validation", http.StatusUnprocessableEntity, "application/json", `{"message":"invalid","errors":{"name":["is required"]}}`, xrplsale.ErrValidation, "", "invalid"},
+	}
+	for _, tt := range tests {
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			t.Run(tt.name+"/"+method, func(t *testing.T) {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", tt.contentType)
+					w.Header().Set(xrplsale.RequestIDHeader, "req_123")
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.body))
+				}))
+				defer srv.Close()
+				client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+				
+				var out map[string]interface{}
+				var err error
+				if method == http.MethodGet {
+					err = client.Get(context.Background(), "/projects/p1", nil, &out, xrplsale.WithNoRetry())
+				} else {
+					err = client.Post(context.Background(), "/projects", map[string]string{"name": "x"}, &out, xrplsale.WithNoRetry())
+				}
+				
+				var apiErr *xrplsale.APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("error = %v, want an APIError", err)
+				}
+				if apiErr.StatusCode != tt.status || apiErr.RequestID != "req_123" || apiErr.Code != tt.wantCode {
+					t.Errorf("APIError = status %d, request %q, code %q; want %d, req_123, %q", apiErr.StatusCode, apiErr.RequestID, apiErr.Code, tt.status, tt.wantCode)
+				}
+				if string(apiErr.RawBody) != tt.body {
+					t.Errorf("RawBody = %q, want %q", apiErr.RawBody, tt.body)
+				}
+				if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+					t.Errorf("error = %v, want it to match %v", err, tt.sentinel)
+				}
+				msg := err.Error()
+				for _, want := range []string{strconv.Itoa(tt.status), tt.wantMessage, tt.wantCode} {
+					if !strings.Contains(msg, want) {
+						t.Errorf("Error() = %q, want it to contain %q", msg, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestTypedErrorDetails(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header map[string]string
+		body   string
+		check  func(t *testing.T, err error)
+	}{
+		{"retry after", http.StatusTooManyRequests, map[string]string{"Retry-After": "7"}, `{"message":"slow down"}`, func(t *testing.T, err error) {
+			var rateErr *xrplsale.RateLimitError
+			if !errors.As(err, &rateErr) || rateErr.RetryAfter != 7*time.Second {
+				t.Fatalf("error = %#v, want RateLimitError with RetryAfter 7s", err)
+			}
+		}},
+		{"field list", http.StatusBadRequest, nil, `{"message":"bad","errors":[{"field":"slug","message":"taken"}]}`, func(t *testing.T, err error) {
+			var valErr *xrplsale.ValidationError
+			if !errors.As(err, &valErr) || len(valErr.Fields) != 1 || valErr.Fields[0] != (xrplsale.FieldError{Field: "slug", Message: "taken"}) {
+				t.Fatalf("error = %#v, want the slug field error", err)
+			}
+		}},
+		{"scope in details", http.StatusForbidden, nil, `{"message":"no","details":{"required_scope":"admin"}}`, func(t *testing.T, err error) {
+			var permErr *xrplsale.PermissionError
+			if !errors.As(err, &permErr) || permErr.RequiredScope != "admin" {
+				t.Fatalf("error = %#v, want PermissionError requiring admin", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			var out map[string]interface{}
+			tt.check(t, client.Get(context.Background(), "/projects", nil, &out, xrplsale.WithNoRetry()))
+		})
+	}
+}
+func TestErrorBodies(t *testing.T) {
+	longPage := "<html>" + strings.Repeat("é", 300) + "</html>"
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		nilResult   bool
+		wantErr     bool
+		wantMessage string
+	}{
+		{"nil result", http.StatusOK, "application/json", `{"id":"p1"}`, true, false, ""},
+		{"nil result with error", http.StatusBadRequest, "application/json", `{"message":"bad"}`, true, true, "bad"},
+		{"HTML error page", http.StatusBadGateway, "text/html", "<html><h1>502</h1></html>", false, true, "API error: Bad Gateway: <html><h1>502</h1></html>"},
+		{"long HTML error page", http.StatusBadGateway, "text/html", longPage, false, true, "API error: Bad Gateway: <html>" + strings.Repeat("é", 97) + "..."},
+		{"empty 500", http.StatusInternalServerError, "", "", false, true, "API error: Internal Server Error"},
+		{"JSON error", http.StatusBadRequest, "application/json", `{"message":"name is required","code":"invalid"}`, false, true, "name is required"},
+	}
+	for _, tt := range tests {
+		for _, method := range []string{http.MethodGet, http.MethodPut} {
+			t.Run(tt.name+"/"+method, func(t *testing.T) {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if tt.contentType != "" {
+						w.Header().Set("Content-Type", tt.contentType)
+					}
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.body))
+				}))
+				defer srv.Close()
+				client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+				
+				var out map[string]interface{}
+				var result interface{} = &out
+				if tt.nilResult {
+					result = nil
+				}
+				var err error
+				if method == http.MethodGet {
+					err = client.Get(context.Background(), "/projects/p1", nil, result, xrplsale.WithNoRetry())
+				} else {
+					err = client.Request(context.Background(), method, "/projects/p1", map[string]string{"name": "x"}, result, xrplsale.WithNoRetry())
+				}
+				
+				if !tt.wantErr {
+					if err != nil {
+						t.Fatalf("error = %v, want nil", err)
+					}
+					return
+				}
+				var apiErr *xrplsale.APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+					t.Fatalf("error = %v, want an APIError with status %d", err, tt.status)
+				}
+				if apiErr.Message != tt.wantMessage {
+					t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMessage)
+				}
+				if !strings.Contains(err.Error(), strconv.Itoa(tt.status)) {
+					t.Errorf("Error() = %q, want it to contain the status", err.Error())
+				}
+			})
+		}
+	}
+}
\ No newline at end of file
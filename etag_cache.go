@@ -0,0 +1,91 @@
+package xrplsale
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+)
+
+// DefaultETagCacheSize is the number of responses kept by the ETag cache
+// when Config.ETagCacheSize is zero
+const DefaultETagCacheSize = 256
+
+// etagEntry is a response remembered with its ETag
+type etagEntry struct {
+	key  string
+	etag string
+	cachedResponse
+}
+
+// etagCache is a concurrency-safe LRU of GET responses keyed by path and
+// query, used to revalidate them with If-None-Match
+type etagCache struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List
+	items map[string]*list.Element
+}
+
+// newETagCache returns an empty cache holding at most max entries
+func newETagCache(max int) *etagCache {
+	if max <= 0 {
+		max = DefaultETagCacheSize
+	}
+	return &etagCache{
+		max:   max,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get returns the entry for key, marking it most recently used
+func (c *etagCache) get(key string) (*etagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*etagEntry), true
+}
+
+// put stores resp and its etag under key, evicting the least recently used
+// entry when the cache is full
+func (c *etagCache) put(key, etag string, resp cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	entry := &etagEntry{key: key, etag: etag, cachedResponse: resp}
+	if elem, ok := c.items[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	
+	c.items[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*etagEntry).key)
+	}
+}
+
+// revalidated updates the ETag cache with a GET response. A 304 is given the
+// body of the cached entry it revalidated, and its Content-Type.
+func (c *Client) revalidated(key string, entry *etagEntry, resp *Response) {
+	if resp.StatusCode == http.StatusNotModified {
+		if entry != nil {
+			resp.Body = entry.body
+			if contentType := entry.header.Get("Content-Type"); contentType != "" {
+				resp.Header = resp.Header.Clone()
+				resp.Header.Set("Content-Type", contentType)
+			}
+		}
+		return
+	}
+	if tag := resp.Header.Get("ETag"); tag != "" {
+		c.etags.put(key, tag, cached(resp))
+	}
+}
\ No newline at end of file
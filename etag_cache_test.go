@@ -0,0 +1,177 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+)
+
+// etagServer serves a project and a generic /items list, tagging each
+// response with an ETag derived from its full URL and answering a matching
+// If-None-Match with 304
+type etagServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	notModified map[string]int
+	full        map[string]int
+}
+
+func newETagServer(t *testing.T, project *xrplsale.Project) *etagServer {
+	t.Helper()
+	es := &etagServer{notModified: map[string]int{}, full: map[string]int{}}
+	es.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri := r.URL.RequestURI()
+		tag := fmt.Sprintf("%q", uri)
+		es.mu.Lock()
+		defer es.mu.Unlock()
+		if r.Header.Get("If-None-Match") == tag {
+			es.notModified[uri]++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		es.full[uri]++
+		w.Header().Set("ETag", tag)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/projects/"+project.ID {
+			json.NewEncoder(w).Encode(project)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"uri": uri})
+	}))
+	t.Cleanup(es.Close)
+	return es
+}
+
+func (es *etagServer) counts(uri string) (full, notModified int) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.full[uri], es.notModified[uri]
+}
+
+func TestETagCacheNotModified(t *testing.T) {
+	project := fixtures.Project()
+	tests := []struct {
+		name            string
+		enabled         bool
+		wantFull        int
+		wantNotModified int
+	}{
+		{"disabled", false, 3, 0},
+		{"enabled", true, 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newETagServer(t, project)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, EnableETagCache: tt.enabled})
+			for i := 0; i < 3; i++ {
+				got, err := client.Projects.Get(context.Background(), project.ID)
+				if err != nil {
+					t.Fatalf("call %d: %v", i, err)
+				}
+				if got.ID != project.ID || got.Name != project.Name {
+					t.Fatalf("call %d returned %+v, want project %s", i, got, project.ID)
+				}
+			}
+			full, notModified := srv.counts("/projects/" + project.ID)
+			if full != tt.wantFull || notModified != tt.wantNotModified {
+				t.Fatalf("%d full responses and %d 304s, want %d and %d", full, notModified, tt.wantFull, tt.wantNotModified)
+			}
+		})
+	}
+}
+
+func TestETagCacheKeyedByURL(t *testing.T) {
+	srv := newETagServer(t, fixtures.Project())
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, EnableETagCache: true})
+	calls := []struct {
+		params map[string]string
+		want   string
+	}{
+		{map[string]string{"page": "1"}, "/items?page=1"},
+		{map[string]string{"page": "2"}, "/items?page=2"},
+		{map[string]string{"page": "1"}, "/items?page=1"},
+		{nil, "/items"},
+		{map[string]string{"page": "2"}, "/items?page=2"},
+	}
+	for i, call := range calls {
+		var got map[string]string
+		if err := client.Get(context.Background(), "/items", call.params, &got); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		if got["uri"] != call.want {
+			t.Fatalf("call %d decoded the body for %q, want %q", i, got["uri"], call.want)
+		}
+	}
+	for uri, want := range map[string][2]int{"/items?page=1": {1, 1}, "/items?page=2": {1, 1}, "/items": {1, 0}} {
+		if full, notModified := srv.counts(uri); full != want[0] || notModified != want[1] {
+			t.Errorf("%s: %d full responses and %d 304s, want %d and %d", uri, full, notModified, want[0], want[1])
+		}
+	}
+}
+
+func TestETagCacheEviction(t *testing.T) {
+	srv := newETagServer(t, fixtures.Project())
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, EnableETagCache: true, ETagCacheSize: 2})
+	get := func(page string) {
+		t.Helper()
+		var got map[string]string
+		if err := client.Get(context.Background(), "/items", map[string]string{"page": page}, &got); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Reading page 1 again keeps it ahead of page 2, so page 3 evicts page 2
+	get("1")
+	get("2")
+	get("1")
+	get("3")
+	get("1")
+	get("2")
+	for uri, want := range map[string][2]int{"/items?page=1": {1, 2}, "/items?page=2": {2, 0}, "/items?page=3": {1, 0}} {
+		if full, notModified := srv.counts(uri); full != want[0] || notModified != want[1] {
+			t.Errorf("%s: %d full responses and %d 304s, want %d and %d", uri, full, notModified, want[0], want[1])
+		}
+	}
+}
+
+func TestETagCacheConcurrent(t *testing.T) {
+	project := fixtures.Project()
+	srv := newETagServer(t, project)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, EnableETagCache: true, ETagCacheSize: 4})
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				got, err := client.Projects.Get(context.Background(), project.ID)
+				if err == nil && got.ID != project.ID {
+					err = fmt.Errorf("got project %q", got.ID)
+				}
+				errs <- err
+				return
+			}
+			var got map[string]string
+			page := fmt.Sprint(i % 8)
+			err := client.Get(context.Background(), "/items", map[string]string{"page": page}, &got)
+			if err == nil && got["uri"] != "/items?page="+page {
+				err = fmt.Errorf("page %s decoded %q", page, got["uri"])
+			}
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,106 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// existsServer answers HEAD and OPTIONS by the last path segment: "found",
+// "missing", "forbidden" or "broken". Error bodies are written, which
+// net/http drops for HEAD.
+func existsServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Allow", "GET, HEAD, OPTIONS, PATCH, DELETE")
+		switch r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:] {
+		case "found":
+			w.Header().Set("ETag", `"v1"`)
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"not_found","message":"not found"}}`))
+		case "forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":"forbidden","message":"forbidden"}}`))
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), sent...)
+	}
+}
+
+func TestExists(t *testing.T) {
+	srv, sent := existsServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	ctx := context.Background()
+	
+	tests := []struct {
+		id   string
+		want bool
+		// wantStatus is the status of the APIError returned, if any
+		wantStatus int
+	}{
+		{"found", true, 0},
+		{"missing", false, 0},
+		{"forbidden", false, http.StatusForbidden},
+		{"broken", false, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		for service, exists := range map[string]func(context.Context, string, ...xrplsale.RequestOption) (bool, error){
+			"projects": client.Projects.Exists,
+			"webhooks": client.Webhooks.Exists,
+		} {
+			got, err := exists(ctx, tt.id, xrplsale.WithNoRetry())
+			var apiErr *xrplsale.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == tt.wantStatus {
+				err = nil
+			}
+			if got != tt.want || err != nil || (tt.wantStatus != 0 && apiErr == nil) {
+				t.Errorf("%s Exists(%s) = %v, %v; want %v with status %d", service, tt.id, got, err, tt.want, tt.wantStatus)
+			}
+			if last := sent()[len(sent())-1]; last != "HEAD /"+service+"/"+tt.id {
+				t.Errorf("%s Exists(%s) sent %s, want a HEAD", service, tt.id, last)
+			}
+		}
+	}
+}
+
+func TestHeadAndOptions(t *testing.T) {
+	srv, _ := existsServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	ctx := context.Background()
+	
+	resp, err := client.Head(ctx, "/projects/found")
+	if err != nil || resp.StatusCode != http.StatusOK || resp.Header.Get("ETag") != `"v1"` || len(resp.Body) != 0 {
+		t.Fatalf("Head() = %+v, %v; want 200 with headers and no body", resp, err)
+	}
+	
+	// Errors decode without a body
+	resp, err = client.Head(ctx, "/projects/forbidden")
+	var apiErr *xrplsale.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("Head() = %v, want a 403 APIError", err)
+	}
+	
+	resp, err = client.Options(ctx, "/projects/found")
+	if err != nil || resp.Header.Get("Allow") != "GET, HEAD, OPTIONS, PATCH, DELETE" {
+		t.Fatalf("Options() = %+v, %v; want the Allow header", resp, err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,137 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// DefaultFailoverStickiness is how long a fallback base URL keeps serving
+// requests after a failover before the primary is tried again
+const DefaultFailoverStickiness = 5 * time.Minute
+
+// failover tracks which base URL requests are sent to when
+// Config.FallbackBaseURLs is set
+type failover struct {
+	// bases holds the primary base URL, then the fallbacks in order
+	bases      []string
+	stickiness time.Duration
+	
+	mu     sync.Mutex
+	active int
+	until  time.Time
+}
+
+// newFailover returns the failover state of config, or nil when it has no
+// fallback base URLs
+func newFailover(config *Config) *failover {
+	if len(config.FallbackBaseURLs) == 0 {
+		return nil
+	}
+	stickiness := config.FailoverStickiness
+	if stickiness <= 0 {
+		stickiness = DefaultFailoverStickiness
+	}
+	bases := []string{strings.TrimSuffix(config.BaseURL, "/")}
+	for _, base := range config.FallbackBaseURLs {
+		bases = append(bases, strings.TrimSuffix(base, "/"))
+	}
+	return &failover{bases: bases, stickiness: stickiness}
+}
+
+// order returns the indexes of the base URLs to try: the sticky fallback
+// first while it is remembered, then the rest in configured order
+func (f *failover) order(now time.Time) []int {
+	f.mu.Lock()
+	active := f.active
+	if active != 0 && !now.Before(f.until) {
+		active, f.active = 0, 0
+	}
+	f.mu.Unlock()
+	
+	order := []int{active}
+	for i := range f.bases {
+		if i != active {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// served records that the base URL at i answered a request
+func (f *failover) served(i int, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if i == f.active {
+		return
+	}
+	f.active = i
+	f.until = now.Add(f.stickiness)
+}
+
+// execute sends the request built by newReq, failing over to the fallback
+// base URLs when a base URL is unreachable or keeps answering 5xx after its
+// retries. It returns the last response and the attempts made in total.
+func (c *Client) execute(ctx context.Context, method, endpoint string, newReq func() *resty.Request) (*resty.Response, int, error) {
+	if c.failover == nil {
+		req := newReq()
+		resp, err := req.Execute(method, endpoint)
+		return resp, max(req.Attempt, 1), err
+	}
+	
+	var (
+		resp     *resty.Response
+		err      error
+		attempts int
+	)
+	order := c.failover.order(time.Now())
+	for n, i := range order {
+		base := c.failover.bases[i]
+		req := newReq()
+		resp, err = req.Execute(method, base+endpoint)
+		attempts += max(req.Attempt, 1)
+		
+		if !shouldFailover(ctx, method, resp, err) {
+			if err == nil {
+				c.failover.served(i, time.Now())
+			}
+			break
+		}
+		if n+1 < len(order) {
+			next := c.failover.bases[order[n+1]]
+			c.logger.Warnf("%s %s failed against %s, failing over to %s", method, endpoint, base, next)
+			c.events.emit(ClientEventFailover, map[string]string{"from": base, "to": next, "endpoint": endpoint})
+		}
+	}
+	return resp, attempts, err
+}
+
+// shouldFailover reports whether a request that failed against one base URL
+// should be sent to the next. Non-idempotent requests only fail over when
+// they never reached the server, unless they may be retried anyway.
+func shouldFailover(ctx context.Context, method string, resp *resty.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err == nil {
+		return resp != nil && resp.StatusCode() >= 500 && (isIdempotent(method) || retryNonIdempotent(ctx))
+	}
+	if errors.Is(err, ErrDryRun) || errors.Is(err, ErrResponseTooLarge) || errors.Is(err, ErrCircuitOpen) ||
+		errors.Is(err, ErrCertificatePinMismatch) {
+		return false
+	}
+	var hookErr *HookError
+	if errors.As(err, &hookErr) {
+		return false
+	}
+	if isIdempotent(method) || retryNonIdempotent(ctx) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
\ No newline at end of file
@@ -0,0 +1,159 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// hostCounter is a transport counting the requests sent to each host
+type hostCounter struct {
+	mu    sync.Mutex
+	hosts map[string]int
+}
+
+func (hc *hostCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	hc.mu.Lock()
+	if hc.hosts == nil {
+		hc.hosts = make(map[string]int)
+	}
+	hc.hosts[req.URL.Host]++
+	hc.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// take returns the requests sent to host since the last call
+func (hc *hostCounter) take(host string) int {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	n := hc.hosts[host]
+	delete(hc.hosts, host)
+	return n
+}
+
+// deadURL returns a URL nothing listens on, so connections are refused
+func deadURL(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.Close()
+	return "http://" + ln.Addr().String()
+}
+
+// statusServer answers every request with status, recording the headers of the last
+func statusServer(t *testing.T, status int) (*httptest.Server, func() http.Header) {
+	t.Helper()
+	var mu sync.Mutex
+	var last http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		last = r.Header.Clone()
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() http.Header {
+		mu.Lock()
+		defer mu.Unlock()
+		return last
+	}
+}
+
+func TestFailover(t *testing.T) {
+	unavailable, _ := statusServer(t, http.StatusServiceUnavailable)
+	invalid, _ := statusServer(t, http.StatusBadRequest)
+	tests := []struct {
+		name       string
+		primary    string
+		method     string
+		wantStatus int
+		// wantPrimary is not checked when zero, as for a dead primary
+		wantPrimary  int
+		wantFallback int
+	}{
+		{"dead primary", deadURL(t), http.MethodGet, 0, 0, 1},
+		{"primary keeps answering 5xx", unavailable.URL, http.MethodGet, 0, 3, 1},
+		{"client errors do not fail over", invalid.URL, http.MethodGet, http.StatusBadRequest, 1, 0},
+		{"POST is not sent twice", unavailable.URL, http.MethodPost, http.StatusServiceUnavailable, 1, 0},
+		{"POST that never left fails over", deadURL(t), http.MethodPost, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fallback, lastHeader := statusServer(t, http.StatusOK)
+			hosts := &hostCounter{}
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey: "key", BaseURL: tt.primary, FallbackBaseURLs: []string{fallback.URL},
+				MaxRetries: 2, RetryWaitTime: time.Millisecond, RequestSigningSecret: "shared", Transport: hosts,
+			})
+			client.SetAuthToken("token")
+			
+			err := client.Request(context.Background(), tt.method, "/projects", nil, nil)
+			var apiErr *xrplsale.APIError
+			if tt.wantStatus == 0 && err != nil || tt.wantStatus != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus) {
+				t.Fatalf("%s = %v, want status %d", tt.method, err, tt.wantStatus)
+			}
+			primaryHost := tt.primary[len("http://"):]
+			if got := hosts.take(primaryHost); tt.wantPrimary > 0 && got != tt.wantPrimary {
+				t.Errorf("%d requests to the primary, want %d", got, tt.wantPrimary)
+			}
+			if got := hosts.take(fallback.Listener.Addr().String()); got != tt.wantFallback {
+				t.Errorf("%d requests to the fallback, want %d", got, tt.wantFallback)
+			}
+			if tt.wantFallback == 0 {
+				return
+			}
+			// Fallbacks are authenticated and signed like the primary
+			header := lastHeader()
+			if header.Get("X-API-Key") != "key" || header.Get("Authorization") != "Bearer token" || header.Get(xrplsale.SignatureHeader) == "" {
+				t.Errorf("fallback request headers %v, want credentials and a signature", header)
+			}
+		})
+	}
+}
+
+func TestFailoverStickiness(t *testing.T) {
+	const stickiness = 200 * time.Millisecond
+	primary, _ := statusServer(t, http.StatusServiceUnavailable)
+	fallback, _ := statusServer(t, http.StatusOK)
+	hosts := &hostCounter{}
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+		APIKey: "key", BaseURL: primary.URL, FallbackBaseURLs: []string{fallback.URL},
+		FailoverStickiness: stickiness, MaxRetries: 1, RetryWaitTime: time.Millisecond, Transport: hosts,
+	})
+	get := func() {
+		t.Helper()
+		if err := client.Get(context.Background(), "/projects", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	primaryHost, fallbackHost := primary.Listener.Addr().String(), fallback.Listener.Addr().String()
+	
+	get()
+	if p, f := hosts.take(primaryHost), hosts.take(fallbackHost); p != 2 || f != 1 {
+		t.Fatalf("failover sent %d to the primary and %d to the fallback, want 2 and 1", p, f)
+	}
+	// The fallback keeps serving while it is remembered
+	for i := 0; i < 3; i++ {
+		get()
+	}
+	if p, f := hosts.take(primaryHost), hosts.take(fallbackHost); p != 0 || f != 3 {
+		t.Fatalf("sticky calls sent %d to the primary and %d to the fallback, want 0 and 3", p, f)
+	}
+	// Then the primary is probed again
+	time.Sleep(stickiness + 50*time.Millisecond)
+	get()
+	if p, f := hosts.take(primaryHost), hosts.take(fallbackHost); p != 2 || f != 1 {
+		t.Fatalf("after stickiness expired, %d to the primary and %d to the fallback, want 2 and 1", p, f)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package xrplsale
+
+import "os"
+
+// lockFile does nothing where advisory locks are not available; writes are
+// still atomic, so concurrent writers cannot corrupt the file
+func lockFile(*os.File, bool) error { return nil }
+
+// unlockFile releases the lock taken by lockFile
+func unlockFile(*os.File) error { return nil }
\ No newline at end of file
@@ -0,0 +1,27 @@
+//go:build unix
+
+package xrplsale
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an advisory lock on f, waiting for other processes
+func lockFile(f *os.File, exclusive bool) error {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	for {
+		err := syscall.Flock(int(f.Fd()), how)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// unlockFile releases the lock taken by lockFile
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
\ No newline at end of file
@@ -0,0 +1,37 @@
+package fixtures
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+// rippleAlphabet is the base58 alphabet used by XRPL addresses
+const rippleAlphabet = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+
+// encodeAddress encodes a 20-byte account ID as a classic XRPL address
+func encodeAddress(accountID []byte) string {
+	payload := append([]byte{0x00}, accountID...)
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	payload = append(payload, second[:4]...)
+	
+	n := new(big.Int).SetBytes(payload)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+	var encoded []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		encoded = append(encoded, rippleAlphabet[mod.Int64()])
+	}
+	for _, b := range payload {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, rippleAlphabet[0])
+	}
+	
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
\ No newline at end of file
@@ -0,0 +1,91 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// eventSpec collects the WebhookEventOptions
+type eventSpec struct {
+	project    *xrplsale.Project
+	investment *xrplsale.Investment
+}
+
+// WebhookEventOption customizes a generated webhook event
+type WebhookEventOption func(*eventSpec)
+
+// EventForProject makes the event refer to project
+func EventForProject(project *xrplsale.Project) WebhookEventOption {
+	return func(s *eventSpec) {
+		s.project = project
+	}
+}
+
+// EventForInvestment makes an investment.created event carry investment
+func EventForInvestment(investment *xrplsale.Investment) WebhookEventOption {
+	return func(s *eventSpec) {
+		s.investment = investment
+	}
+}
+
+// WebhookEvent returns an event of eventType whose data has the shape the
+// API sends for that type. Unknown types get an empty data object.
+func (g *Generator) WebhookEvent(eventType string, opts ...WebhookEventOption) *xrplsale.WebhookEvent {
+	var spec eventSpec
+	for _, opt := range opts {
+		opt(&spec)
+	}
+	if spec.project == nil {
+		spec.project = g.Project()
+	}
+	
+	var data interface{} = map[string]interface{}{}
+	switch eventType {
+	case xrplsale.EventInvestmentCreated:
+		if spec.investment == nil {
+			spec.investment = g.Investment(ForProject(spec.project))
+		}
+		data = spec.investment
+	case xrplsale.EventProjectLaunched:
+		data = spec.project
+	case xrplsale.EventTierCompleted:
+		tier := xrplsale.Tier{Tier: 1}
+		if len(spec.project.Tiers) > 0 {
+			tier = spec.project.Tiers[0]
+		}
+		data = map[string]interface{}{
+			"project_id":  spec.project.ID,
+			"tier":        tier.Tier,
+			"tokens_sold": tier.TotalTokens,
+		}
+	case xrplsale.EventProjectAnnouncementPublished:
+		g.mu.Lock()
+		id := g.id("ann")
+		g.mu.Unlock()
+		data = xrplsale.AnnouncementPublishedPayload{
+			ProjectID:      spec.project.ID,
+			AnnouncementID: id,
+			Title:          fmt.Sprintf("%s sale update", spec.project.Name),
+		}
+	}
+	
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return &xrplsale.WebhookEvent{
+		ID:        g.id("evt"),
+		Type:      eventType,
+		Data:      toMap(data),
+		CreatedAt: xrplsale.Timestamp{Time: g.tick()},
+	}
+}
+
+// toMap converts v to the generic form a decoded event carries
+func toMap(v interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(JSON(v), &m); err != nil {
+		panic(fmt.Sprintf("fixtures: %v", err))
+	}
+	return m
+}
\ No newline at end of file
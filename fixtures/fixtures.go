@@ -0,0 +1,379 @@
+// Package fixtures generates realistic, internally consistent XRPL.Sale
+// models for tests. Values are derived from a seed, so a given seed always
+// produces the same fixtures.
+package fixtures
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// Epoch is the earliest timestamp a generator produces
+var Epoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Generator produces fixtures from a seeded source. Timestamps advance with
+// every value generated, so fixtures created later are always newer.
+// A Generator is safe for concurrent use, but only sequential use is reproducible.
+type Generator struct {
+	mu    sync.Mutex
+	rng   *rand.Rand
+	clock time.Time
+}
+
+// New returns a generator seeded with seed
+func New(seed int64) *Generator {
+	return &Generator{
+		rng:   rand.New(rand.NewSource(seed)),
+		clock: Epoch,
+	}
+}
+
+// std backs the package-level functions
+var std = New(1)
+
+// Seed resets the generator used by the package-level functions
+func Seed(seed int64) {
+	std.mu.Lock()
+	defer std.mu.Unlock()
+	std.rng = rand.New(rand.NewSource(seed))
+	std.clock = Epoch
+}
+
+// projectSpec collects the ProjectOptions
+type projectSpec struct {
+	name        string
+	status      xrplsale.ProjectStatus
+	tierCount   int
+	totalSupply int64
+}
+
+// ProjectOption customizes a generated project
+type ProjectOption func(*projectSpec)
+
+// WithProjectName sets the project name; the token symbol is derived from it
+func WithProjectName(name string) ProjectOption {
+	return func(s *projectSpec) {
+		s.name = name
+	}
+}
+
+// WithProjectStatus sets the project status
+func WithProjectStatus(status xrplsale.ProjectStatus) ProjectOption {
+	return func(s *projectSpec) {
+		s.status = status
+	}
+}
+
+// WithTierCount sets the number of pricing tiers
+func WithTierCount(n int) ProjectOption {
+	return func(s *projectSpec) {
+		s.tierCount = n
+	}
+}
+
+// WithTotalSupply sets the token supply; tiers sell 60% of it
+func WithTotalSupply(supply int64) ProjectOption {
+	return func(s *projectSpec) {
+		s.totalSupply = supply
+	}
+}
+
+// investmentSpec collects the InvestmentOptions
+type investmentSpec struct {
+	project  *xrplsale.Project
+	amount   int64
+	investor string
+	status   string
+}
+
+// InvestmentOption customizes a generated investment
+type InvestmentOption func(*investmentSpec)
+
+// ForProject makes the investment in project. Without it a new project is generated.
+func ForProject(project *xrplsale.Project) InvestmentOption {
+	return func(s *investmentSpec) {
+		s.project = project
+	}
+}
+
+// WithAmountXRP sets the invested amount in whole XRP
+func WithAmountXRP(amount int64) InvestmentOption {
+	return func(s *investmentSpec) {
+		s.amount = amount
+	}
+}
+
+// WithInvestor sets the investor's account address
+func WithInvestor(address string) InvestmentOption {
+	return func(s *investmentSpec) {
+		s.investor = address
+	}
+}
+
+// WithInvestmentStatus sets the investment status
+func WithInvestmentStatus(status string) InvestmentOption {
+	return func(s *investmentSpec) {
+		s.status = status
+	}
+}
+
+// Project returns a project from the package generator
+func Project(opts ...ProjectOption) *xrplsale.Project {
+	return std.Project(opts...)
+}
+
+// Investment returns an investment from the package generator
+func Investment(opts ...InvestmentOption) *xrplsale.Investment {
+	return std.Investment(opts...)
+}
+
+// Investments returns n investments in project from the package generator
+func Investments(project *xrplsale.Project, n int) []xrplsale.Investment {
+	return std.Investments(project, n)
+}
+
+// Webhook returns a webhook from the package generator
+func Webhook(events ...string) *xrplsale.Webhook {
+	return std.Webhook(events...)
+}
+
+// WebhookEvent returns an event from the package generator
+func WebhookEvent(eventType string, opts ...WebhookEventOption) *xrplsale.WebhookEvent {
+	return std.WebhookEvent(eventType, opts...)
+}
+
+// Address returns a valid classic XRPL address from the package generator
+func Address() string {
+	return std.Address()
+}
+
+// Project returns a project whose tiers partition 60% of its supply at
+// increasing prices
+func (g *Generator) Project(opts ...ProjectOption) *xrplsale.Project {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	spec := projectSpec{
+		name:        fmt.Sprintf("%s %s", projectAdjectives[g.rng.Intn(len(projectAdjectives))], projectNouns[g.rng.Intn(len(projectNouns))]),
+		status:      xrplsale.StatusActive,
+		tierCount:   3,
+		totalSupply: int64(10+g.rng.Intn(990)) * 1_000_000,
+	}
+	for _, opt := range opts {
+		opt(&spec)
+	}
+	
+	created := g.tick()
+	project := &xrplsale.Project{
+		ID:            g.id("proj"),
+		Name:          spec.name,
+		Description:   fmt.Sprintf("%s token sale on the XRP Ledger", spec.name),
+		TokenSymbol:   symbolFor(spec.name),
+		TotalSupply:   wholeAmount(spec.totalSupply),
+		Status:        spec.status,
+		SaleStartDate: xrplsale.Timestamp{Time: created.AddDate(0, 0, 7)},
+		SaleEndDate:   xrplsale.Timestamp{Time: created.AddDate(0, 0, 37)},
+		CreatedAt:     xrplsale.Timestamp{Time: created},
+	}
+	
+	// Prices start between 0.0005 and 0.01 XRP and rise 25% per tier
+	basePrice := big.NewRat(int64(5+g.rng.Intn(96)), 10_000)
+	perTier := spec.totalSupply * 6 / 10 / int64(max(spec.tierCount, 1))
+	for i := 0; i < spec.tierCount; i++ {
+		price := new(big.Rat).Mul(basePrice, big.NewRat(int64(4+i), 4))
+		project.Tiers = append(project.Tiers, xrplsale.Tier{
+			Tier:          i + 1,
+			PricePerToken: ratAmount(price),
+			TotalTokens:   wholeAmount(perTier),
+		})
+	}
+	return project
+}
+
+// Investment returns a confirmed investment whose token amount matches the
+// price of the tier it was made in
+func (g *Generator) Investment(opts ...InvestmentOption) *xrplsale.Investment {
+	spec := investmentSpec{status: "confirmed"}
+	for _, opt := range opts {
+		opt(&spec)
+	}
+	if spec.project == nil {
+		spec.project = g.Project()
+	}
+	
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	if spec.amount == 0 {
+		spec.amount = int64(10 + g.rng.Intn(9991))
+	}
+	if spec.investor == "" {
+		spec.investor = g.address()
+	}
+	
+	investment := &xrplsale.Investment{
+		ID:              g.id("inv"),
+		ProjectID:       spec.project.ID,
+		InvestorAccount: spec.investor,
+		AmountXRP:       wholeAmount(spec.amount),
+		Status:          spec.status,
+		TransactionHash: g.hex(32),
+		CreatedAt:       xrplsale.Timestamp{Time: g.tick()},
+	}
+	if tiers := spec.project.Tiers; len(tiers) > 0 {
+		tier := tiers[g.rng.Intn(len(tiers))]
+		investment.Tier = tier.Tier
+		investment.TokenAmount = tokensFor(spec.amount, tier.PricePerToken)
+	}
+	return investment
+}
+
+// Investments returns n investments in project, oldest first
+func (g *Generator) Investments(project *xrplsale.Project, n int) []xrplsale.Investment {
+	investments := make([]xrplsale.Investment, 0, n)
+	for i := 0; i < n; i++ {
+		investments = append(investments, *g.Investment(ForProject(project)))
+	}
+	return investments
+}
+
+// Webhook returns an active webhook subscribed to events, or to every
+// event type when none are given
+func (g *Generator) Webhook(events ...string) *xrplsale.Webhook {
+	if len(events) == 0 {
+		events = []string{
+			xrplsale.EventInvestmentCreated,
+			xrplsale.EventProjectLaunched,
+			xrplsale.EventTierCompleted,
+		}
+	}
+	
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	id := g.id("wh")
+	return &xrplsale.Webhook{
+		ID:        id,
+		URL:       fmt.Sprintf("https://hooks.example.com/xrplsale/%s", id),
+		Events:    events,
+		Active:    true,
+		CreatedAt: xrplsale.Timestamp{Time: g.tick()},
+	}
+}
+
+// Address returns a valid classic XRPL address
+func (g *Generator) Address() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.address()
+}
+
+// Stats returns the statistics the API would report for project after investments
+func Stats(project *xrplsale.Project, investments []xrplsale.Investment) *xrplsale.ProjectStats {
+	var raised, sold xrplsale.Amount
+	investors := make(map[string]bool)
+	for _, inv := range investments {
+		raised = raised.Add(inv.AmountXRP)
+		sold = sold.Add(inv.TokenAmount)
+		investors[inv.InvestorAccount] = true
+	}
+	return &xrplsale.ProjectStats{
+		ProjectID:       project.ID,
+		TotalRaisedXRP:  raised,
+		TokensSold:      sold,
+		InvestorCount:   len(investors),
+		InvestmentCount: len(investments),
+	}
+}
+
+// tick advances the clock by up to an hour and returns the new time
+func (g *Generator) tick() time.Time {
+	g.clock = g.clock.Add(time.Duration(1+g.rng.Intn(3600)) * time.Second)
+	return g.clock
+}
+
+// id returns a random identifier with the API's prefix convention
+func (g *Generator) id(prefix string) string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, 12)
+	for i := range b {
+		b[i] = chars[g.rng.Intn(len(chars))]
+	}
+	return prefix + "_" + string(b)
+}
+
+// hex returns n random bytes as upper-case hex, as used for ledger hashes
+func (g *Generator) hex(n int) string {
+	b := make([]byte, n)
+	g.rng.Read(b)
+	return fmt.Sprintf("%X", b)
+}
+
+// address returns a random classic address
+func (g *Generator) address() string {
+	accountID := make([]byte, 20)
+	g.rng.Read(accountID)
+	return encodeAddress(accountID)
+}
+
+// tokensFor returns the tokens amountXRP buys at price
+func tokensFor(amountXRP int64, price xrplsale.Amount) xrplsale.Amount {
+	if price.IsZero() {
+		return xrplsale.Amount{}
+	}
+	return ratAmount(new(big.Rat).Quo(big.NewRat(amountXRP, 1), price.Rat()))
+}
+
+// formatRat formats r with up to 6 decimals and no trailing zeros
+func formatRat(r *big.Rat) string {
+	s := r.FloatString(6)
+	for s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// ratAmount rounds r to 6 decimals
+func ratAmount(r *big.Rat) xrplsale.Amount {
+	return xrplsale.MustParseAmount(formatRat(r))
+}
+
+// wholeAmount returns n as an amount
+func wholeAmount(n int64) xrplsale.Amount {
+	return xrplsale.MustParseAmount(strconv.FormatInt(n, 10))
+}
+
+// symbolFor derives a token symbol from the initials of name
+func symbolFor(name string) string {
+	symbol := []byte{}
+	start := true
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c == ' ' {
+			start = true
+			continue
+		}
+		if start && (c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') {
+			symbol = append(symbol, c&^0x20)
+		}
+		start = false
+	}
+	if len(symbol) < 3 {
+		symbol = append(symbol, "TKN"[:3-len(symbol)]...)
+	}
+	return string(symbol)
+}
+
+var (
+	projectAdjectives = []string{"Ripple", "Ledger", "Quantum", "Coral", "Aurora", "Nimbus", "Harbor", "Summit"}
+	projectNouns      = []string{"Finance", "Protocol", "Exchange", "Network", "Labs", "Markets", "Vault", "Bridge"}
+)
\ No newline at end of file
@@ -0,0 +1,152 @@
+package fixtures_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/internal/keypairs"
+)
+
+// generate builds one value of every kind from a generator seeded with seed
+func generate(seed int64) []interface{} {
+	gen := fixtures.New(seed)
+	project := gen.Project(fixtures.WithTierCount(4))
+	return []interface{}{
+		project,
+		gen.Investments(project, 5),
+		gen.Webhook(),
+		gen.WebhookEvent(xrplsale.EventInvestmentCreated, fixtures.EventForProject(project)),
+		gen.Address(),
+	}
+}
+
+func TestGeneratorReproducible(t *testing.T) {
+	first, again, other := generate(42), generate(42), generate(43)
+	for i := range first {
+		if !reflect.DeepEqual(first[i], again[i]) {
+			t.Errorf("value %d differs between two generators seeded 42:\n%+v\n%+v", i, first[i], again[i])
+		}
+		if reflect.DeepEqual(first[i], other[i]) {
+			t.Errorf("value %d is the same for seeds 42 and 43", i)
+		}
+	}
+}
+
+func TestProjectConsistent(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []fixtures.ProjectOption
+		tiers int
+	}{
+		{"defaults", nil, 3},
+		{"one tier", []fixtures.ProjectOption{fixtures.WithTierCount(1)}, 1},
+		{"many tiers", []fixtures.ProjectOption{fixtures.WithTierCount(8), fixtures.WithTotalSupply(1_000_000)}, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := fixtures.New(1).Project(tt.opts...)
+			if len(project.Tiers) != tt.tiers {
+				t.Fatalf("%d tiers, want %d", len(project.Tiers), tt.tiers)
+			}
+			var allocated xrplsale.Amount
+			for i, tier := range project.Tiers {
+				if tier.Tier != i+1 {
+					t.Errorf("tier %d is numbered %d", i+1, tier.Tier)
+				}
+				if i > 0 && tier.PricePerToken.Cmp(project.Tiers[i-1].PricePerToken) <= 0 {
+					t.Errorf("tier %d price %s does not rise from %s", tier.Tier, tier.PricePerToken, project.Tiers[i-1].PricePerToken)
+				}
+				allocated = allocated.Add(tier.TotalTokens)
+			}
+			if allocated.Cmp(project.TotalSupply) > 0 {
+				t.Errorf("tiers allocate %s of a %s supply", allocated, project.TotalSupply)
+			}
+			if !project.CreatedAt.Before(project.SaleStartDate.Time) || !project.SaleStartDate.Before(project.SaleEndDate.Time) {
+				t.Errorf("dates out of order: created %s, sale %s to %s", project.CreatedAt, project.SaleStartDate, project.SaleEndDate)
+			}
+		})
+	}
+}
+
+func TestInvestmentsConsistent(t *testing.T) {
+	gen := fixtures.New(7)
+	project := gen.Project()
+	investments := gen.Investments(project, 50)
+	
+	prices := map[int]*big.Rat{}
+	for _, tier := range project.Tiers {
+		prices[tier.Tier] = tier.PricePerToken.Rat()
+	}
+	for i, inv := range investments {
+		if inv.ProjectID != project.ID || !keypairs.ValidAddress(inv.InvestorAccount) {
+			t.Fatalf("investment %d = %+v, want it in the project from a valid address", i, inv)
+		}
+		if i > 0 && !investments[i-1].CreatedAt.Before(inv.CreatedAt.Time) {
+			t.Fatalf("investment %d is not newer than the one before", i)
+		}
+		price, ok := prices[inv.Tier]
+		if !ok {
+			t.Fatalf("investment %d is in tier %d, which the project lacks", i, inv.Tier)
+		}
+		// Token amounts are rounded to 6 decimals
+		cost := new(big.Rat).Mul(inv.TokenAmount.Rat(), price)
+		diff := new(big.Rat).Sub(cost, inv.AmountXRP.Rat())
+		if diff.Abs(diff).Cmp(new(big.Rat).Mul(price, big.NewRat(1, 1_000_000))) > 0 {
+			t.Fatalf("investment %d: %s tokens at %s XRP cost %s, want %s", i, inv.TokenAmount, price.FloatString(6), cost.FloatString(6), inv.AmountXRP)
+		}
+	}
+	
+	stats := fixtures.Stats(project, investments)
+	var raised xrplsale.Amount
+	investors := map[string]bool{}
+	for _, inv := range investments {
+		raised = raised.Add(inv.AmountXRP)
+		investors[inv.InvestorAccount] = true
+	}
+	if !stats.TotalRaisedXRP.Equal(raised) || stats.InvestmentCount != 50 || stats.InvestorCount != len(investors) {
+		t.Fatalf("Stats() = %+v, want %s XRP from 50 investments by %d investors", stats, raised, len(investors))
+	}
+}
+
+func TestPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		page, limit    int
+		want           []int
+		wantTotalPages int
+	}{
+		{1, 2, []int{1, 2}, 3},
+		{3, 2, []int{5}, 3},
+		{4, 2, []int{}, 3},
+		{0, 0, []int{1, 2, 3, 4, 5}, 1},
+	}
+	for _, tt := range tests {
+		page := fixtures.Page(items, tt.page, tt.limit)
+		if !reflect.DeepEqual(page.Data, tt.want) || page.Pagination.Total != 5 || page.Pagination.TotalPages != tt.wantTotalPages {
+			t.Errorf("Page(%d, %d) = %+v, want %v of 5 in %d pages", tt.page, tt.limit, page, tt.want, tt.wantTotalPages)
+		}
+	}
+	
+	var decoded xrplsale.PaginatedResponse[int]
+	if err := json.Unmarshal(fixtures.JSON(fixtures.Page(items, 1, 2)), &decoded); err != nil || decoded.Pagination.TotalPages != 3 {
+		t.Fatalf("JSON(Page()) decodes to %+v, %v", decoded, err)
+	}
+}
+
+func TestSignedWebhook(t *testing.T) {
+	event := fixtures.New(3).WebhookEvent(xrplsale.EventProjectLaunched)
+	body, signature := fixtures.SignedWebhook(event, "whsec_test")
+	
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", WebhookSecret: "whsec_test"})
+	if !client.VerifyWebhookSignature(body, signature) {
+		t.Fatal("VerifyWebhookSignature() = false for a signed fixture")
+	}
+	parsed, err := client.ParseWebhookEvent(body)
+	if err != nil || parsed.ID != event.ID || parsed.Type != xrplsale.EventProjectLaunched {
+		t.Fatalf("ParseWebhookEvent() = %+v, %v; want the event back", parsed, err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package fixtures
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// JSON renders v in the API's wire format. It panics if v cannot be encoded,
+// which only happens for values fixtures never produce.
+func JSON(v interface{}) []byte {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("fixtures: %v", err))
+	}
+	return raw
+}
+
+// Page wraps page number page of items, limit per page, as a list endpoint
+// would return it. Pages past the end are empty.
+func Page[T any](items []T, page, limit int) *xrplsale.PaginatedResponse[T] {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = len(items)
+	}
+	
+	resp := &xrplsale.PaginatedResponse[T]{
+		Data: []T{},
+		Pagination: xrplsale.Pagination{
+			Page:  page,
+			Limit: limit,
+			Total: len(items),
+		},
+	}
+	if limit > 0 {
+		resp.Pagination.TotalPages = (len(items) + limit - 1) / limit
+	}
+	
+	start := (page - 1) * limit
+	if start < len(items) {
+		end := min(start+limit, len(items))
+		resp.Data = items[start:end]
+	}
+	return resp
+}
+
+// SignedWebhook renders event as a webhook delivery body together with the
+// signature header value Client.VerifyWebhookSignature accepts for secret
+func SignedWebhook(event *xrplsale.WebhookEvent, secret string) (body []byte, signature string) {
+	body = JSON(event)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return body, "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
\ No newline at end of file
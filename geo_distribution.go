@@ -0,0 +1,127 @@
+package xrplsale
+
+import (
+	"math/big"
+	"sort"
+	"time"
+)
+
+// UnknownCountry is the bucket code used for investors whose country could not be determined
+const UnknownCountry = "unknown"
+
+// DateRange represents an inclusive range of calendar dates
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// params returns the range as start_date/end_date query parameters
+func (dr *DateRange) params() map[string]string {
+	params := make(map[string]string)
+	if dr == nil {
+		return params
+	}
+	if !dr.Start.IsZero() {
+		params["start_date"] = dr.Start.Format("2006-01-02")
+	}
+	if !dr.End.IsZero() {
+		params["end_date"] = dr.End.Format("2006-01-02")
+	}
+	return params
+}
+
+// CountryBucket holds the investor count and amount invested for one country
+type CountryBucket struct {
+	Country       string `json:"country"` // ISO 3166-1 alpha-2 code
+	InvestorCount int    `json:"investor_count"`
+	AmountXRP     Amount `json:"amount_xrp"`
+}
+
+// SuppressedBucket aggregates countries hidden by the API's privacy threshold.
+// Countries with fewer than PrivacyThreshold investors are never reported
+// individually, so a country missing from Countries is not necessarily zero.
+type SuppressedBucket struct {
+	CountryCount  int    `json:"country_count"`
+	InvestorCount int    `json:"investor_count"`
+	AmountXRP     Amount `json:"amount_xrp"`
+}
+
+// GeoDistribution represents the geographical distribution of a project's investors
+type GeoDistribution struct {
+	ProjectID        string           `json:"project_id"`
+	Countries        []CountryBucket  `json:"countries"`
+	Unknown          CountryBucket    `json:"unknown"`
+	Suppressed       SuppressedBucket `json:"suppressed"`
+	PrivacyThreshold int              `json:"privacy_threshold"`
+	TotalInvestors   int              `json:"total_investors"`
+	TotalAmountXRP   Amount           `json:"total_amount_xrp"`
+}
+
+// CountryShare is a country's share of investors and invested amount, in percent
+type CountryShare struct {
+	Country         string
+	InvestorPercent float64
+	AmountPercent   float64
+}
+
+// TopN returns up to n countries ordered by investor count, then amount;
+// full ties keep the API's order. The unknown and suppressed buckets are
+// never included.
+func (gd *GeoDistribution) TopN(n int) []CountryBucket {
+	if n <= 0 || len(gd.Countries) == 0 {
+		return nil
+	}
+	
+	sorted := make([]CountryBucket, len(gd.Countries))
+	copy(sorted, gd.Countries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].InvestorCount != sorted[j].InvestorCount {
+			return sorted[i].InvestorCount > sorted[j].InvestorCount
+		}
+		return sorted[i].AmountXRP.Cmp(sorted[j].AmountXRP) > 0
+	})
+	
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+	return sorted[:n]
+}
+
+// Percentages returns each country's share of the totals, followed by the
+// unknown bucket. Shares are zero when the corresponding total is zero.
+func (gd *GeoDistribution) Percentages() []CountryShare {
+	totalInvestors := float64(gd.TotalInvestors)
+	
+	share := func(b CountryBucket) CountryShare {
+		s := CountryShare{Country: b.Country}
+		if totalInvestors > 0 {
+			s.InvestorPercent = float64(b.InvestorCount) / totalInvestors * 100
+		}
+		if gd.TotalAmountXRP.Sign() > 0 {
+			s.AmountPercent, _ = new(big.Rat).Quo(b.AmountXRP.rat(), gd.TotalAmountXRP.rat()).Float64()
+			s.AmountPercent *= 100
+		}
+		return s
+	}
+	
+	shares := make([]CountryShare, 0, len(gd.Countries)+1)
+	for _, b := range gd.Countries {
+		shares = append(shares, share(b))
+	}
+	
+	unknown := gd.Unknown
+	unknown.Country = UnknownCountry
+	shares = append(shares, share(unknown))
+	return shares
+}
+
+// IsSuppressed reports whether a country absent from Countries may have been
+// hidden by the privacy threshold rather than having no investors
+func (gd *GeoDistribution) IsSuppressed(country string) bool {
+	for _, b := range gd.Countries {
+		if b.Country == country {
+			return false
+		}
+	}
+	return gd.Suppressed.CountryCount > 0
+}
\ No newline at end of file
@@ -0,0 +1,156 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// bucket builds a country bucket with an amount in XRP
+func bucket(country string, investors int, amount string) xrplsale.CountryBucket {
+	return xrplsale.CountryBucket{Country: country, InvestorCount: investors, AmountXRP: xrplsale.MustParseAmount(amount)}
+}
+
+func TestGeoDistributionTopN(t *testing.T) {
+	gd := &xrplsale.GeoDistribution{Countries: []xrplsale.CountryBucket{
+		bucket("DE", 5, "100"),
+		bucket("US", 9, "50"),
+		bucket("FR", 5, "300"),
+		bucket("JP", 5, "100"),
+		bucket("CH", 2, "1000"),
+	}}
+	tests := []struct {
+		name string
+		n    int
+		want []string
+	}{
+		{"zero", 0, nil},
+		{"negative", -1, nil},
+		{"count, then amount, then API order", 4, []string{"US", "FR", "DE", "JP"}},
+		{"more than there are", 10, []string{"US", "FR", "DE", "JP", "CH"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, b := range gd.TopN(tt.n) {
+				got = append(got, b.Country)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TopN(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+	if gd.Countries[0].Country != "DE" {
+		t.Error("TopN reordered the distribution's countries")
+	}
+	if got := (&xrplsale.GeoDistribution{}).TopN(3); got != nil {
+		t.Errorf("TopN on an empty distribution = %v, want nil", got)
+	}
+}
+
+func TestGeoDistributionPercentages(t *testing.T) {
+	tests := []struct {
+		name string
+		gd   xrplsale.GeoDistribution
+		want []xrplsale.CountryShare
+	}{
+		{"shares of the totals", xrplsale.GeoDistribution{
+			Countries:      []xrplsale.CountryBucket{bucket("US", 3, "75"), bucket("DE", 1, "0.1")},
+			Unknown:        bucket("", 0, "24.9"),
+			TotalInvestors: 4,
+			TotalAmountXRP: xrplsale.MustParseAmount("100"),
+		}, []xrplsale.CountryShare{{"US", 75, 75}, {"DE", 25, 0.1}, {xrplsale.UnknownCountry, 0, 24.9}}},
+		{"zero totals", xrplsale.GeoDistribution{
+			Countries: []xrplsale.CountryBucket{bucket("US", 0, "0")},
+		}, []xrplsale.CountryShare{{"US", 0, 0}, {xrplsale.UnknownCountry, 0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.gd.Percentages()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Percentages() = %+v, want %+v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				s := got[i]
+				if s.Country != want.Country || math.Abs(s.InvestorPercent-want.InvestorPercent) > 1e-9 || math.Abs(s.AmountPercent-want.AmountPercent) > 1e-9 {
+					t.Errorf("share %d = %+v, want %+v", i, s, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGeoDistributionIsSuppressed(t *testing.T) {
+	tests := []struct {
+		name       string
+		suppressed int
+		country    string
+		want       bool
+	}{
+		{"reported country", 3, "US", false},
+		{"absent country with suppressed buckets", 3, "LI", true},
+		{"absent country without suppressed buckets", 0, "LI", false},
+	}
+	for _, tt := range tests {
+		gd := &xrplsale.GeoDistribution{
+			Countries:  []xrplsale.CountryBucket{bucket("US", 12, "500")},
+			Suppressed: xrplsale.SuppressedBucket{CountryCount: tt.suppressed, InvestorCount: 2 * tt.suppressed},
+		}
+		if got := gd.IsSuppressed(tt.country); got != tt.want {
+			t.Errorf("%s: IsSuppressed(%q) = %v, want %v", tt.name, tt.country, got, tt.want)
+		}
+	}
+}
+
+func TestGetGeoDistribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"amounts as strings and numbers", `{"project_id":"proj_1","countries":[{"country":"US","investor_count":3,"amount_xrp":"75.5"}],
+			"unknown":{"investor_count":1,"amount_xrp":24.5},"suppressed":{"country_count":2,"investor_count":3,"amount_xrp":"10"},
+			"privacy_threshold":5,"total_investors":7,"total_amount_xrp":"110"}`, false},
+		// An unparsable amount fails the call instead of counting as zero
+		{"unparsable amount", `{"countries":[{"country":"US","investor_count":3,"amount_xrp":"lots"}],"total_amount_xrp":"110"}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			gd, err := client.Analytics.GetGeoDistribution(context.Background(), "proj_1", nil)
+			if tt.wantErr {
+				var decodeErr *xrplsale.DecodeError
+				if !errors.As(err, &decodeErr) {
+					t.Fatalf("GetGeoDistribution() = %v, want a DecodeError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != "" {
+				t.Errorf("query %q, want none without a date range", query)
+			}
+			if !gd.Unknown.AmountXRP.Equal(xrplsale.MustParseAmount("24.5")) || gd.Suppressed.CountryCount != 2 || gd.PrivacyThreshold != 5 {
+				t.Errorf("GetGeoDistribution() = %+v", gd)
+			}
+			if shares := gd.Percentages(); math.Abs(shares[0].AmountPercent-75.5/110*100) > 1e-9 {
+				t.Errorf("US amount share %v", shares[0].AmountPercent)
+			}
+		})
+	}
+}
\ No newline at end of file
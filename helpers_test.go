@@ -0,0 +1,147 @@
+package xrplsale_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// hitCounter is a transport counting the requests that reach the network,
+// by method and path
+type hitCounter struct {
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func (hc *hitCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	hc.mu.Lock()
+	if hc.hits == nil {
+		hc.hits = make(map[string]int)
+	}
+	hc.hits[req.Method+" "+req.URL.Path]++
+	hc.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// count returns the number of requests sent for method and path
+func (hc *hitCounter) count(method, path string) int {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	return hc.hits[method+" "+path]
+}
+
+// total returns the number of requests sent
+func (hc *hitCounter) total() int {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	n := 0
+	for _, hits := range hc.hits {
+		n += hits
+	}
+	return n
+}
+
+// newCountingClient starts a fake server and returns a client whose
+// requests to it are counted
+func newCountingClient(t *testing.T, configure ...func(*xrplsale.Config)) (*xrplsaletest.Server, *xrplsale.Client, *hitCounter) {
+	t.Helper()
+	srv := xrplsaletest.NewServer()
+	t.Cleanup(srv.Close)
+	hits := &hitCounter{}
+	configure = append([]func(*xrplsale.Config){func(c *xrplsale.Config) { c.Transport = hits }}, configure...)
+	return srv, xrplsaletest.NewClient(srv, configure...), hits
+}
+
+// anySigner signs challenges with a dummy signature, which the fake server
+// accepts
+type anySigner struct{ address string }
+
+func (s anySigner) Address() string { return s.address }
+
+func (s anySigner) SignChallenge(context.Context, string) (string, error) { return "00", nil }
+
+// signIn authenticates client with the fake server as a new wallet
+func signIn(t *testing.T, client *xrplsale.Client) *xrplsale.AuthResponse {
+	t.Helper()
+	response, err := client.Auth.AuthenticateWithSigner(context.Background(), anySigner{fixtures.Address()})
+	if err != nil {
+		t.Fatalf("signing in: %v", err)
+	}
+	return response
+}
+
+// stallTransport sends the first after requests and holds every later one
+// until its context ends, as a server that stopped answering would
+type stallTransport struct {
+	after int32
+	sent  atomic.Int32
+}
+
+func (st *stallTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if st.sent.Add(1) <= st.after {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+// goroutineHeader matches the first line of a goroutine's stack trace
+var goroutineHeader = regexp.MustCompile(`^goroutine (\d+) `)
+
+// goroutines returns the stack traces of all goroutines, by goroutine ID
+func goroutines() map[string]string {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	stacks := make(map[string]string)
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if m := goroutineHeader.FindStringSubmatch(stack); m != nil {
+			stacks[m[1]] = stack
+		}
+	}
+	return stacks
+}
+
+// checkGoroutineLeaks snapshots the running goroutines. The returned
+// function fails the test if goroutines started since then still run SDK
+// code or keep a client connection open once they had time to exit.
+func checkGoroutineLeaks(t *testing.T) func() {
+	before := goroutines()
+	return func() {
+		t.Helper()
+		var leaked []string
+		for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+			leaked = leaked[:0]
+			for id, stack := range goroutines() {
+				if _, ok := before[id]; ok {
+					continue
+				}
+				if strings.Contains(stack, "github.com/xrplsale/go-sdk.") || strings.Contains(stack, "net/http.(*persistConn)") {
+					leaked = append(leaked, stack)
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+		}
+		if len(leaked) > 0 {
+			t.Errorf("%d goroutines leaked:\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+		}
+	}
+}
\ No newline at end of file
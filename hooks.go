@@ -0,0 +1,127 @@
+package xrplsale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RequestInfo describes an attempt about to be sent to the API
+type RequestInfo struct {
+	Method   string
+	Endpoint string
+	
+	// Attempt is 1 for the first try and increases with each retry
+	Attempt int
+	
+	// Header holds the per-request headers; changes made by a hook are sent
+	Header http.Header
+	
+	// Body is a JSON snapshot of the request body, nil when there is none
+	Body []byte
+}
+
+// ResponseInfo describes the outcome of a call once all attempts are done
+type ResponseInfo struct {
+	Method   string
+	Endpoint string
+	
+	// StatusCode is zero when no response was received
+	StatusCode int
+	Attempts   int
+	Duration   time.Duration
+	Err        error
+	
+	// IdempotencyKey is the Idempotency-Key sent with the call, if any
+	IdempotencyKey string
+	
+	// RequestID is the API's X-Request-ID for the call
+	RequestID string
+	
+	// FromCache is set, and Attempts zero, when the response came from a
+	// client-side cache without reaching the API
+	FromCache bool
+}
+
+// RequestHook is called before every attempt. Returning an error aborts the call.
+type RequestHook func(ctx context.Context, info *RequestInfo) error
+
+// ResponseHook is called once per call after the final attempt
+type ResponseHook func(ctx context.Context, info *ResponseInfo)
+
+// HookError is returned when a request hook aborts a call
+type HookError struct {
+	Err error
+}
+
+// Error implements the error interface
+func (e *HookError) Error() string {
+	return fmt.Sprintf("request aborted by hook: %v", e.Err)
+}
+
+// Unwrap returns the error returned by the hook
+func (e *HookError) Unwrap() error { return e.Err }
+
+// OnRequest registers a hook run before every attempt of every call, in
+// registration order. Hooks are shared with clients derived from this one.
+func (c *Client) OnRequest(hook RequestHook) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.requestHooks = append(c.requestHooks, hook)
+}
+
+// OnResponse registers a hook run after every call, in registration order.
+// Hooks are shared with clients derived from this one.
+func (c *Client) OnResponse(hook ResponseHook) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.responseHooks = append(c.responseHooks, hook)
+}
+
+// runRequestHooks is the resty middleware invoking the request hooks
+func (core *clientCore) runRequestHooks(_ *resty.Client, r *resty.Request) error {
+	core.hooksMu.RLock()
+	hooks := core.requestHooks
+	core.hooksMu.RUnlock()
+	if len(hooks) == 0 {
+		return nil
+	}
+	
+	info := &RequestInfo{
+		Method:   r.Method,
+		Endpoint: r.URL,
+		Attempt:  r.Attempt,
+		Header:   r.Header,
+	}
+	if r.Attempt == 0 {
+		// resty only counts attempts when retries are enabled
+		info.Attempt = 1
+	}
+	if r.Body != nil {
+		if body, err := json.Marshal(r.Body); err == nil {
+			info.Body = body
+		}
+	}
+	
+	for _, hook := range hooks {
+		if err := hook(r.Context(), info); err != nil {
+			return &HookError{Err: err}
+		}
+	}
+	return nil
+}
+
+// runResponseHooks invokes the response hooks
+func (core *clientCore) runResponseHooks(ctx context.Context, info *ResponseInfo) {
+	core.hooksMu.RLock()
+	hooks := core.responseHooks
+	core.hooksMu.RUnlock()
+	
+	for _, hook := range hooks {
+		hook(ctx, info)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,139 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestRequestHookSetsHeader(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Audit-User")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"proj_1"}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	client.OnRequest(func(ctx context.Context, info *xrplsale.RequestInfo) error {
+		info.Header.Set("X-Audit-User", "ops@example.com")
+		return nil
+	})
+	
+	if _, err := client.Projects.Get(context.Background(), "proj_1"); err != nil {
+		t.Fatal(err)
+	}
+	if got != "ops@example.com" {
+		t.Fatalf("server saw X-Audit-User %q, want the hook's header", got)
+	}
+}
+
+func TestRequestHooksOrderAndAbort(t *testing.T) {
+	errDenied := errors.New("denied by policy")
+	tests := []struct {
+		name     string
+		failAt   int
+		wantRan  []string
+		wantSent int
+	}{
+		{"all hooks pass", -1, []string{"first", "second", "third"}, 1},
+		{"first aborts", 0, []string{"first"}, 0},
+		{"second aborts", 1, []string{"first", "second"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t)
+			project := fixtures.Project()
+			srv.AddProjects(project)
+			
+			var ran []string
+			for i, name := range []string{"first", "second", "third"} {
+				i, name := i, name
+				client.OnRequest(func(ctx context.Context, info *xrplsale.RequestInfo) error {
+					ran = append(ran, name)
+					if i == tt.failAt {
+						return errDenied
+					}
+					return nil
+				})
+			}
+			var responses []*xrplsale.ResponseInfo
+			client.OnResponse(func(ctx context.Context, info *xrplsale.ResponseInfo) {
+				responses = append(responses, info)
+			})
+			
+			_, err := client.Projects.Get(context.Background(), project.ID)
+			if !equalStrings(ran, tt.wantRan) {
+				t.Errorf("hooks ran %q, want %q", ran, tt.wantRan)
+			}
+			if hits.total() != tt.wantSent {
+				t.Errorf("%d requests sent, want %d", hits.total(), tt.wantSent)
+			}
+			if len(responses) != 1 {
+				t.Fatalf("response hook ran %d times, want once per call", len(responses))
+			}
+			if tt.failAt < 0 {
+				if err != nil || responses[0].StatusCode != http.StatusOK {
+					t.Fatalf("Get() = %v, response %+v", err, responses[0])
+				}
+				return
+			}
+			var hookErr *xrplsale.HookError
+			if !errors.As(err, &hookErr) || !errors.Is(err, errDenied) {
+				t.Fatalf("Get() = %v, want a HookError wrapping the hook's error", err)
+			}
+			if !errors.Is(responses[0].Err, errDenied) || responses[0].StatusCode != 0 {
+				t.Fatalf("response hook got %+v, want the abort and no status", responses[0])
+			}
+		})
+	}
+}
+
+func TestHookInfo(t *testing.T) {
+	srv, client, _ := newCountingClient(t)
+	srv.Fail(http.MethodPatch, "/projects/proj_1", xrplsaletest.Failure{Status: http.StatusServiceUnavailable, Times: 2})
+	
+	var (
+		mu       sync.Mutex
+		requests []xrplsale.RequestInfo
+		response *xrplsale.ResponseInfo
+	)
+	client.OnRequest(func(ctx context.Context, info *xrplsale.RequestInfo) error {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, *info)
+		return nil
+	})
+	client.OnResponse(func(ctx context.Context, info *xrplsale.ResponseInfo) {
+		response = info
+	})
+	
+	// The project does not exist, so the third attempt gets a 404
+	err := client.Patch(context.Background(), "/projects/proj_1", map[string]string{"name": "renamed"}, nil, xrplsale.WithRetryNonIdempotent())
+	if !errors.Is(err, xrplsale.ErrNotFound) {
+		t.Fatalf("Patch() = %v, want the final 404", err)
+	}
+	
+	if len(requests) != 3 {
+		t.Fatalf("request hook ran %d times, want once per attempt", len(requests))
+	}
+	for i, info := range requests {
+		if info.Attempt != i+1 || info.Method != http.MethodPatch || info.Endpoint != "/projects/proj_1" {
+			t.Errorf("attempt %d: %+v", i+1, info)
+		}
+		if string(info.Body) != `{"name":"renamed"}` {
+			t.Errorf("attempt %d body = %s, want the JSON snapshot", i+1, info.Body)
+		}
+	}
+	if response == nil || response.StatusCode != http.StatusNotFound || response.Attempts != 3 ||
+		response.Duration <= 0 || !errors.Is(response.Err, xrplsale.ErrNotFound) {
+		t.Fatalf("response hook got %+v, want 404 after 3 attempts", response)
+	}
+}
\ No newline at end of file
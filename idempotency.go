@@ -0,0 +1,77 @@
+package xrplsale
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader is the header carrying a request's idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// NewIdempotencyKey returns a fresh random idempotency key
+func NewIdempotencyKey() string {
+	return uuid.NewString()
+}
+
+// WithIdempotencyKey sends key as the request's Idempotency-Key, so the API
+// performs the call at most once however often it is repeated. Requests
+// carrying a key are retried by the default retry policy even if they are
+// POST or PATCH.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(ro *requestOptions) {
+		ro.idempotencyKey = key
+	}
+}
+
+// idempotencyKey returns the key to send for a call: the caller's, or with
+// Config.AutoIdempotency a new one for each POST. The key is set on the
+// request once, so every retry of the call reuses it.
+func (c *Client) idempotencyKey(method string, ro *requestOptions) string {
+	if ro.idempotencyKey == "" && c.config.AutoIdempotency && method == http.MethodPost {
+		return NewIdempotencyKey()
+	}
+	return ro.idempotencyKey
+}
+
+// idempotentCallError attaches the idempotency key of a failed call to an
+// error that did not come from the API
+type idempotentCallError struct {
+	key string
+	err error
+}
+
+// Error implements the error interface
+func (e *idempotentCallError) Error() string { return e.err.Error() }
+
+// Unwrap returns the underlying error
+func (e *idempotentCallError) Unwrap() error { return e.err }
+
+// withIdempotencyKey records key on err so IdempotencyKeyOf can report it
+func withIdempotencyKey(err error, key string) error {
+	if err == nil || key == "" {
+		return err
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.IdempotencyKey = key
+		return err
+	}
+	return &idempotentCallError{key: key, err: err}
+}
+
+// IdempotencyKeyOf returns the idempotency key sent by the call that failed
+// with err, or "" if it had none. Log it to correlate a retried job with the
+// original attempt.
+func IdempotencyKeyOf(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IdempotencyKey
+	}
+	var callErr *idempotentCallError
+	if errors.As(err, &callErr) {
+		return callErr.key
+	}
+	return ""
+}
\ No newline at end of file
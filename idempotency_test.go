@@ -0,0 +1,152 @@
+package xrplsale_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// keyServer records the Idempotency-Key of every request and fails the
+// first failures attempts of each call with a 503
+type keyServer struct {
+	failures int
+	
+	mu       sync.Mutex
+	keys     []string
+	attempts int
+}
+
+func (s *keyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.keys = append(s.keys, r.Header.Get(xrplsale.IdempotencyKeyHeader))
+	s.attempts++
+	fail := s.failures < 0 || s.attempts <= s.failures
+	if !fail {
+		s.attempts = 0
+	}
+	s.mu.Unlock()
+	
+	w.Header().Set("Content-Type", "application/json")
+	if fail {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":{"code":"unavailable","message":"try again"}}`))
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(`{"id":"inv_1","status":"pending"}`))
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		auto     bool
+		method   string
+		opts     []xrplsale.RequestOption
+		failures int
+		// wantKeys holds the expected key of each request, where "auto"
+		// stands for a generated key and "auto2" for a different one
+		wantKeys []string
+	}{
+		{"auto key reused across retries", true, http.MethodPost, nil, 1, []string{"auto", "auto", "auto2", "auto2"}},
+		{"caller key", false, http.MethodPost, []xrplsale.RequestOption{xrplsale.WithIdempotencyKey("job-42")}, 2, []string{"job-42", "job-42", "job-42", "job-42", "job-42", "job-42"}},
+		{"caller key overrides auto", true, http.MethodPost, []xrplsale.RequestOption{xrplsale.WithIdempotencyKey("job-42")}, 0, []string{"job-42", "job-42"}},
+		{"no key without auto", false, http.MethodPost, nil, 0, []string{"", ""}},
+		{"no auto key for GET", true, http.MethodGet, nil, 0, []string{"", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &keyServer{failures: tt.failures}
+			srv := httptest.NewServer(api)
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey:          "key",
+				BaseURL:         srv.URL,
+				AutoIdempotency: tt.auto,
+				RetryWaitTime:   time.Millisecond,
+			})
+			var reported []string
+			client.OnResponse(func(_ context.Context, info *xrplsale.ResponseInfo) {
+				reported = append(reported, info.IdempotencyKey)
+			})
+			
+			for i := 0; i < 2; i++ {
+				if _, err := client.Do(context.Background(), tt.method, "/investments", nil, nil, tt.opts...); err != nil {
+					t.Fatalf("call %d = %v", i+1, err)
+				}
+			}
+			
+			if len(api.keys) != len(tt.wantKeys) {
+				t.Fatalf("sent keys %q, want %d requests", api.keys, len(tt.wantKeys))
+			}
+			generated := map[string]string{}
+			for i, want := range tt.wantKeys {
+				got := api.keys[i]
+				if want == "auto" || want == "auto2" {
+					if got == "" {
+						t.Fatalf("request %d sent no key", i+1)
+					}
+					if prev, ok := generated[want]; ok && prev != got {
+						t.Fatalf("request %d sent %q, want %q as before", i+1, got, prev)
+					}
+					generated[want] = got
+					continue
+				}
+				if got != want {
+					t.Fatalf("request %d sent key %q, want %q", i+1, got, want)
+				}
+			}
+			if len(generated) == 2 && generated["auto"] == generated["auto2"] {
+				t.Fatalf("two calls shared the key %q", generated["auto"])
+			}
+			
+			// The response hook reports the key of each call
+			wantReported := []string{api.keys[0], api.keys[len(api.keys)-1]}
+			if !equalStrings(reported, wantReported) {
+				t.Errorf("reported keys %q, want %q", reported, wantReported)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKeyOfError(t *testing.T) {
+	api := &keyServer{failures: -1}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+		APIKey:          "key",
+		BaseURL:         srv.URL,
+		AutoIdempotency: true,
+		RetryWaitTime:   time.Millisecond,
+	})
+	
+	_, err := client.Investments.Create(context.Background(), &xrplsale.CreateInvestmentRequest{
+		ProjectID:       "proj_1",
+		AmountXRP:       "10",
+		InvestorAccount: "rInvestor",
+	})
+	if err == nil {
+		t.Fatal("Create() succeeded against a failing server")
+	}
+	if len(api.keys) < 2 {
+		t.Fatalf("%d attempts, want the keyed POST retried", len(api.keys))
+	}
+	if key := xrplsale.IdempotencyKeyOf(err); key == "" || key != api.keys[0] {
+		t.Fatalf("IdempotencyKeyOf() = %q, want the key sent, %q", key, api.keys[0])
+	}
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.Investments.Create(ctx, &xrplsale.CreateInvestmentRequest{ProjectID: "proj_1", AmountXRP: "10", InvestorAccount: "rInvestor"},
+		xrplsale.WithIdempotencyKey("job-7"))
+	if key := xrplsale.IdempotencyKeyOf(err); err == nil || key != "job-7" {
+		t.Fatalf("IdempotencyKeyOf(%v) = %q, want job-7 on a transport error", err, key)
+	}
+	if key := xrplsale.IdempotencyKeyOf(context.Canceled); key != "" {
+		t.Fatalf("IdempotencyKeyOf() = %q for an unrelated error", key)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,70 @@
+package keypairs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// alphabet is the XRP Ledger's base58 alphabet
+const alphabet = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+
+// errChecksum is returned for a base58 string whose checksum does not match
+var errChecksum = errors.New("invalid checksum")
+
+// encodeCheck base58-encodes payload followed by its 4-byte checksum
+func encodeCheck(payload []byte) string {
+	data := append(append([]byte(nil), payload...), checksum(payload)...)
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// decodeCheck decodes a base58 string and verifies and strips its checksum
+func decodeCheck(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	zeros := 0
+	for i := 0; i < len(s); i++ {
+		digit := bytes.IndexByte([]byte(alphabet), s[i])
+		if digit < 0 {
+			return nil, errors.New("invalid base58 character")
+		}
+		if digit == 0 && i == zeros {
+			zeros++
+		}
+		n.Mul(n, radix).Add(n, big.NewInt(int64(digit)))
+	}
+	data := append(make([]byte, zeros), n.Bytes()...)
+	if len(data) < 5 {
+		return nil, errors.New("base58 string too short")
+	}
+	payload, sum := data[:len(data)-4], data[len(data)-4:]
+	if !bytes.Equal(checksum(payload), sum) {
+		return nil, errChecksum
+	}
+	return payload, nil
+}
+
+// checksum is the first 4 bytes of the double SHA-256 of payload
+func checksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
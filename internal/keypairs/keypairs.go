@@ -0,0 +1,148 @@
+// Package keypairs derives XRP Ledger keys from family seeds and raw
+// private keys and signs messages with them, producing the same keys,
+// addresses and signatures as the ripple-keypairs library.
+package keypairs
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Algorithm is the signing algorithm of a key pair
+type Algorithm string
+
+const (
+	Secp256k1 Algorithm = "secp256k1"
+	Ed25519   Algorithm = "ed25519"
+)
+
+var (
+	secp256k1SeedPrefix = []byte{0x21}
+	ed25519SeedPrefix   = []byte{0x01, 0xe1, 0x4b}
+	accountPrefix       = []byte{0x00}
+)
+
+// KeyPair is an account's signing key
+type KeyPair struct {
+	algorithm Algorithm
+	ed        ed25519.PrivateKey
+	secp      *big.Int
+	public    []byte
+}
+
+// FromSeed derives the master key pair of a family seed ("s..." for
+// secp256k1, "sEd..." for ed25519)
+func FromSeed(seed string) (*KeyPair, error) {
+	payload, err := decodeCheck(seed)
+	if err != nil {
+		return nil, fmt.Errorf("decoding seed: %w", err)
+	}
+	switch {
+	case len(payload) == 19 && bytes.HasPrefix(payload, ed25519SeedPrefix):
+		return fromEd25519Secret(sha512Half(payload[3:])), nil
+	case len(payload) == 17 && bytes.HasPrefix(payload, secp256k1SeedPrefix):
+		return fromSecp256k1Entropy(payload[1:])
+	}
+	return nil, errors.New("decoding seed: not a family seed")
+}
+
+// FromPrivateKey returns the key pair of a hex private key as printed by
+// XRPL tools: "ED" and 32 bytes for ed25519, or 32 bytes, optionally with a
+// "00" prefix, for secp256k1
+func FromPrivateKey(privateKey string) (*KeyPair, error) {
+	raw, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding private key: %w", err)
+	}
+	switch {
+	case len(raw) == 33 && raw[0] == 0xed:
+		return fromEd25519Secret(raw[1:]), nil
+	case len(raw) == 33 && raw[0] == 0x00:
+		raw = raw[1:]
+		fallthrough
+	case len(raw) == 32:
+		priv := new(big.Int).SetBytes(raw)
+		if !validScalar(priv) {
+			return nil, errors.New("private key out of range")
+		}
+		return &KeyPair{algorithm: Secp256k1, secp: priv, public: scalarBaseMult(priv).compressed()}, nil
+	}
+	return nil, fmt.Errorf("private key has unexpected length %d", len(raw))
+}
+
+// fromEd25519Secret returns the ed25519 key pair of a 32-byte secret
+func fromEd25519Secret(secret []byte) *KeyPair {
+	priv := ed25519.NewKeyFromSeed(secret)
+	public := append([]byte{0xed}, priv.Public().(ed25519.PublicKey)...)
+	return &KeyPair{algorithm: Ed25519, ed: priv, public: public}
+}
+
+// fromSecp256k1Entropy derives the master key pair of a secp256k1 seed:
+// the root key is the first valid SHA-512 half of the entropy and a
+// sequence number, and account 0's key is added to it
+func fromSecp256k1Entropy(entropy []byte) (*KeyPair, error) {
+	root := deriveScalar(entropy, nil)
+	rootPublic := scalarBaseMult(root).compressed()
+	intermediate := deriveScalar(rootPublic, []byte{0, 0, 0, 0})
+	priv := new(big.Int).Add(root, intermediate)
+	priv.Mod(priv, curveN)
+	if !validScalar(priv) {
+		return nil, errors.New("seed derives an invalid key")
+	}
+	return &KeyPair{algorithm: Secp256k1, secp: priv, public: scalarBaseMult(priv).compressed()}, nil
+}
+
+// deriveScalar returns the first valid scalar SHA512Half(bytes, discrim,
+// seq) for seq counting up from zero
+func deriveScalar(data, discrim []byte) *big.Int {
+	for seq := uint32(0); ; seq++ {
+		buf := append(append(append([]byte(nil), data...), discrim...), binary.BigEndian.AppendUint32(nil, seq)...)
+		if k := new(big.Int).SetBytes(sha512Half(buf)); validScalar(k) {
+			return k
+		}
+	}
+}
+
+// Algorithm returns the key pair's signing algorithm
+func (k *KeyPair) Algorithm() Algorithm { return k.algorithm }
+
+// PublicKey returns the 33-byte public key: compressed for secp256k1,
+// 0xED and the key for ed25519
+func (k *KeyPair) PublicKey() []byte { return append([]byte(nil), k.public...) }
+
+// Address returns the classic address of the account the key is the master
+// key of
+func (k *KeyPair) Address() string {
+	sum := sha256.Sum256(k.public)
+	id := ripemd160(sum[:])
+	return encodeCheck(append(append([]byte(nil), accountPrefix...), id[:]...))
+}
+
+// Sign signs message: ed25519 keys sign it directly, secp256k1 keys sign
+// its SHA-512 half with a canonical DER signature
+func (k *KeyPair) Sign(message []byte) []byte {
+	if k.algorithm == Ed25519 {
+		return ed25519.Sign(k.ed, message)
+	}
+	return signSecp256k1(k.secp, sha512Half(message))
+}
+
+// ValidAddress reports whether address is a well-formed classic address
+func ValidAddress(address string) bool {
+	payload, err := decodeCheck(address)
+	return err == nil && len(payload) == 21 && payload[0] == accountPrefix[0] && strings.HasPrefix(address, "r")
+}
+
+// sha512Half returns the first 32 bytes of the SHA-512 of data
+func sha512Half(data []byte) []byte {
+	sum := sha512.Sum512(data)
+	return sum[:32]
+}
@@ -0,0 +1,168 @@
+package keypairs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+)
+
+// secp256k1 is not in the standard library. This implementation uses
+// math/big and is not constant-time, which is acceptable for signing an
+// occasional sign-in challenge on the caller's own machine; keys that need
+// stronger protection belong in an HSM behind a custom signer.
+
+var (
+	curveP, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+	curveN, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	curveGx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+	curveGy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+	halfN      = new(big.Int).Rsh(curveN, 1)
+)
+
+// point is an affine point of secp256k1; nil coordinates are infinity
+type point struct {
+	x, y *big.Int
+}
+
+func (p point) infinity() bool { return p.x == nil }
+
+// add returns p+q
+func (p point) add(q point) point {
+	if p.infinity() {
+		return q
+	}
+	if q.infinity() {
+		return p
+	}
+	var lambda *big.Int
+	if p.x.Cmp(q.x) == 0 {
+		if p.y.Cmp(q.y) != 0 {
+			// q is -p
+			return point{}
+		}
+		// Tangent: 3x² / 2y
+		num := new(big.Int).Mul(p.x, p.x)
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(p.y, 1)
+		lambda = num.Mul(num, den.ModInverse(den, curveP))
+	} else {
+		num := new(big.Int).Sub(q.y, p.y)
+		den := new(big.Int).Sub(q.x, p.x)
+		den.Mod(den, curveP)
+		lambda = num.Mul(num, den.ModInverse(den, curveP))
+	}
+	lambda.Mod(lambda, curveP)
+	
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, p.x).Sub(x, q.x).Mod(x, curveP)
+	y := new(big.Int).Sub(p.x, x)
+	y.Mul(y, lambda).Sub(y, p.y).Mod(y, curveP)
+	return point{x, y}
+}
+
+// scalarBaseMult returns k·G
+func scalarBaseMult(k *big.Int) point {
+	var result point
+	addend := point{curveGx, curveGy}
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			result = result.add(addend)
+		}
+		addend = addend.add(addend)
+	}
+	return result
+}
+
+// compressed returns the 33-byte SEC1 compressed encoding of p
+func (p point) compressed() []byte {
+	out := make([]byte, 33)
+	out[0] = 0x02 + byte(p.y.Bit(0))
+	p.x.FillBytes(out[1:])
+	return out
+}
+
+// validScalar reports whether k is a usable private key or nonce
+func validScalar(k *big.Int) bool {
+	return k.Sign() > 0 && k.Cmp(curveN) < 0
+}
+
+// signSecp256k1 signs the 32-byte hash with priv and returns the canonical
+// (low-S) DER signature, with the nonce derived as in RFC 6979
+func signSecp256k1(priv *big.Int, hash []byte) []byte {
+	e := new(big.Int).SetBytes(hash)
+	e.Mod(e, curveN)
+	nonces := newNonceGenerator(priv, e)
+	for {
+		k := nonces.next()
+		r := scalarBaseMult(k).x
+		r.Mod(r, curveN)
+		if r.Sign() == 0 {
+			continue
+		}
+		s := new(big.Int).Mul(r, priv)
+		s.Add(s, e).Mul(s, new(big.Int).ModInverse(k, curveN)).Mod(s, curveN)
+		if s.Sign() == 0 {
+			continue
+		}
+		if s.Cmp(halfN) > 0 {
+			s.Sub(curveN, s)
+		}
+		return derSignature(r, s)
+	}
+}
+
+// nonceGenerator yields the deterministic nonces of RFC 6979 section 3.2
+// with HMAC-SHA256
+type nonceGenerator struct {
+	k, v  []byte
+	first bool
+}
+
+func newNonceGenerator(priv, e *big.Int) *nonceGenerator {
+	g := &nonceGenerator{k: make([]byte, 32), v: make([]byte, 32), first: true}
+	for i := range g.v {
+		g.v[i] = 1
+	}
+	seed := append(priv.FillBytes(make([]byte, 32)), e.FillBytes(make([]byte, 32))...)
+	g.k = g.mac(g.k, g.v, []byte{0}, seed)
+	g.v = g.mac(g.k, g.v)
+	g.k = g.mac(g.k, g.v, []byte{1}, seed)
+	g.v = g.mac(g.k, g.v)
+	return g
+}
+
+// next returns the next candidate nonce in [1, n-1]
+func (g *nonceGenerator) next() *big.Int {
+	for {
+		if !g.first {
+			g.k = g.mac(g.k, g.v, []byte{0})
+			g.v = g.mac(g.k, g.v)
+		}
+		g.first = false
+		g.v = g.mac(g.k, g.v)
+		if k := new(big.Int).SetBytes(g.v); validScalar(k) {
+			return k
+		}
+	}
+}
+
+func (g *nonceGenerator) mac(key []byte, parts ...[]byte) []byte {
+	h := hmac.New(sha256.New, key)
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// derSignature encodes r and s as an ASN.1 DER ECDSA signature
+func derSignature(r, s *big.Int) []byte {
+	encode := func(n *big.Int) []byte {
+		b := n.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return append([]byte{0x02, byte(len(b))}, b...)
+	}
+	body := append(encode(r), encode(s)...)
+	return append([]byte{0x30, byte(len(body))}, body...)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+)
+
+// TestMocksUpToDate fails when mocks/api.go differs from what go generate
+// would write for the current api.go
+func TestMocksUpToDate(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "../../api.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := generate(fset, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../mocks/api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("mocks/api.go is stale; run go generate github.com/xrplsale/go-sdk")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,96 @@
+package xrplsale
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// InvestmentCSVExport reports how far ExportCSV got
+type InvestmentCSVExport struct {
+	// Rows is the number of investments written by this call
+	Rows int
+	
+	// Checkpoint is the ID of the last investment written. When the export
+	// stops early, pass it to WithResumeAfter to append the remaining rows.
+	Checkpoint string
+	
+	// Complete is set once every investment has been written
+	Complete bool
+}
+
+// investmentCSVHeader names the columns written by ExportCSV
+var investmentCSVHeader = []string{"id", "project_id", "investor_account", "amount_xrp", "token_amount", "tier", "status", "transaction_hash", "created_at"}
+
+// ExportCSV writes every investment of a project to w as CSV, in ascending
+// ID order, flushing after each page of investments. An export cut short by
+// WithOperationTimeout or ctx ends on a whole row, and its progress is
+// returned alongside the error. Call it again with
+// WithResumeAfter(export.Checkpoint) to append the rest. The header is
+// written with the first row, so neither a resumed export nor a retry of
+// one that wrote nothing repeats it.
+func (is *InvestmentsService) ExportCSV(ctx context.Context, projectID string, w io.Writer, reqOpts ...RequestOption) (*InvestmentCSVExport, error) {
+	ctx, cancel := operationContext(ctx, reqOpts)
+	defer cancel()
+	
+	ro := newRequestOptions(reqOpts)
+	export := &InvestmentCSVExport{Checkpoint: ro.resumeAfter}
+	cw := csv.NewWriter(w)
+	header := ro.resumeAfter == ""
+	pending := 0
+	flush := func() error {
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		export.Rows += pending
+		pending = 0
+		return nil
+	}
+	
+	it := is.IterateAllByProject(ctx, projectID, append(reqOpts[:len(reqOpts):len(reqOpts)], WithOrderByID())...)
+	for it.Next() {
+		if header {
+			if err := cw.Write(investmentCSVHeader); err != nil {
+				return export, err
+			}
+			header = false
+		}
+		inv := it.Value()
+		record := []string{
+			inv.ID, inv.ProjectID, inv.InvestorAccount, inv.AmountXRP.String(), inv.TokenAmount.String(),
+			strconv.Itoa(inv.Tier), inv.Status, inv.TransactionHash, inv.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if err := cw.Write(record); err != nil {
+			return export, err
+		}
+		if pending++; pending == DefaultIteratePageSize {
+			if err := flush(); err != nil {
+				return export, err
+			}
+			export.Checkpoint = inv.ID
+		}
+	}
+	if err := it.Err(); err != nil {
+		if flushErr := flush(); flushErr != nil {
+			return export, flushErr
+		}
+		export.Checkpoint = it.Checkpoint()
+		return export, fmt.Errorf("exporting investments: %w", err)
+	}
+	if header {
+		// An empty project still gets its header
+		if err := cw.Write(investmentCSVHeader); err != nil {
+			return export, err
+		}
+	}
+	if err := flush(); err != nil {
+		return export, err
+	}
+	export.Checkpoint = it.Checkpoint()
+	export.Complete = true
+	return export, nil
+}
\ No newline at end of file
@@ -0,0 +1,104 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestExportCSV(t *testing.T) {
+	srv, client, _ := newCountingClient(t)
+	gen := fixtures.New(11)
+	project := gen.Project()
+	investments := gen.Investments(project, 250)
+	srv.AddProjects(project)
+	srv.AddInvestments(investments...)
+	var ids []string
+	for _, inv := range investments {
+		ids = append(ids, inv.ID)
+	}
+	sort.Strings(ids)
+	
+	tests := []struct {
+		name string
+		// With stall, the first run stops answering after stallAfter requests
+		stall      bool
+		stallAfter int32
+		wantRows   int
+	}{
+		{"complete", false, 0, 250},
+		{"cut short after two pages", true, 2, 200},
+		{"cut short before any page", true, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			first := client
+			var opts []xrplsale.RequestOption
+			if tt.stall {
+				first = xrplsaletest.NewClient(srv, func(c *xrplsale.Config) { c.Transport = &stallTransport{after: tt.stallAfter} })
+				opts = append(opts, xrplsale.WithOperationTimeout(100*time.Millisecond))
+			}
+			
+			export, err := first.Investments.ExportCSV(context.Background(), project.ID, &buf, opts...)
+			if export.Rows != tt.wantRows {
+				t.Fatalf("Rows = %d, want %d", export.Rows, tt.wantRows)
+			}
+			if tt.wantRows == len(ids) {
+				if err != nil || !export.Complete || export.Checkpoint != ids[len(ids)-1] {
+					t.Fatalf("ExportCSV() = %+v, %v; want a complete export", export, err)
+				}
+			} else {
+				if !errors.Is(err, context.DeadlineExceeded) || export.Complete {
+					t.Fatalf("ExportCSV() = %+v, %v; want a partial export and DeadlineExceeded", export, err)
+				}
+				wantCheckpoint := ""
+				if tt.wantRows > 0 {
+					wantCheckpoint = ids[tt.wantRows-1]
+				}
+				if export.Checkpoint != wantCheckpoint {
+					t.Fatalf("Checkpoint = %q, want %q", export.Checkpoint, wantCheckpoint)
+				}
+				resumed, err := client.Investments.ExportCSV(context.Background(), project.ID, &buf, xrplsale.WithResumeAfter(export.Checkpoint))
+				if err != nil || !resumed.Complete || resumed.Rows != len(ids)-tt.wantRows {
+					t.Fatalf("resumed ExportCSV() = %+v, %v; want the remaining %d rows", resumed, err, len(ids)-tt.wantRows)
+				}
+			}
+			
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != len(ids)+1 || records[0][0] != "id" {
+				t.Fatalf("%d records, want a header and %d rows", len(records), len(ids))
+			}
+			for i, record := range records[1:] {
+				if record[0] != ids[i] || record[1] != project.ID {
+					t.Fatalf("row %d = %q, want investment %s", i+1, record, ids[i])
+				}
+			}
+		})
+	}
+}
+func TestExportCSVEmptyProject(t *testing.T) {
+	srv, client, _ := newCountingClient(t)
+	project := fixtures.Project()
+	srv.AddProjects(project)
+	
+	var buf bytes.Buffer
+	export, err := client.Investments.ExportCSV(context.Background(), project.ID, &buf)
+	if err != nil || !export.Complete || export.Rows != 0 {
+		t.Fatalf("ExportCSV() = %+v, %v; want an empty complete export", export, err)
+	}
+	if records, _ := csv.NewReader(&buf).ReadAll(); len(records) != 1 || records[0][0] != "id" {
+		t.Fatalf("records = %q, want only the header", records)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,224 @@
+package xrplsale
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+)
+
+// DefaultIteratePageSize is the number of records an iterator requests per page
+const DefaultIteratePageSize = 100
+
+// WithOrderByID makes an iterator walk records in ascending ID order using
+// an after_id cursor instead of page numbers. Every request asks for IDs
+// above the last one seen, so records inserted during the iteration never
+// shift the pages and each record is yielded exactly once.
+func WithOrderByID() RequestOption {
+	return func(ro *requestOptions) {
+		ro.orderByID = true
+	}
+}
+
+// WithResumeAfter makes an iterator start after the record with the given
+// ID, typically a value saved from Iterator.Checkpoint. It implies
+// WithOrderByID.
+func WithResumeAfter(id string) RequestOption {
+	return func(ro *requestOptions) {
+		ro.orderByID = true
+		ro.resumeAfter = id
+	}
+}
+
+// pageFetcher fetches the page of records selected by params
+type pageFetcher[T any] func(ctx context.Context, params map[string]string) (*PaginatedResponse[T], error)
+
+// Iterator walks every record of a paginated listing, fetching one page at
+// a time. Iteration stops at the first error.
+//
+//	it := client.Investments.IterateAllByProject(ctx, projectID, xrplsale.WithOrderByID())
+//	for it.Next() {
+//		investment := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch pageFetcher[T]
+	idOf  func(T) string
+	
+	byID       bool
+	pageSize   int
+	page       int
+	checkpoint string
+	
+	buf   []T
+	value T
+	err   error
+	done  bool
+}
+
+// newIterator returns an iterator over the pages returned by fetch
+func newIterator[T any](ctx context.Context, fetch pageFetcher[T], idOf func(T) string, ro *requestOptions) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:        ctx,
+		fetch:      fetch,
+		idOf:       idOf,
+		byID:       ro.orderByID,
+		pageSize:   DefaultIteratePageSize,
+		checkpoint: ro.resumeAfter,
+	}
+}
+
+// Next advances to the next record, returning false when every record has
+// been yielded or on error
+func (it *Iterator[T]) Next() bool {
+	for len(it.buf) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fill()
+	}
+	it.value = it.buf[0]
+	it.buf = it.buf[1:]
+	it.checkpoint = it.idOf(it.value)
+	return true
+}
+
+// fill fetches the next page into the buffer
+func (it *Iterator[T]) fill() {
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return
+	}
+	
+	params := map[string]string{
+		"limit": strconv.Itoa(it.pageSize),
+	}
+	if it.byID {
+		params["sort_by"] = "id"
+		params["sort_order"] = "asc"
+		if it.checkpoint != "" {
+			params["after_id"] = it.checkpoint
+		}
+	} else {
+		it.page++
+		params["page"] = strconv.Itoa(it.page)
+	}
+	
+	result, err := it.fetch(it.ctx, params)
+	if err != nil {
+		it.err = err
+		return
+	}
+	if it.byID && it.checkpoint != "" {
+		for _, value := range result.Data {
+			if it.idOf(value) == it.checkpoint {
+				// Without this the same page would be requested forever
+				it.err = fmt.Errorf("iterating by ID: the API ignored after_id %q", it.checkpoint)
+				return
+			}
+		}
+	}
+	it.buf = result.Data
+	if it.byID {
+		// A short page means nothing above the cursor was left when it was
+		// served; later inserts are picked up by resuming from Checkpoint
+		it.done = len(result.Data) < it.pageSize
+	} else {
+		it.done = len(result.Data) == 0 || it.page >= result.Pagination.TotalPages
+	}
+}
+
+// Value returns the record yielded by the last call to Next
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, or nil if every record
+// was yielded
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Checkpoint returns the ID of the last record yielded, or the ID passed to
+// WithResumeAfter before the first one. It is the high-water mark of an
+// ID-ordered iteration: save it and pass it to WithResumeAfter to continue
+// later without skipping or repeating records.
+func (it *Iterator[T]) Checkpoint() string {
+	return it.checkpoint
+}
+
+// IterateAllByProject iterates over every investment of a project. By
+// default pages are requested by number in the API's default order; use
+// WithOrderByID for a backfill that must not skip or repeat records while
+// investments are being made, and WithResumeAfter to continue from a
+// checkpoint.
+func (is *InvestmentsService) IterateAllByProject(ctx context.Context, projectID string, reqOpts ...RequestOption) *Iterator[Investment] {
+	endpoint := fmt.Sprintf("/projects/%s/investments", projectID)
+	fetch := func(ctx context.Context, params map[string]string) (*PaginatedResponse[Investment], error) {
+		var result PaginatedResponse[Investment]
+		err := is.client.Get(ctx, endpoint, params, &result, reqOpts...)
+		return &result, err
+	}
+	idOf := func(investment Investment) string {
+		return investment.ID
+	}
+	return newIterator(ctx, fetch, idOf, newRequestOptions(reqOpts))
+}
+
+// iterate returns an iterator over every project matching opts. opts.Limit
+// is the page size (default DefaultIteratePageSize); opts.Page is ignored.
+func (ps *ProjectsService) iterate(ctx context.Context, opts *ListProjectsOptions, reqOpts []RequestOption) *Iterator[Project] {
+	invalid := opts.validate()
+	filter := encodeQuery(opts)
+	delete(filter, "page")
+	fetch := func(ctx context.Context, params map[string]string) (*PaginatedResponse[Project], error) {
+		if invalid != nil {
+			return nil, invalid
+		}
+		params = maps.Clone(params)
+		for key, value := range filter {
+			if _, ok := params[key]; !ok {
+				params[key] = value
+			}
+		}
+		var result PaginatedResponse[Project]
+		err := ps.client.Get(ctx, "/projects", params, &result, reqOpts...)
+		return &result, err
+	}
+	idOf := func(project Project) string {
+		return project.ID
+	}
+	it := newIterator(ctx, fetch, idOf, newRequestOptions(reqOpts))
+	if opts != nil && opts.Limit > 0 {
+		it.pageSize = opts.Limit
+	}
+	return it
+}
+
+// forEach calls fn with every record of it until fn or a page fetch fails
+func forEach[T any](it *Iterator[T], fn func(T) error) error {
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// ForEach calls fn with every project matching opts, fetching pages as fn
+// consumes them. It stops at the first error, from fn or a page fetch, and
+// returns it. opts.Limit is the page size; opts.Page is ignored. On Go 1.23
+// and later, All offers the same walk as a range-over-func iterator.
+func (ps *ProjectsService) ForEach(ctx context.Context, opts *ListProjectsOptions, fn func(Project) error, reqOpts ...RequestOption) error {
+	return forEach(ps.iterate(ctx, opts, reqOpts), fn)
+}
+
+// ForEachByProject calls fn with every investment of a project, fetching
+// pages as fn consumes them. It stops at the first error, from fn or a page
+// fetch, and returns it. The iteration options of IterateAllByProject
+// apply. On Go 1.23 and later, AllByProject offers the same walk as a
+// range-over-func iterator.
+func (is *InvestmentsService) ForEachByProject(ctx context.Context, projectID string, fn func(Investment) error, reqOpts ...RequestOption) error {
+	return forEach(is.IterateAllByProject(ctx, projectID, reqOpts...), fn)
+}
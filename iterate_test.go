@@ -0,0 +1,220 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// newInvestments returns n investments in project, leaving the IDs for the
+// fake server to assign in creation order
+func newInvestments(project *xrplsale.Project, n int) []xrplsale.Investment {
+	investments := fixtures.Investments(project, n)
+	for i := range investments {
+		investments[i].ID = ""
+	}
+	return investments
+}
+
+func TestIterateAllByProject(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []xrplsale.RequestOption
+		rows int
+	}{
+		{"page numbers", nil, 250},
+		{"ordered by ID", []xrplsale.RequestOption{xrplsale.WithOrderByID()}, 250},
+		{"ordered by ID, whole pages", []xrplsale.RequestOption{xrplsale.WithOrderByID()}, 2 * xrplsale.DefaultIteratePageSize},
+		{"empty", []xrplsale.RequestOption{xrplsale.WithOrderByID()}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := xrplsaletest.NewServer()
+			defer srv.Close()
+			project := fixtures.Project()
+			srv.AddProjects(project)
+			srv.AddInvestments(newInvestments(project, tt.rows)...)
+			client := xrplsaletest.NewClient(srv)
+			
+			it := client.Investments.IterateAllByProject(context.Background(), project.ID, tt.opts...)
+			seen := map[string]bool{}
+			for it.Next() {
+				id := it.Value().ID
+				if seen[id] {
+					t.Fatalf("%s yielded twice", id)
+				}
+				seen[id] = true
+				if it.Checkpoint() != id {
+					t.Fatalf("Checkpoint() = %q after yielding %q", it.Checkpoint(), id)
+				}
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if len(seen) != tt.rows {
+				t.Fatalf("yielded %d investments, want %d", len(seen), tt.rows)
+			}
+		})
+	}
+}
+
+func TestIterateByIDWhileInserting(t *testing.T) {
+	srv := xrplsaletest.NewServer()
+	defer srv.Close()
+	project := fixtures.Project()
+	srv.AddProjects(project)
+	srv.AddInvestments(newInvestments(project, 500)...)
+	client := xrplsaletest.NewClient(srv)
+	
+	// New investments keep arriving while the backfill runs
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100 && ctx.Err() == nil; i++ {
+			srv.AddInvestments(newInvestments(project, 3)...)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	
+	seen := map[string]int{}
+	checkpoint := ""
+	// Backfill in runs of at most 120 records, resuming from the checkpoint
+	for run := 0; ; run++ {
+		it := client.Investments.IterateAllByProject(context.Background(), project.ID, xrplsale.WithResumeAfter(checkpoint))
+		n := 0
+		for n < 120 && it.Next() {
+			id := it.Value().ID
+			if id <= checkpoint {
+				t.Fatalf("run %d yielded %s at or below checkpoint %s", run, id, checkpoint)
+			}
+			seen[id]++
+			n++
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+		checkpoint = it.Checkpoint()
+		if n < 120 {
+			break
+		}
+	}
+	wg.Wait()
+	
+	// Catch up with anything inserted after the last run
+	it := client.Investments.IterateAllByProject(context.Background(), project.ID, xrplsale.WithResumeAfter(checkpoint))
+	for it.Next() {
+		seen[it.Value().ID]++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	
+	all := srv.Investments()
+	if len(all) <= 500 {
+		t.Fatal("no investments were inserted during the backfill")
+	}
+	for _, investment := range all {
+		if seen[investment.ID] != 1 {
+			t.Errorf("%s yielded %d times, want exactly once", investment.ID, seen[investment.ID])
+		}
+	}
+	if len(seen) != len(all) {
+		t.Fatalf("yielded %d distinct investments, want %d", len(seen), len(all))
+	}
+}
+
+func TestIterateByIDIgnoredCursor(t *testing.T) {
+	// A server that ignores after_id serves the first page forever
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items := make([]string, xrplsale.DefaultIteratePageSize)
+		for i := range items {
+			items[i] = fmt.Sprintf(`{"id":"inv_%03d"}`, i)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":[%s],"pagination":{"page":1,"limit":%d,"total":1000,"total_pages":10}}`, strings.Join(items, ","), len(items))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	it := client.Investments.IterateAllByProject(context.Background(), "proj_1", xrplsale.WithOrderByID())
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if n != xrplsale.DefaultIteratePageSize || it.Err() == nil || !strings.Contains(it.Err().Error(), "after_id") {
+		t.Fatalf("yielded %d then %v, want one page and an error about after_id", n, it.Err())
+	}
+}
+func TestForEach(t *testing.T) {
+	const rows, pageSize = 25, 10
+	errStop := errors.New("stop")
+	tests := []struct {
+		name string
+		// fn is called with the number of records seen so far
+		fn           func(srv *xrplsaletest.Server, n int) error
+		wantRecords  int
+		wantRequests int
+		wantErr      error
+		wantStatus   int
+	}{
+		{"every record", func(*xrplsaletest.Server, int) error { return nil }, rows, 3, nil, 0},
+		{"callback error stops the walk", func(_ *xrplsaletest.Server, n int) error {
+			if n == 5 {
+				return errStop
+			}
+			return nil
+		}, 5, 1, errStop, 0},
+		{"page error", func(srv *xrplsaletest.Server, n int) error {
+			if n == 1 {
+				srv.Fail(http.MethodGet, "/projects", xrplsaletest.Failure{Status: http.StatusBadRequest})
+			}
+			return nil
+		}, pageSize, 2, nil, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t)
+			for i := 0; i < rows; i++ {
+				srv.AddProjects(fixtures.Project())
+			}
+			
+			n := 0
+			err := client.Projects.ForEach(context.Background(), &xrplsale.ListProjectsOptions{Limit: pageSize}, func(xrplsale.Project) error {
+				n++
+				return tt.fn(srv, n)
+			})
+			var apiErr *xrplsale.APIError
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ForEach() = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantStatus != 0:
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("ForEach() = %v, want the failed page's error", err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			}
+			if n != tt.wantRecords {
+				t.Errorf("fn called %d times, want %d", n, tt.wantRecords)
+			}
+			if got := hits.count(http.MethodGet, "/projects"); got != tt.wantRequests {
+				t.Errorf("%d page requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
\ No newline at end of file
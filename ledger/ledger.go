@@ -0,0 +1,265 @@
+// Package ledger cross-checks what the XRPL.Sale platform records against
+// live XRP Ledger state, using a rippled JSON-RPC endpoint. Only validated
+// ledger data is read.
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+const (
+	// MainnetURL is a public rippled JSON-RPC endpoint for the XRPL mainnet
+	MainnetURL = "https://xrplcluster.com"
+	
+	// TestnetURL is a public rippled JSON-RPC endpoint for the XRPL testnet
+	TestnetURL = "https://s.altnet.rippletest.net:51234"
+	
+	// DefaultTimeout is the default JSON-RPC request timeout
+	DefaultTimeout = 15 * time.Second
+)
+
+// Account root flags, see https://xrpl.org/accountroot.html
+const (
+	lsfRequireAuth   = 0x00040000
+	lsfNoFreeze      = 0x00200000
+	lsfGlobalFreeze  = 0x00400000
+	lsfDefaultRipple = 0x00800000
+)
+
+// transferRateUnit is the TransferRate of an issuer charging no fee
+const transferRateUnit = 1_000_000_000
+
+// ErrAccountNotFound is returned when an account does not exist in the
+// validated ledger
+var ErrAccountNotFound = errors.New("account not found in the validated ledger")
+
+// Client reads ledger state from a rippled JSON-RPC endpoint
+type Client struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for the rippled JSON-RPC endpoint at url
+func NewClient(url string) *Client {
+	return &Client{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// Discrepancy is a field whose ledger value differs from the platform's
+// record. Field uses the JSON name of the TokenDetails field.
+type Discrepancy struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// Verification is the result of checking TokenDetails against the ledger
+type Verification struct {
+	IssuerAddress string        `json:"issuer_address"`
+	CurrencyCode  string        `json:"currency_code"`
+	LedgerIndex   uint32        `json:"ledger_index"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// OK reports whether the ledger matches the platform's record
+func (v *Verification) OK() bool {
+	return len(v.Discrepancies) == 0
+}
+
+// VerifyOnLedger compares details with the issuer account in the latest
+// validated ledger. Flags and the transfer fee must match exactly; the
+// amount issued so far must not exceed TotalSupply. A missing issuer
+// account is reported as a discrepancy, not an error.
+func (c *Client) VerifyOnLedger(ctx context.Context, details *xrplsale.TokenDetails) (*Verification, error) {
+	v := &Verification{
+		IssuerAddress: details.IssuerAddress,
+		CurrencyCode:  details.CurrencyCode,
+	}
+	
+	account, err := c.accountInfo(ctx, details.IssuerAddress)
+	if errors.Is(err, ErrAccountNotFound) {
+		v.mismatch("issuer_address", details.IssuerAddress, "account not found")
+		return v, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	v.LedgerIndex = account.LedgerIndex
+	
+	flags := account.AccountData.Flags
+	v.compareFlag("flags.default_ripple", details.Flags.DefaultRipple, flags&lsfDefaultRipple != 0)
+	v.compareFlag("flags.require_auth", details.Flags.RequireAuth, flags&lsfRequireAuth != 0)
+	v.compareFlag("flags.global_freeze", details.Flags.GlobalFreeze, flags&lsfGlobalFreeze != 0)
+	v.compareFlag("flags.no_freeze", details.Flags.NoFreeze, flags&lsfNoFreeze != 0)
+	
+	fee, err := transferFee(account.AccountData.TransferRate)
+	if err != nil {
+		return nil, err
+	}
+	if !fee.Equal(details.TransferFee) {
+		v.mismatch("transfer_fee", details.TransferFee.String(), fee.String())
+	}
+	
+	issued, err := c.obligation(ctx, details.IssuerAddress, details.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	if issued.Cmp(details.TotalSupply) > 0 {
+		v.mismatch("total_supply", "at most "+details.TotalSupply.String()+" issued", issued.String()+" issued")
+	}
+	return v, nil
+}
+
+// compareFlag records a discrepancy when a flag differs
+func (v *Verification) compareFlag(field string, expected, actual bool) {
+	if expected != actual {
+		v.mismatch(field, strconv.FormatBool(expected), strconv.FormatBool(actual))
+	}
+}
+
+// mismatch records a discrepancy
+func (v *Verification) mismatch(field, expected, actual string) {
+	v.Discrepancies = append(v.Discrepancies, Discrepancy{
+		Field:    field,
+		Expected: expected,
+		Actual:   actual,
+	})
+}
+
+// transferFee converts a ledger TransferRate to a percentage. A rate of
+// zero means no fee.
+func transferFee(rate uint32) (xrplsale.Amount, error) {
+	if rate == 0 {
+		return xrplsale.Amount{}, nil
+	}
+	if rate < transferRateUnit {
+		return xrplsale.Amount{}, fmt.Errorf("invalid TransferRate %d", rate)
+	}
+	return xrplsale.ParseAmount(fmt.Sprintf("%de-7", rate-transferRateUnit))
+}
+
+// accountInfoResult is the part of an account_info result used here
+type accountInfoResult struct {
+	LedgerIndex uint32 `json:"ledger_index"`
+	AccountData struct {
+		Flags        uint32 `json:"Flags"`
+		TransferRate uint32 `json:"TransferRate"`
+	} `json:"account_data"`
+}
+
+// accountInfo fetches an account root from the validated ledger
+func (c *Client) accountInfo(ctx context.Context, account string) (*accountInfoResult, error) {
+	var result accountInfoResult
+	err := c.call(ctx, "account_info", map[string]interface{}{
+		"account":      account,
+		"ledger_index": "validated",
+	}, &result)
+	return &result, err
+}
+
+// obligation returns the amount of currency the issuer has issued
+func (c *Client) obligation(ctx context.Context, issuer, currency string) (xrplsale.Amount, error) {
+	var result struct {
+		Obligations map[string]string `json:"obligations"`
+	}
+	err := c.call(ctx, "gateway_balances", map[string]interface{}{
+		"account":      issuer,
+		"ledger_index": "validated",
+		"strict":       true,
+	}, &result)
+	if err != nil {
+		return xrplsale.Amount{}, err
+	}
+	value, ok := result.Obligations[ledgerCurrency(currency)]
+	if !ok {
+		return xrplsale.Amount{}, nil
+	}
+	return xrplsale.ParseAmount(value)
+}
+
+// ledgerCurrency returns the code the ledger reports for currency:
+// 3-character codes as is, longer ones as 40 uppercase hex characters
+func ledgerCurrency(currency string) string {
+	if len(currency) <= 3 {
+		return currency
+	}
+	if len(currency) == 40 {
+		if _, err := hex.DecodeString(currency); err == nil {
+			return strings.ToUpper(currency)
+		}
+	}
+	code := make([]byte, 20)
+	copy(code, currency)
+	return strings.ToUpper(hex.EncodeToString(code))
+}
+
+// rpcError is the error part of a rippled JSON-RPC result
+type rpcError struct {
+	Status       string `json:"status"`
+	Error        string `json:"error"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// call makes a JSON-RPC request and decodes its result into out
+func (c *Client) call(ctx context.Context, method string, params map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"method": method,
+		"params": []interface{}{params},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected HTTP status %d", method, resp.StatusCode)
+	}
+	
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("%s: decoding response: %w", method, err)
+	}
+	var status rpcError
+	if err := json.Unmarshal(envelope.Result, &status); err != nil {
+		return fmt.Errorf("%s: decoding result: %w", method, err)
+	}
+	if status.Error == "actNotFound" {
+		return ErrAccountNotFound
+	}
+	if status.Status != "success" {
+		msg := status.ErrorMessage
+		if msg == "" {
+			msg = status.Error
+		}
+		return fmt.Errorf("%s: %s", method, msg)
+	}
+	return json.Unmarshal(envelope.Result, out)
+}
\ No newline at end of file
@@ -0,0 +1,175 @@
+package ledger_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/ledger"
+)
+
+// issuer is the ledger state of an issuer account served by rippledServer
+type issuer struct {
+	flags        uint32
+	transferRate uint32
+	obligations  map[string]string
+}
+
+// rippledServer answers account_info and gateway_balances for the given
+// accounts from ledger 90000000
+func rippledServer(t *testing.T, accounts map[string]issuer) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string                   `json:"method"`
+			Params []map[string]interface{} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Params) != 1 || req.Params[0]["ledger_index"] != "validated" {
+			t.Errorf("unexpected request %+v, %v", req, err)
+		}
+		account, ok := accounts[req.Params[0]["account"].(string)]
+		var result map[string]interface{}
+		switch {
+		case !ok:
+			result = map[string]interface{}{"status": "error", "error": "actNotFound"}
+		case req.Method == "account_info":
+			result = map[string]interface{}{
+				"status":       "success",
+				"ledger_index": 90000000,
+				"account_data": map[string]interface{}{"Flags": account.flags, "TransferRate": account.transferRate},
+			}
+		case req.Method == "gateway_balances":
+			result = map[string]interface{}{"status": "success", "obligations": account.obligations}
+		default:
+			result = map[string]interface{}{"status": "error", "error": "unknownCmd", "error_message": "Unknown method."}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"result": result})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVerifyOnLedger(t *testing.T) {
+	const (
+		defaultRipple = 0x00800000
+		requireAuth   = 0x00040000
+		noFreeze      = 0x00200000
+		globalFreeze  = 0x00400000
+	)
+	// "SALETOKEN" as the ledger's 40 character hex code
+	const hexCode = "53414C45544F4B454E0000000000000000000000"
+	claimed := xrplsale.TokenDetails{
+		IssuerAddress: "rIssuer",
+		CurrencyCode:  "SALETOKEN",
+		TotalSupply:   xrplsale.MustParseAmount("1000000"),
+		TransferFee:   xrplsale.MustParseAmount("0.5"),
+		Flags:         xrplsale.TokenFlags{DefaultRipple: true, NoFreeze: true},
+	}
+	tests := []struct {
+		name    string
+		account issuer
+		details func(d *xrplsale.TokenDetails)
+		want    []ledger.Discrepancy
+	}{
+		{"matches", issuer{defaultRipple | noFreeze, 1_005_000_000, map[string]string{hexCode: "250000"}}, nil, nil},
+		{"whole supply issued", issuer{defaultRipple | noFreeze, 1_005_000_000, map[string]string{hexCode: "1000000"}}, nil, nil},
+		{"nothing issued yet", issuer{defaultRipple | noFreeze, 1_005_000_000, nil}, nil, nil},
+		{"no fee", issuer{defaultRipple | noFreeze, 0, nil}, func(d *xrplsale.TokenDetails) { d.TransferFee = xrplsale.Amount{} }, nil},
+		{"three letter code", issuer{defaultRipple | noFreeze, 1_005_000_000, map[string]string{"SAL": "5"}}, func(d *xrplsale.TokenDetails) { d.CurrencyCode, d.TotalSupply = "SAL", xrplsale.MustParseAmount("4") }, []ledger.Discrepancy{
+			{Field: "total_supply", Expected: "at most 4 issued", Actual: "5 issued"},
+		}},
+		{"flags and fee differ", issuer{requireAuth | globalFreeze, 1_002_000_000, nil}, nil, []ledger.Discrepancy{
+			{Field: "flags.default_ripple", Expected: "true", Actual: "false"},
+			{Field: "flags.require_auth", Expected: "false", Actual: "true"},
+			{Field: "flags.global_freeze", Expected: "false", Actual: "true"},
+			{Field: "flags.no_freeze", Expected: "true", Actual: "false"},
+			{Field: "transfer_fee", Expected: "0.5", Actual: "0.2"},
+		}},
+		{"over issued", issuer{defaultRipple | noFreeze, 1_005_000_000, map[string]string{hexCode: "1000000.5"}}, nil, []ledger.Discrepancy{
+			{Field: "total_supply", Expected: "at most 1000000 issued", Actual: "1000000.5 issued"},
+		}},
+		{"missing issuer", issuer{}, func(d *xrplsale.TokenDetails) { d.IssuerAddress = "rNobody" }, []ledger.Discrepancy{
+			{Field: "issuer_address", Expected: "rNobody", Actual: "account not found"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := rippledServer(t, map[string]issuer{"rIssuer": tt.account})
+			details := claimed
+			if tt.details != nil {
+				tt.details(&details)
+			}
+			v, err := ledger.NewClient(srv.URL).VerifyOnLedger(context.Background(), &details)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v.OK() != (len(tt.want) == 0) || len(v.Discrepancies) != len(tt.want) {
+				t.Fatalf("discrepancies %+v, want %+v", v.Discrepancies, tt.want)
+			}
+			for i := range tt.want {
+				if v.Discrepancies[i] != tt.want[i] {
+					t.Errorf("discrepancy %d = %+v, want %+v", i, v.Discrepancies[i], tt.want[i])
+				}
+			}
+			if v.IssuerAddress != details.IssuerAddress || v.CurrencyCode != details.CurrencyCode {
+				t.Errorf("Verification describes %s/%s", v.IssuerAddress, v.CurrencyCode)
+			}
+			if details.IssuerAddress == "rIssuer" && v.LedgerIndex != 90000000 {
+				t.Errorf("LedgerIndex = %d, want 90000000", v.LedgerIndex)
+			}
+		})
+	}
+}
+
+func TestVerifyOnLedgerErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{"HTTP error", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadGateway) }, "unexpected HTTP status 502"},
+		{"RPC error", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"result":{"status":"error","error":"noNetwork","error_message":"Not synced to the network."}}`))
+		}, "Not synced"},
+		{"not JSON", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("<html>")) }, "decoding response"},
+		{"invalid transfer rate", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"result":{"status":"success","account_data":{"Flags":0,"TransferRate":5}}}`))
+		}, "invalid TransferRate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+			_, err := ledger.NewClient(srv.URL).VerifyOnLedger(context.Background(), &xrplsale.TokenDetails{IssuerAddress: "rIssuer", CurrencyCode: "SAL"})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || errors.Is(err, ledger.ErrAccountNotFound) {
+				t.Fatalf("VerifyOnLedger() = %v, want an error mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetTokenDetails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/proj_1/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"project_id":"proj_1","issuer_address":"rIssuer","currency_code":"SAL","total_supply":"1000000","transfer_fee":0.5,"flags":{"default_ripple":true,"no_freeze":true}}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	details, err := client.Projects.GetTokenDetails(context.Background(), "proj_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := xrplsale.TokenFlags{DefaultRipple: true, NoFreeze: true}
+	if details.IssuerAddress != "rIssuer" || details.CurrencyCode != "SAL" || details.TotalSupply.String() != "1000000" || details.TransferFee.String() != "0.5" || details.Flags != want {
+		t.Fatalf("GetTokenDetails() = %+v", details)
+	}
+}
\ No newline at end of file
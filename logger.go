@@ -0,0 +1,32 @@
+package xrplsale
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+// Logger receives the SDK's log output, including resty's debug dumps
+type Logger interface {
+	Errorf(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Debugf(format string, v ...interface{})
+}
+
+// stdLogger is the default Logger, writing to stderr
+type stdLogger struct {
+	l *log.Logger
+}
+
+// newStdLogger returns a Logger writing to stderr
+func newStdLogger() *stdLogger {
+	return &stdLogger{l: log.New(os.Stderr, "", log.Ldate|log.Lmicroseconds)}
+}
+
+func (s *stdLogger) Errorf(format string, v ...interface{}) { s.output("ERROR XRPLSALE", format, v) }
+func (s *stdLogger) Warnf(format string, v ...interface{})  { s.output("WARN XRPLSALE", format, v) }
+func (s *stdLogger) Debugf(format string, v ...interface{}) { s.output("DEBUG XRPLSALE", format, v) }
+
+func (s *stdLogger) output(level, format string, v []interface{}) {
+	s.l.Print(level + " " + fmt.Sprintf(format, v...))
+}
\ No newline at end of file
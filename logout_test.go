@@ -0,0 +1,72 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// failPath is a transport failing requests to one path with a network error
+type failPath struct {
+	next http.RoundTripper
+	path string
+}
+
+func (fp *failPath) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == fp.path {
+		return nil, errors.New("connection reset")
+	}
+	return fp.next.RoundTrip(req)
+}
+
+func TestLogout(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		networkError bool
+		wantErr      bool
+	}{
+		{"revoked", 0, false, false},
+		{"API error", http.StatusInternalServerError, false, true},
+		{"network error", 0, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &xrplsale.MemoryTokenStore{}
+			auth := &authCapture{}
+			srv, client, _ := newCountingClient(t, func(c *xrplsale.Config) {
+				c.TokenStore, c.AutoRefresh, c.RetryOnUnauthorized = store, true, true
+				next := c.Transport
+				if tt.networkError {
+					next = &failPath{next: next, path: "/auth/logout"}
+				}
+				auth.next, c.Transport = next, auth
+			})
+			signIn(t, client)
+			if tt.status != 0 {
+				srv.Fail(http.MethodPost, "/auth/logout", xrplsaletest.Failure{Status: tt.status})
+			}
+			
+			err := client.Auth.Logout(context.Background(), xrplsale.WithNoRetry())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Logout() = %v, want an error %v", err, tt.wantErr)
+			}
+			if _, err := client.Auth.GetProfile(context.Background()); !errors.Is(err, xrplsale.ErrUnauthorized) {
+				t.Errorf("GetProfile() after Logout() = %v, want ErrUnauthorized", err)
+			}
+			if header := auth.token("/auth/profile"); header != "" {
+				t.Errorf("request after Logout() sent Authorization %q", header)
+			}
+			if _, err := client.Auth.Refresh(context.Background(), ""); err == nil || errors.Is(err, xrplsale.ErrSessionExpired) {
+				t.Errorf("Refresh() after Logout() = %v, want no refresh token", err)
+			}
+			if tokens, err := store.Get(context.Background()); err != nil || tokens.Token != "" || tokens.RefreshToken != "" {
+				t.Errorf("token store after Logout() = %+v, %v, want it empty", tokens, err)
+			}
+		})
+	}
+}
\ No newline at end of file
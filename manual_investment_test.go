@@ -0,0 +1,74 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestCreateManualInvestment(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   xrplsale.CreateManualInvestmentRequest
+		status    int
+		response  string
+		wantSent  bool
+		wantErr   error
+		wantScope string
+	}{
+		{"payment reference", xrplsale.CreateManualInvestmentRequest{ProjectID: "proj_1", Amount: "500", PaymentReference: "wire-42"},
+			http.StatusCreated, `{"id":"inv_1","manual":true}`, true, nil, ""},
+		{"unverified", xrplsale.CreateManualInvestmentRequest{ProjectID: "proj_1", Amount: "500", Unverified: true},
+			http.StatusCreated, `{"id":"inv_2","manual":true}`, true, nil, ""},
+		{"no reference", xrplsale.CreateManualInvestmentRequest{ProjectID: "proj_1", Amount: "500"},
+			http.StatusCreated, `{}`, false, xrplsale.ErrValidation, ""},
+		{"not the owner", xrplsale.CreateManualInvestmentRequest{ProjectID: "proj_1", Amount: "500", PaymentReference: "wire-42"},
+			http.StatusForbidden, `{"message":"forbidden","required_scope":"investments:write"}`, true, xrplsale.ErrForbidden, xrplsale.ScopeInvestmentsWrite},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []xrplsale.CreateManualInvestmentRequest
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var got xrplsale.CreateManualInvestmentRequest
+				if r.Method != http.MethodPost || r.URL.Path != "/investments/manual" || json.NewDecoder(r.Body).Decode(&got) != nil {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				sent = append(sent, got)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			investment, err := client.Investments.CreateManual(context.Background(), &tt.request)
+			if (len(sent) == 1) != tt.wantSent {
+				t.Fatalf("CreateManual() sent %d requests, want sent=%v", len(sent), tt.wantSent)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateManual() = %v, want %v", err, tt.wantErr)
+				}
+				var permErr *xrplsale.PermissionError
+				if tt.wantScope != "" && (!errors.As(err, &permErr) || permErr.RequiredScope != tt.wantScope) {
+					t.Errorf("CreateManual() = %#v, want a PermissionError requiring %q", err, tt.wantScope)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !investment.Manual {
+				t.Errorf("CreateManual() returned %+v, want it flagged Manual", investment)
+			}
+			if sent[0] != tt.request {
+				t.Errorf("sent %+v, want %+v", sent[0], tt.request)
+			}
+		})
+	}
+}
\ No newline at end of file
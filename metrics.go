@@ -0,0 +1,126 @@
+package xrplsale
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MetricsCollector receives a sample after every API call, e.g. to export
+// Prometheus metrics. Observe is called synchronously and must not block.
+type MetricsCollector interface {
+	Observe(sample MetricsSample)
+}
+
+// MetricsSample describes one API call, including all of its retries
+type MetricsSample struct {
+	Method string
+	
+	// Route is the endpoint with identifiers replaced, e.g. "/projects/{id}",
+	// so it is safe to use as a metric label
+	Route string
+	
+	// StatusCode is the status of the last attempt, zero if none was received
+	StatusCode int
+	Duration   time.Duration
+	Attempts   int
+	Failed     bool
+	
+	// Cached is set, and Attempts zero, for a call served from a
+	// client-side cache
+	Cached bool
+}
+
+// observe reports the call described by info to the metrics collector
+func (c *Client) observe(info *ResponseInfo) {
+	if c.config.MetricsCollector == nil {
+		return
+	}
+	c.config.MetricsCollector.Observe(MetricsSample{
+		Method:     info.Method,
+		Route:      routeTemplate(info.Endpoint),
+		StatusCode: info.StatusCode,
+		Duration:   info.Duration,
+		Attempts:   info.Attempts,
+		Failed:     info.Err != nil,
+		Cached:     info.FromCache,
+	})
+}
+
+// RouteMetrics aggregates the samples for one method and route
+type RouteMetrics struct {
+	Method        string
+	Route         string
+	Calls         int
+	CacheHits     int
+	Failures      int
+	Attempts      int
+	TotalDuration time.Duration
+	StatusCodes   map[int]int
+}
+
+// InMemoryMetrics is a MetricsCollector that aggregates samples per route.
+// It is meant for tests and debugging; the zero value is ready to use.
+type InMemoryMetrics struct {
+	mu     sync.Mutex
+	routes map[string]*RouteMetrics
+}
+
+// Observe implements MetricsCollector
+func (m *InMemoryMetrics) Observe(sample MetricsSample) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	if m.routes == nil {
+		m.routes = make(map[string]*RouteMetrics)
+	}
+	key := sample.Method + " " + sample.Route
+	rm, ok := m.routes[key]
+	if !ok {
+		rm = &RouteMetrics{Method: sample.Method, Route: sample.Route, StatusCodes: make(map[int]int)}
+		m.routes[key] = rm
+	}
+	
+	rm.Calls++
+	rm.Attempts += sample.Attempts
+	rm.TotalDuration += sample.Duration
+	if sample.Failed {
+		rm.Failures++
+	}
+	if sample.Cached {
+		rm.CacheHits++
+	}
+	if sample.StatusCode != 0 {
+		rm.StatusCodes[sample.StatusCode]++
+	}
+}
+
+// Snapshot returns a copy of the metrics, ordered by route then method
+func (m *InMemoryMetrics) Snapshot() []RouteMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	snapshot := make([]RouteMetrics, 0, len(m.routes))
+	for _, rm := range m.routes {
+		cp := *rm
+		cp.StatusCodes = make(map[int]int, len(rm.StatusCodes))
+		for code, n := range rm.StatusCodes {
+			cp.StatusCodes[code] = n
+		}
+		snapshot = append(snapshot, cp)
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Route != snapshot[j].Route {
+			return snapshot[i].Route < snapshot[j].Route
+		}
+		return snapshot[i].Method < snapshot[j].Method
+	})
+	return snapshot
+}
+
+// Reset discards all aggregated metrics
+func (m *InMemoryMetrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes = nil
+}
\ No newline at end of file
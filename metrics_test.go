@@ -0,0 +1,98 @@
+package xrplsale_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestInMemoryMetrics(t *testing.T) {
+	metrics := &xrplsale.InMemoryMetrics{}
+	srv, client, _ := newCountingClient(t, func(c *xrplsale.Config) { c.MetricsCollector = metrics })
+	projects := []*xrplsale.Project{fixtures.Project(), fixtures.Project()}
+	srv.AddProjects(projects...)
+	
+	// Two failed attempts before the first call succeeds
+	srv.Fail(http.MethodGet, "/projects/"+projects[0].ID, xrplsaletest.Failure{Status: http.StatusServiceUnavailable, Times: 2})
+	ctx := context.Background()
+	for _, p := range projects {
+		if _, err := client.Projects.Get(ctx, p.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.Projects.Get(ctx, "proj_missing0"); err == nil {
+		t.Fatal("Get() of a missing project succeeded")
+	}
+	if _, err := client.Projects.GetStats(ctx, projects[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	
+	tests := []struct {
+		route       string
+		calls       int
+		failures    int
+		attempts    int
+		statusCodes map[int]int
+	}{
+		{"/projects/{id}", 3, 1, 5, map[int]int{http.StatusOK: 2, http.StatusNotFound: 1}},
+		{"/projects/{id}/stats", 1, 0, 1, map[int]int{http.StatusOK: 1}},
+	}
+	snapshot := metrics.Snapshot()
+	if len(snapshot) != len(tests) {
+		t.Fatalf("Snapshot() = %+v, want %d routes", snapshot, len(tests))
+	}
+	for i, tt := range tests {
+		rm := snapshot[i]
+		if rm.Method != http.MethodGet || rm.Route != tt.route || rm.Calls != tt.calls || rm.Failures != tt.failures || rm.Attempts != tt.attempts {
+			t.Errorf("route %d = %+v, want %s with %d calls, %d failures, %d attempts", i, rm, tt.route, tt.calls, tt.failures, tt.attempts)
+		}
+		if len(rm.StatusCodes) != len(tt.statusCodes) {
+			t.Errorf("%s status codes = %v, want %v", tt.route, rm.StatusCodes, tt.statusCodes)
+		}
+		for code, n := range tt.statusCodes {
+			if rm.StatusCodes[code] != n {
+				t.Errorf("%s status codes = %v, want %v", tt.route, rm.StatusCodes, tt.statusCodes)
+			}
+		}
+		if rm.TotalDuration <= 0 {
+			t.Errorf("%s TotalDuration = %s", tt.route, rm.TotalDuration)
+		}
+	}
+	
+	// Snapshots are copies
+	snapshot[0].StatusCodes[http.StatusTeapot] = 1
+	if metrics.Snapshot()[0].StatusCodes[http.StatusTeapot] != 0 {
+		t.Error("changing a snapshot changed the metrics")
+	}
+	metrics.Reset()
+	if len(metrics.Snapshot()) != 0 {
+		t.Error("Reset() kept metrics")
+	}
+}
+
+func TestInMemoryMetricsConcurrent(t *testing.T) {
+	metrics := &xrplsale.InMemoryMetrics{}
+	srv, client, _ := newCountingClient(t, func(c *xrplsale.Config) { c.MetricsCollector = metrics })
+	project := fixtures.Project()
+	srv.AddProjects(project)
+	
+	const calls = 50
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Projects.Get(context.Background(), project.ID)
+			metrics.Snapshot()
+		}()
+	}
+	wg.Wait()
+	if snapshot := metrics.Snapshot(); len(snapshot) != 1 || snapshot[0].Calls != calls {
+		t.Fatalf("Snapshot() = %+v, want %d calls", snapshot, calls)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,888 @@
+// Code generated by internal/mockgen from api.go; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"io"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// ProjectsAPI is a mock of xrplsale.ProjectsAPI.
+// Each method calls the Func field of the same name when it is set and
+// otherwise returns zero values.
+type ProjectsAPI struct {
+	ListFunc                    func(ctx context.Context, opts *xrplsale.ListProjectsOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.PaginatedResponse[xrplsale.Project], error)
+	ListAllFunc                 func(ctx context.Context, opts *xrplsale.ListProjectsOptions, reqOpts ...xrplsale.RequestOption) ([]xrplsale.Project, error)
+	ForEachFunc                 func(ctx context.Context, opts *xrplsale.ListProjectsOptions, fn func(xrplsale.Project) error, reqOpts ...xrplsale.RequestOption) error
+	GetActiveFunc               func(ctx context.Context, page int, limit int, reqOpts ...xrplsale.RequestOption) (*xrplsale.PaginatedResponse[xrplsale.Project], error)
+	GetFunc                     func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.Project, error)
+	GetBySlugFunc               func(ctx context.Context, slug string, reqOpts ...xrplsale.RequestOption) (*xrplsale.Project, error)
+	SearchFunc                  func(ctx context.Context, query string, opts *xrplsale.ListProjectsOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.PaginatedResponse[xrplsale.Project], error)
+	ExistsFunc                  func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (bool, error)
+	CreateFunc                  func(ctx context.Context, project *xrplsale.CreateProjectRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.Project, error)
+	UpdateFunc                  func(ctx context.Context, projectID string, updates map[string]interface{}, reqOpts ...xrplsale.RequestOption) (*xrplsale.Project, error)
+	LaunchFunc                  func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.Project, error)
+	ProvisionFunc               func(ctx context.Context, spec *xrplsale.ProjectSpec, reqOpts ...xrplsale.RequestOption) (*xrplsale.ProvisionResult, error)
+	GetStatsFunc                func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.ProjectStats, error)
+	GetStatsHistoryFunc         func(ctx context.Context, projectID string, period xrplsale.Period, granularity xrplsale.Granularity, reqOpts ...xrplsale.RequestOption) (*xrplsale.StatsHistory, error)
+	BuildDistributionReportFunc func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.DistributionReport, error)
+	WatchStatsFunc              func(ctx context.Context, projectID string, opts *xrplsale.WatchOptions) *xrplsale.Subscription[xrplsale.ProjectStats]
+	GetTokenDetailsFunc         func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.TokenDetails, error)
+	ListAnnouncementsFunc       func(ctx context.Context, projectID string, opts *xrplsale.ListAnnouncementsOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.PaginatedResponse[xrplsale.Announcement], error)
+	CreateAnnouncementFunc      func(ctx context.Context, projectID string, announcement *xrplsale.CreateAnnouncementRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.Announcement, error)
+	UpdateAnnouncementFunc      func(ctx context.Context, projectID string, announcementID string, updates map[string]interface{}, reqOpts ...xrplsale.RequestOption) (*xrplsale.Announcement, error)
+	DeleteAnnouncementFunc      func(ctx context.Context, projectID string, announcementID string, reqOpts ...xrplsale.RequestOption) error
+	GetMyPermissionsFunc        func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.ProjectPermissions, error)
+	ListCollaboratorsFunc       func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.Collaborators, error)
+	InviteCollaboratorFunc      func(ctx context.Context, projectID string, invite *xrplsale.InviteCollaboratorRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.CollaboratorInvitation, error)
+	UpdateCollaboratorRoleFunc  func(ctx context.Context, projectID string, collaboratorID string, role xrplsale.CollaboratorRole, reqOpts ...xrplsale.RequestOption) (*xrplsale.Collaborator, error)
+	RemoveCollaboratorFunc      func(ctx context.Context, projectID string, collaboratorID string, reqOpts ...xrplsale.RequestOption) error
+	UploadDocumentFunc          func(ctx context.Context, projectID string, filename string, r io.Reader, docType xrplsale.DocumentType, reqOpts ...xrplsale.RequestOption) (*xrplsale.ProjectDocument, error)
+}
+
+var _ xrplsale.ProjectsAPI = (*ProjectsAPI)(nil)
+
+// List calls ListFunc
+func (m *ProjectsAPI) List(ctx context.Context, opts *xrplsale.ListProjectsOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PaginatedResponse[xrplsale.Project], err error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts, reqOpts...)
+	}
+	return
+}
+
+// ListAll calls ListAllFunc
+func (m *ProjectsAPI) ListAll(ctx context.Context, opts *xrplsale.ListProjectsOptions, reqOpts ...xrplsale.RequestOption) (r0 []xrplsale.Project, err error) {
+	if m.ListAllFunc != nil {
+		return m.ListAllFunc(ctx, opts, reqOpts...)
+	}
+	return
+}
+
+// ForEach calls ForEachFunc
+func (m *ProjectsAPI) ForEach(ctx context.Context, opts *xrplsale.ListProjectsOptions, fn func(xrplsale.Project) error, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.ForEachFunc != nil {
+		return m.ForEachFunc(ctx, opts, fn, reqOpts...)
+	}
+	return
+}
+
+// GetActive calls GetActiveFunc
+func (m *ProjectsAPI) GetActive(ctx context.Context, page int, limit int, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PaginatedResponse[xrplsale.Project], err error) {
+	if m.GetActiveFunc != nil {
+		return m.GetActiveFunc(ctx, page, limit, reqOpts...)
+	}
+	return
+}
+
+// Get calls GetFunc
+func (m *ProjectsAPI) Get(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Project, err error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, projectID, reqOpts...)
+	}
+	return
+}
+
+// GetBySlug calls GetBySlugFunc
+func (m *ProjectsAPI) GetBySlug(ctx context.Context, slug string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Project, err error) {
+	if m.GetBySlugFunc != nil {
+		return m.GetBySlugFunc(ctx, slug, reqOpts...)
+	}
+	return
+}
+
+// Search calls SearchFunc
+func (m *ProjectsAPI) Search(ctx context.Context, query string, opts *xrplsale.ListProjectsOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PaginatedResponse[xrplsale.Project], err error) {
+	if m.SearchFunc != nil {
+		return m.SearchFunc(ctx, query, opts, reqOpts...)
+	}
+	return
+}
+
+// Exists calls ExistsFunc
+func (m *ProjectsAPI) Exists(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (r0 bool, err error) {
+	if m.ExistsFunc != nil {
+		return m.ExistsFunc(ctx, projectID, reqOpts...)
+	}
+	return
+}
+
+// Create calls CreateFunc
+func (m *ProjectsAPI) Create(ctx context.Context, project *xrplsale.CreateProjectRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Project, err error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, project, reqOpts...)
+	}
+	return
+}
+
+// Update calls UpdateFunc
+func (m *ProjectsAPI) Update(ctx context.Context, projectID string, updates map[string]interface{}, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Project, err error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, projectID, updates, reqOpts...)
+	}
+	return
+}
+
+// Launch calls LaunchFunc
+func (m *ProjectsAPI) Launch(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Project, err error) {
+	if m.LaunchFunc != nil {
+		return m.LaunchFunc(ctx, projectID, reqOpts...)
+	}
+	return
+}
+
+// Provision calls ProvisionFunc
+func (m *ProjectsAPI) Provision(ctx context.Context, spec *xrplsale.ProjectSpec, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.ProvisionResult, err error) {
+	if m.ProvisionFunc != nil {
+		return m.ProvisionFunc(ctx, spec, reqOpts...)
+	}
+	return
+}
+
+// GetStats calls GetStatsFunc
+func (m *ProjectsAPI) GetStats(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.ProjectStats, err error) {
+	if m.GetStatsFunc != nil {
+		return m.GetStatsFunc(ctx, projectID, reqOpts...)
+	}
+	return
+}
+
+// GetStatsHistory calls GetStatsHistoryFunc
+func (m *ProjectsAPI) GetStatsHistory(ctx context.Context, projectID string, period xrplsale.Period, granularity xrplsale.Granularity, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.StatsHistory, err error) {
+	if m.GetStatsHistoryFunc != nil {
+		return m.GetStatsHistoryFunc(ctx, projectID, period, granularity, reqOpts...)
+	}
+	return
+}
+
+// BuildDistributionReport calls BuildDistributionReportFunc
+func (m *ProjectsAPI) BuildDistributionReport(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.DistributionReport, err error) {
+	if m.BuildDistributionReportFunc != nil {
+		return m.BuildDistributionReportFunc(ctx, projectID, reqOpts...)
+	}
+	return
+}
+
+// WatchStats calls WatchStatsFunc
+func (m *ProjectsAPI) WatchStats(ctx context.Context, projectID string, opts *xrplsale.WatchOptions) (r0 *xrplsale.Subscription[xrplsale.ProjectStats]) {
+	if m.WatchStatsFunc != nil {
+		return m.WatchStatsFunc(ctx, projectID, opts)
+	}
+	return
+}
+
+// GetTokenDetails calls GetTokenDetailsFunc
+func (m *ProjectsAPI) GetTokenDetails(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.TokenDetails, err error) {
+	if m.GetTokenDetailsFunc != nil {
+		return m.GetTokenDetailsFunc(ctx, projectID, reqOpts...)
+	}
+	return
+}
+
+// ListAnnouncements calls ListAnnouncementsFunc
+func (m *ProjectsAPI) ListAnnouncements(ctx context.Context, projectID string, opts *xrplsale.ListAnnouncementsOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PaginatedResponse[xrplsale.Announcement], err error) {
+	if m.ListAnnouncementsFunc != nil {
+		return m.ListAnnouncementsFunc(ctx, projectID, opts, reqOpts...)
+	}
+	return
+}
+
+// CreateAnnouncement calls CreateAnnouncementFunc
+func (m *ProjectsAPI) CreateAnnouncement(ctx context.Context, projectID string, announcement *xrplsale.CreateAnnouncementRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Announcement, err error) {
+	if m.CreateAnnouncementFunc != nil {
+		return m.CreateAnnouncementFunc(ctx, projectID, announcement, reqOpts...)
+	}
+	return
+}
+
+// UpdateAnnouncement calls UpdateAnnouncementFunc
+func (m *ProjectsAPI) UpdateAnnouncement(ctx context.Context, projectID string, announcementID string, updates map[string]interface{}, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Announcement, err error) {
+	if m.UpdateAnnouncementFunc != nil {
+		return m.UpdateAnnouncementFunc(ctx, projectID, announcementID, updates, reqOpts...)
+	}
+	return
+}
+
+// DeleteAnnouncement calls DeleteAnnouncementFunc
+func (m *ProjectsAPI) DeleteAnnouncement(ctx context.Context, projectID string, announcementID string, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.DeleteAnnouncementFunc != nil {
+		return m.DeleteAnnouncementFunc(ctx, projectID, announcementID, reqOpts...)
+	}
+	return
+}
+
+// GetMyPermissions calls GetMyPermissionsFunc
+func (m *ProjectsAPI) GetMyPermissions(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.ProjectPermissions, err error) {
+	if m.GetMyPermissionsFunc != nil {
+		return m.GetMyPermissionsFunc(ctx, projectID, reqOpts...)
+	}
+	return
+}
+
+// ListCollaborators calls ListCollaboratorsFunc
+func (m *ProjectsAPI) ListCollaborators(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Collaborators, err error) {
+	if m.ListCollaboratorsFunc != nil {
+		return m.ListCollaboratorsFunc(ctx, projectID, reqOpts...)
+	}
+	return
+}
+
+// InviteCollaborator calls InviteCollaboratorFunc
+func (m *ProjectsAPI) InviteCollaborator(ctx context.Context, projectID string, invite *xrplsale.InviteCollaboratorRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.CollaboratorInvitation, err error) {
+	if m.InviteCollaboratorFunc != nil {
+		return m.InviteCollaboratorFunc(ctx, projectID, invite, reqOpts...)
+	}
+	return
+}
+
+// UpdateCollaboratorRole calls UpdateCollaboratorRoleFunc
+func (m *ProjectsAPI) UpdateCollaboratorRole(ctx context.Context, projectID string, collaboratorID string, role xrplsale.CollaboratorRole, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Collaborator, err error) {
+	if m.UpdateCollaboratorRoleFunc != nil {
+		return m.UpdateCollaboratorRoleFunc(ctx, projectID, collaboratorID, role, reqOpts...)
+	}
+	return
+}
+
+// RemoveCollaborator calls RemoveCollaboratorFunc
+func (m *ProjectsAPI) RemoveCollaborator(ctx context.Context, projectID string, collaboratorID string, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.RemoveCollaboratorFunc != nil {
+		return m.RemoveCollaboratorFunc(ctx, projectID, collaboratorID, reqOpts...)
+	}
+	return
+}
+
+// UploadDocument calls UploadDocumentFunc
+func (m *ProjectsAPI) UploadDocument(ctx context.Context, projectID string, filename string, r io.Reader, docType xrplsale.DocumentType, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.ProjectDocument, err error) {
+	if m.UploadDocumentFunc != nil {
+		return m.UploadDocumentFunc(ctx, projectID, filename, r, docType, reqOpts...)
+	}
+	return
+}
+
+// InvestmentsAPI is a mock of xrplsale.InvestmentsAPI.
+// Each method calls the Func field of the same name when it is set and
+// otherwise returns zero values.
+type InvestmentsAPI struct {
+	CreateFunc                func(ctx context.Context, investment *xrplsale.CreateInvestmentRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.Investment, error)
+	CreateManualFunc          func(ctx context.Context, investment *xrplsale.CreateManualInvestmentRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.Investment, error)
+	GetFunc                   func(ctx context.Context, investmentID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.Investment, error)
+	GetByProjectFunc          func(ctx context.Context, projectID string, page int, limit int, reqOpts ...xrplsale.RequestOption) (*xrplsale.PaginatedResponse[xrplsale.Investment], error)
+	IterateAllByProjectFunc   func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) *xrplsale.Iterator[xrplsale.Investment]
+	ForEachByProjectFunc      func(ctx context.Context, projectID string, fn func(xrplsale.Investment) error, reqOpts ...xrplsale.RequestOption) error
+	ExportCSVFunc             func(ctx context.Context, projectID string, w io.Writer, reqOpts ...xrplsale.RequestOption) (*xrplsale.InvestmentCSVExport, error)
+	StreamByProjectFunc       func(ctx context.Context, projectID string, opts *xrplsale.StreamOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.Stream[xrplsale.Investment], error)
+	WatchInvestmentFunc       func(ctx context.Context, investmentID string, opts *xrplsale.WatchOptions) *xrplsale.Subscription[xrplsale.Investment]
+	WaitForConfirmationFunc   func(ctx context.Context, investmentID string, opts *xrplsale.ConfirmationOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.Investment, error)
+	GetInvestorSummaryFunc    func(ctx context.Context, investorAccount string, reqOpts ...xrplsale.RequestOption) (*xrplsale.InvestorSummary, error)
+	SimulateFunc              func(ctx context.Context, simulation *xrplsale.SimulateInvestmentRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.SimulationResult, error)
+	CheckSpendableBalanceFunc func(ctx context.Context, account string, amount xrplsale.CurrencyAmount, reqOpts ...xrplsale.RequestOption) (*xrplsale.BalanceCheck, error)
+	ReconcilePaymentsFunc     func(ctx context.Context, projectID string, external []xrplsale.ExternalPayment, opts *xrplsale.PaymentReconciliationOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.ReconciliationReport, error)
+	FlagDisputeFunc           func(ctx context.Context, investmentID string, dispute *xrplsale.DisputeRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.Dispute, error)
+	GetDisputeFunc            func(ctx context.Context, disputeID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.Dispute, error)
+	ListDisputesFunc          func(ctx context.Context, projectID string, opts *xrplsale.ListDisputesOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.PaginatedResponse[xrplsale.Dispute], error)
+	AttachEvidenceFunc        func(ctx context.Context, disputeID string, filename string, r io.Reader, description string, reqOpts ...xrplsale.RequestOption) (*xrplsale.DisputeEvidence, error)
+}
+
+var _ xrplsale.InvestmentsAPI = (*InvestmentsAPI)(nil)
+
+// Create calls CreateFunc
+func (m *InvestmentsAPI) Create(ctx context.Context, investment *xrplsale.CreateInvestmentRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Investment, err error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, investment, reqOpts...)
+	}
+	return
+}
+
+// CreateManual calls CreateManualFunc
+func (m *InvestmentsAPI) CreateManual(ctx context.Context, investment *xrplsale.CreateManualInvestmentRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Investment, err error) {
+	if m.CreateManualFunc != nil {
+		return m.CreateManualFunc(ctx, investment, reqOpts...)
+	}
+	return
+}
+
+// Get calls GetFunc
+func (m *InvestmentsAPI) Get(ctx context.Context, investmentID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Investment, err error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, investmentID, reqOpts...)
+	}
+	return
+}
+
+// GetByProject calls GetByProjectFunc
+func (m *InvestmentsAPI) GetByProject(ctx context.Context, projectID string, page int, limit int, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PaginatedResponse[xrplsale.Investment], err error) {
+	if m.GetByProjectFunc != nil {
+		return m.GetByProjectFunc(ctx, projectID, page, limit, reqOpts...)
+	}
+	return
+}
+
+// IterateAllByProject calls IterateAllByProjectFunc
+func (m *InvestmentsAPI) IterateAllByProject(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Iterator[xrplsale.Investment]) {
+	if m.IterateAllByProjectFunc != nil {
+		return m.IterateAllByProjectFunc(ctx, projectID, reqOpts...)
+	}
+	return
+}
+
+// ForEachByProject calls ForEachByProjectFunc
+func (m *InvestmentsAPI) ForEachByProject(ctx context.Context, projectID string, fn func(xrplsale.Investment) error, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.ForEachByProjectFunc != nil {
+		return m.ForEachByProjectFunc(ctx, projectID, fn, reqOpts...)
+	}
+	return
+}
+
+// ExportCSV calls ExportCSVFunc
+func (m *InvestmentsAPI) ExportCSV(ctx context.Context, projectID string, w io.Writer, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.InvestmentCSVExport, err error) {
+	if m.ExportCSVFunc != nil {
+		return m.ExportCSVFunc(ctx, projectID, w, reqOpts...)
+	}
+	return
+}
+
+// StreamByProject calls StreamByProjectFunc
+func (m *InvestmentsAPI) StreamByProject(ctx context.Context, projectID string, opts *xrplsale.StreamOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Stream[xrplsale.Investment], err error) {
+	if m.StreamByProjectFunc != nil {
+		return m.StreamByProjectFunc(ctx, projectID, opts, reqOpts...)
+	}
+	return
+}
+
+// WatchInvestment calls WatchInvestmentFunc
+func (m *InvestmentsAPI) WatchInvestment(ctx context.Context, investmentID string, opts *xrplsale.WatchOptions) (r0 *xrplsale.Subscription[xrplsale.Investment]) {
+	if m.WatchInvestmentFunc != nil {
+		return m.WatchInvestmentFunc(ctx, investmentID, opts)
+	}
+	return
+}
+
+// WaitForConfirmation calls WaitForConfirmationFunc
+func (m *InvestmentsAPI) WaitForConfirmation(ctx context.Context, investmentID string, opts *xrplsale.ConfirmationOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Investment, err error) {
+	if m.WaitForConfirmationFunc != nil {
+		return m.WaitForConfirmationFunc(ctx, investmentID, opts, reqOpts...)
+	}
+	return
+}
+
+// GetInvestorSummary calls GetInvestorSummaryFunc
+func (m *InvestmentsAPI) GetInvestorSummary(ctx context.Context, investorAccount string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.InvestorSummary, err error) {
+	if m.GetInvestorSummaryFunc != nil {
+		return m.GetInvestorSummaryFunc(ctx, investorAccount, reqOpts...)
+	}
+	return
+}
+
+// Simulate calls SimulateFunc
+func (m *InvestmentsAPI) Simulate(ctx context.Context, simulation *xrplsale.SimulateInvestmentRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.SimulationResult, err error) {
+	if m.SimulateFunc != nil {
+		return m.SimulateFunc(ctx, simulation, reqOpts...)
+	}
+	return
+}
+
+// CheckSpendableBalance calls CheckSpendableBalanceFunc
+func (m *InvestmentsAPI) CheckSpendableBalance(ctx context.Context, account string, amount xrplsale.CurrencyAmount, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.BalanceCheck, err error) {
+	if m.CheckSpendableBalanceFunc != nil {
+		return m.CheckSpendableBalanceFunc(ctx, account, amount, reqOpts...)
+	}
+	return
+}
+
+// ReconcilePayments calls ReconcilePaymentsFunc
+func (m *InvestmentsAPI) ReconcilePayments(ctx context.Context, projectID string, external []xrplsale.ExternalPayment, opts *xrplsale.PaymentReconciliationOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.ReconciliationReport, err error) {
+	if m.ReconcilePaymentsFunc != nil {
+		return m.ReconcilePaymentsFunc(ctx, projectID, external, opts, reqOpts...)
+	}
+	return
+}
+
+// FlagDispute calls FlagDisputeFunc
+func (m *InvestmentsAPI) FlagDispute(ctx context.Context, investmentID string, dispute *xrplsale.DisputeRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Dispute, err error) {
+	if m.FlagDisputeFunc != nil {
+		return m.FlagDisputeFunc(ctx, investmentID, dispute, reqOpts...)
+	}
+	return
+}
+
+// GetDispute calls GetDisputeFunc
+func (m *InvestmentsAPI) GetDispute(ctx context.Context, disputeID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Dispute, err error) {
+	if m.GetDisputeFunc != nil {
+		return m.GetDisputeFunc(ctx, disputeID, reqOpts...)
+	}
+	return
+}
+
+// ListDisputes calls ListDisputesFunc
+func (m *InvestmentsAPI) ListDisputes(ctx context.Context, projectID string, opts *xrplsale.ListDisputesOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PaginatedResponse[xrplsale.Dispute], err error) {
+	if m.ListDisputesFunc != nil {
+		return m.ListDisputesFunc(ctx, projectID, opts, reqOpts...)
+	}
+	return
+}
+
+// AttachEvidence calls AttachEvidenceFunc
+func (m *InvestmentsAPI) AttachEvidence(ctx context.Context, disputeID string, filename string, r io.Reader, description string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.DisputeEvidence, err error) {
+	if m.AttachEvidenceFunc != nil {
+		return m.AttachEvidenceFunc(ctx, disputeID, filename, r, description, reqOpts...)
+	}
+	return
+}
+
+// AnalyticsAPI is a mock of xrplsale.AnalyticsAPI.
+// Each method calls the Func field of the same name when it is set and
+// otherwise returns zero values.
+type AnalyticsAPI struct {
+	GetPlatformAnalyticsFunc func(ctx context.Context, reqOpts ...xrplsale.RequestOption) (*xrplsale.PlatformAnalytics, error)
+	GetProjectAnalyticsFunc  func(ctx context.Context, projectID string, startDate time.Time, endDate time.Time, reqOpts ...xrplsale.RequestOption) (*xrplsale.ProjectAnalytics, error)
+	GetGeoDistributionFunc   func(ctx context.Context, projectID string, dateRange *xrplsale.DateRange, reqOpts ...xrplsale.RequestOption) (*xrplsale.GeoDistribution, error)
+	GetTrendsFunc            func(ctx context.Context, period xrplsale.Period, reqOpts ...xrplsale.RequestOption) (*xrplsale.MarketTrends, error)
+	GetMarketTrendsFunc      func(ctx context.Context, period string, reqOpts ...xrplsale.RequestOption) (*xrplsale.MarketTrends, error)
+	ExportDataFunc           func(ctx context.Context, exportReq *xrplsale.ExportDataRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.ExportResult, error)
+	DownloadExportFunc       func(ctx context.Context, exportID string, w io.Writer, reqOpts ...xrplsale.RequestOption) (int64, error)
+}
+
+var _ xrplsale.AnalyticsAPI = (*AnalyticsAPI)(nil)
+
+// GetPlatformAnalytics calls GetPlatformAnalyticsFunc
+func (m *AnalyticsAPI) GetPlatformAnalytics(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PlatformAnalytics, err error) {
+	if m.GetPlatformAnalyticsFunc != nil {
+		return m.GetPlatformAnalyticsFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// GetProjectAnalytics calls GetProjectAnalyticsFunc
+func (m *AnalyticsAPI) GetProjectAnalytics(ctx context.Context, projectID string, startDate time.Time, endDate time.Time, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.ProjectAnalytics, err error) {
+	if m.GetProjectAnalyticsFunc != nil {
+		return m.GetProjectAnalyticsFunc(ctx, projectID, startDate, endDate, reqOpts...)
+	}
+	return
+}
+
+// GetGeoDistribution calls GetGeoDistributionFunc
+func (m *AnalyticsAPI) GetGeoDistribution(ctx context.Context, projectID string, dateRange *xrplsale.DateRange, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.GeoDistribution, err error) {
+	if m.GetGeoDistributionFunc != nil {
+		return m.GetGeoDistributionFunc(ctx, projectID, dateRange, reqOpts...)
+	}
+	return
+}
+
+// GetTrends calls GetTrendsFunc
+func (m *AnalyticsAPI) GetTrends(ctx context.Context, period xrplsale.Period, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.MarketTrends, err error) {
+	if m.GetTrendsFunc != nil {
+		return m.GetTrendsFunc(ctx, period, reqOpts...)
+	}
+	return
+}
+
+// GetMarketTrends calls GetMarketTrendsFunc
+func (m *AnalyticsAPI) GetMarketTrends(ctx context.Context, period string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.MarketTrends, err error) {
+	if m.GetMarketTrendsFunc != nil {
+		return m.GetMarketTrendsFunc(ctx, period, reqOpts...)
+	}
+	return
+}
+
+// ExportData calls ExportDataFunc
+func (m *AnalyticsAPI) ExportData(ctx context.Context, exportReq *xrplsale.ExportDataRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.ExportResult, err error) {
+	if m.ExportDataFunc != nil {
+		return m.ExportDataFunc(ctx, exportReq, reqOpts...)
+	}
+	return
+}
+
+// DownloadExport calls DownloadExportFunc
+func (m *AnalyticsAPI) DownloadExport(ctx context.Context, exportID string, w io.Writer, reqOpts ...xrplsale.RequestOption) (r0 int64, err error) {
+	if m.DownloadExportFunc != nil {
+		return m.DownloadExportFunc(ctx, exportID, w, reqOpts...)
+	}
+	return
+}
+
+// AuthAPI is a mock of xrplsale.AuthAPI.
+// Each method calls the Func field of the same name when it is set and
+// otherwise returns zero values.
+type AuthAPI struct {
+	GenerateChallengeFunc             func(ctx context.Context, walletAddress string, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthChallenge, error)
+	AuthenticateFunc                  func(ctx context.Context, authReq *xrplsale.AuthRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthResponse, error)
+	AuthenticateWithSignerFunc        func(ctx context.Context, signer xrplsale.ChallengeSigner, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthResponse, error)
+	AuthenticateWithSeedFunc          func(ctx context.Context, walletAddress string, familySeed string, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthResponse, error)
+	AuthenticateWithPrivateKeyFunc    func(ctx context.Context, walletAddress string, privateKey string, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthResponse, error)
+	AuthenticateServiceAccountFunc    func(ctx context.Context, clientID string, clientSecret string, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthResponse, error)
+	CreateXamanSignInFunc             func(ctx context.Context, reqOpts ...xrplsale.RequestOption) (*xrplsale.XamanSignInRequest, error)
+	GetXamanSignInStatusFunc          func(ctx context.Context, uuid string, reqOpts ...xrplsale.RequestOption) (*xrplsale.XamanSignInStatus, error)
+	CompleteXamanSignInFunc           func(ctx context.Context, uuid string, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthResponse, error)
+	WaitForXamanSignInFunc            func(ctx context.Context, uuid string, pollInterval time.Duration, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthResponse, error)
+	RefreshFunc                       func(ctx context.Context, refreshToken string, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthResponse, error)
+	LogoutFunc                        func(ctx context.Context, reqOpts ...xrplsale.RequestOption) error
+	VerifySessionFunc                 func(ctx context.Context, reqOpts ...xrplsale.RequestOption) (*xrplsale.SessionStatus, error)
+	CreateStreamTokenFunc             func(ctx context.Context, reqOpts ...xrplsale.RequestOption) (*xrplsale.StreamToken, error)
+	ListSessionsFunc                  func(ctx context.Context, reqOpts ...xrplsale.RequestOption) ([]*xrplsale.Session, error)
+	RevokeSessionFunc                 func(ctx context.Context, sessionID string, reqOpts ...xrplsale.RequestOption) error
+	RevokeAllSessionsFunc             func(ctx context.Context, exceptCurrent bool, reqOpts ...xrplsale.RequestOption) error
+	Enable2FAFunc                     func(ctx context.Context, reqOpts ...xrplsale.RequestOption) (*xrplsale.TwoFactorSetup, error)
+	Confirm2FAFunc                    func(ctx context.Context, code string, reqOpts ...xrplsale.RequestOption) error
+	Disable2FAFunc                    func(ctx context.Context, code string, reqOpts ...xrplsale.RequestOption) error
+	Complete2FAFunc                   func(ctx context.Context, challengeToken string, code string, reqOpts ...xrplsale.RequestOption) (*xrplsale.AuthResponse, error)
+	GetProfileFunc                    func(ctx context.Context, reqOpts ...xrplsale.RequestOption) (*xrplsale.UserProfile, error)
+	UpdateProfileFunc                 func(ctx context.Context, update *xrplsale.UpdateProfileRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.UserProfile, error)
+	GetNotificationPreferencesFunc    func(ctx context.Context, reqOpts ...xrplsale.RequestOption) (*xrplsale.NotificationPreferences, error)
+	UpdateNotificationPreferencesFunc func(ctx context.Context, update *xrplsale.NotificationPreferencesUpdate, reqOpts ...xrplsale.RequestOption) (*xrplsale.NotificationPreferences, error)
+	HasScopeFunc                      func(scope string) bool
+	ScopesFunc                        func() []string
+	LoadScopesFunc                    func(ctx context.Context, reqOpts ...xrplsale.RequestOption) ([]string, error)
+}
+
+var _ xrplsale.AuthAPI = (*AuthAPI)(nil)
+
+// GenerateChallenge calls GenerateChallengeFunc
+func (m *AuthAPI) GenerateChallenge(ctx context.Context, walletAddress string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthChallenge, err error) {
+	if m.GenerateChallengeFunc != nil {
+		return m.GenerateChallengeFunc(ctx, walletAddress, reqOpts...)
+	}
+	return
+}
+
+// Authenticate calls AuthenticateFunc
+func (m *AuthAPI) Authenticate(ctx context.Context, authReq *xrplsale.AuthRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthResponse, err error) {
+	if m.AuthenticateFunc != nil {
+		return m.AuthenticateFunc(ctx, authReq, reqOpts...)
+	}
+	return
+}
+
+// AuthenticateWithSigner calls AuthenticateWithSignerFunc
+func (m *AuthAPI) AuthenticateWithSigner(ctx context.Context, signer xrplsale.ChallengeSigner, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthResponse, err error) {
+	if m.AuthenticateWithSignerFunc != nil {
+		return m.AuthenticateWithSignerFunc(ctx, signer, reqOpts...)
+	}
+	return
+}
+
+// AuthenticateWithSeed calls AuthenticateWithSeedFunc
+func (m *AuthAPI) AuthenticateWithSeed(ctx context.Context, walletAddress string, familySeed string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthResponse, err error) {
+	if m.AuthenticateWithSeedFunc != nil {
+		return m.AuthenticateWithSeedFunc(ctx, walletAddress, familySeed, reqOpts...)
+	}
+	return
+}
+
+// AuthenticateWithPrivateKey calls AuthenticateWithPrivateKeyFunc
+func (m *AuthAPI) AuthenticateWithPrivateKey(ctx context.Context, walletAddress string, privateKey string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthResponse, err error) {
+	if m.AuthenticateWithPrivateKeyFunc != nil {
+		return m.AuthenticateWithPrivateKeyFunc(ctx, walletAddress, privateKey, reqOpts...)
+	}
+	return
+}
+
+// AuthenticateServiceAccount calls AuthenticateServiceAccountFunc
+func (m *AuthAPI) AuthenticateServiceAccount(ctx context.Context, clientID string, clientSecret string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthResponse, err error) {
+	if m.AuthenticateServiceAccountFunc != nil {
+		return m.AuthenticateServiceAccountFunc(ctx, clientID, clientSecret, reqOpts...)
+	}
+	return
+}
+
+// CreateXamanSignIn calls CreateXamanSignInFunc
+func (m *AuthAPI) CreateXamanSignIn(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.XamanSignInRequest, err error) {
+	if m.CreateXamanSignInFunc != nil {
+		return m.CreateXamanSignInFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// GetXamanSignInStatus calls GetXamanSignInStatusFunc
+func (m *AuthAPI) GetXamanSignInStatus(ctx context.Context, uuid string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.XamanSignInStatus, err error) {
+	if m.GetXamanSignInStatusFunc != nil {
+		return m.GetXamanSignInStatusFunc(ctx, uuid, reqOpts...)
+	}
+	return
+}
+
+// CompleteXamanSignIn calls CompleteXamanSignInFunc
+func (m *AuthAPI) CompleteXamanSignIn(ctx context.Context, uuid string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthResponse, err error) {
+	if m.CompleteXamanSignInFunc != nil {
+		return m.CompleteXamanSignInFunc(ctx, uuid, reqOpts...)
+	}
+	return
+}
+
+// WaitForXamanSignIn calls WaitForXamanSignInFunc
+func (m *AuthAPI) WaitForXamanSignIn(ctx context.Context, uuid string, pollInterval time.Duration, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthResponse, err error) {
+	if m.WaitForXamanSignInFunc != nil {
+		return m.WaitForXamanSignInFunc(ctx, uuid, pollInterval, reqOpts...)
+	}
+	return
+}
+
+// Refresh calls RefreshFunc
+func (m *AuthAPI) Refresh(ctx context.Context, refreshToken string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthResponse, err error) {
+	if m.RefreshFunc != nil {
+		return m.RefreshFunc(ctx, refreshToken, reqOpts...)
+	}
+	return
+}
+
+// Logout calls LogoutFunc
+func (m *AuthAPI) Logout(ctx context.Context, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.LogoutFunc != nil {
+		return m.LogoutFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// VerifySession calls VerifySessionFunc
+func (m *AuthAPI) VerifySession(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.SessionStatus, err error) {
+	if m.VerifySessionFunc != nil {
+		return m.VerifySessionFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// CreateStreamToken calls CreateStreamTokenFunc
+func (m *AuthAPI) CreateStreamToken(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.StreamToken, err error) {
+	if m.CreateStreamTokenFunc != nil {
+		return m.CreateStreamTokenFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// ListSessions calls ListSessionsFunc
+func (m *AuthAPI) ListSessions(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 []*xrplsale.Session, err error) {
+	if m.ListSessionsFunc != nil {
+		return m.ListSessionsFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// RevokeSession calls RevokeSessionFunc
+func (m *AuthAPI) RevokeSession(ctx context.Context, sessionID string, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.RevokeSessionFunc != nil {
+		return m.RevokeSessionFunc(ctx, sessionID, reqOpts...)
+	}
+	return
+}
+
+// RevokeAllSessions calls RevokeAllSessionsFunc
+func (m *AuthAPI) RevokeAllSessions(ctx context.Context, exceptCurrent bool, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.RevokeAllSessionsFunc != nil {
+		return m.RevokeAllSessionsFunc(ctx, exceptCurrent, reqOpts...)
+	}
+	return
+}
+
+// Enable2FA calls Enable2FAFunc
+func (m *AuthAPI) Enable2FA(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.TwoFactorSetup, err error) {
+	if m.Enable2FAFunc != nil {
+		return m.Enable2FAFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// Confirm2FA calls Confirm2FAFunc
+func (m *AuthAPI) Confirm2FA(ctx context.Context, code string, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.Confirm2FAFunc != nil {
+		return m.Confirm2FAFunc(ctx, code, reqOpts...)
+	}
+	return
+}
+
+// Disable2FA calls Disable2FAFunc
+func (m *AuthAPI) Disable2FA(ctx context.Context, code string, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.Disable2FAFunc != nil {
+		return m.Disable2FAFunc(ctx, code, reqOpts...)
+	}
+	return
+}
+
+// Complete2FA calls Complete2FAFunc
+func (m *AuthAPI) Complete2FA(ctx context.Context, challengeToken string, code string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.AuthResponse, err error) {
+	if m.Complete2FAFunc != nil {
+		return m.Complete2FAFunc(ctx, challengeToken, code, reqOpts...)
+	}
+	return
+}
+
+// GetProfile calls GetProfileFunc
+func (m *AuthAPI) GetProfile(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.UserProfile, err error) {
+	if m.GetProfileFunc != nil {
+		return m.GetProfileFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// UpdateProfile calls UpdateProfileFunc
+func (m *AuthAPI) UpdateProfile(ctx context.Context, update *xrplsale.UpdateProfileRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.UserProfile, err error) {
+	if m.UpdateProfileFunc != nil {
+		return m.UpdateProfileFunc(ctx, update, reqOpts...)
+	}
+	return
+}
+
+// GetNotificationPreferences calls GetNotificationPreferencesFunc
+func (m *AuthAPI) GetNotificationPreferences(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.NotificationPreferences, err error) {
+	if m.GetNotificationPreferencesFunc != nil {
+		return m.GetNotificationPreferencesFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// UpdateNotificationPreferences calls UpdateNotificationPreferencesFunc
+func (m *AuthAPI) UpdateNotificationPreferences(ctx context.Context, update *xrplsale.NotificationPreferencesUpdate, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.NotificationPreferences, err error) {
+	if m.UpdateNotificationPreferencesFunc != nil {
+		return m.UpdateNotificationPreferencesFunc(ctx, update, reqOpts...)
+	}
+	return
+}
+
+// HasScope calls HasScopeFunc
+func (m *AuthAPI) HasScope(scope string) (r0 bool) {
+	if m.HasScopeFunc != nil {
+		return m.HasScopeFunc(scope)
+	}
+	return
+}
+
+// Scopes calls ScopesFunc
+func (m *AuthAPI) Scopes() (r0 []string) {
+	if m.ScopesFunc != nil {
+		return m.ScopesFunc()
+	}
+	return
+}
+
+// LoadScopes calls LoadScopesFunc
+func (m *AuthAPI) LoadScopes(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 []string, err error) {
+	if m.LoadScopesFunc != nil {
+		return m.LoadScopesFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// WebhooksAPI is a mock of xrplsale.WebhooksAPI.
+// Each method calls the Func field of the same name when it is set and
+// otherwise returns zero values.
+type WebhooksAPI struct {
+	RegisterFunc         func(ctx context.Context, webhook *xrplsale.RegisterWebhookRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.Webhook, error)
+	EnsureRegisteredFunc func(ctx context.Context, req xrplsale.RegisterWebhookRequest, reqOpts ...xrplsale.RequestOption) (*xrplsale.Webhook, error)
+	ReconcileFunc        func(ctx context.Context, desired []xrplsale.RegisterWebhookRequest, opts *xrplsale.ReconcileOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.ReconcileResult, error)
+	ListFunc             func(ctx context.Context, reqOpts ...xrplsale.RequestOption) ([]*xrplsale.Webhook, error)
+	ListPagedFunc        func(ctx context.Context, page int, limit int, reqOpts ...xrplsale.RequestOption) (*xrplsale.PaginatedResponse[xrplsale.Webhook], error)
+	GetFunc              func(ctx context.Context, webhookID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.Webhook, error)
+	ExistsFunc           func(ctx context.Context, webhookID string, reqOpts ...xrplsale.RequestOption) (bool, error)
+	UpdateFunc           func(ctx context.Context, webhookID string, updates map[string]interface{}, reqOpts ...xrplsale.RequestOption) (*xrplsale.Webhook, error)
+	DeleteFunc           func(ctx context.Context, webhookID string, reqOpts ...xrplsale.RequestOption) error
+	TestFunc             func(ctx context.Context, webhookID string, reqOpts ...xrplsale.RequestOption) error
+	GetDeliveriesFunc    func(ctx context.Context, webhookID string, page int, limit int, reqOpts ...xrplsale.RequestOption) (*xrplsale.PaginatedResponse[xrplsale.WebhookDelivery], error)
+	StreamDeliveriesFunc func(ctx context.Context, webhookID string, opts *xrplsale.StreamOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.Stream[xrplsale.WebhookDelivery], error)
+	PurgeDeliveriesFunc  func(ctx context.Context, webhookID string, olderThan time.Time, opts *xrplsale.PurgeOptions, reqOpts ...xrplsale.RequestOption) (*xrplsale.PurgeResult, error)
+}
+
+var _ xrplsale.WebhooksAPI = (*WebhooksAPI)(nil)
+
+// Register calls RegisterFunc
+func (m *WebhooksAPI) Register(ctx context.Context, webhook *xrplsale.RegisterWebhookRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Webhook, err error) {
+	if m.RegisterFunc != nil {
+		return m.RegisterFunc(ctx, webhook, reqOpts...)
+	}
+	return
+}
+
+// EnsureRegistered calls EnsureRegisteredFunc
+func (m *WebhooksAPI) EnsureRegistered(ctx context.Context, req xrplsale.RegisterWebhookRequest, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Webhook, err error) {
+	if m.EnsureRegisteredFunc != nil {
+		return m.EnsureRegisteredFunc(ctx, req, reqOpts...)
+	}
+	return
+}
+
+// Reconcile calls ReconcileFunc
+func (m *WebhooksAPI) Reconcile(ctx context.Context, desired []xrplsale.RegisterWebhookRequest, opts *xrplsale.ReconcileOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.ReconcileResult, err error) {
+	if m.ReconcileFunc != nil {
+		return m.ReconcileFunc(ctx, desired, opts, reqOpts...)
+	}
+	return
+}
+
+// List calls ListFunc
+func (m *WebhooksAPI) List(ctx context.Context, reqOpts ...xrplsale.RequestOption) (r0 []*xrplsale.Webhook, err error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, reqOpts...)
+	}
+	return
+}
+
+// ListPaged calls ListPagedFunc
+func (m *WebhooksAPI) ListPaged(ctx context.Context, page int, limit int, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PaginatedResponse[xrplsale.Webhook], err error) {
+	if m.ListPagedFunc != nil {
+		return m.ListPagedFunc(ctx, page, limit, reqOpts...)
+	}
+	return
+}
+
+// Get calls GetFunc
+func (m *WebhooksAPI) Get(ctx context.Context, webhookID string, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Webhook, err error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, webhookID, reqOpts...)
+	}
+	return
+}
+
+// Exists calls ExistsFunc
+func (m *WebhooksAPI) Exists(ctx context.Context, webhookID string, reqOpts ...xrplsale.RequestOption) (r0 bool, err error) {
+	if m.ExistsFunc != nil {
+		return m.ExistsFunc(ctx, webhookID, reqOpts...)
+	}
+	return
+}
+
+// Update calls UpdateFunc
+func (m *WebhooksAPI) Update(ctx context.Context, webhookID string, updates map[string]interface{}, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Webhook, err error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, webhookID, updates, reqOpts...)
+	}
+	return
+}
+
+// Delete calls DeleteFunc
+func (m *WebhooksAPI) Delete(ctx context.Context, webhookID string, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, webhookID, reqOpts...)
+	}
+	return
+}
+
+// Test calls TestFunc
+func (m *WebhooksAPI) Test(ctx context.Context, webhookID string, reqOpts ...xrplsale.RequestOption) (err error) {
+	if m.TestFunc != nil {
+		return m.TestFunc(ctx, webhookID, reqOpts...)
+	}
+	return
+}
+
+// GetDeliveries calls GetDeliveriesFunc
+func (m *WebhooksAPI) GetDeliveries(ctx context.Context, webhookID string, page int, limit int, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PaginatedResponse[xrplsale.WebhookDelivery], err error) {
+	if m.GetDeliveriesFunc != nil {
+		return m.GetDeliveriesFunc(ctx, webhookID, page, limit, reqOpts...)
+	}
+	return
+}
+
+// StreamDeliveries calls StreamDeliveriesFunc
+func (m *WebhooksAPI) StreamDeliveries(ctx context.Context, webhookID string, opts *xrplsale.StreamOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.Stream[xrplsale.WebhookDelivery], err error) {
+	if m.StreamDeliveriesFunc != nil {
+		return m.StreamDeliveriesFunc(ctx, webhookID, opts, reqOpts...)
+	}
+	return
+}
+
+// PurgeDeliveries calls PurgeDeliveriesFunc
+func (m *WebhooksAPI) PurgeDeliveries(ctx context.Context, webhookID string, olderThan time.Time, opts *xrplsale.PurgeOptions, reqOpts ...xrplsale.RequestOption) (r0 *xrplsale.PurgeResult, err error) {
+	if m.PurgeDeliveriesFunc != nil {
+		return m.PurgeDeliveriesFunc(ctx, webhookID, olderThan, opts, reqOpts...)
+	}
+	return
+}
@@ -0,0 +1,11 @@
+// Package mocks implements the SDK's service interfaces for unit tests of
+// code built on the SDK. Set the Func fields a test needs; other methods
+// return zero values.
+//
+//	projects := &mocks.ProjectsAPI{
+//		GetFunc: func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.Project, error) {
+//			return &xrplsale.Project{ID: projectID, Status: xrplsale.StatusActive}, nil
+//		},
+//	}
+//	client.Projects = projects
+package mocks
\ No newline at end of file
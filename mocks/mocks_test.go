@@ -0,0 +1,39 @@
+package mocks_test
+
+import (
+	"context"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/mocks"
+)
+
+var (
+	_ xrplsale.ProjectsAPI    = (*mocks.ProjectsAPI)(nil)
+	_ xrplsale.InvestmentsAPI = (*mocks.InvestmentsAPI)(nil)
+	_ xrplsale.AnalyticsAPI   = (*mocks.AnalyticsAPI)(nil)
+	_ xrplsale.AuthAPI        = (*mocks.AuthAPI)(nil)
+	_ xrplsale.WebhooksAPI    = (*mocks.WebhooksAPI)(nil)
+)
+
+func TestMockThroughClient(t *testing.T) {
+	client := xrplsale.NewClient("key")
+	var gotID string
+	client.Projects = &mocks.ProjectsAPI{
+		GetFunc: func(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) (*xrplsale.Project, error) {
+			gotID = projectID
+			return &xrplsale.Project{ID: projectID, Status: xrplsale.StatusActive}, nil
+		},
+	}
+	ctx := context.Background()
+	
+	project, err := client.Projects.Get(ctx, "proj_1")
+	if err != nil || project.ID != "proj_1" || gotID != "proj_1" {
+		t.Fatalf("Get = %+v, %v; GetFunc saw %q", project, err, gotID)
+	}
+	
+	stats, err := client.Projects.GetStats(ctx, "proj_1")
+	if stats != nil || err != nil {
+		t.Errorf("unset GetStats = %+v, %v; want zero values", stats, err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,58 @@
+//go:build go1.23
+
+package mocks
+
+import (
+	"context"
+	"iter"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// The range-over-func iterators are written by hand, since mockgen only
+// sees api.go. They walk the pages of the matching list method's Func
+// field, so a test sets that instead; without it they yield nothing.
+
+// All walks the pages returned by ListFunc
+func (m *ProjectsAPI) All(ctx context.Context, opts *xrplsale.ListProjectsOptions, reqOpts ...xrplsale.RequestOption) iter.Seq2[xrplsale.Project, error] {
+	pageOpts := xrplsale.ListProjectsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return pages(func(page int) (*xrplsale.PaginatedResponse[xrplsale.Project], error) {
+		pageOpts := pageOpts
+		pageOpts.Page = page
+		return m.List(ctx, &pageOpts, reqOpts...)
+	})
+}
+
+// AllByProject walks the pages returned by GetByProjectFunc
+func (m *InvestmentsAPI) AllByProject(ctx context.Context, projectID string, reqOpts ...xrplsale.RequestOption) iter.Seq2[xrplsale.Investment, error] {
+	return pages(func(page int) (*xrplsale.PaginatedResponse[xrplsale.Investment], error) {
+		return m.GetByProject(ctx, projectID, page, xrplsale.DefaultIteratePageSize, reqOpts...)
+	})
+}
+
+// pages yields the records of the pages returned by fetch, from page 1
+// until an empty or last page, or a failed fetch whose error it yields
+func pages[T any](fetch func(page int) (*xrplsale.PaginatedResponse[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page := 1; page != 0; {
+			result, err := fetch(page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if result == nil || result.IsEmpty() {
+				return
+			}
+			for _, value := range result.Data {
+				if !yield(value, nil) {
+					return
+				}
+			}
+			page = result.NextPageNumber()
+		}
+	}
+}
\ No newline at end of file
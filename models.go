@@ -0,0 +1,260 @@
+package xrplsale
+
+import (
+	"fmt"
+	"time"
+)
+
+// AuthResponse holds the tokens issued by a successful authentication or refresh
+type AuthResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	
+	// ExpiresIn is the token's lifetime in seconds
+	ExpiresIn int `json:"expires_in"`
+	
+	// ExpiresAt is when Token expires, from ExpiresIn or else the token's exp
+	// claim; zero when unknown. Authenticate and Refresh set it.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	
+	// Scopes lists what the token may do, see the Scope constants. When the
+	// API leaves it out, Authenticate and Refresh fill it from the token's
+	// claims.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// Pagination describes the page returned by a paginated endpoint
+type Pagination struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// String formats the pagination for logs
+func (p Pagination) String() string {
+	return fmt.Sprintf("page %d/%d (limit %d, total %d)", p.Page, p.TotalPages, p.Limit, p.Total)
+}
+
+// PaginatedResponse wraps one page of results
+type PaginatedResponse[T any] struct {
+	Data       []T        `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// TotalPages returns the number of pages, taken from the API's
+// total_pages or computed from total and limit when it is missing. A
+// response without a limit counts as a single page.
+func (r *PaginatedResponse[T]) TotalPages() int {
+	p := r.Pagination
+	switch {
+	case p.TotalPages > 0:
+		return p.TotalPages
+	case p.Total <= 0:
+		return 0
+	case p.Limit <= 0:
+		return 1
+	}
+	return (p.Total + p.Limit - 1) / p.Limit
+}
+
+// HasNextPage reports whether a page follows this one
+func (r *PaginatedResponse[T]) HasNextPage() bool {
+	return r.NextPageNumber() > 0
+}
+
+// NextPageNumber returns the number of the following page, or 0 if this
+// is the last one
+func (r *PaginatedResponse[T]) NextPageNumber() int {
+	page := max(r.Pagination.Page, 1)
+	if page >= r.TotalPages() {
+		return 0
+	}
+	return page + 1
+}
+
+// IsEmpty reports whether the page holds no results
+func (r *PaginatedResponse[T]) IsEmpty() bool {
+	return len(r.Data) == 0
+}
+
+// Tier represents a pricing tier of a token sale
+type Tier struct {
+	Tier          int    `json:"tier"`
+	PricePerToken Amount `json:"price_per_token"`
+	TotalTokens   Amount `json:"total_tokens"`
+}
+
+// Project represents a token sale project
+type Project struct {
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	Slug          string        `json:"slug,omitempty"`
+	Description   string        `json:"description"`
+	TokenSymbol   string        `json:"token_symbol"`
+	TotalSupply   Amount        `json:"total_supply"`
+	Status        ProjectStatus `json:"status"`
+	Tiers         []Tier        `json:"tiers"`
+	SaleStartDate Timestamp     `json:"sale_start_date"`
+	SaleEndDate   Timestamp     `json:"sale_end_date"`
+	CreatedAt     Timestamp     `json:"created_at"`
+	
+	// Score is the relevance of a search result, higher first; zero
+	// outside searches or when the API does not score them
+	Score float64 `json:"score,omitempty"`
+}
+
+// ProjectStats holds a project's sale totals
+type ProjectStats struct {
+	ProjectID       string `json:"project_id"`
+	TotalRaisedXRP  Amount `json:"total_raised_xrp"`
+	TokensSold      Amount `json:"tokens_sold"`
+	InvestorCount   int    `json:"investor_count"`
+	InvestmentCount int    `json:"investment_count"`
+}
+
+// StatsHistory is a project's statistics over a period, one point per bucket
+type StatsHistory struct {
+	ProjectID   string       `json:"project_id"`
+	Period      Period       `json:"period"`
+	Granularity Granularity  `json:"granularity"`
+	Points      []StatsPoint `json:"points"`
+}
+
+// StatsPoint holds the cumulative project statistics at the end of the
+// bucket starting at Time
+type StatsPoint struct {
+	Time            Timestamp `json:"time"`
+	TotalRaisedXRP  Amount    `json:"total_raised_xrp"`
+	TokensSold      Amount    `json:"tokens_sold"`
+	InvestorCount   int       `json:"investor_count"`
+	InvestmentCount int       `json:"investment_count"`
+}
+
+// Investment represents an investment in a project
+type Investment struct {
+	ID               string    `json:"id"`
+	ProjectID        string    `json:"project_id"`
+	InvestorAccount  string    `json:"investor_account"`
+	AmountXRP        Amount    `json:"amount_xrp"`
+	TokenAmount      Amount    `json:"token_amount"`
+	Tier             int       `json:"tier"`
+	Status           string    `json:"status"`
+	TransactionHash  string    `json:"transaction_hash,omitempty"`
+	Manual           bool      `json:"manual"`
+	PaymentReference string    `json:"payment_reference,omitempty"`
+	CreatedAt        Timestamp `json:"created_at"`
+	
+	// TargetLedgerIndex is the ledger the payment is expected to be validated
+	// in and EstimatedConfirmation when that ledger should close. Both are
+	// only set while the investment is pending and the API can estimate them.
+	TargetLedgerIndex     uint32     `json:"target_ledger_index,omitempty"`
+	EstimatedConfirmation *Timestamp `json:"estimated_confirmation,omitempty"`
+	
+	// LedgerIndex is the ledger the payment was validated in, once confirmed
+	LedgerIndex uint32 `json:"ledger_index,omitempty"`
+	
+	// PaymentInstructions tell the investor how to pay, while pending
+	PaymentInstructions *PaymentInstructions `json:"payment_instructions,omitempty"`
+}
+
+// PaymentInstructions describe the XRPL payment that funds a pending
+// investment
+type PaymentInstructions struct {
+	Destination    string     `json:"destination"`
+	DestinationTag uint32     `json:"destination_tag,omitempty"`
+	AmountXRP      Amount     `json:"amount_xrp"`
+	Memo           string     `json:"memo,omitempty"`
+	ExpiresAt      *Timestamp `json:"expires_at,omitempty"`
+	
+	// TargetLedgerIndex and EstimatedConfirmation are the API's estimate of
+	// when a payment made now would be validated, when it can make one
+	TargetLedgerIndex     uint32     `json:"target_ledger_index,omitempty"`
+	EstimatedConfirmation *Timestamp `json:"estimated_confirmation,omitempty"`
+}
+
+// CreateInvestmentRequest represents a request to invest in a project
+type CreateInvestmentRequest struct {
+	ProjectID       string `json:"project_id"`
+	AmountXRP       string `json:"amount_xrp"`
+	InvestorAccount string `json:"investor_account"`
+}
+
+// SimulationResult is the outcome of a simulated investment
+type SimulationResult struct {
+	ProjectID     string `json:"project_id"`
+	AmountXRP     Amount `json:"amount_xrp"`
+	TokenAmount   Amount `json:"token_amount"`
+	Tier          int    `json:"tier"`
+	PricePerToken Amount `json:"price_per_token"`
+}
+
+// CreateManualInvestmentRequest records an investment settled outside the platform.
+// Either PaymentReference must be set or Unverified must be true.
+type CreateManualInvestmentRequest struct {
+	ProjectID        string `json:"project_id"`
+	InvestorAccount  string `json:"investor_account"`
+	Amount           string `json:"amount"`
+	Currency         string `json:"currency"`
+	PaymentReference string `json:"payment_reference,omitempty"`
+	Unverified       bool   `json:"unverified,omitempty"`
+	Note             string `json:"note,omitempty"`
+}
+
+// Validate checks the request before it is sent
+func (r *CreateManualInvestmentRequest) Validate() error {
+	if r.PaymentReference == "" && !r.Unverified {
+		return fmt.Errorf("%w: manual investment requires a payment reference or Unverified set", ErrValidation)
+	}
+	return nil
+}
+
+// Webhook represents a registered webhook endpoint
+type Webhook struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Description string    `json:"description,omitempty"`
+	Active      bool      `json:"active"`
+	CreatedAt   Timestamp `json:"created_at"`
+}
+
+// RegisterWebhookRequest describes a webhook to register
+type RegisterWebhookRequest struct {
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Webhook delivery statuses
+const (
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+	DeliveryPending   = "pending"
+)
+
+// WebhookDelivery represents one attempt log of delivering an event to a webhook
+type WebhookDelivery struct {
+	ID             string     `json:"id"`
+	WebhookID      string     `json:"webhook_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	Attempts       int        `json:"attempts"`
+	NextRetryAt    *Timestamp `json:"next_retry_at,omitempty"`
+	CreatedAt      Timestamp  `json:"created_at"`
+}
+
+// PendingRetry reports whether the platform will still retry the delivery
+func (d *WebhookDelivery) PendingRetry() bool {
+	return d.Status == DeliveryPending || (d.NextRetryAt != nil && !d.NextRetryAt.IsZero())
+}
+
+// WebhookEvent represents an event delivered to a webhook endpoint
+type WebhookEvent struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Data      map[string]interface{} `json:"data"`
+	CreatedAt Timestamp              `json:"created_at"`
+}
\ No newline at end of file
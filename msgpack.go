@@ -0,0 +1,1096 @@
+package xrplsale
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// MessagePack format bytes, see https://github.com/msgpack/msgpack/blob/master/spec.md
+const (
+	mpNil      = 0xc0
+	mpFalse    = 0xc2
+	mpTrue     = 0xc3
+	mpBin8     = 0xc4
+	mpBin16    = 0xc5
+	mpBin32    = 0xc6
+	mpExt8     = 0xc7
+	mpExt16    = 0xc8
+	mpExt32    = 0xc9
+	mpFloat32  = 0xca
+	mpFloat64  = 0xcb
+	mpUint8    = 0xcc
+	mpUint16   = 0xcd
+	mpUint32   = 0xce
+	mpUint64   = 0xcf
+	mpInt8     = 0xd0
+	mpInt16    = 0xd1
+	mpInt32    = 0xd2
+	mpInt64    = 0xd3
+	mpFixExt1  = 0xd4
+	mpFixExt16 = 0xd8
+	mpStr8     = 0xd9
+	mpStr16    = 0xda
+	mpStr32    = 0xdb
+	mpArray16  = 0xdc
+	mpArray32  = 0xdd
+	mpMap16    = 0xde
+	mpMap32    = 0xdf
+	
+	// mpTimestampExt is the extension type of MessagePack timestamps
+	mpTimestampExt = -1
+)
+
+// MarshalMessagePack encodes v as MessagePack with the field names and
+// value formats of its JSON encoding, e.g. Amount as a decimal string, so
+// the two formats carry the same contract
+func MarshalMessagePack(v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return appendMessagePack(nil, generic), nil
+}
+
+// appendMessagePack appends the encoding of a value decoded from JSON
+func appendMessagePack(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, mpNil)
+	case bool:
+		if v {
+			return append(b, mpTrue)
+		}
+		return append(b, mpFalse)
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return appendMessagePackInt(b, n)
+		}
+		if n, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return binary.BigEndian.AppendUint64(append(b, mpUint64), n)
+		}
+		f, _ := v.Float64()
+		return binary.BigEndian.AppendUint64(append(b, mpFloat64), math.Float64bits(f))
+	case string:
+		return append(appendMessagePackHeader(b, len(v), 0xa0, 32, mpStr8, mpStr16, mpStr32), v...)
+	case []interface{}:
+		b = appendMessagePackHeader(b, len(v), 0x90, 16, 0, mpArray16, mpArray32)
+		for _, elem := range v {
+			b = appendMessagePack(b, elem)
+		}
+		return b
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b = appendMessagePackHeader(b, len(v), 0x80, 16, 0, mpMap16, mpMap32)
+		for _, key := range keys {
+			b = appendMessagePack(b, key)
+			b = appendMessagePack(b, v[key])
+		}
+		return b
+	}
+	panic(fmt.Sprintf("msgpack: unexpected %T decoded from JSON", v))
+}
+
+// appendMessagePackInt appends n in its shortest encoding
+func appendMessagePackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0 && n <= 0x7f:
+		return append(b, byte(n))
+	case n < 0 && n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8 && n <= math.MaxInt8:
+		return append(b, mpInt8, byte(n))
+	case n >= math.MinInt16 && n <= math.MaxInt16:
+		return binary.BigEndian.AppendUint16(append(b, mpInt16), uint16(n))
+	case n >= math.MinInt32 && n <= math.MaxInt32:
+		return binary.BigEndian.AppendUint32(append(b, mpInt32), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, mpInt64), uint64(n))
+}
+
+// appendMessagePackHeader appends the header of a string, array or map of
+// n items: a fix format below fixMax, else the 8 (strings only), 16 or 32
+// bit format
+func appendMessagePackHeader(b []byte, n int, fix byte, fixMax int, f8, f16, f32 byte) []byte {
+	switch {
+	case n < fixMax:
+		return append(b, fix|byte(n))
+	case f8 != 0 && n <= math.MaxUint8:
+		return append(b, f8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, f16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, f32), uint32(n))
+}
+
+// UnmarshalMessagePack decodes MessagePack data into v, mapping map keys to
+// struct fields by their json tags like encoding/json does. Types with an
+// UnmarshalJSON method, such as Amount and Timestamp, are handed the JSON
+// form of their value, so they accept the same input in either format.
+func UnmarshalMessagePack(data []byte, v interface{}) error {
+	return unmarshalMessagePack(data, v, false)
+}
+
+// unmarshalMessagePack decodes data into v, rejecting map keys that match
+// no struct field when strict is set
+func unmarshalMessagePack(data []byte, v interface{}, strict bool) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("msgpack: decode into non-pointer %T", v)
+	}
+	d := &msgpackDecoder{data: data, strict: strict}
+	if err := d.value(rv.Elem()); err != nil {
+		return err
+	}
+	if d.pos != len(d.data) {
+		return d.errorf("unexpected data after the MessagePack value")
+	}
+	return nil
+}
+
+// MessagePackError reports invalid MessagePack data or a value that cannot
+// be stored in the Go value decoded into
+type MessagePackError struct {
+	// Field is the dotted path of the struct field being decoded, if any
+	Field string
+	
+	// Offset is the byte offset of the offending value
+	Offset int64
+	
+	Msg string
+}
+
+// Error implements the error interface
+func (e *MessagePackError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("msgpack: field %q: %s", e.Field, e.Msg)
+	}
+	return "msgpack: " + e.Msg
+}
+
+// errTruncated is reported for data ending inside a value
+var errTruncated = errors.New("unexpected end of data")
+
+// msgpackDecoder decodes one MessagePack value from data
+type msgpackDecoder struct {
+	data   []byte
+	pos    int
+	strict bool
+	
+	// path holds the field names leading to the value being decoded
+	path []string
+}
+
+// errorf returns a MessagePackError at the current position
+func (d *msgpackDecoder) errorf(format string, args ...interface{}) error {
+	return &MessagePackError{Field: strings.Join(d.path, "."), Offset: int64(d.pos), Msg: fmt.Sprintf(format, args...)}
+}
+
+// typeError reports a value of kind that cannot be stored in t
+func (d *msgpackDecoder) typeError(kind string, t reflect.Type) error {
+	return d.errorf("cannot decode %s into Go value of type %s", kind, t)
+}
+
+// peek returns the format byte of the next value
+func (d *msgpackDecoder) peek() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, d.errorf("%v", errTruncated)
+	}
+	return d.data[d.pos], nil
+}
+
+// take consumes and returns the next n bytes
+func (d *msgpackDecoder) take(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, d.errorf("%v", errTruncated)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// uint reads a big-endian unsigned integer of size bytes
+func (d *msgpackDecoder) uint(size int) (uint64, error) {
+	b, err := d.take(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n, nil
+}
+
+// msgpackKind classifies a format byte
+type msgpackKind int
+
+const (
+	kindNil msgpackKind = iota
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindStr
+	kindBin
+	kindArray
+	kindMap
+	kindExt
+)
+
+// String names the kind in errors
+func (k msgpackKind) String() string {
+	return [...]string{"nil", "bool", "integer", "integer", "float", "string", "binary", "array", "map", "extension"}[k]
+}
+
+// kindOf classifies the format byte c
+func kindOf(c byte) msgpackKind {
+	switch {
+	case c <= 0x7f, c >= mpUint8 && c <= mpUint64:
+		return kindUint
+	case c >= 0xe0, c >= mpInt8 && c <= mpInt64:
+		return kindInt
+	case c <= 0x8f, c == mpMap16, c == mpMap32:
+		return kindMap
+	case c <= 0x9f, c == mpArray16, c == mpArray32:
+		return kindArray
+	case c <= 0xbf, c >= mpStr8 && c <= mpStr32:
+		return kindStr
+	case c == mpNil:
+		return kindNil
+	case c == mpFalse, c == mpTrue:
+		return kindBool
+	case c >= mpBin8 && c <= mpBin32:
+		return kindBin
+	case c == mpFloat32, c == mpFloat64:
+		return kindFloat
+	}
+	return kindExt
+}
+
+// length reads the header of a string, binary, array or map and returns
+// its number of bytes or items
+func (d *msgpackDecoder) length() (int, error) {
+	c, err := d.peek()
+	if err != nil {
+		return 0, err
+	}
+	d.pos++
+	var n uint64
+	switch {
+	case c >= 0x80 && c <= 0x8f, c >= 0x90 && c <= 0x9f:
+		return int(c & 0x0f), nil
+	case c >= 0xa0 && c <= 0xbf:
+		return int(c & 0x1f), nil
+	case c == mpStr8, c == mpBin8:
+		n, err = d.uint(1)
+	case c == mpStr16, c == mpBin16, c == mpArray16, c == mpMap16:
+		n, err = d.uint(2)
+	case c == mpStr32, c == mpBin32, c == mpArray32, c == mpMap32:
+		n, err = d.uint(4)
+	default:
+		d.pos--
+		return 0, d.errorf("format 0x%02x has no length", c)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)) {
+		// Every item takes at least a byte
+		return 0, d.errorf("%v", errTruncated)
+	}
+	return int(n), nil
+}
+
+// bytes reads a string or binary value, returning a slice of the input
+func (d *msgpackDecoder) bytes() ([]byte, error) {
+	n, err := d.length()
+	if err != nil {
+		return nil, err
+	}
+	return d.take(n)
+}
+
+// number reads an integer or float; exactly one of the results is set
+// according to kind
+func (d *msgpackDecoder) number() (i int64, u uint64, f float64, kind msgpackKind, err error) {
+	c, err := d.peek()
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	d.pos++
+	switch {
+	case c <= 0x7f:
+		return 0, uint64(c), 0, kindUint, nil
+	case c >= 0xe0:
+		return int64(int8(c)), 0, 0, kindInt, nil
+	case c >= mpUint8 && c <= mpUint64:
+		u, err = d.uint(1 << (c - mpUint8))
+		return 0, u, 0, kindUint, err
+	case c >= mpInt8 && c <= mpInt64:
+		size := 1 << (c - mpInt8)
+		u, err = d.uint(size)
+		shift := 64 - 8*size
+		return int64(u<<shift) >> shift, 0, 0, kindInt, err
+	case c == mpFloat32:
+		u, err = d.uint(4)
+		return 0, 0, float64(math.Float32frombits(uint32(u))), kindFloat, err
+	case c == mpFloat64:
+		u, err = d.uint(8)
+		return 0, 0, math.Float64frombits(u), kindFloat, err
+	}
+	d.pos--
+	return 0, 0, 0, 0, d.typeError(kindOf(c).String(), reflect.TypeOf(0.0))
+}
+
+// ext reads an extension value
+func (d *msgpackDecoder) ext() (int8, []byte, error) {
+	c, err := d.peek()
+	if err != nil {
+		return 0, nil, err
+	}
+	d.pos++
+	var n uint64
+	switch {
+	case c >= mpFixExt1 && c <= mpFixExt16:
+		n = 1 << (c - mpFixExt1)
+	case c >= mpExt8 && c <= mpExt32:
+		n, err = d.uint(1 << (c - mpExt8))
+	default:
+		d.pos--
+		return 0, nil, d.errorf("invalid format byte 0x%02x", c)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	typ, err := d.take(1)
+	if err != nil {
+		return 0, nil, err
+	}
+	data, err := d.take(int(min(n, uint64(len(d.data)+1))))
+	return int8(typ[0]), data, err
+}
+
+// timestamp decodes the payload of a timestamp extension
+func decodeMessagePackTimestamp(data []byte) (time.Time, bool) {
+	switch len(data) {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), true
+	case 8:
+		n := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(n&0x3ffffffff), int64(n>>34)).UTC(), true
+	case 12:
+		return time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data))).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// skip consumes the next value and returns its bytes
+func (d *msgpackDecoder) skip() ([]byte, error) {
+	start := d.pos
+	c, err := d.peek()
+	if err != nil {
+		return nil, err
+	}
+	switch kindOf(c) {
+	case kindNil, kindBool:
+		d.pos++
+	case kindInt, kindUint, kindFloat:
+		_, _, _, _, err = d.number()
+	case kindStr, kindBin:
+		_, err = d.bytes()
+	case kindExt:
+		_, _, err = d.ext()
+	case kindArray, kindMap:
+		var n int
+		if n, err = d.length(); err != nil {
+			break
+		}
+		if kindOf(c) == kindMap {
+			n *= 2
+		}
+		for i := 0; i < n && err == nil; i++ {
+			_, err = d.skip()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d.data[start:d.pos], nil
+}
+
+// value decodes the next value into v
+func (d *msgpackDecoder) value(v reflect.Value) error {
+	c, err := d.peek()
+	if err != nil {
+		return err
+	}
+	if c == mpNil {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+			d.pos++
+			v.SetZero()
+			return nil
+		}
+		if u, ok := jsonUnmarshaler(v); ok {
+			d.pos++
+			return d.wrap(u.UnmarshalJSON([]byte("null")))
+		}
+		// Like JSON null, nil leaves other values unchanged
+		d.pos++
+		return nil
+	}
+	
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if kindOf(c) == kindStr && v.CanAddr() {
+		if u, ok := v.Addr().Interface().(stringUnmarshaler); ok {
+			s, err := d.bytes()
+			if err != nil {
+				return err
+			}
+			return d.wrap(u.unmarshalString(string(s)))
+		}
+	}
+	if u, ok := jsonUnmarshaler(v); ok {
+		start := d.pos
+		raw, err := d.skip()
+		if err != nil {
+			return err
+		}
+		js, err := appendJSON(nil, &msgpackDecoder{data: raw})
+		if err != nil {
+			d.pos = start
+			return d.errorf("%v", err)
+		}
+		return d.wrap(u.UnmarshalJSON(js))
+	}
+	if kindOf(c) == kindStr && v.CanAddr() {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			text, err := d.bytes()
+			if err != nil {
+				return err
+			}
+			return d.wrap(u.UnmarshalText(text))
+		}
+	}
+	
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return d.typeError(kindOf(c).String(), v.Type())
+		}
+		generic, err := d.generic()
+		if err != nil {
+			return err
+		}
+		if generic == nil {
+			v.SetZero()
+		} else {
+			v.Set(reflect.ValueOf(generic))
+		}
+		return nil
+	case reflect.Struct:
+		return d.structValue(v)
+	case reflect.Map:
+		return d.mapValue(v)
+	case reflect.Slice, reflect.Array:
+		return d.sliceValue(v)
+	case reflect.String:
+		if k := kindOf(c); k != kindStr && k != kindBin {
+			return d.typeError(k.String(), v.Type())
+		}
+		s, err := d.bytes()
+		if err != nil {
+			return err
+		}
+		v.SetString(string(s))
+		return nil
+	case reflect.Bool:
+		if kindOf(c) != kindBool {
+			return d.typeError(kindOf(c).String(), v.Type())
+		}
+		d.pos++
+		v.SetBool(c == mpTrue)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return d.numberValue(v)
+	}
+	return d.typeError(kindOf(c).String(), v.Type())
+}
+
+// stringUnmarshaler is implemented by the SDK's types with an UnmarshalJSON
+// method that accept a JSON string, so they decode a MessagePack string
+// without a detour through JSON
+type stringUnmarshaler interface {
+	unmarshalString(s string) error
+}
+
+// jsonUnmarshaler returns v's UnmarshalJSON method, if its pointer has one
+func jsonUnmarshaler(v reflect.Value) (json.Unmarshaler, bool) {
+	if v.Kind() == reflect.Pointer || !v.CanAddr() {
+		return nil, false
+	}
+	u, ok := v.Addr().Interface().(json.Unmarshaler)
+	return u, ok
+}
+
+// wrap reports an error of an Unmarshal method at the current field
+func (d *msgpackDecoder) wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	return d.errorf("%v", err)
+}
+
+// numberValue decodes an integer or float into a numeric v
+func (d *msgpackDecoder) numberValue(v reflect.Value) error {
+	c, _ := d.peek()
+	if k := kindOf(c); k != kindInt && k != kindUint && k != kindFloat {
+		return d.typeError(k.String(), v.Type())
+	}
+	start := d.pos
+	i, u, f, kind, err := d.number()
+	if err != nil {
+		return err
+	}
+	overflow := func() error {
+		d.pos = start
+		return d.errorf("number out of range for Go value of type %s", v.Type())
+	}
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		switch kind {
+		case kindInt:
+			f = float64(i)
+		case kindUint:
+			f = float64(u)
+		}
+		if v.OverflowFloat(f) {
+			return overflow()
+		}
+		v.SetFloat(f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch kind {
+		case kindUint:
+			if u > math.MaxInt64 {
+				return overflow()
+			}
+			i = int64(u)
+		case kindFloat:
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				d.pos = start
+				return d.typeError("float", v.Type())
+			}
+			i = int64(f)
+		}
+		if v.OverflowInt(i) {
+			return overflow()
+		}
+		v.SetInt(i)
+	default:
+		switch kind {
+		case kindInt:
+			if i < 0 {
+				return overflow()
+			}
+			u = uint64(i)
+		case kindFloat:
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+				d.pos = start
+				return d.typeError("float", v.Type())
+			}
+			u = uint64(f)
+		}
+		if v.OverflowUint(u) {
+			return overflow()
+		}
+		v.SetUint(u)
+	}
+	return nil
+}
+
+// sliceValue decodes an array into a slice or array v. A binary value or a
+// base64 string decodes into a []byte, as in JSON.
+func (d *msgpackDecoder) sliceValue(v reflect.Value) error {
+	c, _ := d.peek()
+	kind := kindOf(c)
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 && (kind == kindBin || kind == kindStr) {
+		raw, err := d.bytes()
+		if err != nil {
+			return err
+		}
+		if kind == kindStr {
+			decoded, err := base64.StdEncoding.DecodeString(string(raw))
+			if err != nil {
+				return d.wrap(err)
+			}
+			raw = decoded
+		}
+		v.SetBytes(bytes.Clone(raw))
+		return nil
+	}
+	if kind != kindArray {
+		return d.typeError(kind.String(), v.Type())
+	}
+	n, err := d.length()
+	if err != nil {
+		return err
+	}
+	if v.Kind() == reflect.Slice {
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+	}
+	for i := 0; i < n; i++ {
+		if i >= v.Len() {
+			if _, err := d.skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := d.value(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	for i := n; i < v.Len(); i++ {
+		v.Index(i).SetZero()
+	}
+	return nil
+}
+
+// mapValue decodes a map into a map v with string or integer keys
+func (d *msgpackDecoder) mapValue(v reflect.Value) error {
+	c, _ := d.peek()
+	if kindOf(c) != kindMap {
+		return d.typeError(kindOf(c).String(), v.Type())
+	}
+	n, err := d.length()
+	if err != nil {
+		return err
+	}
+	t := v.Type()
+	if v.IsNil() {
+		v.Set(reflect.MakeMapWithSize(t, n))
+	}
+	for i := 0; i < n; i++ {
+		key, err := d.key()
+		if err != nil {
+			return err
+		}
+		kv := reflect.New(t.Key()).Elem()
+		switch t.Key().Kind() {
+		case reflect.String:
+			kv.SetString(key)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(key, 10, 64)
+			if err != nil || kv.OverflowInt(n) {
+				return d.typeError("map key "+strconv.Quote(key), t.Key())
+			}
+			kv.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			n, err := strconv.ParseUint(key, 10, 64)
+			if err != nil || kv.OverflowUint(n) {
+				return d.typeError("map key "+strconv.Quote(key), t.Key())
+			}
+			kv.SetUint(n)
+		default:
+			return d.typeError("map", t)
+		}
+		ev := reflect.New(t.Elem()).Elem()
+		d.path = append(d.path, key)
+		err = d.value(ev)
+		d.path = d.path[:len(d.path)-1]
+		if err != nil {
+			return err
+		}
+		v.SetMapIndex(kv, ev)
+	}
+	return nil
+}
+
+// key reads a map key: a string, or an integer formatted in decimal
+func (d *msgpackDecoder) key() (string, error) {
+	c, err := d.peek()
+	if err != nil {
+		return "", err
+	}
+	switch kindOf(c) {
+	case kindStr:
+		s, err := d.bytes()
+		return string(s), err
+	case kindInt, kindUint:
+		i, u, _, kind, err := d.number()
+		if kind == kindInt {
+			return strconv.FormatInt(i, 10), err
+		}
+		return strconv.FormatUint(u, 10), err
+	}
+	return "", d.errorf("map key of type %s", kindOf(c))
+}
+
+// keyBytes is key without copying string keys out of the data
+func (d *msgpackDecoder) keyBytes() ([]byte, error) {
+	c, err := d.peek()
+	if err != nil {
+		return nil, err
+	}
+	if kindOf(c) == kindStr {
+		return d.bytes()
+	}
+	key, err := d.key()
+	return []byte(key), err
+}
+
+// structValue decodes a map into struct v, matching keys to the fields'
+// json names exactly or else case-insensitively
+func (d *msgpackDecoder) structValue(v reflect.Value) error {
+	c, _ := d.peek()
+	if kindOf(c) != kindMap {
+		return d.typeError(kindOf(c).String(), v.Type())
+	}
+	n, err := d.length()
+	if err != nil {
+		return err
+	}
+	fields := cachedFields(v.Type())
+	for i := 0; i < n; i++ {
+		key, err := d.keyBytes()
+		if err != nil {
+			return err
+		}
+		field := fields.lookup(key)
+		if field == nil {
+			if d.strict {
+				return d.errorf("unknown field %q", key)
+			}
+			if _, err := d.skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		fv, err := fieldByIndex(v, field.index)
+		if err != nil {
+			return d.errorf("%v", err)
+		}
+		d.path = append(d.path, field.name)
+		err = d.value(fv)
+		d.path = d.path[:len(d.path)-1]
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldByIndex returns the nested field of v at index, allocating the
+// embedded struct pointers on the way
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// msgpackField is a struct field decoded from the map key name
+type msgpackField struct {
+	name  string
+	index []int
+}
+
+// msgpackFields are the decodable fields of a struct type
+type msgpackFields struct {
+	list   []msgpackField
+	byName map[string]*msgpackField
+}
+
+// lookup returns the field named key, comparing case-insensitively when
+// no name matches exactly
+func (fs *msgpackFields) lookup(key []byte) *msgpackField {
+	if f, ok := fs.byName[string(key)]; ok {
+		return f
+	}
+	for i := range fs.list {
+		if bytes.EqualFold([]byte(fs.list[i].name), key) {
+			return &fs.list[i]
+		}
+	}
+	return nil
+}
+
+// fieldCache maps struct types to their *msgpackFields
+var fieldCache sync.Map
+
+// cachedFields returns the fields of struct type t
+func cachedFields(t reflect.Type) *msgpackFields {
+	if fs, ok := fieldCache.Load(t); ok {
+		return fs.(*msgpackFields)
+	}
+	fs := &msgpackFields{byName: make(map[string]*msgpackField)}
+	seen := make(map[string]bool)
+	collectFields(t, nil, seen, fs)
+	for i := range fs.list {
+		fs.byName[fs.list[i].name] = &fs.list[i]
+	}
+	actual, _ := fieldCache.LoadOrStore(t, fs)
+	return actual.(*msgpackFields)
+}
+
+// collectFields adds the fields of t under index to fs, following the
+// json package: tagged names, "-" skipped, and the fields of untagged
+// embedded structs promoted, shallower ones first
+func collectFields(t reflect.Type, index []int, seen map[string]bool, fs *msgpackFields) {
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			embedded = append(embedded, sf)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		fs.list = append(fs.list, msgpackField{name: name, index: append(append([]int(nil), index...), i)})
+	}
+	for _, sf := range embedded {
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		collectFields(ft, append(append([]int(nil), index...), sf.Index...), seen, fs)
+	}
+}
+
+// generic decodes the next value as encoding/json decodes into an empty
+// interface: maps, slices, strings, float64s, bools and nil
+func (d *msgpackDecoder) generic() (interface{}, error) {
+	c, err := d.peek()
+	if err != nil {
+		return nil, err
+	}
+	switch kind := kindOf(c); kind {
+	case kindNil:
+		d.pos++
+		return nil, nil
+	case kindBool:
+		d.pos++
+		return c == mpTrue, nil
+	case kindInt, kindUint, kindFloat:
+		i, u, f, kind, err := d.number()
+		switch kind {
+		case kindInt:
+			return float64(i), err
+		case kindUint:
+			return float64(u), err
+		}
+		return f, err
+	case kindStr:
+		s, err := d.bytes()
+		return string(s), err
+	case kindBin:
+		b, err := d.bytes()
+		return base64.StdEncoding.EncodeToString(b), err
+	case kindExt:
+		typ, data, err := d.ext()
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := decodeMessagePackTimestamp(data); ok && typ == mpTimestampExt {
+			return t.Format(time.RFC3339Nano), nil
+		}
+		return nil, d.errorf("unsupported extension type %d", typ)
+	case kindArray:
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		list := make([]interface{}, n)
+		for i := range list {
+			if list[i], err = d.generic(); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	default:
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, n)
+		for i := 0; i < n; i++ {
+			key, err := d.key()
+			if err != nil {
+				return nil, err
+			}
+			if m[key], err = d.generic(); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	}
+}
+
+// messagePackToJSON transcodes a MessagePack value to JSON, e.g. to parse
+// an error body or replay it where JSON is expected
+func messagePackToJSON(data []byte) ([]byte, error) {
+	d := &msgpackDecoder{data: data}
+	js, err := appendJSON(nil, d)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(data) {
+		return nil, d.errorf("unexpected data after the MessagePack value")
+	}
+	return js, nil
+}
+
+// appendJSON appends the JSON form of d's next value to b. Binary values
+// become base64 strings and timestamps RFC 3339 strings.
+func appendJSON(b []byte, d *msgpackDecoder) ([]byte, error) {
+	c, err := d.peek()
+	if err != nil {
+		return nil, err
+	}
+	switch kindOf(c) {
+	case kindNil:
+		d.pos++
+		return append(b, "null"...), nil
+	case kindBool:
+		d.pos++
+		return strconv.AppendBool(b, c == mpTrue), nil
+	case kindInt, kindUint, kindFloat:
+		i, u, f, kind, err := d.number()
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case kindInt:
+			return strconv.AppendInt(b, i, 10), nil
+		case kindUint:
+			return strconv.AppendUint(b, u, 10), nil
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, d.errorf("%v has no JSON representation", f)
+		}
+		return strconv.AppendFloat(b, f, 'g', -1, 64), nil
+	case kindStr:
+		s, err := d.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONString(b, s), nil
+	case kindBin, kindExt:
+		v, err := d.generic()
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONString(b, []byte(v.(string))), nil
+	case kindArray:
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, '[')
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if b, err = appendJSON(b, d); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, ']'), nil
+	default:
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, '{')
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			key, err := d.key()
+			if err != nil {
+				return nil, err
+			}
+			b = append(appendJSONString(b, []byte(key)), ':')
+			if b, err = appendJSON(b, d); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, '}'), nil
+	}
+}
+
+// appendJSONString appends s as a JSON string, replacing invalid UTF-8
+func appendJSONString(b, s []byte) []byte {
+	const hex = "0123456789abcdef"
+	b = append(b, '"')
+	for len(s) > 0 {
+		r, size := utf8.DecodeRune(s)
+		switch {
+		case r == '"' || r == '\\':
+			b = append(b, '\\', byte(r))
+		case r < 0x20:
+			b = append(b, '\\', 'u', '0', '0', hex[r>>4], hex[r&0xf])
+		case r == utf8.RuneError && size == 1:
+			b = append(b, `�`...)
+		default:
+			b = append(b, s[:size]...)
+		}
+		s = s[size:]
+	}
+	return append(b, '"')
+}
\ No newline at end of file
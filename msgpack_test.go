@@ -0,0 +1,253 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// investmentsPage returns a page of n generated investments
+func investmentsPage(n int) *xrplsale.PaginatedResponse[xrplsale.Investment] {
+	gen := fixtures.New(1)
+	project := gen.Project()
+	page := &xrplsale.PaginatedResponse[xrplsale.Investment]{Data: gen.Investments(project, n)}
+	page.Pagination.Page = 1
+	page.Pagination.Limit = n
+	page.Pagination.Total = n
+	return page
+}
+
+func TestMessagePackRoundTrip(t *testing.T) {
+	gen := fixtures.New(1)
+	project := gen.Project(fixtures.WithTierCount(3))
+	
+	tests := []struct {
+		name string
+		in   interface{}
+		out  func() interface{}
+	}{
+		{"project", project, func() interface{} { return new(xrplsale.Project) }},
+		{"investment", gen.Investment(fixtures.ForProject(project)), func() interface{} { return new(xrplsale.Investment) }},
+		{"page", investmentsPage(20), func() interface{} { return new(xrplsale.PaginatedResponse[xrplsale.Investment]) }},
+		{"webhook", gen.Webhook("investment.created"), func() interface{} { return new(xrplsale.Webhook) }},
+		{"map", map[string]interface{}{"a": 1.5, "b": []interface{}{"x", nil, true}}, func() interface{} { return new(map[string]interface{}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonBody, err := json.Marshal(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			msgpackBody, err := xrplsale.MarshalMessagePack(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(msgpackBody) >= len(jsonBody) {
+				t.Errorf("MessagePack body is %d bytes, JSON %d", len(msgpackBody), len(jsonBody))
+			}
+			
+			fromJSON, fromMessagePack := tt.out(), tt.out()
+			if err := json.Unmarshal(jsonBody, fromJSON); err != nil {
+				t.Fatal(err)
+			}
+			if err := xrplsale.UnmarshalMessagePack(msgpackBody, fromMessagePack); err != nil {
+				t.Fatalf("UnmarshalMessagePack() = %v", err)
+			}
+			if !reflect.DeepEqual(fromJSON, fromMessagePack) {
+				t.Fatalf("decoded values differ:\njson    %+v\nmsgpack %+v", fromJSON, fromMessagePack)
+			}
+		})
+	}
+}
+
+func TestUnmarshalMessagePack(t *testing.T) {
+	type target struct {
+		Name  string             `json:"name"`
+		Count int8               `json:"count"`
+		Tags  []string           `json:"tags,omitempty"`
+		When  xrplsale.Timestamp `json:"when"`
+		Sub   *struct {
+			OK bool `json:"ok"`
+		} `json:"sub"`
+	}
+	when := time.Date(2026, 3, 1, 12, 30, 0, 500, time.UTC)
+	// timestamp 64: 30 bits of nanoseconds, then 34 bits of seconds
+	timestamp := binary.BigEndian.AppendUint64([]byte{0xd7, 0xff}, uint64(when.Nanosecond())<<34|uint64(when.Unix()))
+	
+	tests := []struct {
+		name    string
+		data    []byte
+		want    target
+		wantErr string
+	}{
+		{"fields by json tag", []byte{0x82, 0xa4, 'n', 'a', 'm', 'e', 0xa1, 'x', 0xa5, 'c', 'o', 'u', 'n', 't', 0x07}, target{Name: "x", Count: 7}, ""},
+		{"key case ignored", []byte{0x81, 0xa4, 'N', 'A', 'M', 'E', 0xa1, 'y'}, target{Name: "y"}, ""},
+		{"unknown key skipped", []byte{0x81, 0xa3, 'z', 'z', 'z', 0x92, 0x01, 0x02}, target{}, ""},
+		{"nil leaves zero", []byte{0x81, 0xa3, 's', 'u', 'b', 0xc0}, target{}, ""},
+		{"timestamp extension", append([]byte{0x81, 0xa4, 'w', 'h', 'e', 'n'}, timestamp...), target{When: xrplsale.Timestamp{Time: when}}, ""},
+		{"overflow", []byte{0x81, 0xa5, 'c', 'o', 'u', 'n', 't', 0xcd, 0x01, 0x00}, target{}, `field "count"`},
+		{"wrong type", []byte{0x81, 0xa4, 't', 'a', 'g', 's', 0xa1, 'x'}, target{}, `field "tags"`},
+		{"truncated", []byte{0x82, 0xa4, 'n', 'a', 'm'}, target{}, "unexpected end of data"},
+		{"trailing data", []byte{0x80, 0x80}, target{}, "after the MessagePack value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got target
+			err := xrplsale.UnmarshalMessagePack(tt.data, &got)
+			if tt.wantErr != "" {
+				var mpErr *xrplsale.MessagePackError
+				if !errors.As(err, &mpErr) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UnmarshalMessagePack() = %v, want MessagePackError containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalMessagePack() = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("UnmarshalMessagePack() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestWireFormatMessagePack(t *testing.T) {
+	srv := xrplsaletest.NewServer()
+	defer srv.Close()
+	project := fixtures.Project()
+	srv.AddProjects(project)
+	srv.AddInvestments(fixtures.Investments(project, 5)...)
+	
+	var contentTypes []string
+	jsonClient := xrplsaletest.NewClient(srv)
+	msgpackClient := xrplsaletest.NewClient(srv, func(c *xrplsale.Config) {
+		c.WireFormat = xrplsale.WireFormatMessagePack
+		c.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := http.DefaultTransport.RoundTrip(req)
+			if err == nil {
+				contentTypes = append(contentTypes, resp.Header.Get("Content-Type"))
+			}
+			return resp, err
+		})
+	})
+	
+	want, err := jsonClient.Investments.GetByProject(context.Background(), project.ID, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := msgpackClient.Investments.GetByProject(context.Background(), project.ID, 1, 10)
+	if err != nil {
+		t.Fatalf("GetByProject() = %v", err)
+	}
+	if len(contentTypes) != 1 || contentTypes[0] != xrplsale.MessagePackContentType {
+		t.Fatalf("response Content-Type = %q, want %q", contentTypes, xrplsale.MessagePackContentType)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("MessagePack page = %+v, want %+v", got, want)
+	}
+	
+	_, err = msgpackClient.Projects.Get(context.Background(), "proj_missing")
+	if !errors.Is(err, xrplsale.ErrNotFound) {
+		t.Fatalf("Get() of a missing project = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWireFormatFallback(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		strict      bool
+		wantErr     bool
+	}{
+		{"JSON answer", "application/json", []byte(`{"id":"proj_1","name":"json"}`), false, false},
+		{"MessagePack answer", "application/x-msgpack", []byte{0x82, 0xa2, 'i', 'd', 0xa6, 'p', 'r', 'o', 'j', '_', '1', 0xa4, 'n', 'a', 'm', 'e', 0xa4, 'j', 's', 'o', 'n'}, false, false},
+		{"strict, unknown field", "application/msgpack", []byte{0x81, 0xa3, 'z', 'z', 'z', 0x01}, true, true},
+		{"invalid MessagePack", "application/msgpack", []byte{0xc1}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var accept string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				accept = r.Header.Get("Accept")
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Write(tt.body)
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey:         "key",
+				BaseURL:        srv.URL,
+				WireFormat:     xrplsale.WireFormatMessagePack,
+				StrictDecoding: tt.strict,
+			})
+			
+			project, err := client.Projects.Get(context.Background(), "proj_1", xrplsale.WithNoRetry())
+			if !strings.Contains(accept, xrplsale.MessagePackContentType) {
+				t.Errorf("Accept = %q, want it to ask for MessagePack", accept)
+			}
+			if tt.wantErr {
+				var decodeErr *xrplsale.DecodeError
+				if !errors.As(err, &decodeErr) {
+					t.Fatalf("Get() = %v, want a DecodeError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get() = %v", err)
+			}
+			if project.ID != "proj_1" || project.Name != "json" {
+				t.Fatalf("Get() = %+v", project)
+			}
+		})
+	}
+}
+
+func TestWireFormatUnknown(t *testing.T) {
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: "http://127.0.0.1:1", WireFormat: "xml"})
+	if _, err := client.Projects.Get(context.Background(), "proj_1"); err == nil || !strings.Contains(err.Error(), "wire format") {
+		t.Fatalf("Get() = %v, want the unknown wire format reported", err)
+	}
+}
+
+func BenchmarkDecodeInvestmentsPage(b *testing.B) {
+	page := investmentsPage(500)
+	jsonBody, _ := json.Marshal(page)
+	msgpackBody, _ := xrplsale.MarshalMessagePack(page)
+	
+	b.Run("json", func(b *testing.B) {
+		b.SetBytes(int64(len(jsonBody)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var out xrplsale.PaginatedResponse[xrplsale.Investment]
+			if err := json.Unmarshal(jsonBody, &out); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("msgpack", func(b *testing.B) {
+		b.SetBytes(int64(len(msgpackBody)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var out xrplsale.PaginatedResponse[xrplsale.Investment]
+			if err := xrplsale.UnmarshalMessagePack(msgpackBody, &out); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
\ No newline at end of file
@@ -0,0 +1,18 @@
+package xrplsale
+
+// OnBehalfOfHeader names the user an admin API key acts for
+const OnBehalfOfHeader = "X-On-Behalf-Of"
+
+// WithOnBehalfOf makes this request act for the user with walletAddress.
+// Only admin API keys may do so; others get a *PermissionError.
+func WithOnBehalfOf(walletAddress string) RequestOption {
+	return WithHeader(OnBehalfOfHeader, walletAddress)
+}
+
+// AsUser returns a client whose every request acts for the user with
+// walletAddress, see WithOnBehalfOf. It is a Clone, so it shares c's
+// configuration, connection pool and hooks, and caches responses apart
+// from c's.
+func (c *Client) AsUser(walletAddress string) *Client {
+	return c.Clone(WithDefaultHeader(OnBehalfOfHeader, walletAddress))
+}
\ No newline at end of file
@@ -0,0 +1,105 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// onBehalfOfServer answers with the user a request acts for, and rejects
+// acting for one with an API key other than "admin"
+func onBehalfOfServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := r.Header.Get(xrplsale.OnBehalfOfHeader)
+		mu.Lock()
+		seen = append(seen, user)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if user != "" && r.Header.Get("X-API-Key") != "admin" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"admin key required","required_scope":"admin"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"` + user + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), seen...)
+	}
+}
+
+func TestOnBehalfOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiKey   string
+		client   func(*xrplsale.Client) *xrplsale.Client
+		opts     []xrplsale.RequestOption
+		wantUser string
+		// wantForbidden expects the server's 403 as a PermissionError
+		wantForbidden bool
+	}{
+		{"request option", "admin", func(c *xrplsale.Client) *xrplsale.Client { return c }, []xrplsale.RequestOption{xrplsale.WithOnBehalfOf("rOwner")}, "rOwner", false},
+		{"derived client", "admin", func(c *xrplsale.Client) *xrplsale.Client { return c.AsUser("rOwner") }, nil, "rOwner", false},
+		{"parent of a derived client", "admin", func(c *xrplsale.Client) *xrplsale.Client { c.AsUser("rOwner"); return c }, nil, "", false},
+		{"non-admin key", "key", func(c *xrplsale.Client) *xrplsale.Client { return c.AsUser("rOwner") }, nil, "rOwner", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, seen := onBehalfOfServer(t)
+			client := tt.client(xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: tt.apiKey, BaseURL: srv.URL}))
+			
+			project, err := client.Projects.Get(context.Background(), "p", append(tt.opts, xrplsale.WithNoRetry())...)
+			var permErr *xrplsale.PermissionError
+			if tt.wantForbidden {
+				if !errors.As(err, &permErr) || permErr.RequiredScope != "admin" {
+					t.Fatalf("Get() = %v, want a PermissionError", err)
+				}
+			} else if err != nil || project.ID != tt.wantUser {
+				t.Fatalf("Get() = %+v, %v, want it to act for %q", project, err, tt.wantUser)
+			}
+			if got := seen(); len(got) != 1 || got[0] != tt.wantUser {
+				t.Errorf("server saw %s %q, want %q once", xrplsale.OnBehalfOfHeader, got, tt.wantUser)
+			}
+		})
+	}
+}
+
+// TestOnBehalfOfCache checks cached responses are kept per acted-for user
+func TestOnBehalfOfCache(t *testing.T) {
+	srv, seen := onBehalfOfServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "admin", BaseURL: srv.URL, CacheTTL: time.Minute})
+	tests := []struct {
+		name     string
+		client   *xrplsale.Client
+		opts     []xrplsale.RequestOption
+		wantUser string
+	}{
+		{"own", client, nil, ""},
+		{"derived client", client.AsUser("rAlice"), nil, "rAlice"},
+		{"another derived client", client.AsUser("rBob"), nil, "rBob"},
+		{"request option", client, []xrplsale.RequestOption{xrplsale.WithOnBehalfOf("rCarol")}, "rCarol"},
+	}
+	for round := 0; round < 2; round++ {
+		for _, tt := range tests {
+			project, err := tt.client.Projects.Get(context.Background(), "p", tt.opts...)
+			if err != nil || project.ID != tt.wantUser {
+				t.Fatalf("%s, round %d: Get() = %+v, %v, want the response for %q", tt.name, round, project, err, tt.wantUser)
+			}
+		}
+	}
+	// The second round came from the cache
+	if got := seen(); len(got) != len(tests) {
+		t.Errorf("server saw %d requests for %d users, want %d", len(got), len(tests), len(tests))
+	}
+}
\ No newline at end of file
@@ -0,0 +1,169 @@
+package xrplsale
+
+import (
+	"context"
+	"time"
+)
+
+// RequestOption customizes a single API call without affecting the client
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the per-call settings collected from RequestOptions
+type requestOptions struct {
+	headers map[string]string
+	timeout time.Duration
+	noRetry bool
+	
+	retryNonIdempotent bool
+	
+	maxResponseBytes int64
+	
+	balancePrecheck bool
+	
+	idempotencyKey string
+	
+	meta *ResponseMeta
+	
+	operationTimeout time.Duration
+	
+	cacheTTL time.Duration
+	
+	orderByID   bool
+	resumeAfter string
+	
+	params map[string]string
+	
+	dryRun bool
+	
+	downloadProgress func(written, total int64)
+	
+	maxElapsedTime time.Duration
+}
+
+// noRetryKey marks a request context whose request must not be retried
+type noRetryKey struct{}
+
+// WithHeader sets a header on this request only
+func WithHeader(key, value string) RequestOption {
+	return func(ro *requestOptions) {
+		if ro.headers == nil {
+			ro.headers = make(map[string]string)
+		}
+		ro.headers[key] = value
+	}
+}
+
+// WithQueryParam adds a query parameter to this request, overriding a
+// parameter of the same name set by the method called
+func WithQueryParam(key, value string) RequestOption {
+	return func(ro *requestOptions) {
+		if ro.params == nil {
+			ro.params = make(map[string]string)
+		}
+		ro.params[key] = value
+	}
+}
+
+// WithRequestTimeout bounds this request, including retries, by the given duration
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(ro *requestOptions) {
+		ro.timeout = timeout
+	}
+}
+
+// WithNoRetry disables retries for this request
+func WithNoRetry() RequestOption {
+	return func(ro *requestOptions) {
+		ro.noRetry = true
+	}
+}
+
+// WithRetryNonIdempotent allows the default retry policy to retry this
+// request even though it is a POST or PATCH. Only use it for calls that are
+// safe to repeat, e.g. ones carrying an idempotency key.
+func WithRetryNonIdempotent() RequestOption {
+	return func(ro *requestOptions) {
+		ro.retryNonIdempotent = true
+	}
+}
+
+// WithMaxResponseBytes overrides Config.MaxResponseBytes for this request.
+// A negative value removes the limit.
+func WithMaxResponseBytes(limit int64) RequestOption {
+	return func(ro *requestOptions) {
+		if limit < 0 {
+			limit = unlimitedResponse
+		}
+		ro.maxResponseBytes = limit
+	}
+}
+
+// WithOperationTimeout bounds a whole operation by the given duration. For
+// composite helpers such as Provision, Reconcile, PurgeDeliveries,
+// BuildDistributionReport, ExportCSV and GetUsage it covers every call
+// they make; on a single call it behaves like WithRequestTimeout. When both
+// are set, the earlier deadline wins.
+func WithOperationTimeout(timeout time.Duration) RequestOption {
+	return func(ro *requestOptions) {
+		ro.operationTimeout = timeout
+	}
+}
+
+// operationContext derives the context of a composite operation from ctx.
+// The returned cancel function must always be called.
+func operationContext(ctx context.Context, opts []RequestOption) (context.Context, context.CancelFunc) {
+	if timeout := newRequestOptions(opts).operationTimeout; timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func() {}
+}
+
+// newRequestOptions applies opts to a fresh set of request options
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	ro := &requestOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(ro)
+		}
+	}
+	return ro
+}
+
+// context derives the request context from ctx. The returned cancel
+// function must always be called.
+func (ro *requestOptions) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ro.noRetry {
+		ctx = context.WithValue(ctx, noRetryKey{}, true)
+	}
+	if ro.retryNonIdempotent || ro.idempotencyKey != "" {
+		ctx = context.WithValue(ctx, retryNonIdempotentKey{}, true)
+	}
+	if ro.maxResponseBytes != 0 {
+		ctx = withResponseLimit(ctx, ro.maxResponseBytes)
+	}
+	if ro.operationTimeout <= 0 && ro.timeout <= 0 {
+		return ctx, noCancel
+	}
+	cancels := make([]context.CancelFunc, 0, 2)
+	for _, timeout := range []time.Duration{ro.operationTimeout, ro.timeout} {
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			cancels = append(cancels, cancel)
+		}
+	}
+	return ctx, func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
+// noCancel is the cancel function of a context that needs no cancelling
+func noCancel() {}
+
+// retryDisabled reports whether the request context opted out of retries
+func retryDisabled(ctx context.Context) bool {
+	noRetry, _ := ctx.Value(noRetryKey{}).(bool)
+	return noRetry
+}
\ No newline at end of file
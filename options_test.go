@@ -0,0 +1,156 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestWithHeader(t *testing.T) {
+	var mu sync.Mutex
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		got = append(got, r.Header.Get("X-Tenant-ID")+"|"+r.Header.Get("X-Trace"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	base := client.Clone(xrplsale.WithTenant("base"))
+	
+	var out map[string]interface{}
+	if err := base.Get(context.Background(), "/projects", nil, &out, xrplsale.WithHeader("X-Trace", "once"), xrplsale.WithHeader("X-Tenant-ID", "override")); err != nil {
+		t.Fatal(err)
+	}
+	if err := base.Get(context.Background(), "/projects", nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"override|once", "base|"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("requests sent headers %q, want %q", got, want)
+	}
+	
+	got = nil
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var opts []xrplsale.RequestOption
+			if i%2 == 0 {
+				opts = append(opts, xrplsale.WithHeader("X-Trace", "even"))
+			}
+			var out map[string]interface{}
+			if err := base.Get(context.Background(), "/projects", nil, &out, opts...); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	counts := make(map[string]int)
+	for _, headers := range got {
+		counts[headers]++
+	}
+	if counts["base|even"] != 10 || counts["base|"] != 10 {
+		t.Fatalf("concurrent requests sent headers %v, want 10 with X-Trace and 10 without", counts)
+	}
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	start := time.Now()
+	var out map[string]interface{}
+	err := client.Get(context.Background(), "/projects", nil, &out, xrplsale.WithRequestTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get() = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("Get() returned after %v, want about 50ms", elapsed)
+	}
+}
+
+func TestWithNoRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []xrplsale.RequestOption
+		want int32
+	}{
+		{"default", nil, 4},
+		{"no retry", []xrplsale.RequestOption{xrplsale.WithNoRetry()}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, RetryWaitTime: time.Millisecond})
+			
+			var out map[string]interface{}
+			if err := client.Get(context.Background(), "/projects", nil, &out, tt.opts...); err == nil {
+				t.Fatal("Get() succeeded against a failing server")
+			}
+			if got := attempts.Load(); got != tt.want {
+				t.Fatalf("server saw %d attempts, want %d", got, tt.want)
+			}
+		})
+	}
+}
+func TestTimeoutPrecedence(t *testing.T) {
+	const short, long = 50 * time.Millisecond, 5 * time.Second
+	tests := []struct {
+		name      string
+		caller    time.Duration
+		operation time.Duration
+		request   time.Duration
+		attempt   time.Duration
+	}{
+		{"caller context", short, long, long, long},
+		{"operation timeout", long, short, long, long},
+		{"request timeout", long, long, short, long},
+		{"attempt timeout", long, long, long, short},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey:    "key",
+				BaseURL:   "http://127.0.0.1:1",
+				Timeout:   tt.attempt,
+				Transport: &stallTransport{},
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), tt.caller)
+			defer cancel()
+			
+			start := time.Now()
+			err := client.Get(ctx, "/projects", nil, nil, xrplsale.WithNoRetry(),
+				xrplsale.WithOperationTimeout(tt.operation), xrplsale.WithRequestTimeout(tt.request))
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Get() = %v, want DeadlineExceeded", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Fatalf("Get() returned after %s, want the %s deadline to win", elapsed, short)
+			}
+		})
+	}
+}
\ No newline at end of file
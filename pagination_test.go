@@ -0,0 +1,47 @@
+package xrplsale_test
+
+import (
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestPaginationMath(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination xrplsale.Pagination
+		items      int
+		wantTotal  int
+		wantNext   int
+		wantEmpty  bool
+	}{
+		{"zero total", xrplsale.Pagination{Page: 1, Limit: 20}, 0, 0, 0, true},
+		{"single page", xrplsale.Pagination{Page: 1, Limit: 20, Total: 7}, 7, 1, 0, false},
+		{"exactly divisible, first page", xrplsale.Pagination{Page: 1, Limit: 10, Total: 30}, 10, 3, 2, false},
+		{"exactly divisible, last page", xrplsale.Pagination{Page: 3, Limit: 10, Total: 30}, 10, 3, 0, false},
+		{"final partial page", xrplsale.Pagination{Page: 4, Limit: 10, Total: 31}, 1, 4, 0, false},
+		{"before the partial page", xrplsale.Pagination{Page: 3, Limit: 10, Total: 31}, 10, 4, 4, false},
+		{"limit 0 counts as one page", xrplsale.Pagination{Page: 1, Total: 31}, 31, 1, 0, false},
+		{"negative limit", xrplsale.Pagination{Page: 1, Limit: -5, Total: 31}, 31, 1, 0, false},
+		{"total_pages from the API wins", xrplsale.Pagination{Page: 1, Limit: 10, Total: 31, TotalPages: 5}, 10, 5, 2, false},
+		{"page 0 is the first page", xrplsale.Pagination{Limit: 10, Total: 30}, 10, 3, 2, false},
+		{"past the end", xrplsale.Pagination{Page: 9, Limit: 10, Total: 30}, 0, 3, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &xrplsale.PaginatedResponse[int]{Data: make([]int, tt.items), Pagination: tt.pagination}
+			if got := page.TotalPages(); got != tt.wantTotal {
+				t.Errorf("TotalPages() = %d, want %d", got, tt.wantTotal)
+			}
+			if got := page.NextPageNumber(); got != tt.wantNext {
+				t.Errorf("NextPageNumber() = %d, want %d", got, tt.wantNext)
+			}
+			if got := page.HasNextPage(); got != (tt.wantNext > 0) {
+				t.Errorf("HasNextPage() = %v, want %v", got, tt.wantNext > 0)
+			}
+			if got := page.IsEmpty(); got != tt.wantEmpty {
+				t.Errorf("IsEmpty() = %v, want %v", got, tt.wantEmpty)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,271 @@
+package xrplsale
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// DefaultPaymentMatchWindow is how far apart an investment and an external
+// payment may be in time for the fallback match by default
+const DefaultPaymentMatchWindow = 10 * time.Minute
+
+// Payment match methods
+const (
+	MatchByTxHash   = "tx_hash"
+	MatchByFallback = "amount_account_time"
+)
+
+// ExternalPayment is a payment record from outside the platform, e.g. a
+// ledger export or an internal order system
+type ExternalPayment struct {
+	ID        string
+	TxHash    string
+	Account   string
+	AmountXRP Amount
+	Time      time.Time
+}
+
+// PaymentReconciliationOptions sets the tolerances of the fallback match,
+// used for records that cannot be matched by transaction hash
+type PaymentReconciliationOptions struct {
+	// TimeWindow is the largest gap between an investment's creation and an
+	// external payment; defaults to DefaultPaymentMatchWindow
+	TimeWindow time.Duration
+	
+	// AmountEpsilonDrops is the largest amount difference, in drops, still
+	// considered equal; defaults to an exact match
+	AmountEpsilonDrops int64
+}
+
+// PaymentMatch pairs a platform investment with an external payment
+type PaymentMatch struct {
+	Investment Investment
+	Payment    ExternalPayment
+	Method     string
+	
+	// AmountDiffDrops is the investment amount minus the payment amount.
+	// It can be non-zero for tx hash matches, which deserve a closer look.
+	AmountDiffDrops int64
+}
+
+// UnmatchedInvestment is a confirmed investment with no external counterpart
+type UnmatchedInvestment struct {
+	Investment Investment
+	Reason     string
+}
+
+// UnmatchedPayment is an external payment with no platform counterpart
+type UnmatchedPayment struct {
+	Payment ExternalPayment
+	Reason  string
+}
+
+// ReconciliationReport is the outcome of reconciling a project's confirmed
+// investments against external payment records
+type ReconciliationReport struct {
+	ProjectID    string
+	Matched      []PaymentMatch
+	PlatformOnly []UnmatchedInvestment
+	ExternalOnly []UnmatchedPayment
+}
+
+// Balanced reports whether every record on both sides was matched
+func (r *ReconciliationReport) Balanced() bool {
+	return len(r.PlatformOnly) == 0 && len(r.ExternalOnly) == 0
+}
+
+// WriteCSV writes one row per matched pair or unmatched record to w as CSV
+func (r *ReconciliationReport) WriteCSV(w io.Writer) error {
+	header := []string{"status", "investment_id", "payment_id", "tx_hash", "account", "platform_amount_xrp", "external_amount_xrp", "match_method", "reason"}
+	return writeCSV(w, header, func(write func([]string) error) error {
+		for _, m := range r.Matched {
+			reason := ""
+			if m.AmountDiffDrops != 0 {
+				reason = fmt.Sprintf("amounts differ by %d drops", m.AmountDiffDrops)
+			}
+			err := write([]string{"matched", m.Investment.ID, m.Payment.ID, m.Investment.TransactionHash, m.Investment.InvestorAccount, m.Investment.AmountXRP.String(), m.Payment.AmountXRP.String(), m.Method, reason})
+			if err != nil {
+				return err
+			}
+		}
+		for _, u := range r.PlatformOnly {
+			err := write([]string{"platform_only", u.Investment.ID, "", u.Investment.TransactionHash, u.Investment.InvestorAccount, u.Investment.AmountXRP.String(), "", "", u.Reason})
+			if err != nil {
+				return err
+			}
+		}
+		for _, u := range r.ExternalOnly {
+			err := write([]string{"external_only", "", u.Payment.ID, u.Payment.TxHash, u.Payment.Account, "", u.Payment.AmountXRP.String(), "", u.Reason})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReconcilePayments streams every confirmed investment of a project and
+// matches it against external payment records: by transaction hash first,
+// then by account, amount and time within the tolerances in opts. Each
+// external payment matches at most one investment. If the stream fails, the
+// report built so far is returned with the error.
+func (is *InvestmentsService) ReconcilePayments(ctx context.Context, projectID string, external []ExternalPayment, opts *PaymentReconciliationOptions, reqOpts ...RequestOption) (*ReconciliationReport, error) {
+	ctx, cancel := operationContext(ctx, reqOpts)
+	defer cancel()
+	
+	m, err := newPaymentMatcher(external, opts)
+	if err != nil {
+		return nil, err
+	}
+	report := &ReconciliationReport{ProjectID: projectID}
+	
+	stream, err := is.StreamByProject(ctx, projectID, &StreamOptions{Status: InvestmentConfirmed}, reqOpts...)
+	if err != nil {
+		return report, err
+	}
+	defer stream.Close()
+	
+	// Hash matches are made while streaming; everything else waits until
+	// every hash has been claimed, so a fallback match never takes a
+	// payment that belongs to a later investment's hash
+	var pending []Investment
+	for stream.Next() {
+		investment := stream.Value()
+		if match, ok := m.matchHash(investment); ok {
+			report.Matched = append(report.Matched, match)
+		} else {
+			pending = append(pending, investment)
+		}
+	}
+	streamErr := stream.Err()
+	
+	for _, investment := range pending {
+		if match, ok := m.matchFallback(investment); ok {
+			report.Matched = append(report.Matched, match)
+			continue
+		}
+		reason := "no external payment matched account, amount and time window"
+		if investment.TransactionHash != "" {
+			reason = "no external payment with transaction hash " + investment.TransactionHash + " and " + reason
+		}
+		report.PlatformOnly = append(report.PlatformOnly, UnmatchedInvestment{Investment: investment, Reason: reason})
+	}
+	
+	if streamErr != nil {
+		// Unmatched external payments may belong to investments not yet streamed
+		return report, streamErr
+	}
+	for i, payment := range m.payments {
+		if m.used[i] {
+			continue
+		}
+		reason := "no confirmed investment matched account, amount and time window"
+		if payment.TxHash != "" {
+			reason = "transaction hash not found among confirmed investments and " + reason
+		}
+		report.ExternalOnly = append(report.ExternalOnly, UnmatchedPayment{Payment: payment, Reason: reason})
+	}
+	return report, nil
+}
+
+// paymentMatcher indexes external payments for matching
+type paymentMatcher struct {
+	payments  []ExternalPayment
+	drops     []int64
+	used      []bool
+	byHash    map[string]int
+	byAccount map[string][]int
+	window    time.Duration
+	epsilon   int64
+}
+
+// newPaymentMatcher indexes payments, failing on amounts with fractional drops
+func newPaymentMatcher(payments []ExternalPayment, opts *PaymentReconciliationOptions) (*paymentMatcher, error) {
+	m := &paymentMatcher{
+		payments:  payments,
+		drops:     make([]int64, len(payments)),
+		used:      make([]bool, len(payments)),
+		byHash:    make(map[string]int),
+		byAccount: make(map[string][]int),
+		window:    DefaultPaymentMatchWindow,
+	}
+	if opts != nil {
+		if opts.TimeWindow > 0 {
+			m.window = opts.TimeWindow
+		}
+		m.epsilon = max(opts.AmountEpsilonDrops, 0)
+	}
+	
+	for i, payment := range payments {
+		drops, err := payment.AmountXRP.Drops()
+		if err != nil {
+			return nil, fmt.Errorf("external payment %d (%s): %w", i, payment.ID, err)
+		}
+		m.drops[i] = drops
+		if payment.TxHash != "" {
+			m.byHash[strings.ToUpper(payment.TxHash)] = i
+		}
+		m.byAccount[payment.Account] = append(m.byAccount[payment.Account], i)
+	}
+	return m, nil
+}
+
+// matchHash matches investment to the unused payment with its transaction hash
+func (m *paymentMatcher) matchHash(investment Investment) (PaymentMatch, bool) {
+	if investment.TransactionHash == "" {
+		return PaymentMatch{}, false
+	}
+	i, ok := m.byHash[strings.ToUpper(investment.TransactionHash)]
+	if !ok || m.used[i] {
+		return PaymentMatch{}, false
+	}
+	return m.claim(investment, i, MatchByTxHash), true
+}
+
+// matchFallback matches investment to the unused payment from the same
+// account within the amount and time tolerances, preferring the closest in time
+func (m *paymentMatcher) matchFallback(investment Investment) (PaymentMatch, bool) {
+	drops, err := investment.AmountXRP.Drops()
+	if err != nil {
+		return PaymentMatch{}, false
+	}
+	
+	best, bestGap := -1, time.Duration(0)
+	for _, i := range m.byAccount[investment.InvestorAccount] {
+		if m.used[i] || absInt64(drops-m.drops[i]) > m.epsilon {
+			continue
+		}
+		gap := investment.CreatedAt.Sub(m.payments[i].Time).Abs()
+		if gap > m.window {
+			continue
+		}
+		if best < 0 || gap < bestGap {
+			best, bestGap = i, gap
+		}
+	}
+	if best < 0 {
+		return PaymentMatch{}, false
+	}
+	return m.claim(investment, best, MatchByFallback), true
+}
+
+// claim marks payment i as matched to investment
+func (m *paymentMatcher) claim(investment Investment, i int, method string) PaymentMatch {
+	m.used[i] = true
+	match := PaymentMatch{Investment: investment, Payment: m.payments[i], Method: method}
+	if drops, err := investment.AmountXRP.Drops(); err == nil {
+		match.AmountDiffDrops = drops - m.drops[i]
+	}
+	return match
+}
+
+// absInt64 returns the absolute value of n
+func absInt64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
\ No newline at end of file
@@ -0,0 +1,203 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestReconcilePayments(t *testing.T) {
+	alice, bob := fixtures.Address(), fixtures.Address()
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	project := fixtures.Project()
+	investment := func(id, account string, amount int64, hash string, minute int, status string) xrplsale.Investment {
+		inv := fixtures.Investment(fixtures.ForProject(project), fixtures.WithInvestor(account), fixtures.WithAmountXRP(amount), fixtures.WithInvestmentStatus(status))
+		inv.ID, inv.TransactionHash = id, hash
+		inv.CreatedAt = xrplsale.Timestamp{Time: start.Add(time.Duration(minute) * time.Minute)}
+		return *inv
+	}
+	payment := func(id, account, amount, hash string, minute int) xrplsale.ExternalPayment {
+		return xrplsale.ExternalPayment{ID: id, TxHash: hash, Account: account, AmountXRP: xrplsale.MustParseAmount(amount), Time: start.Add(time.Duration(minute) * time.Minute)}
+	}
+	
+	tests := []struct {
+		name         string
+		investments  []xrplsale.Investment
+		external     []xrplsale.ExternalPayment
+		opts         *xrplsale.PaymentReconciliationOptions
+		wantMatched  []string
+		wantPlatform []string
+		wantExternal []string
+	}{
+		{
+			name:        "tx hash ignoring case",
+			investments: []xrplsale.Investment{investment("inv_1", alice, 100, "ABCD", 0, xrplsale.InvestmentConfirmed)},
+			external:    []xrplsale.ExternalPayment{payment("pay_1", bob, "99", "abcd", 60)},
+			wantMatched: []string{"inv_1=pay_1 tx_hash diff 1000000"},
+		},
+		{
+			name:        "fallback within the default window",
+			investments: []xrplsale.Investment{investment("inv_1", alice, 100, "", 0, xrplsale.InvestmentConfirmed)},
+			external:    []xrplsale.ExternalPayment{payment("pay_1", alice, "100", "", 9)},
+			wantMatched: []string{"inv_1=pay_1 amount_account_time diff 0"},
+		},
+		{
+			name:         "fallback outside the default window",
+			investments:  []xrplsale.Investment{investment("inv_1", alice, 100, "", 0, xrplsale.InvestmentConfirmed)},
+			external:     []xrplsale.ExternalPayment{payment("pay_1", alice, "100", "", 11)},
+			wantPlatform: []string{"inv_1"},
+			wantExternal: []string{"pay_1"},
+		},
+		{
+			name:        "configured window",
+			investments: []xrplsale.Investment{investment("inv_1", alice, 100, "", 0, xrplsale.InvestmentConfirmed)},
+			external:    []xrplsale.ExternalPayment{payment("pay_1", alice, "100", "", -30)},
+			opts:        &xrplsale.PaymentReconciliationOptions{TimeWindow: time.Hour},
+			wantMatched: []string{"inv_1=pay_1 amount_account_time diff 0"},
+		},
+		{
+			name:        "amount within epsilon",
+			investments: []xrplsale.Investment{investment("inv_1", alice, 100, "", 0, xrplsale.InvestmentConfirmed)},
+			external:    []xrplsale.ExternalPayment{payment("pay_1", alice, "99.99999", "", 0)},
+			opts:        &xrplsale.PaymentReconciliationOptions{AmountEpsilonDrops: 10},
+			wantMatched: []string{"inv_1=pay_1 amount_account_time diff 10"},
+		},
+		{
+			name:         "amount beyond epsilon",
+			investments:  []xrplsale.Investment{investment("inv_1", alice, 100, "", 0, xrplsale.InvestmentConfirmed)},
+			external:     []xrplsale.ExternalPayment{payment("pay_1", alice, "99.99998", "", 0)},
+			opts:         &xrplsale.PaymentReconciliationOptions{AmountEpsilonDrops: 10},
+			wantPlatform: []string{"inv_1"},
+			wantExternal: []string{"pay_1"},
+		},
+		{
+			name:         "other account",
+			investments:  []xrplsale.Investment{investment("inv_1", alice, 100, "", 0, xrplsale.InvestmentConfirmed)},
+			external:     []xrplsale.ExternalPayment{payment("pay_1", bob, "100", "", 0)},
+			wantPlatform: []string{"inv_1"},
+			wantExternal: []string{"pay_1"},
+		},
+		{
+			name:        "closest in time wins",
+			investments: []xrplsale.Investment{investment("inv_1", alice, 100, "", 0, xrplsale.InvestmentConfirmed)},
+			external: []xrplsale.ExternalPayment{
+				payment("pay_far", alice, "100", "", -8),
+				payment("pay_near", alice, "100", "", 2),
+			},
+			wantMatched:  []string{"inv_1=pay_near amount_account_time diff 0"},
+			wantExternal: []string{"pay_far"},
+		},
+		{
+			name: "hash claims before fallback",
+			investments: []xrplsale.Investment{
+				investment("inv_early", alice, 100, "", 0, xrplsale.InvestmentConfirmed),
+				investment("inv_late", alice, 100, "FEED", 1, xrplsale.InvestmentConfirmed),
+			},
+			external:     []xrplsale.ExternalPayment{payment("pay_1", alice, "100", "FEED", 0)},
+			wantMatched:  []string{"inv_late=pay_1 tx_hash diff 0"},
+			wantPlatform: []string{"inv_early"},
+		},
+		{
+			name: "unconfirmed investments are skipped",
+			investments: []xrplsale.Investment{
+				investment("inv_pending", alice, 100, "BEEF", 0, xrplsale.InvestmentPending),
+				investment("inv_1", bob, 50, "CAFE", 0, xrplsale.InvestmentConfirmed),
+			},
+			external: []xrplsale.ExternalPayment{
+				payment("pay_1", alice, "100", "BEEF", 0),
+				payment("pay_2", bob, "50", "CAFE", 0),
+			},
+			wantMatched:  []string{"inv_1=pay_2 tx_hash diff 0"},
+			wantExternal: []string{"pay_1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := xrplsaletest.NewServer()
+			defer srv.Close()
+			srv.AddProjects(project)
+			srv.AddInvestments(tt.investments...)
+			client := xrplsaletest.NewClient(srv)
+			
+			report, err := client.Investments.ReconcilePayments(context.Background(), project.ID, tt.external, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var matched, platform, external []string
+			for _, m := range report.Matched {
+				matched = append(matched, m.Investment.ID+"="+m.Payment.ID+" "+m.Method+" diff "+strconv.FormatInt(m.AmountDiffDrops, 10))
+			}
+			for _, u := range report.PlatformOnly {
+				if u.Reason == "" {
+					t.Errorf("%s has no reason", u.Investment.ID)
+				}
+				platform = append(platform, u.Investment.ID)
+			}
+			for _, u := range report.ExternalOnly {
+				if u.Reason == "" {
+					t.Errorf("%s has no reason", u.Payment.ID)
+				}
+				external = append(external, u.Payment.ID)
+			}
+			if !equalStrings(matched, tt.wantMatched) || !equalStrings(platform, tt.wantPlatform) || !equalStrings(external, tt.wantExternal) {
+				t.Fatalf("matched %q, platform only %q, external only %q\nwant %q, %q, %q", matched, platform, external, tt.wantMatched, tt.wantPlatform, tt.wantExternal)
+			}
+			if want := len(tt.wantPlatform) == 0 && len(tt.wantExternal) == 0; report.Balanced() != want {
+				t.Fatalf("Balanced() = %v, want %v", !want, want)
+			}
+		})
+	}
+}
+
+func TestReconcilePaymentsFractionalDrops(t *testing.T) {
+	srv := xrplsaletest.NewServer()
+	defer srv.Close()
+	client := xrplsaletest.NewClient(srv)
+	external := []xrplsale.ExternalPayment{{ID: "pay_1", AmountXRP: xrplsale.MustParseAmount("0.0000001")}}
+	if _, err := client.Investments.ReconcilePayments(context.Background(), "proj_1", external, nil); err == nil || !strings.Contains(err.Error(), "pay_1") {
+		t.Fatalf("ReconcilePayments() = %v, want an error naming pay_1", err)
+	}
+}
+
+func TestReconciliationReportCSV(t *testing.T) {
+	report := &xrplsale.ReconciliationReport{
+		Matched: []xrplsale.PaymentMatch{{
+			Investment:      xrplsale.Investment{ID: "inv_1", TransactionHash: "ABCD", InvestorAccount: "rAlice", AmountXRP: xrplsale.MustParseAmount("100")},
+			Payment:         xrplsale.ExternalPayment{ID: "pay_1", AmountXRP: xrplsale.MustParseAmount("99.5")},
+			Method:          xrplsale.MatchByTxHash,
+			AmountDiffDrops: 500000,
+		}},
+		PlatformOnly: []xrplsale.UnmatchedInvestment{{Investment: xrplsale.Investment{ID: "inv_2", AmountXRP: xrplsale.MustParseAmount("5")}, Reason: "missing"}},
+		ExternalOnly: []xrplsale.UnmatchedPayment{{Payment: xrplsale.ExternalPayment{ID: "pay_2", TxHash: "FEED", Account: "rBob", AmountXRP: xrplsale.MustParseAmount("7")}, Reason: "unknown"}},
+	}
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"status", "investment_id", "payment_id", "tx_hash", "account", "platform_amount_xrp", "external_amount_xrp", "match_method", "reason"},
+		{"matched", "inv_1", "pay_1", "ABCD", "rAlice", "100", "99.5", "tx_hash", "amounts differ by 500000 drops"},
+		{"platform_only", "inv_2", "", "", "", "5", "", "", "missing"},
+		{"external_only", "", "pay_2", "FEED", "rBob", "", "7", "", "unknown"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("%d rows, want %d: %q", len(rows), len(want), rows)
+	}
+	for i := range want {
+		if !equalStrings(rows[i], want[i]) {
+			t.Errorf("row %d = %q, want %q", i, rows[i], want[i])
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,42 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPingTimeout bounds Ping, independently of Config.Timeout
+const DefaultPingTimeout = 5 * time.Second
+
+// PingResult describes the API as reported by its health endpoint
+type PingResult struct {
+	Status      string    `json:"status"`
+	APIVersion  string    `json:"api_version"`
+	ServerTime  Timestamp `json:"server_time"`
+	Environment string    `json:"environment"`
+	
+	// Latency is the round trip time of the health check
+	Latency time.Duration `json:"-"`
+}
+
+// Ping checks that the API is reachable, e.g. for a readiness probe. The
+// health endpoint needs no auth token. Ping is never retried or served from
+// a cache, and gives up after DefaultPingTimeout unless reqOpts set another
+// timeout. A rejected API key is reported as an error matching ErrUnauthorized.
+func (c *Client) Ping(ctx context.Context, reqOpts ...RequestOption) (*PingResult, error) {
+	opts := append([]RequestOption{WithRequestTimeout(DefaultPingTimeout), WithNoRetry(), WithCache(-1)}, reqOpts...)
+	
+	start := time.Now()
+	var result PingResult
+	err := c.Get(ctx, "/health", nil, &result, opts...)
+	result.Latency = time.Since(start)
+	if errors.Is(err, ErrUnauthorized) {
+		return nil, fmt.Errorf("API key rejected, check your credentials: %w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
\ No newline at end of file
@@ -0,0 +1,79 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		delay    time.Duration
+		opts     []xrplsale.RequestOption
+		wantErr  error
+		wantText string
+	}{
+		{"healthy", http.StatusOK, `{"status":"ok","api_version":"1.0","server_time":"2024-03-01T12:00:00Z","environment":"testnet"}`, 0, nil, nil, ""},
+		{"bad key", http.StatusUnauthorized, `{"message":"invalid api key"}`, 0, nil, xrplsale.ErrUnauthorized, "check your credentials"},
+		{"down", http.StatusServiceUnavailable, `{"message":"maintenance"}`, 0, nil, nil, "maintenance"},
+		{"slow", http.StatusOK, `{}`, 500 * time.Millisecond, []xrplsale.RequestOption{xrplsale.WithRequestTimeout(50 * time.Millisecond)}, context.DeadlineExceeded, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				if r.URL.Path != "/health" || r.Header.Get("X-API-Key") != "key" || r.Header.Get("Authorization") != "" {
+					t.Errorf("Ping sent %s with key %q and auth %q", r.URL.Path, r.Header.Get("X-API-Key"), r.Header.Get("Authorization"))
+				}
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			// A long client timeout and a TTL cache must not apply to Ping
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, Timeout: time.Minute, CacheTTL: time.Minute})
+			
+			for i := 0; i < 2; i++ {
+				result, err := client.Ping(context.Background(), tt.opts...)
+				if tt.wantErr == nil && tt.wantText == "" {
+					if err != nil {
+						t.Fatal(err)
+					}
+					if result.Status != "ok" || result.APIVersion != "1.0" || result.Environment != "testnet" || result.ServerTime.Unix() != 1709294400 || result.Latency <= 0 {
+						t.Fatalf("Ping() = %+v", result)
+					}
+					continue
+				}
+				if result != nil || err == nil {
+					t.Fatalf("Ping() = %+v, %v; want an error", result, err)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Ping() = %v, want %v", err, tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantText) {
+					t.Fatalf("Ping() = %v, want it to mention %q", err, tt.wantText)
+				}
+			}
+			// Never retried, never cached
+			if got := hits.Load(); got != 2 {
+				t.Fatalf("%d requests for two pings, want 2", got)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,78 @@
+package xrplsale
+
+import "context"
+
+// Ptr returns a pointer to v, for the optional fields of update requests
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// UpdateProfileRequest changes the current user's profile. Only non-nil
+// fields are sent; a pointer to "" clears a field.
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"display_name,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	
+	// KYCContact updates the contact details used for KYC reviews
+	KYCContact *KYCContactUpdate `json:"kyc_contact,omitempty"`
+}
+
+// KYCContactUpdate changes the KYC contact details. Only non-nil fields are
+// sent.
+type KYCContactUpdate struct {
+	FullName *string `json:"full_name,omitempty"`
+	Email    *string `json:"email,omitempty"`
+	Phone    *string `json:"phone,omitempty"`
+	
+	// Country is an ISO 3166-1 alpha-2 code
+	Country *string `json:"country,omitempty"`
+}
+
+// NotificationPreferences are the notifications the current user receives
+type NotificationPreferences struct {
+	// Channels
+	Email bool `json:"email"`
+	Push  bool `json:"push"`
+	
+	// Topics
+	InvestmentUpdates    bool `json:"investment_updates"`
+	ProjectAnnouncements bool `json:"project_announcements"`
+	SecurityAlerts       bool `json:"security_alerts"`
+	Marketing            bool `json:"marketing"`
+}
+
+// NotificationPreferencesUpdate changes notification preferences. Only
+// non-nil fields are sent.
+type NotificationPreferencesUpdate struct {
+	Email                *bool `json:"email,omitempty"`
+	Push                 *bool `json:"push,omitempty"`
+	InvestmentUpdates    *bool `json:"investment_updates,omitempty"`
+	ProjectAnnouncements *bool `json:"project_announcements,omitempty"`
+	SecurityAlerts       *bool `json:"security_alerts,omitempty"`
+	Marketing            *bool `json:"marketing,omitempty"`
+}
+
+// UpdateProfile changes the current user's profile and returns it updated.
+// Rejected values are reported as a *ValidationError with a FieldError per
+// field.
+func (as *AuthService) UpdateProfile(ctx context.Context, update *UpdateProfileRequest, reqOpts ...RequestOption) (*UserProfile, error) {
+	var profile UserProfile
+	err := as.client.Patch(ctx, "/auth/profile", update, &profile, reqOpts...)
+	return &profile, err
+}
+
+// GetNotificationPreferences retrieves the current user's notification
+// preferences
+func (as *AuthService) GetNotificationPreferences(ctx context.Context, reqOpts ...RequestOption) (*NotificationPreferences, error) {
+	var prefs NotificationPreferences
+	err := as.client.Get(ctx, "/auth/profile/notifications", nil, &prefs, reqOpts...)
+	return &prefs, err
+}
+
+// UpdateNotificationPreferences changes the current user's notification
+// preferences and returns them updated
+func (as *AuthService) UpdateNotificationPreferences(ctx context.Context, update *NotificationPreferencesUpdate, reqOpts ...RequestOption) (*NotificationPreferences, error) {
+	var prefs NotificationPreferences
+	err := as.client.Patch(ctx, "/auth/profile/notifications", update, &prefs, reqOpts...)
+	return &prefs, err
+}
\ No newline at end of file
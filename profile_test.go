@@ -0,0 +1,81 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestUpdateProfileSendsOnlySetFields(t *testing.T) {
+	srv, last := captureServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	updateProfile := func(update *xrplsale.UpdateProfileRequest) func() error {
+		return func() error {
+			_, err := client.Auth.UpdateProfile(context.Background(), update)
+			return err
+		}
+	}
+	updateNotifications := func(update *xrplsale.NotificationPreferencesUpdate) func() error {
+		return func() error {
+			_, err := client.Auth.UpdateNotificationPreferences(context.Background(), update)
+			return err
+		}
+	}
+	tests := []struct {
+		name     string
+		call     func() error
+		wantBody string
+	}{
+		{"display name", updateProfile(&xrplsale.UpdateProfileRequest{DisplayName: xrplsale.Ptr("Ann")}), `{"display_name":"Ann"}`},
+		{"cleared email", updateProfile(&xrplsale.UpdateProfileRequest{Email: xrplsale.Ptr("")}), `{"email":""}`},
+		{"KYC phone", updateProfile(&xrplsale.UpdateProfileRequest{KYCContact: &xrplsale.KYCContactUpdate{Phone: xrplsale.Ptr("+15550100")}}), `{"kyc_contact":{"phone":"+15550100"}}`},
+		{"nothing", updateProfile(&xrplsale.UpdateProfileRequest{}), `{}`},
+		{"marketing off", updateNotifications(&xrplsale.NotificationPreferencesUpdate{Marketing: xrplsale.Ptr(false)}), `{"marketing":false}`},
+		{"push on", updateNotifications(&xrplsale.NotificationPreferencesUpdate{Push: xrplsale.Ptr(true)}), `{"push":true}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatal(err)
+			}
+			if last.method != http.MethodPatch || last.body != tt.wantBody {
+				t.Errorf("sent %s %s, want PATCH %s", last.method, last.body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestUpdateProfileValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"list", `{"message":"invalid profile","errors":[{"field":"email","message":"is not an email address"}]}`},
+		{"by field", `{"message":"invalid profile","errors":{"email":["is not an email address"]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			_, err := client.Auth.UpdateProfile(context.Background(), &xrplsale.UpdateProfileRequest{Email: xrplsale.Ptr("not-an-email")})
+			var validationErr *xrplsale.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("UpdateProfile() = %v, want a ValidationError", err)
+			}
+			want := xrplsale.FieldError{Field: "email", Message: "is not an email address"}
+			if len(validationErr.Fields) != 1 || validationErr.Fields[0] != want {
+				t.Errorf("field errors %+v, want %+v", validationErr.Fields, want)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,202 @@
+package xrplsale
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// projectIDPlaceholder stands in for the project ID in planned endpoints
+// until the project has been created
+const projectIDPlaceholder = "{project_id}"
+
+// MilestoneSpec describes a project milestone to create
+type MilestoneSpec struct {
+	Title          string    `json:"title"`
+	Description    string    `json:"description,omitempty"`
+	TargetDate     time.Time `json:"target_date"`
+	ReleasePercent float64   `json:"release_percent,omitempty"`
+}
+
+// RestrictionsSpec describes who may invest in a project
+type RestrictionsSpec struct {
+	BlockedCountries []string `json:"blocked_countries,omitempty"`
+	RequireKYC       bool     `json:"require_kyc"`
+	MinInvestmentXRP string   `json:"min_investment_xrp,omitempty"`
+	MaxInvestmentXRP string   `json:"max_investment_xrp,omitempty"`
+	Whitelist        []string `json:"whitelist,omitempty"`
+}
+
+// ProjectSpec declaratively describes a complete project for Provision
+type ProjectSpec struct {
+	Project      CreateProjectRequest
+	Tiers        []Tier
+	Milestones   []MilestoneSpec
+	Restrictions *RestrictionsSpec
+	SocialLinks  map[string]string
+	
+	// DryRun plans the calls without making any of them
+	DryRun bool
+	
+	// Resume continues a previous, partially failed run. Steps it completed are skipped.
+	Resume *ProvisionResult
+}
+
+// validate rejects a spec listing a tier twice: steps are resumed by name,
+// and a second tier:N step would be skipped as already completed
+func (spec *ProjectSpec) validate() error {
+	seen := make(map[int]bool, len(spec.Tiers))
+	for _, tier := range spec.Tiers {
+		if seen[tier.Tier] {
+			return fmt.Errorf("%w: tier %d is listed more than once", ErrValidation, tier.Tier)
+		}
+		seen[tier.Tier] = true
+	}
+	return nil
+}
+
+// ProvisionStep is a single API call made by Provision
+type ProvisionStep struct {
+	Name     string
+	Method   string
+	Endpoint string
+	Body     interface{}
+}
+
+// ProvisionResult records the progress of a Provision run
+type ProvisionResult struct {
+	ProjectID string
+	Project   *Project
+	DryRun    bool
+	
+	// Planned lists every step in dependency order
+	Planned []ProvisionStep
+	
+	// Completed holds the names of the steps that succeeded, including those of a resumed run
+	Completed []string
+	
+	// Failed is set when a step failed; pass the result as ProjectSpec.Resume to retry
+	Failed *ProvisionError
+}
+
+// completed reports whether the named step has already succeeded
+func (r *ProvisionResult) completed(name string) bool {
+	for _, done := range r.Completed {
+		if done == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ProvisionError identifies the step that stopped a Provision run and why
+type ProvisionError struct {
+	Step string
+	Err  error
+}
+
+// Error implements the error interface
+func (e *ProvisionError) Error() string {
+	return fmt.Sprintf("provision step %s failed: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the error of the failed step
+func (e *ProvisionError) Unwrap() error { return e.Err }
+
+// Provision creates a project and its tiers, milestones, restrictions and
+// social links in dependency order. On failure the returned result names the
+// failed step and can be passed back as spec.Resume to skip completed steps.
+// This includes a step interrupted by WithOperationTimeout. A spec listing
+// a tier number twice is rejected with ErrValidation before any call.
+func (ps *ProjectsService) Provision(ctx context.Context, spec *ProjectSpec, reqOpts ...RequestOption) (*ProvisionResult, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := operationContext(ctx, reqOpts)
+	defer cancel()
+	
+	result := &ProvisionResult{DryRun: spec.DryRun, Planned: planProvision(spec)}
+	if spec.Resume != nil {
+		result.ProjectID = spec.Resume.ProjectID
+		result.Project = spec.Resume.Project
+		result.Completed = append(result.Completed, spec.Resume.Completed...)
+	}
+	if spec.DryRun {
+		return result, nil
+	}
+	
+	for _, step := range result.Planned {
+		if result.completed(step.Name) {
+			continue
+		}
+		
+		endpoint := strings.ReplaceAll(step.Endpoint, projectIDPlaceholder, result.ProjectID)
+		var err error
+		if step.Name == "create_project" {
+			var project Project
+			err = ps.client.Request(ctx, step.Method, endpoint, step.Body, &project, reqOpts...)
+			if err == nil {
+				result.Project = &project
+				result.ProjectID = project.ID
+			}
+		} else {
+			err = ps.client.Request(ctx, step.Method, endpoint, step.Body, nil, reqOpts...)
+		}
+		
+		if err != nil {
+			result.Failed = &ProvisionError{Step: step.Name, Err: err}
+			return result, result.Failed
+		}
+		result.Completed = append(result.Completed, step.Name)
+	}
+	
+	return result, nil
+}
+
+// planProvision lists the calls needed to provision spec, in dependency order
+func planProvision(spec *ProjectSpec) []ProvisionStep {
+	base := "/projects/" + projectIDPlaceholder
+	steps := []ProvisionStep{{
+		Name:     "create_project",
+		Method:   http.MethodPost,
+		Endpoint: "/projects",
+		Body:     &spec.Project,
+	}}
+	
+	for i := range spec.Tiers {
+		steps = append(steps, ProvisionStep{
+			Name:     fmt.Sprintf("tier:%d", spec.Tiers[i].Tier),
+			Method:   http.MethodPost,
+			Endpoint: base + "/tiers",
+			Body:     &spec.Tiers[i],
+		})
+	}
+	for i := range spec.Milestones {
+		steps = append(steps, ProvisionStep{
+			Name:     fmt.Sprintf("milestone:%d", i),
+			Method:   http.MethodPost,
+			Endpoint: base + "/milestones",
+			Body:     &spec.Milestones[i],
+		})
+	}
+	if spec.Restrictions != nil {
+		steps = append(steps, ProvisionStep{
+			Name:     "restrictions",
+			Method:   http.MethodPut,
+			Endpoint: base + "/restrictions",
+			Body:     spec.Restrictions,
+		})
+	}
+	if len(spec.SocialLinks) > 0 {
+		steps = append(steps, ProvisionStep{
+			Name:     "social_links",
+			Method:   http.MethodPut,
+			Endpoint: base + "/social-links",
+			Body:     spec.SocialLinks,
+		})
+	}
+	
+	return steps
+}
\ No newline at end of file
@@ -0,0 +1,182 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// provisionServer accepts every provisioning call, recording them, and
+// fails the first call to failCall with a 422
+type provisionServer struct {
+	mu       sync.Mutex
+	calls    []string
+	failCall string
+}
+
+func (s *provisionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	call := r.Method + " " + r.URL.Path
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	fail := call == s.failCall
+	if fail {
+		s.failCall = ""
+	}
+	s.mu.Unlock()
+	
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case fail:
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error":{"code":"VALIDATION_ERROR","message":"rejected"}}`))
+	case call == "POST /projects":
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"proj_1","name":"Provisioned"}`))
+	default:
+		w.Write([]byte(`{}`))
+	}
+}
+
+func (s *provisionServer) sent() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func provisionSpec() *xrplsale.ProjectSpec {
+	return &xrplsale.ProjectSpec{
+		Tiers: []xrplsale.Tier{
+			{Tier: 1, PricePerToken: xrplsale.MustParseAmount("0.01"), TotalTokens: xrplsale.MustParseAmount("1000")},
+			{Tier: 2, PricePerToken: xrplsale.MustParseAmount("0.02"), TotalTokens: xrplsale.MustParseAmount("1000")},
+		},
+		Milestones: []xrplsale.MilestoneSpec{
+			{Title: "Testnet", TargetDate: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{Title: "Mainnet", TargetDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Restrictions: &xrplsale.RestrictionsSpec{RequireKYC: true},
+		SocialLinks:  map[string]string{"x": "https://x.com/example"},
+	}
+}
+
+func TestProvision(t *testing.T) {
+	allCalls := []string{
+		"POST /projects",
+		"POST /projects/proj_1/tiers",
+		"POST /projects/proj_1/tiers",
+		"POST /projects/proj_1/milestones",
+		"POST /projects/proj_1/milestones",
+		"PUT /projects/proj_1/restrictions",
+		"PUT /projects/proj_1/social-links",
+	}
+	allSteps := []string{"create_project", "tier:1", "tier:2", "milestone:0", "milestone:1", "restrictions", "social_links"}
+	
+	tests := []struct {
+		name          string
+		dryRun        bool
+		failCall      string
+		wantCalls     []string
+		wantCompleted []string
+		wantFailed    string
+	}{
+		{"dry run", true, "", nil, nil, ""},
+		{"all steps", false, "", allCalls, allSteps, ""},
+		{"restrictions fail", false, "PUT /projects/proj_1/restrictions", allCalls[:6], allSteps[:5], "restrictions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &provisionServer{failCall: tt.failCall}
+			srv := httptest.NewServer(api)
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			spec := provisionSpec()
+			spec.DryRun = tt.dryRun
+			
+			result, err := client.Projects.Provision(context.Background(), spec)
+			if tt.wantFailed == "" && err != nil {
+				t.Fatalf("Provision() = %v", err)
+			}
+			if tt.wantFailed != "" {
+				var provErr *xrplsale.ProvisionError
+				if !errors.As(err, &provErr) || provErr.Step != tt.wantFailed || result.Failed != provErr {
+					t.Fatalf("Provision() = %v, want step %s to fail", err, tt.wantFailed)
+				}
+				if !errors.Is(err, xrplsale.ErrValidation) {
+					t.Errorf("Provision() = %v, want the step's error wrapped", err)
+				}
+			}
+			if got := api.sent(); !equalStrings(got, tt.wantCalls) {
+				t.Errorf("calls = %q, want %q", got, tt.wantCalls)
+			}
+			if !equalStrings(result.Completed, tt.wantCompleted) {
+				t.Errorf("Completed = %q, want %q", result.Completed, tt.wantCompleted)
+			}
+			var planned []string
+			for _, step := range result.Planned {
+				planned = append(planned, step.Name)
+			}
+			if !equalStrings(planned, allSteps) {
+				t.Errorf("Planned = %q, want %q", planned, allSteps)
+			}
+		})
+	}
+}
+
+func TestProvisionResume(t *testing.T) {
+	api := &provisionServer{failCall: "POST /projects/proj_1/milestones"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	spec := provisionSpec()
+	first, err := client.Projects.Provision(context.Background(), spec)
+	if err == nil || first.Failed == nil || first.Failed.Step != "milestone:0" {
+		t.Fatalf("Provision() = %v, want milestone:0 to fail", err)
+	}
+	
+	spec.Resume = first
+	second, err := client.Projects.Provision(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("resumed Provision() = %v", err)
+	}
+	if second.ProjectID != "proj_1" || len(second.Completed) != len(second.Planned) {
+		t.Fatalf("resumed run = %+v, want every step of proj_1 completed", second)
+	}
+	want := []string{
+		"POST /projects",
+		"POST /projects/proj_1/tiers",
+		"POST /projects/proj_1/tiers",
+		"POST /projects/proj_1/milestones",
+		"POST /projects/proj_1/milestones",
+		"POST /projects/proj_1/milestones",
+		"PUT /projects/proj_1/restrictions",
+		"PUT /projects/proj_1/social-links",
+	}
+	if got := api.sent(); !equalStrings(got, want) {
+		t.Fatalf("calls = %q, want the failed milestone retried and nothing repeated: %q", got, want)
+	}
+}
+
+func TestProvisionDuplicateTier(t *testing.T) {
+	api := &provisionServer{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	spec := provisionSpec()
+	spec.Tiers[1].Tier = spec.Tiers[0].Tier
+	for _, dryRun := range []bool{true, false} {
+		spec.DryRun = dryRun
+		if _, err := client.Projects.Provision(context.Background(), spec); !errors.Is(err, xrplsale.ErrValidation) {
+			t.Fatalf("Provision(dry run %t) = %v, want ErrValidation", dryRun, err)
+		}
+	}
+	if calls := api.sent(); len(calls) != 0 {
+		t.Fatalf("calls = %q, want none", calls)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,25 @@
+package xrplsale
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Search lists the projects matching a full-text query over their name,
+// token symbol and description, most relevant first unless opts.SortBy
+// says otherwise. Project.Score holds each result's relevance when the API
+// provides it. The other filters and paging of opts apply; its Search is
+// replaced by query. An empty query fails with an error matching
+// ErrValidation before any request is sent.
+func (ps *ProjectsService) Search(ctx context.Context, query string, opts *ListProjectsOptions, reqOpts ...RequestOption) (*PaginatedResponse[Project], error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("%w: empty search query", ErrValidation)
+	}
+	searchOpts := ListProjectsOptions{}
+	if opts != nil {
+		searchOpts = *opts
+	}
+	searchOpts.Search = query
+	return ps.List(ctx, &searchOpts, reqOpts...)
+}
\ No newline at end of file
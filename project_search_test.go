@@ -0,0 +1,87 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestSearchProjects(t *testing.T) {
+	projects := []*xrplsale.Project{
+		{ID: "proj_name", Name: "Sun & Wind+", TokenSymbol: "SUN", Status: xrplsale.StatusActive},
+		{ID: "proj_description", Name: "Grid", TokenSymbol: "GRD", Description: "sun powered", Status: xrplsale.StatusActive},
+		{ID: "proj_symbol", Name: "Other", TokenSymbol: "SUNNY", Status: xrplsale.StatusDraft},
+	}
+	tests := []struct {
+		name       string
+		query      string
+		opts       *xrplsale.ListProjectsOptions
+		wantIDs    []string
+		wantScores []float64
+		wantErr    error
+	}{
+		{"relevance order", "sun", nil, []string{"proj_name", "proj_symbol", "proj_description"}, []float64{5, 2, 1}, nil},
+		{"ampersand", "& wind", nil, []string{"proj_name"}, []float64{3}, nil},
+		{"plus", "Wind+", nil, []string{"proj_name"}, []float64{3}, nil},
+		{"other filters apply", "sun", &xrplsale.ListProjectsOptions{Status: xrplsale.StatusDraft, Search: "ignored"}, []string{"proj_symbol"}, []float64{2}, nil},
+		{"no match", "moon", nil, nil, nil, nil},
+		{"empty query", " ", nil, nil, nil, xrplsale.ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t)
+			srv.AddProjects(projects...)
+			
+			result, err := client.Projects.Search(context.Background(), tt.query, tt.opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || hits.total() != 0 {
+					t.Fatalf("Search(%q) = %v after %d requests, want %v before any", tt.query, err, hits.total(), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Data) != len(tt.wantIDs) {
+				t.Fatalf("Search(%q) = %d results, want %v", tt.query, len(result.Data), tt.wantIDs)
+			}
+			for i, project := range result.Data {
+				if project.ID != tt.wantIDs[i] || project.Score != tt.wantScores[i] {
+					t.Errorf("result %d = %s scored %v, want %s scored %v", i, project.ID, project.Score, tt.wantIDs[i], tt.wantScores[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSearchProjectsQueryEncoding(t *testing.T) {
+	srv, lastQuery := queryServer(t, `{"data":[],"pagination":{}}`)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"ampersand and plus", "R&D + solar"},
+		{"equals and hash", "a=b #1"},
+		{"percent", "100% green"},
+		{"unicode", "énergie ☀"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &xrplsale.ListProjectsOptions{Page: 2}
+			if _, err := client.Projects.Search(context.Background(), tt.query, opts); err != nil {
+				t.Fatal(err)
+			}
+			query, err := url.ParseQuery(lastQuery())
+			if err != nil || query.Get("q") != tt.query || query.Get("page") != "2" {
+				t.Errorf("server read q=%q page=%q from %q, want q=%q page=2", query.Get("q"), query.Get("page"), lastQuery(), tt.query)
+			}
+			if opts.Search != "" {
+				t.Errorf("Search() changed the caller's options to %+v", opts)
+			}
+		})
+	}
+}
\ No newline at end of file
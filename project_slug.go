@@ -0,0 +1,40 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrAmbiguousSlug is returned by GetBySlug when the API lists more than
+// one project for a slug
+var ErrAmbiguousSlug = errors.New("slug matches more than one project")
+
+// GetBySlug retrieves the project with the given slug, e.g. from a link on
+// a marketing site. Slugs may contain any Unicode; they are sent
+// percent-encoded. It fails with a *NotFoundError when no project has the
+// slug, and with an error matching ErrAmbiguousSlug if the API lists
+// several.
+func (ps *ProjectsService) GetBySlug(ctx context.Context, slug string, reqOpts ...RequestOption) (*Project, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("%w: empty project slug", ErrValidation)
+	}
+	// Two results are enough to tell a unique slug from an ambiguous one
+	params := map[string]string{"slug": slug, "limit": "2"}
+	var result PaginatedResponse[Project]
+	if err := ps.client.Get(ctx, "/projects", params, &result, reqOpts...); err != nil {
+		return nil, err
+	}
+	switch {
+	case len(result.Data) == 0:
+		return nil, &NotFoundError{APIError: &APIError{
+			Message:    fmt.Sprintf("no project with slug %q", slug),
+			Code:       "not_found",
+			StatusCode: http.StatusNotFound,
+		}}
+	case len(result.Data) > 1 || result.Pagination.Total > 1:
+		return nil, fmt.Errorf("%w: %q", ErrAmbiguousSlug, slug)
+	}
+	return &result.Data[0], nil
+}
\ No newline at end of file
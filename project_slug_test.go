@@ -0,0 +1,89 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"unicode"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// queryServer records the last request's raw query and answers every
+// request with body
+func queryServer(t *testing.T, body string) (*httptest.Server, func() string) {
+	t.Helper()
+	var mu sync.Mutex
+	last := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		last = r.URL.RawQuery
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return last
+	}
+}
+
+func TestGetBySlug(t *testing.T) {
+	const one = `{"data":[{"id":"proj_1","slug":"s"}],"pagination":{"total":1}}`
+	tests := []struct {
+		name    string
+		slug    string
+		body    string
+		wantErr error
+	}{
+		{"ascii", "xrp-launch", one, nil},
+		{"unicode", "café-🚀", one, nil},
+		{"reserved characters", "a&b=c/d?e#f+g h%", one, nil},
+		{"not found", "missing", `{"data":[],"pagination":{"total":0}}`, xrplsale.ErrNotFound},
+		{"two results", "dup", `{"data":[{"id":"proj_1"},{"id":"proj_2"}],"pagination":{"total":2}}`, xrplsale.ErrAmbiguousSlug},
+		{"more by total", "dup", `{"data":[{"id":"proj_1"}],"pagination":{"total":3}}`, xrplsale.ErrAmbiguousSlug},
+		{"empty slug", "", one, xrplsale.ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, lastQuery := queryServer(t, tt.body)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			project, err := client.Projects.GetBySlug(context.Background(), tt.slug)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetBySlug(%q) = %v, want %v", tt.slug, err, tt.wantErr)
+				}
+			} else if err != nil || project.ID != "proj_1" {
+				t.Fatalf("GetBySlug(%q) = %+v, %v", tt.slug, project, err)
+			}
+			var notFound *xrplsale.NotFoundError
+			if errors.Is(tt.wantErr, xrplsale.ErrNotFound) && (!errors.As(err, &notFound) || notFound.StatusCode != http.StatusNotFound) {
+				t.Errorf("GetBySlug(%q) = %#v, want a *NotFoundError", tt.slug, err)
+			}
+			
+			if tt.slug == "" {
+				if lastQuery() != "" {
+					t.Errorf("empty slug sent %q", lastQuery())
+				}
+				return
+			}
+			for _, r := range lastQuery() {
+				if r > unicode.MaxASCII {
+					t.Fatalf("query %q is not percent-encoded", lastQuery())
+				}
+			}
+			// The server decodes exactly the slug sent
+			query, err := url.ParseQuery(lastQuery())
+			if err != nil || query.Get("slug") != tt.slug {
+				t.Errorf("server read slug %q from %q, want %q", query.Get("slug"), lastQuery(), tt.slug)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,45 @@
+package xrplsale
+
+import "fmt"
+
+// ProjectStatus is the lifecycle stage of a project. The API may add
+// statuses; a Project decodes them as-is, but list filters only accept the
+// ones known to this SDK version.
+type ProjectStatus string
+
+// Project statuses
+const (
+	StatusDraft         ProjectStatus = "draft"
+	StatusPendingReview ProjectStatus = "pending_review"
+	StatusUpcoming      ProjectStatus = "upcoming"
+	StatusActive        ProjectStatus = "active"
+	StatusCompleted     ProjectStatus = "completed"
+	StatusCancelled     ProjectStatus = "cancelled"
+	StatusPaused        ProjectStatus = "paused"
+)
+
+// knownProjectStatuses lists the statuses Validate accepts
+var knownProjectStatuses = map[ProjectStatus]bool{
+	StatusDraft:         true,
+	StatusPendingReview: true,
+	StatusUpcoming:      true,
+	StatusActive:        true,
+	StatusCompleted:     true,
+	StatusCancelled:     true,
+	StatusPaused:        true,
+}
+
+// Known reports whether s is one of the statuses defined by this SDK version
+func (s ProjectStatus) Known() bool {
+	return knownProjectStatuses[s]
+}
+
+// Validate returns an error matching ErrValidation if s is unknown. The
+// API ignores a filter it does not recognise, so a misspelt status would
+// otherwise list every project.
+func (s ProjectStatus) Validate() error {
+	if !s.Known() {
+		return fmt.Errorf("%w: unknown project status %q", ErrValidation, string(s))
+	}
+	return nil
+}
\ No newline at end of file
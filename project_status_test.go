@@ -0,0 +1,105 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+)
+
+func TestProjectStatusValidate(t *testing.T) {
+	tests := []struct {
+		status xrplsale.ProjectStatus
+		known  bool
+	}{
+		{xrplsale.StatusDraft, true},
+		{xrplsale.StatusPendingReview, true},
+		{xrplsale.StatusUpcoming, true},
+		{xrplsale.StatusActive, true},
+		{xrplsale.StatusCompleted, true},
+		{xrplsale.StatusCancelled, true},
+		{xrplsale.StatusPaused, true},
+		{"actve", false},
+		{"Active", false},
+		{"", false},
+	}
+	
+	for _, tt := range tests {
+		if got := tt.status.Known(); got != tt.known {
+			t.Errorf("%q.Known() = %v, want %v", tt.status, got, tt.known)
+		}
+		err := tt.status.Validate()
+		if tt.known && err != nil {
+			t.Errorf("%q.Validate() = %v, want nil", tt.status, err)
+		}
+		if !tt.known && !errors.Is(err, xrplsale.ErrValidation) {
+			t.Errorf("%q.Validate() = %v, want ErrValidation", tt.status, err)
+		}
+	}
+}
+
+func TestUnknownStatusFilter(t *testing.T) {
+	ctx := context.Background()
+	opts := &xrplsale.ListProjectsOptions{Status: "actve"}
+	
+	tests := []struct {
+		name string
+		call func(*xrplsale.Client) error
+	}{
+		{"List", func(client *xrplsale.Client) error {
+			_, err := client.Projects.List(ctx, opts)
+			return err
+		}},
+		{"ForEach", func(client *xrplsale.Client) error {
+			return client.Projects.ForEach(ctx, opts, func(xrplsale.Project) error {
+				t.Error("ForEach called fn for an invalid filter")
+				return nil
+			})
+		}},
+		{"ListAll", func(client *xrplsale.Client) error {
+			_, err := client.Projects.ListAll(ctx, opts)
+			return err
+		}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t)
+			srv.AddProjects(fixtures.Project(fixtures.WithProjectStatus(xrplsale.StatusActive)))
+			
+			if err := tt.call(client); !errors.Is(err, xrplsale.ErrValidation) {
+				t.Fatalf("err = %v, want ErrValidation", err)
+			}
+			if n := hits.total(); n != 0 {
+				t.Errorf("sent %d requests, want none", n)
+			}
+		})
+	}
+}
+
+func TestUnknownStatusDecodes(t *testing.T) {
+	srv, client, _ := newCountingClient(t)
+	project := fixtures.Project(fixtures.WithProjectStatus("archived"))
+	srv.AddProjects(project)
+	
+	got, err := client.Projects.Get(context.Background(), project.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != "archived" {
+		t.Errorf("Status = %q, want %q", got.Status, "archived")
+	}
+	if got.Status.Known() {
+		t.Error("Known() = true for a status this SDK does not define")
+	}
+	
+	page, err := client.Projects.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(page.Data) != 1 || page.Data[0].Status != "archived" {
+		t.Errorf("List decoded %+v, want one archived project", page.Data)
+	}
+}
\ No newline at end of file
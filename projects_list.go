@@ -0,0 +1,55 @@
+package xrplsale
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListAll retrieves every project matching opts, fetching page after page.
+// opts.Limit is the page size (default DefaultIteratePageSize) and
+// opts.Page is ignored. A project that moves to a later page while the
+// listing is walked is returned once. The walk stops at the first short
+// page even if the API reports more, and fails if a page only repeats
+// projects already seen, so a misbehaving API cannot make it loop forever.
+func (ps *ProjectsService) ListAll(ctx context.Context, opts *ListProjectsOptions, reqOpts ...RequestOption) ([]Project, error) {
+	pageOpts := ListProjectsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	if pageOpts.Limit <= 0 {
+		pageOpts.Limit = DefaultIteratePageSize
+	}
+	
+	var projects []Project
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		pageOpts.Page = page
+		result, err := ps.List(ctx, &pageOpts, reqOpts...)
+		if err != nil {
+			return nil, err
+		}
+		
+		fresh := 0
+		for _, project := range result.Data {
+			if seen[project.ID] {
+				continue
+			}
+			seen[project.ID] = true
+			projects = append(projects, project)
+			fresh++
+		}
+		if len(result.Data) > 0 && fresh == 0 {
+			return nil, fmt.Errorf("listing projects: page %d only repeats earlier results", page)
+		}
+		
+		if len(result.Data) < pageOpts.Limit {
+			return projects, nil
+		}
+		if total := result.TotalPages(); total > 0 && page >= total {
+			return projects, nil
+		}
+	}
+}
\ No newline at end of file
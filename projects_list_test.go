@@ -0,0 +1,110 @@
+package xrplsale_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+)
+
+func TestListAllProjects(t *testing.T) {
+	tests := []struct {
+		name         string
+		projects     int
+		limit        int
+		wantRequests int
+	}{
+		{"short final page", 25, 10, 3},
+		{"whole pages", 20, 10, 2},
+		{"default page size", xrplsale.DefaultIteratePageSize + 1, 0, 2},
+		{"empty", 0, 10, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t)
+			for i := 0; i < tt.projects; i++ {
+				srv.AddProjects(fixtures.Project())
+			}
+			
+			projects, err := client.Projects.ListAll(context.Background(), &xrplsale.ListProjectsOptions{Limit: tt.limit, Page: 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+			seen := map[string]bool{}
+			for _, project := range projects {
+				seen[project.ID] = true
+			}
+			if len(projects) != tt.projects || len(seen) != tt.projects {
+				t.Errorf("ListAll() = %d projects, %d distinct, want %d", len(projects), len(seen), tt.projects)
+			}
+			if got := hits.count(http.MethodGet, "/projects"); got != tt.wantRequests {
+				t.Errorf("%d page requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestListAllProjectsMisbehavingServer(t *testing.T) {
+	fullPage := func(ids ...string) string {
+		data := make([]string, len(ids))
+		for i, id := range ids {
+			data[i] = `{"id":"` + id + `"}`
+		}
+		return `{"data":[` + strings.Join(data, ",") + `],"pagination":{"limit":2,"total":1000}}`
+	}
+	tests := []struct {
+		name string
+		// page answers the page-th request; the sixth and later are empty
+		page         func(page int) string
+		cancel       bool
+		wantProjects int
+		wantRequests int32
+		wantErr      string
+	}{
+		{"page repeats the last", func(int) string { return fullPage("p1", "p2") }, false, 0, 2, "only repeats"},
+		// Each page adds one new project; the overlap is dropped
+		{"page overlaps the last", func(page int) string {
+			return fullPage(fmt.Sprint("p", page), fmt.Sprint("p", page+1))
+		}, false, 6, 6, ""},
+		{"cancelled", func(int) string { return fullPage("p1", "p2") }, true, 0, 0, "context canceled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1))
+				w.Header().Set("Content-Type", "application/json")
+				if n > 5 {
+					// A listing that keeps going is cut short here
+					w.Write([]byte(`{"data":[],"pagination":{"limit":2}}`))
+					return
+				}
+				w.Write([]byte(tt.page(n)))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+			
+			projects, err := client.Projects.ListAll(ctx, &xrplsale.ListProjectsOptions{Limit: 2})
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("ListAll() = %v, want an error containing %q", err, tt.wantErr)
+			}
+			if len(projects) != tt.wantProjects {
+				t.Errorf("ListAll() = %d projects, want %d", len(projects), tt.wantProjects)
+			}
+			if requests.Load() != tt.wantRequests {
+				t.Errorf("%d page requests, want %d", requests.Load(), tt.wantRequests)
+			}
+		})
+	}
+}
\ No newline at end of file
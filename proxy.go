@@ -0,0 +1,109 @@
+package xrplsale
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// proxyFunc returns the proxy selection for the client's transport:
+// Config.ProxyURL when set, fallback otherwise (usually the HTTPS_PROXY and
+// HTTP_PROXY environment variables), and no proxy for hosts matching
+// Config.NoProxy. A nil fallback means no proxy.
+func (config *Config) proxyFunc(fallback func(*http.Request) (*url.URL, error)) (func(*http.Request) (*url.URL, error), error) {
+	proxy := fallback
+	if proxy == nil {
+		proxy = func(*http.Request) (*url.URL, error) { return nil, nil }
+	}
+	if config.ProxyURL != "" {
+		raw := config.ProxyURL
+		if !strings.Contains(raw, "://") {
+			raw = "http://" + raw
+		}
+		proxyURL, err := url.Parse(raw)
+		if err != nil || proxyURL.Host == "" {
+			// The URL may embed credentials, so it is not quoted
+			return nil, errors.New("invalid ProxyURL")
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("unsupported ProxyURL scheme %q", proxyURL.Scheme)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+	
+	if len(config.NoProxy) == 0 {
+		return proxy, nil
+	}
+	bypass, err := parseNoProxy(config.NoProxy)
+	if err != nil {
+		return nil, err
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		if bypass.matches(req.URL.Hostname()) {
+			return nil, nil
+		}
+		return proxy(req)
+	}, nil
+}
+
+// noProxy lists the hosts reached without a proxy
+type noProxy struct {
+	all     bool
+	domains []string
+	nets    []*net.IPNet
+}
+
+// parseNoProxy parses NO_PROXY style entries: "*", host names, which also
+// match their subdomains, with an optional leading "." or "*.", IP
+// addresses and CIDR ranges
+func parseNoProxy(entries []string) (*noProxy, error) {
+	np := &noProxy{}
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			np.all = true
+		case strings.Contains(entry, "/"):
+			_, ipNet, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid NoProxy entry %q: %w", entry, err)
+			}
+			np.nets = append(np.nets, ipNet)
+		default:
+			if host, _, err := net.SplitHostPort(entry); err == nil {
+				entry = host
+			}
+			entry = strings.TrimPrefix(strings.TrimPrefix(entry, "*"), ".")
+			np.domains = append(np.domains, entry)
+		}
+	}
+	return np, nil
+}
+
+// matches reports whether host is reached without a proxy
+func (np *noProxy) matches(host string) bool {
+	if np.all {
+		return true
+	}
+	host = strings.ToLower(host)
+	if ip := net.ParseIP(host); ip != nil {
+		for _, ipNet := range np.nets {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+	}
+	for _, domain := range np.domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
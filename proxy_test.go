@@ -0,0 +1,133 @@
+package xrplsale
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestProxyFunc(t *testing.T) {
+	noProxy := []string{"internal.example.com", ".corp.example", "*.lab.example", "api.example:443", "10.0.0.0/8", "192.168.1.5/32"}
+	tests := []struct {
+		url     string
+		proxied bool
+	}{
+		{"https://api.xrpl.sale/v1/projects", true},
+		{"https://internal.example.com/v1", false},
+		{"https://eu.internal.example.com/v1", false},
+		{"https://notinternal.example.com/v1", true},
+		{"https://staging.corp.example/v1", false},
+		{"https://corp.example/v1", false},
+		{"https://api-testnet.lab.example/v1", false},
+		{"https://api.example/v1", false},
+		{"https://10.1.2.3/v1", false},
+		{"https://11.1.2.3/v1", true},
+		{"https://192.168.1.5/v1", false},
+		{"https://192.168.1.6/v1", true},
+		{"https://INTERNAL.EXAMPLE.COM/v1", false},
+	}
+	config := &Config{ProxyURL: "proxy.internal:3128", NoProxy: noProxy}
+	proxy, err := config.proxyFunc(http.ProxyFromEnvironment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+		got, err := proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (got != nil) != tt.proxied || (got != nil && got.String() != "http://proxy.internal:3128") {
+			t.Errorf("proxy for %s = %v, want proxied %v", tt.url, got, tt.proxied)
+		}
+	}
+	
+	for _, config := range []*Config{
+		{ProxyURL: "ftp://proxy.internal"},
+		{ProxyURL: "http://"},
+		{ProxyURL: "http://proxy.internal", NoProxy: []string{"10.0.0.0/33"}},
+	} {
+		if _, err := config.proxyFunc(http.ProxyFromEnvironment); err == nil {
+			t.Errorf("proxyFunc() accepted ProxyURL %q, NoProxy %q", config.ProxyURL, config.NoProxy)
+		}
+	}
+	all, err := (&Config{ProxyURL: "socks5://proxy.internal:1080", NoProxy: []string{"*"}}).proxyFunc(http.ProxyFromEnvironment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.xrpl.sale/v1", nil)
+	if got, _ := all(req); got != nil {
+		t.Errorf(`NoProxy "*" still proxied to %v`, got)
+	}
+}
+
+func TestProxyOnTheWire(t *testing.T) {
+	// The proxy answers for the API itself and records what reached it
+	var mu sync.Mutex
+	var seen []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Method+" "+r.RequestURI+" "+r.Header.Get("Proxy-Authorization"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"via":"proxy"}`))
+	}))
+	defer proxy.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"via":"direct"}`))
+	}))
+	defer api.Close()
+	proxyHost := strings.TrimPrefix(proxy.URL, "http://")
+	
+	tests := []struct {
+		name     string
+		proxyURL string
+		noProxy  []string
+		wantVia  string
+		wantAuth string
+	}{
+		{"proxy", proxy.URL, nil, "proxy", ""},
+		{"proxy with credentials", "http://ops:s3cret@" + proxyHost, nil, "proxy", "Basic " + base64.StdEncoding.EncodeToString([]byte("ops:s3cret"))},
+		{"proxy without scheme", proxyHost, nil, "proxy", ""},
+		{"bypassed by host", proxy.URL, []string{"127.0.0.1"}, "direct", ""},
+		{"bypassed by CIDR", proxy.URL, []string{"127.0.0.0/8"}, "direct", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			seen = nil
+			mu.Unlock()
+			client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: api.URL, ProxyURL: tt.proxyURL, NoProxy: tt.noProxy})
+			var got struct{ Via string }
+			if err := client.Get(context.Background(), "/projects", map[string]string{"page": "2"}, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Via != tt.wantVia {
+				t.Fatalf("answered %s, want %s", got.Via, tt.wantVia)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if tt.wantVia == "direct" {
+				if len(seen) != 0 {
+					t.Fatalf("proxy saw %q", seen)
+				}
+				return
+			}
+			// Plain HTTP goes to the proxy in absolute form
+			want := "GET " + api.URL + "/projects?page=2 " + tt.wantAuth
+			if len(seen) != 1 || seen[0] != want {
+				t.Fatalf("proxy saw %q, want %q", seen, want)
+			}
+		})
+	}
+	
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: api.URL, ProxyURL: proxy.URL, Transport: http.DefaultTransport})
+	if err := client.Get(context.Background(), "/projects", nil, nil); err == nil || !strings.Contains(err.Error(), "ProxyURL") {
+		t.Fatalf("Get() with ProxyURL and a custom Transport = %v, want a configuration error", err)
+	}
+}
\ No newline at end of file
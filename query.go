@@ -0,0 +1,99 @@
+package xrplsale
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pageParams are the query parameters of a plain page request
+type pageParams struct {
+	Page  int `url:"page"`
+	Limit int `url:"limit"`
+}
+
+// timeType is the reflected type of time.Time
+var timeType = reflect.TypeOf(time.Time{})
+
+// encodeQuery returns the query parameters described by the url tags of
+// opts, a struct or a pointer to one; a nil pointer yields no parameters.
+// Tags name the parameter and may add ",omitempty" to skip zero values,
+// or ",positive" to send an integer only when it is greater than zero:
+//
+//	Status string `url:"status,omitempty"`
+//	Page   int    `url:"page,positive"`
+//
+// Strings, bools, integers and floats are formatted as by strconv,
+// time.Time as RFC 3339 in UTC and []string joined by commas. Nil
+// pointers are always skipped; other pointers are encoded as their value,
+// so a *bool can send "false". Fields without a url tag are ignored.
+func encodeQuery(opts interface{}) map[string]string {
+	params := make(map[string]string)
+	v := reflect.ValueOf(opts)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return params
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("encodeQuery: want a struct, got %s", v.Type()))
+	}
+	
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("url")
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		omitEmpty, positive := flags == "omitempty", flags == "positive"
+		
+		value := v.Field(i)
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				continue
+			}
+			value, omitEmpty = value.Elem(), false
+		}
+		if omitEmpty && value.IsZero() || positive && value.Int() <= 0 {
+			continue
+		}
+		if encoded, ok := encodeQueryValue(value); ok || !omitEmpty {
+			params[name] = encoded
+		}
+	}
+	return params
+}
+
+// encodeQueryValue formats a single query parameter value, reporting
+// false for an empty slice
+func encodeQueryValue(v reflect.Value) (string, bool) {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).UTC().Format(time.RFC3339), true
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), true
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			items := make([]string, v.Len())
+			for i := range items {
+				items[i] = v.Index(i).String()
+			}
+			return strings.Join(items, ","), len(items) > 0
+		}
+	}
+	return fmt.Sprint(v.Interface()), true
+}
\ No newline at end of file
@@ -0,0 +1,109 @@
+package xrplsale
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEncodeQuery(t *testing.T) {
+	type kinds struct {
+		Name     string    `url:"name"`
+		Count    int       `url:"count"`
+		Size     uint8     `url:"size"`
+		Ratio    float64   `url:"ratio"`
+		Active   bool      `url:"active"`
+		Since    time.Time `url:"since"`
+		Tags     []string  `url:"tags"`
+		Skipped  string    `url:"-"`
+		Untagged string
+	}
+	type omitted struct {
+		Name   string    `url:"name,omitempty"`
+		Count  int       `url:"count,omitempty"`
+		Active bool      `url:"active,omitempty"`
+		Since  time.Time `url:"since,omitempty"`
+		Tags   []string  `url:"tags,omitempty"`
+		Flag   *bool     `url:"flag,omitempty"`
+	}
+	no := false
+	berlin := time.FixedZone("CET", 3600)
+	
+	tests := []struct {
+		name string
+		opts interface{}
+		want map[string]string
+	}{
+		{"nil options", (*ListProjectsOptions)(nil), map[string]string{}},
+		{"zero values are sent without omitempty", kinds{}, map[string]string{
+			"name": "", "count": "0", "size": "0", "ratio": "0", "active": "false", "since": "0001-01-01T00:00:00Z", "tags": "",
+		}},
+		{"every kind", &kinds{"a b", -3, 7, 0.25, true, time.Date(2024, 3, 1, 13, 0, 0, 0, berlin), []string{"x", "y"}, "s", "u"}, map[string]string{
+			"name": "a b", "count": "-3", "size": "7", "ratio": "0.25", "active": "true", "since": "2024-03-01T12:00:00Z", "tags": "x,y",
+		}},
+		{"omitempty skips zero values and empty slices", omitted{Tags: []string{}}, map[string]string{}},
+		{"a set pointer is sent even when false", omitted{Flag: &no}, map[string]string{"flag": "false"}},
+		{"single-item slice", omitted{Tags: []string{"only"}}, map[string]string{"tags": "only"}},
+		{"named string type", ListDisputesOptions{Status: "open", Limit: 5}, map[string]string{"status": "open", "limit": "5"}},
+		{"plain page params", pageParams{}, map[string]string{"page": "0", "limit": "0"}},
+		{"negative page and limit are omitted", ListProjectsOptions{Page: -1, Limit: -20}, map[string]string{}},
+		{"positive page and limit", ListAnnouncementsOptions{Page: 2, Limit: 10}, map[string]string{"page": "2", "limit": "10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeQuery(tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encodeQuery(%+v) = %v, want %v", tt.opts, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEncodeQueryMatchesListParams compares ListProjectsOptions against the
+// map Projects.List used to build by hand
+func TestEncodeQueryMatchesListParams(t *testing.T) {
+	handRolled := func(opts *ListProjectsOptions) map[string]string {
+		params := make(map[string]string)
+		if opts.Status != "" {
+			params["status"] = string(opts.Status)
+		}
+		if opts.Search != "" {
+			params["q"] = opts.Search
+		}
+		if opts.Page > 0 {
+			params["page"] = fmt.Sprintf("%d", opts.Page)
+		}
+		if opts.Limit > 0 {
+			params["limit"] = fmt.Sprintf("%d", opts.Limit)
+		}
+		if opts.SortBy != "" {
+			params["sort_by"] = opts.SortBy
+		}
+		if opts.SortOrder != "" {
+			params["sort_order"] = opts.SortOrder
+		}
+		return params
+	}
+	for _, opts := range []*ListProjectsOptions{
+		{},
+		{Status: "active"},
+		{Page: 2, Limit: 50},
+		{Page: -1, Limit: -5},
+		{Status: "active", Search: "solar & wind", Page: 1, Limit: 10, SortBy: "created_at", SortOrder: "desc"},
+	} {
+		got, want := queryString(encodeQuery(opts)), queryString(handRolled(opts))
+		if got != want {
+			t.Errorf("encodeQuery(%+v) = %q, want %q", *opts, got, want)
+		}
+	}
+}
+
+// queryString encodes params the way the request does, sorted by name
+func queryString(params map[string]string) string {
+	values := url.Values{}
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	return values.Encode()
+}
\ No newline at end of file
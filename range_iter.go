@@ -0,0 +1,61 @@
+//go:build go1.23
+
+package xrplsale
+
+import (
+	"context"
+	"iter"
+)
+
+// projectIterators are the ProjectsAPI methods that need Go 1.23
+type projectIterators interface {
+	All(ctx context.Context, opts *ListProjectsOptions, reqOpts ...RequestOption) iter.Seq2[Project, error]
+}
+
+// investmentIterators are the InvestmentsAPI methods that need Go 1.23
+type investmentIterators interface {
+	AllByProject(ctx context.Context, projectID string, reqOpts ...RequestOption) iter.Seq2[Investment, error]
+}
+
+// seqOf adapts the iterators made by newIt to range-over-func. Each range
+// starts a new iteration. Pages are fetched as the loop consumes records,
+// and not at all after it breaks; a failed fetch is yielded once, with a
+// zero record, and ends the loop.
+func seqOf[T any](newIt func() *Iterator[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		it := newIt()
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// All returns an iterator over every project matching opts, fetching pages
+// as the loop consumes them. A failed fetch ends the loop with its error.
+// opts.Limit is the page size; opts.Page is ignored.
+//
+//	for project, err := range client.Projects.All(ctx, nil) {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func (ps *ProjectsService) All(ctx context.Context, opts *ListProjectsOptions, reqOpts ...RequestOption) iter.Seq2[Project, error] {
+	return seqOf(func() *Iterator[Project] {
+		return ps.iterate(ctx, opts, reqOpts)
+	})
+}
+
+// AllByProject returns an iterator over every investment of a project,
+// fetching pages as the loop consumes them. A failed fetch ends the loop
+// with its error. The iteration options of IterateAllByProject apply.
+func (is *InvestmentsService) AllByProject(ctx context.Context, projectID string, reqOpts ...RequestOption) iter.Seq2[Investment, error] {
+	return seqOf(func() *Iterator[Investment] {
+		return is.IterateAllByProject(ctx, projectID, reqOpts...)
+	})
+}
\ No newline at end of file
@@ -0,0 +1,11 @@
+//go:build !go1.23
+
+package xrplsale
+
+// projectIterators are the ProjectsAPI methods that need Go 1.23; use
+// ForEach on earlier versions
+type projectIterators interface{}
+
+// investmentIterators are the InvestmentsAPI methods that need Go 1.23; use
+// ForEachByProject on earlier versions
+type investmentIterators interface{}
\ No newline at end of file
@@ -0,0 +1,126 @@
+//go:build go1.23
+
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math"
+	"net/http"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// idsOf maps an iterator's records to their IDs
+func idsOf[T any](seq iter.Seq2[T, error], id func(T) string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for record, err := range seq {
+			if !yield(id(record), err) {
+				return
+			}
+		}
+	}
+}
+
+func TestRangeIterators(t *testing.T) {
+	// setup seeds rows records and returns the iterator over them and the
+	// path of its pages
+	type setup func(srv *xrplsaletest.Server, client *xrplsale.Client, rows int) (iter.Seq2[string, error], string)
+	sources := []struct {
+		name     string
+		pageSize int
+		setup    setup
+	}{
+		{"projects", 10, func(srv *xrplsaletest.Server, client *xrplsale.Client, rows int) (iter.Seq2[string, error], string) {
+			for i := 0; i < rows; i++ {
+				srv.AddProjects(fixtures.Project())
+			}
+			seq := client.Projects.All(context.Background(), &xrplsale.ListProjectsOptions{Limit: 10})
+			return idsOf(seq, func(p xrplsale.Project) string { return p.ID }), "/projects"
+		}},
+		{"investments", xrplsale.DefaultIteratePageSize, func(srv *xrplsaletest.Server, client *xrplsale.Client, rows int) (iter.Seq2[string, error], string) {
+			project := fixtures.Project()
+			srv.AddProjects(project)
+			srv.AddInvestments(newInvestments(project, rows)...)
+			seq := client.Investments.AllByProject(context.Background(), project.ID, xrplsale.WithOrderByID())
+			return idsOf(seq, func(i xrplsale.Investment) string { return i.ID }), "/projects/" + project.ID + "/investments"
+		}},
+	}
+	tests := []struct {
+		name string
+		// pages is the number of pages of records, the last one half full
+		pages float64
+		// breakAfter stops the loop after that many records; failAfter makes
+		// every page fetch fail once that many were yielded
+		breakAfter, failAfter int
+		// wantPages is the number of records and of page requests, in pages
+		wantPages float64
+		wantErr   bool
+	}{
+		{"empty", 0, 0, 0, 0, false},
+		{"every page", 2.5, 0, 0, 2.5, false},
+		{"early break", 2.5, 5, 0, 1, false},
+		{"mid-stream error", 2.5, 0, 1, 1, true},
+	}
+	for _, src := range sources {
+		for _, tt := range tests {
+			t.Run(src.name+"/"+tt.name, func(t *testing.T) {
+				srv, client, hits := newCountingClient(t)
+				rows := int(tt.pages * float64(src.pageSize))
+				seq, path := src.setup(srv, client, rows)
+				
+				records, errs := 0, 0
+				seen := map[string]bool{}
+				for id, err := range seq {
+					if err != nil {
+						if id != "" {
+							t.Errorf("error %v yielded with record %q", err, id)
+						}
+						var apiErr *xrplsale.APIError
+						if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+							t.Errorf("yielded %v, want the failed page's error", err)
+						}
+						errs++
+						continue
+					}
+					if seen[id] {
+						t.Fatalf("%s yielded twice", id)
+					}
+					seen[id] = true
+					records++
+					if records == tt.failAfter {
+						srv.Fail(http.MethodGet, path, xrplsaletest.Failure{Status: http.StatusBadRequest})
+					}
+					if records == tt.breakAfter {
+						break
+					}
+				}
+				
+				// The page being consumed when the server broke is yielded whole
+				wantRecords := int(tt.wantPages * float64(src.pageSize))
+				if tt.breakAfter > 0 {
+					wantRecords = tt.breakAfter
+				}
+				if records != wantRecords {
+					t.Errorf("yielded %d records, want %d", records, wantRecords)
+				}
+				if wantErrs := map[bool]int{true: 1}[tt.wantErr]; errs != wantErrs {
+					t.Errorf("yielded %d errors, want %d", errs, wantErrs)
+				}
+				// Pages are fetched as the loop consumes them, and the failed
+				// one once more
+				wantRequests := max(int(math.Ceil(tt.wantPages)), 1)
+				if tt.wantErr {
+					wantRequests++
+				}
+				if got := hits.count(http.MethodGet, path); got != wantRequests {
+					t.Errorf("%d page requests, want %d", got, wantRequests)
+				}
+			})
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,88 @@
+package xrplsale
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit configures client-side request pacing
+type RateLimit struct {
+	// RequestsPerSecond is the sustained request rate
+	RequestsPerSecond float64
+	
+	// Burst is the number of requests allowed at once; defaults to 1
+	Burst int
+}
+
+// rateLimiter is a token bucket shared by every request made through a client
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	
+	// onExhausted, when set, is called with the wait whenever a request is
+	// delayed after a run of undelayed ones
+	onExhausted func(wait time.Duration)
+	exhausted   bool
+}
+
+// newRateLimiter creates a limiter with a full bucket
+func newRateLimiter(limit *RateLimit) *rateLimiter {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done. Tokens are reserved
+// in arrival order, so waiters are served first come, first served.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	deficit := -l.tokens
+	wait := time.Duration(deficit / l.rate * float64(time.Second))
+	notify := deficit > 0 && !l.exhausted
+	l.exhausted = deficit > 0
+	l.mu.Unlock()
+	
+	if deficit <= 0 {
+		return nil
+	}
+	if notify && l.onExhausted != nil {
+		l.onExhausted(wait)
+	}
+	
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Return the unused reservation
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// rateLimitExhausted emits the lifecycle event for a rate limit that started delaying requests
+func (c *clientCore) rateLimitExhausted(wait time.Duration) {
+	c.events.emit(ClientEventRateLimitExhausted, map[string]string{"wait": wait.String()})
+}
\ No newline at end of file
@@ -0,0 +1,92 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+)
+
+func TestRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    xrplsale.RateLimit
+		requests int
+		atLeast  time.Duration
+		atMost   time.Duration
+	}{
+		{"2 rps", xrplsale.RateLimit{RequestsPerSecond: 2}, 5, 2 * time.Second, 3 * time.Second},
+		{"burst absorbs a batch", xrplsale.RateLimit{RequestsPerSecond: 1, Burst: 4}, 4, 0, 500 * time.Millisecond},
+		{"burst then pacing", xrplsale.RateLimit{RequestsPerSecond: 10, Burst: 2}, 6, 400 * time.Millisecond, 1500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t, func(c *xrplsale.Config) { c.RateLimit = &tt.limit })
+			project := fixtures.Project()
+			srv.AddProjects(project)
+			
+			// Spread the requests over two services and several goroutines:
+			// the limit belongs to the client, not to a service
+			start := time.Now()
+			var wg sync.WaitGroup
+			errs := make(chan error, tt.requests)
+			for i := 0; i < tt.requests; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					var err error
+					if i%2 == 0 {
+						_, err = client.Projects.Get(context.Background(), project.ID)
+					} else {
+						_, err = client.Investments.GetByProject(context.Background(), project.ID, 1, 10)
+					}
+					errs <- err
+				}(i)
+			}
+			wg.Wait()
+			elapsed := time.Since(start)
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			
+			if hits.total() != tt.requests {
+				t.Fatalf("%d requests sent, want %d", hits.total(), tt.requests)
+			}
+			if elapsed < tt.atLeast || elapsed > tt.atMost {
+				t.Fatalf("%d requests took %s, want between %s and %s", tt.requests, elapsed, tt.atLeast, tt.atMost)
+			}
+		})
+	}
+}
+
+func TestRateLimitCancelled(t *testing.T) {
+	srv, client, hits := newCountingClient(t, func(c *xrplsale.Config) {
+		c.RateLimit = &xrplsale.RateLimit{RequestsPerSecond: 0.5}
+	})
+	project := fixtures.Project()
+	srv.AddProjects(project)
+	
+	if _, err := client.Projects.Get(context.Background(), project.ID); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Projects.Get(ctx, project.ID)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get() while rate limited = %v, want the context's deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Get() returned after %s, want it to stop waiting at the deadline", elapsed)
+	}
+	if hits.total() != 1 {
+		t.Fatalf("%d requests sent, want only the first", hits.total())
+	}
+}
\ No newline at end of file
@@ -0,0 +1,307 @@
+// Package recorder records the SDK's HTTP traffic to a cassette file and
+// replays it, so integration tests can run once against the testnet and
+// then offline and deterministically in CI.
+//
+//	rec, err := recorder.New("testdata/projects_list.json", recorder.ModeReplay)
+//	client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+//		APIKey:    os.Getenv("XRPLSALE_API_KEY"),
+//		Transport: rec,
+//	})
+//	...
+//	err = rec.Save() // writes the cassette in ModeRecord
+//
+// Requests are matched on method, path, query and a hash of the body. Each
+// recorded interaction is replayed once, in order, so repeated calls such
+// as polls get the responses they got while recording. Credentials never
+// reach the cassette: request headers are not stored, and token,
+// refresh_token, secret, client_secret and api_key fields are replaced in
+// bodies and query strings before matching.
+package recorder
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder calls the API or replays a cassette
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette and never calls the API
+	ModeReplay Mode = iota
+	
+	// ModeRecord calls the API and records every interaction
+	ModeRecord
+)
+
+// Redacted replaces credentials in recorded bodies and queries
+const Redacted = "REDACTED"
+
+// ErrUnmatched is returned in replay mode for a request the cassette has
+// no unused interaction for
+var ErrUnmatched = errors.New("recorder: no recorded interaction matches the request")
+
+// sensitiveFields matches the credential fields of JSON bodies
+var sensitiveFields = regexp.MustCompile(`("(?:token|refresh_token|secret|client_secret|api_key)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// sensitiveParams lists the query parameters replaced by Redacted
+var sensitiveParams = []string{"token", "refresh_token", "secret", "client_secret", "api_key"}
+
+// droppedResponseHeaders lists the response headers never recorded.
+// Content-Length is recomputed on replay, since scrubbing changes it.
+var droppedResponseHeaders = []string{"Set-Cookie", "Date", "Content-Length"}
+
+// Cassette is the file format of a recording
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it got
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the part of a request used for matching. Body is kept for
+// readability only; BodySHA256 is what is compared.
+type Request struct {
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Query      string `json:"query,omitempty"`
+	BodySHA256 string `json:"body_sha256,omitempty"`
+	Body       string `json:"body,omitempty"`
+}
+
+// Response is a recorded response. Bodies that are not valid UTF-8 are
+// stored in BodyBase64 instead of Body.
+type Response struct {
+	Status     int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// Recorder is an http.RoundTripper for Config.Transport that records or
+// replays interactions. It is safe for concurrent use, but replaying
+// concurrent identical requests returns their responses in arrival order.
+type Recorder struct {
+	// Base sends requests in ModeRecord (default http.DefaultTransport)
+	Base http.RoundTripper
+	
+	mode      Mode
+	path      string
+	mu        sync.Mutex
+	cassette  Cassette
+	used      []bool
+	unmatched []string
+}
+
+// New returns a recorder for the cassette at path. In ModeReplay the
+// cassette must exist; in ModeRecord it is written by Save.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+	if mode == ModeRecord {
+		return r, nil
+	}
+	
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: loading cassette: %w", err)
+	}
+	if err := json.Unmarshal(raw, &r.cassette); err != nil {
+		return nil, fmt.Errorf("recorder: decoding cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeRecord {
+		return r.record(req, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+// replay answers req with the first unused interaction matching it
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		r.used[i] = true
+		return interaction.Response.httpResponse(req)
+	}
+	
+	desc := recorded.Method + " " + recorded.Path
+	if recorded.Query != "" {
+		desc += "?" + recorded.Query
+	}
+	r.unmatched = append(r.unmatched, desc)
+	return nil, fmt.Errorf("%w: %s (cassette %s)", ErrUnmatched, desc, r.path)
+}
+
+// record sends req and records the interaction
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	base := r.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	
+	header := resp.Header.Clone()
+	for _, name := range droppedResponseHeaders {
+		header.Del(name)
+	}
+	response := Response{Status: resp.StatusCode, Header: header}
+	if utf8.Valid(body) {
+		response.Body = scrubBody(string(body))
+	} else {
+		response.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+	
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: recorded, Response: response})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory. It does nothing in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	r.mu.Lock()
+	err := enc.Encode(r.cassette)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, buf.Bytes(), 0o644)
+}
+
+// Unmatched returns the requests replay found no interaction for, so a test
+// can fail even when the code under test swallowed the error
+func (r *Recorder) Unmatched() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.unmatched...)
+}
+
+// newRequest describes req for matching, reading and restoring its body
+func newRequest(req *http.Request) (Request, error) {
+	recorded := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  scrubQuery(req.URL.Query()),
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+	
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, fmt.Errorf("recorder: reading request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) == 0 {
+		return recorded, nil
+	}
+	
+	var scrubbed string
+	if utf8.Valid(body) {
+		scrubbed = scrubBody(string(body))
+		recorded.Body = scrubbed
+	} else {
+		scrubbed = string(body)
+	}
+	sum := sha256.Sum256([]byte(scrubbed))
+	recorded.BodySHA256 = hex.EncodeToString(sum[:])
+	return recorded, nil
+}
+
+// matches reports whether a recorded request matches req
+func (r Request) matches(req Request) bool {
+	return r.Method == req.Method && r.Path == req.Path && r.Query == req.Query && r.BodySHA256 == req.BodySHA256
+}
+
+// httpResponse builds the response replayed for req
+func (r Response) httpResponse(req *http.Request) (*http.Response, error) {
+	body := []byte(r.Body)
+	if r.BodyBase64 != "" {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(r.BodyBase64); err != nil {
+			return nil, fmt.Errorf("recorder: decoding recorded body: %w", err)
+		}
+	}
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// scrubBody replaces the credential fields of a JSON body
+func scrubBody(body string) string {
+	return sensitiveFields.ReplaceAllString(body, `${1}"`+Redacted+`"`)
+}
+
+// scrubQuery encodes a query in canonical order with credentials replaced
+func scrubQuery(query url.Values) string {
+	for _, name := range sensitiveParams {
+		for key := range query {
+			if strings.EqualFold(key, name) {
+				query[key] = []string{Redacted}
+			}
+		}
+	}
+	return query.Encode()
+}
\ No newline at end of file
@@ -0,0 +1,105 @@
+package recorder_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/recorder"
+)
+
+func TestReplayProjectsList(t *testing.T) {
+	rec, err := recorder.New("testdata/projects_list.json", recorder.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nothing listens on the base URL, so any request reaching it fails
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "other_key", BaseURL: "http://127.0.0.1:1", Transport: rec})
+	ctx := context.Background()
+	opts := &xrplsale.ListProjectsOptions{Status: "active", Page: 1, Limit: 10}
+	
+	page, err := client.Projects.List(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Data) != 2 || page.Data[0].ID != "proj_u62wqm6n0ch6" || page.Data[1].Name != "Harbor Markets" || page.Pagination.Total != 2 {
+		t.Fatalf("List() = %+v, want the recorded page", page)
+	}
+	
+	// The interaction is used up, and other requests never matched
+	tests := []struct {
+		name string
+		opts *xrplsale.ListProjectsOptions
+	}{
+		{"repeated call", opts},
+		{"different query", &xrplsale.ListProjectsOptions{Status: "active", Page: 2, Limit: 10}},
+	}
+	for _, tt := range tests {
+		if _, err := client.Projects.List(ctx, tt.opts, xrplsale.WithNoRetry()); !errors.Is(err, recorder.ErrUnmatched) {
+			t.Errorf("%s: List() = %v, want ErrUnmatched", tt.name, err)
+		}
+	}
+	if unmatched := rec.Unmatched(); len(unmatched) != 2 || unmatched[1] != "GET /projects?limit=10&page=2&status=active" {
+		t.Errorf("Unmatched() = %q", unmatched)
+	}
+}
+
+func TestRecordScrubsCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=live_cookie")
+		w.Write([]byte(`{"token":"live_access_token","refresh_token":"live_refresh_token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassettes", "auth.json")
+	
+	rec, err := recorder.New(path, recorder.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "live_api_key", BaseURL: srv.URL, Transport: rec})
+	if err := client.Post(context.Background(), "/auth/refresh", map[string]string{"refresh_token": "live_old_refresh"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["token"] != "live_access_token" {
+		t.Fatalf("recording changed the response the client saw: %v", got)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "live_") {
+		t.Fatalf("cassette leaks credentials:\n%s", raw)
+	}
+	
+	// The scrubbed cassette replays the same request with other credentials
+	replay, err := recorder.New(path, recorder.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "ci_key", BaseURL: srv.URL, Transport: replay})
+	srv.Close()
+	got = nil
+	if err := client.Post(context.Background(), "/auth/refresh", map[string]string{"refresh_token": "ci_refresh"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["token"] != recorder.Redacted || got["expires_in"] != 3600.0 {
+		t.Fatalf("replayed %v, want the scrubbed response", got)
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := recorder.New(filepath.Join(t.TempDir(), "missing.json"), recorder.ModeReplay); err == nil {
+		t.Fatal("New() loaded a missing cassette")
+	}
+}
\ No newline at end of file
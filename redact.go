@@ -0,0 +1,75 @@
+package xrplsale
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// sensitiveHeaders lists the headers masked in debug output
+var sensitiveHeaders = []string{
+	"X-API-Key",
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// sensitiveBodyFields matches the credential fields of auth and webhook
+// request and response bodies, in both compact and indented JSON
+var sensitiveBodyFields = regexp.MustCompile(`("(?:token|refresh_token|secret|api_key)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// redactSecret masks all but the last 4 characters of secret. Short values
+// are masked entirely, since 4 characters would reveal most of them.
+func redactSecret(secret string) string {
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
+// redactHeader masks the sensitive headers of h. Their values are replaced
+// rather than edited in place: resty's log headers are shallow copies,
+// sharing their values with the request actually sent.
+func redactHeader(h http.Header) {
+	for _, name := range sensitiveHeaders {
+		values := h.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		masked := make([]string, len(values))
+		for i, value := range values {
+			if scheme, credential, ok := strings.Cut(value, " "); ok && name == "Authorization" {
+				masked[i] = scheme + " " + redactSecret(credential)
+			} else {
+				masked[i] = redactSecret(value)
+			}
+		}
+		h[http.CanonicalHeaderKey(name)] = masked
+	}
+}
+
+// redactBody masks token and refresh_token fields in a logged JSON body
+func redactBody(body string) string {
+	return sensitiveBodyFields.ReplaceAllStringFunc(body, func(field string) string {
+		m := sensitiveBodyFields.FindStringSubmatch(field)
+		value := strings.TrimPrefix(field, m[1])
+		return m[1] + `"` + redactSecret(strings.Trim(value, `"`)) + `"`
+	})
+}
+
+// enableRedactedDebug turns on resty's debug output with credentials masked
+func enableRedactedDebug(httpClient *resty.Client) {
+	httpClient.SetDebug(true).
+		OnRequestLog(func(rl *resty.RequestLog) error {
+			redactHeader(rl.Header)
+			rl.Body = redactBody(rl.Body)
+			return nil
+		}).
+		OnResponseLog(func(rl *resty.ResponseLog) error {
+			redactHeader(rl.Header)
+			rl.Body = redactBody(rl.Body)
+			return nil
+		})
+}
\ No newline at end of file
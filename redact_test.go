@@ -0,0 +1,75 @@
+package xrplsale_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// bufferLogger collects everything the client logs
+type bufferLogger struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (l *bufferLogger) Errorf(format string, v ...interface{}) { l.printf(format, v...) }
+func (l *bufferLogger) Warnf(format string, v ...interface{})  { l.printf(format, v...) }
+func (l *bufferLogger) Debugf(format string, v ...interface{}) { l.printf(format, v...) }
+
+func (l *bufferLogger) printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(&l.buf, format+"\n", v...)
+}
+
+func (l *bufferLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.String()
+}
+
+func TestDebugOutputRedacted(t *testing.T) {
+	srv := xrplsaletest.NewServer()
+	defer srv.Close()
+	logger := &bufferLogger{}
+	client := xrplsaletest.NewClient(srv, func(c *xrplsale.Config) {
+		c.Debug = true
+		c.Logger = logger
+	})
+	
+	auth := signIn(t, client)
+	if _, err := client.Auth.GetProfile(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	output := logger.String()
+	if !strings.Contains(output, "/auth/wallet") || !strings.Contains(output, "/auth/profile") {
+		t.Fatalf("debug output does not show the calls:\n%s", output)
+	}
+	
+	secrets := []struct {
+		name  string
+		value string
+	}{
+		{"API key", xrplsaletest.APIKey},
+		{"access token", auth.Token},
+		{"refresh token", auth.RefreshToken},
+	}
+	for _, secret := range secrets {
+		t.Run(secret.name, func(t *testing.T) {
+			if secret.value == "" {
+				t.Fatal("no secret issued")
+			}
+			if strings.Contains(output, secret.value) {
+				t.Fatalf("debug output contains the %s:\n%s", secret.name, output)
+			}
+			if !strings.Contains(output, "****"+secret.value[len(secret.value)-4:]) {
+				t.Errorf("debug output does not show the masked %s", secret.name)
+			}
+		})
+	}
+}
\ No newline at end of file
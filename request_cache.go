@@ -0,0 +1,89 @@
+package xrplsale
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// requestCacheMaxEntries bounds the number of responses a request cache holds
+const requestCacheMaxEntries = 64
+
+// requestCacheKey is the context key under which a request cache is stored
+type requestCacheKey struct{}
+
+// cachedResponse is a response body kept by one of the caches, with the
+// headers that say how to decode it
+type cachedResponse struct {
+	header http.Header
+	body   []byte
+}
+
+// cached returns the parts of resp a cache keeps
+func cached(resp *Response) cachedResponse {
+	return cachedResponse{header: resp.Header, body: resp.Body}
+}
+
+// requestCache remembers successful GET responses for the lifetime of a context
+type requestCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+	order   []string
+}
+
+// ContextWithRequestCache returns a context in which identical GET requests
+// (same path, query parameters, credentials and headers) are served from the first successful
+// response instead of reaching the network again. Use it to scope a single
+// logical operation that may fetch the same resource several times; the
+// cache lives exactly as long as the context is used. Error responses and
+// non-GET requests are never cached.
+func ContextWithRequestCache(ctx context.Context) context.Context {
+	if requestCacheFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, requestCacheKey{}, &requestCache{
+		entries: make(map[string]cachedResponse),
+	})
+}
+
+// requestCacheFrom returns the request cache carried by ctx, if any
+func requestCacheFrom(ctx context.Context) *requestCache {
+	cache, _ := ctx.Value(requestCacheKey{}).(*requestCache)
+	return cache
+}
+
+// requestCacheKeyFor names the resource a GET request fetches; cache keys
+// add the client identity and headers to it
+func requestCacheKeyFor(endpoint string, params map[string]string) string {
+	values := make(url.Values, len(params))
+	for key, value := range params {
+		values.Set(key, value)
+	}
+	// Encode sorts by key, making the key independent of map order
+	return "GET " + endpoint + "?" + values.Encode()
+}
+
+// get returns the cached response for key
+func (rc *requestCache) get(key string) (cachedResponse, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	resp, ok := rc.entries[key]
+	return resp, ok
+}
+
+// put stores resp under key, evicting the oldest entry when the cache is full
+func (rc *requestCache) put(key string, resp cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	
+	if _, ok := rc.entries[key]; ok {
+		return
+	}
+	if len(rc.order) >= requestCacheMaxEntries {
+		delete(rc.entries, rc.order[0])
+		rc.order = rc.order[1:]
+	}
+	rc.entries[key] = resp
+	rc.order = append(rc.order, key)
+}
\ No newline at end of file
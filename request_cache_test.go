@@ -0,0 +1,207 @@
+package xrplsale_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+)
+
+// overview fetches each project and its statistics, as a helper building
+// a dashboard from several projects would
+func overview(ctx context.Context, client *xrplsale.Client, projectIDs ...string) ([]xrplsale.ProjectStats, error) {
+	var all []xrplsale.ProjectStats
+	for _, id := range projectIDs {
+		if _, err := client.Projects.Get(ctx, id); err != nil {
+			return nil, err
+		}
+		stats, err := client.Projects.GetStats(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, *stats)
+	}
+	return all, nil
+}
+
+func TestRequestCacheSharedFetches(t *testing.T) {
+	tests := []struct {
+		name      string
+		cached    bool
+		wantHits  int
+		wantHitsA int
+	}{
+		// Two overviews sharing project a: 2 GETs per project each
+		{"without cache", false, 8, 4},
+		{"with cache", true, 6, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t)
+			a, b, c := fixtures.Project(), fixtures.Project(), fixtures.Project()
+			srv.AddProjects(a, b, c)
+			srv.AddInvestments(fixtures.Investments(a, 3)...)
+			
+			ctx := context.Background()
+			if tt.cached {
+				ctx = xrplsale.ContextWithRequestCache(ctx)
+			}
+			first, err := overview(ctx, client, a.ID, b.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := overview(ctx, client, a.ID, c.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			
+			if got := hits.total(); got != tt.wantHits {
+				t.Errorf("requests sent = %d, want %d", got, tt.wantHits)
+			}
+			if got := hits.count(http.MethodGet, "/projects/"+a.ID) + hits.count(http.MethodGet, "/projects/"+a.ID+"/stats"); got != tt.wantHitsA {
+				t.Errorf("requests for project a = %d, want %d", got, tt.wantHitsA)
+			}
+			if first[0].InvestmentCount != 3 || second[0].InvestmentCount != 3 {
+				t.Errorf("InvestmentCount = %d, %d, want 3", first[0].InvestmentCount, second[0].InvestmentCount)
+			}
+		})
+	}
+}
+
+func TestRequestCacheScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		second   func(ctx context.Context, client *xrplsale.Client, id string) error
+		wantHits int
+	}{
+		{"identical GET", func(ctx context.Context, client *xrplsale.Client, id string) error {
+			_, err := client.Projects.Get(ctx, id)
+			return err
+		}, 1},
+		{"other per-request header", func(ctx context.Context, client *xrplsale.Client, id string) error {
+			_, err := client.Projects.Get(ctx, id, xrplsale.WithHeader("X-Tenant-ID", "other"))
+			return err
+		}, 2},
+		{"other client identity", func(ctx context.Context, client *xrplsale.Client, id string) error {
+			_, err := client.Clone(xrplsale.WithAuthToken("other-token")).Projects.Get(ctx, id)
+			return err
+		}, 2},
+		{"acting for a user", func(ctx context.Context, client *xrplsale.Client, id string) error {
+			_, err := client.Projects.Get(ctx, id, xrplsale.WithOnBehalfOf(fixtures.Address()))
+			return err
+		}, 2},
+		{"other query", func(ctx context.Context, client *xrplsale.Client, id string) error {
+			_, err := client.Projects.Get(ctx, id, xrplsale.WithQueryParam("expand", "tiers"))
+			return err
+		}, 2},
+		{"outside the context", func(_ context.Context, client *xrplsale.Client, id string) error {
+			_, err := client.Projects.Get(context.Background(), id)
+			return err
+		}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t)
+			project := fixtures.Project()
+			srv.AddProjects(project)
+			
+			ctx := xrplsale.ContextWithRequestCache(context.Background())
+			if _, err := client.Projects.Get(ctx, project.ID); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.second(ctx, client, project.ID); err != nil {
+				t.Fatal(err)
+			}
+			if got := hits.count(http.MethodGet, "/projects/"+project.ID); got != tt.wantHits {
+				t.Errorf("GETs sent = %d, want %d", got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestRequestCacheSkipsErrorsAndWrites(t *testing.T) {
+	srv, client, hits := newCountingClient(t, func(c *xrplsale.Config) { c.MaxRetries = 0 })
+	project := fixtures.Project(fixtures.WithProjectStatus(xrplsale.StatusDraft))
+	srv.AddProjects(project)
+	ctx := xrplsale.ContextWithRequestCache(context.Background())
+	
+	srv.FailNext(http.MethodGet, "/projects/{id}", http.StatusNotFound)
+	if _, err := client.Projects.Get(ctx, project.ID); err == nil {
+		t.Fatal("Get() succeeded despite the injected failure")
+	}
+	if _, err := client.Projects.Get(ctx, project.ID); err != nil {
+		t.Fatalf("Get() after a failure = %v; the error was cached", err)
+	}
+	if got := hits.count(http.MethodGet, "/projects/"+project.ID); got != 2 {
+		t.Errorf("GETs sent = %d, want 2", got)
+	}
+	
+	for i := 0; i < 2; i++ {
+		client.Projects.Launch(ctx, project.ID)
+	}
+	if got := hits.count(http.MethodPost, "/projects/"+project.ID+"/launch"); got != 2 {
+		t.Errorf("POSTs sent = %d, want 2", got)
+	}
+}
+func TestCacheHitReported(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  func() context.Context
+		opts []xrplsale.RequestOption
+	}{
+		{"request cache", func() context.Context { return xrplsale.ContextWithRequestCache(context.Background()) }, nil},
+		{"TTL cache", context.Background, []xrplsale.RequestOption{xrplsale.WithCache(time.Minute)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(xrplsale.RequestIDHeader, fmt.Sprintf("req_%d", sent.Add(1)))
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id":"proj_1"}`))
+			}))
+			defer srv.Close()
+			metrics := &xrplsale.InMemoryMetrics{}
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, MetricsCollector: metrics})
+			var infos []xrplsale.ResponseInfo
+			client.OnResponse(func(_ context.Context, info *xrplsale.ResponseInfo) { infos = append(infos, *info) })
+			
+			ctx := tt.ctx()
+			var metas [2]xrplsale.ResponseMeta
+			var responses [2]*xrplsale.Response
+			for i := range metas {
+				resp, err := client.Do(ctx, http.MethodGet, "/projects/proj_1", nil, nil, append(tt.opts, xrplsale.WithResponseMeta(&metas[i]))...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				responses[i] = resp
+			}
+			
+			if sent.Load() != 1 {
+				t.Fatalf("%d requests sent, want 1", sent.Load())
+			}
+			if responses[0].FromCache || !responses[1].FromCache || string(responses[1].Body) != `{"id":"proj_1"}` {
+				t.Fatalf("FromCache = %t, %t; body %q", responses[0].FromCache, responses[1].FromCache, responses[1].Body)
+			}
+			if meta := metas[0]; meta.FromCache || meta.Attempts != 1 || meta.RequestID != "req_1" {
+				t.Errorf("first call meta = %+v", meta)
+			}
+			if meta := metas[1]; !meta.FromCache || meta.Attempts != 0 || meta.StatusCode != http.StatusOK || meta.RequestID != "req_1" || meta.Header.Get(xrplsale.RequestIDHeader) != "req_1" {
+				t.Errorf("cached call meta = %+v, want the cached call's status and request ID", meta)
+			}
+			if len(infos) != 2 || infos[0].FromCache || !infos[1].FromCache || infos[1].StatusCode != http.StatusOK || infos[1].RequestID != "req_1" {
+				t.Errorf("hooks saw %+v, want both calls with the second from cache", infos)
+			}
+			routes := metrics.Snapshot()
+			if len(routes) != 1 || routes[0].Calls != 2 || routes[0].CacheHits != 1 || routes[0].Attempts != 1 || routes[0].StatusCodes[http.StatusOK] != 2 {
+				t.Errorf("metrics = %+v, want 2 calls, 1 from cache, 1 attempt", routes)
+			}
+		})
+	}
+}
\ No newline at end of file
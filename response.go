@@ -0,0 +1,180 @@
+package xrplsale
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Response is a raw API response returned by Client.Do
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	
+	// FromCache is set when the response came from a client-side cache
+	// without reaching the API
+	FromCache bool
+	
+	// method and endpoint identify the request in decode errors; strict
+	// rejects unknown fields when decoding
+	method   string
+	endpoint string
+	strict   bool
+}
+
+// DecodeError is returned when a response body cannot be decoded into the
+// expected type. With Config.StrictDecoding it is also returned for fields
+// the SDK does not know about.
+type DecodeError struct {
+	Method   string
+	Endpoint string
+	
+	// Field is the offending field, when the decoder reported one
+	Field string
+	
+	// Offset is the byte offset in Body at or just after the problem
+	Offset int64
+	
+	// Body is the raw response body
+	Body []byte
+	
+	Err error
+}
+
+// Error implements the error interface
+func (e *DecodeError) Error() string {
+	msg := "decode response"
+	if e.Endpoint != "" {
+		msg = fmt.Sprintf("%s of %s %s", msg, e.Method, e.Endpoint)
+	}
+	if e.Field != "" {
+		msg = fmt.Sprintf("%s: field %q", msg, e.Field)
+	}
+	if e.Offset > 0 {
+		msg = fmt.Sprintf("%s at byte %d", msg, e.Offset)
+	}
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+// Unwrap returns the underlying decoder error
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// Decode decodes the body into v: MessagePack when the response's
+// Content-Type says so, JSON otherwise. For responses to clients with
+// Config.StrictDecoding, unknown fields are an error. Failures are
+// returned as *DecodeError.
+func (r *Response) Decode(v interface{}) error {
+	if r.isMessagePack() {
+		return r.decodeMessagePack(v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(r.Body))
+	if r.strict {
+		dec.DisallowUnknownFields()
+	}
+	err := dec.Decode(v)
+	if err == nil {
+		if _, tokenErr := dec.Token(); tokenErr != io.EOF {
+			err = errors.New("unexpected data after the JSON value")
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	
+	decodeErr := &DecodeError{
+		Method:   r.method,
+		Endpoint: r.endpoint,
+		Offset:   dec.InputOffset(),
+		Body:     r.Body,
+		Err:      err,
+	}
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		decodeErr.Field = typeErr.Field
+		decodeErr.Offset = typeErr.Offset
+	case errors.As(err, &syntaxErr):
+		decodeErr.Offset = syntaxErr.Offset
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// The decoder reads the whole body before noticing it ends early
+		decodeErr.Offset = int64(len(r.Body))
+	default:
+		decodeErr.Field = unknownField(err)
+	}
+	return decodeErr
+}
+
+// isMessagePack reports whether the body is MessagePack
+func (r *Response) isMessagePack() bool {
+	return isMessagePack(r.Header.Get("Content-Type"))
+}
+
+// decodeMessagePack decodes the MessagePack body into v
+func (r *Response) decodeMessagePack(v interface{}) error {
+	err := unmarshalMessagePack(r.Body, v, r.strict)
+	if err == nil {
+		return nil
+	}
+	decodeErr := &DecodeError{
+		Method:   r.method,
+		Endpoint: r.endpoint,
+		Body:     r.Body,
+		Err:      err,
+	}
+	var mpErr *MessagePackError
+	if errors.As(err, &mpErr) {
+		decodeErr.Field, decodeErr.Offset = mpErr.Field, mpErr.Offset
+	}
+	return decodeErr
+}
+
+// isArray reports whether the body is an array, in either format
+func (r *Response) isArray() bool {
+	if r.isMessagePack() {
+		return len(r.Body) > 0 && kindOf(r.Body[0]) == kindArray
+	}
+	body := bytes.TrimSpace(r.Body)
+	return len(body) > 0 && body[0] == '['
+}
+
+// jsonBody returns an error body as JSON, transcoding it from MessagePack
+// when header says so. A body that fails to transcode is returned as is.
+func jsonBody(header http.Header, body []byte) []byte {
+	if !isMessagePack(header.Get("Content-Type")) {
+		return body
+	}
+	if js, err := messagePackToJSON(body); err == nil {
+		return js
+	}
+	return body
+}
+
+// unknownField extracts the field name from the decoder's unknown field error
+func unknownField(err error) string {
+	const prefix = "json: unknown field "
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return ""
+	}
+	field, unquoteErr := strconv.Unquote(strings.TrimPrefix(msg, prefix))
+	if unquoteErr != nil {
+		return ""
+	}
+	return field
+}
+
+// decodeResult decodes the body into result for the typed request methods.
+// A nil result or an empty body is not decoded.
+func (r *Response) decodeResult(result interface{}) error {
+	if result == nil || len(bytes.TrimSpace(r.Body)) == 0 {
+		return nil
+	}
+	return r.Decode(result)
+}
\ No newline at end of file
@@ -0,0 +1,108 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxResponseBytes is the default cap on a response body read into memory
+const DefaultMaxResponseBytes = 32 << 20
+
+// ErrResponseTooLarge is matched by errors returned when a response body exceeds the size limit
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ResponseTooLargeError is returned when a response body exceeds Config.MaxResponseBytes,
+// Config.MaxStreamBytes or the limit set with WithMaxResponseBytes. Reading stops as soon
+// as the limit is crossed.
+type ResponseTooLargeError struct {
+	Method   string
+	Endpoint string
+	Limit    int64
+	Read     int64
+}
+
+// Error implements the error interface
+func (e *ResponseTooLargeError) Error() string {
+	msg := "response body"
+	if e.Endpoint != "" {
+		msg = fmt.Sprintf("%s of %s %s", msg, e.Method, e.Endpoint)
+	}
+	return fmt.Sprintf("%s exceeds limit of %d bytes (read %d)", msg, e.Limit, e.Read)
+}
+
+// Is reports whether target is ErrResponseTooLarge
+func (e *ResponseTooLargeError) Is(target error) bool { return target == ErrResponseTooLarge }
+
+// maxResponseBytesKey carries a per-call response size limit in the request context
+type maxResponseBytesKey struct{}
+
+// unlimitedResponse disables the size limit for a call
+const unlimitedResponse int64 = -1
+
+// limitedTransport enforces the response size limit on every response body
+type limitedTransport struct {
+	base  http.RoundTripper
+	limit int64
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	
+	limit := t.limit
+	if override, ok := req.Context().Value(maxResponseBytesKey{}).(int64); ok {
+		limit = override
+	}
+	if limit > 0 {
+		resp.Body = &limitedBody{body: resp.Body, limit: limit}
+	}
+	return resp, nil
+}
+
+// limitedBody fails reads once more than limit bytes have been read
+type limitedBody struct {
+	body  io.ReadCloser
+	limit int64
+	read  int64
+}
+
+// Read implements io.Reader
+func (b *limitedBody) Read(p []byte) (int, error) {
+	// Read at most one byte past the limit, enough to detect the overflow
+	if remaining := b.limit + 1 - b.read; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := b.body.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n, &ResponseTooLargeError{Limit: b.limit, Read: b.read}
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+// withResponseLimit returns ctx carrying a per-call response size limit
+func withResponseLimit(ctx context.Context, limit int64) context.Context {
+	return context.WithValue(ctx, maxResponseBytesKey{}, limit)
+}
+
+// streamLimit returns the size limit of a streamed or downloaded body
+func (c *Client) streamLimit(ro *requestOptions) int64 {
+	if ro.maxResponseBytes != 0 {
+		return ro.maxResponseBytes
+	}
+	if c.config.MaxStreamBytes > 0 {
+		return c.config.MaxStreamBytes
+	}
+	return unlimitedResponse
+}
\ No newline at end of file
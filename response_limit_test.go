@@ -0,0 +1,222 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// oversizedHandler answers every request with a JSON body of size bytes,
+// without a Content-Length unless withLength is set
+func oversizedHandler(size int, withLength bool) http.HandlerFunc {
+	const prefix, suffix = `{"id":"proj_1","description":"`, `"}`
+	body := []byte(prefix + strings.Repeat("x", size-len(prefix)-len(suffix)) + suffix)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if withLength {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		for chunk := body; len(chunk) > 0; chunk = chunk[min(len(chunk), 4096):] {
+			w.Write(chunk[:min(len(chunk), 4096)])
+			w.(http.Flusher).Flush()
+		}
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	const size = 1 << 20
+	tests := []struct {
+		name       string
+		configured int64
+		opts       []xrplsale.RequestOption
+		wantLimit  int64
+	}{
+		{"configured limit", 64 << 10, nil, 64 << 10},
+		{"per-call limit", 0, []xrplsale.RequestOption{xrplsale.WithMaxResponseBytes(1 << 10)}, 1 << 10},
+		{"per-call limit lifted", 64 << 10, []xrplsale.RequestOption{xrplsale.WithMaxResponseBytes(-1)}, 0},
+		{"under the default", 0, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := 0
+			handler := oversizedHandler(size, false)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				handler(w, r)
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, MaxResponseBytes: tt.configured})
+			
+			project, err := client.Projects.Get(context.Background(), "proj_1", tt.opts...)
+			if tt.wantLimit == 0 {
+				if err != nil || !strings.HasPrefix(project.Description, "xxx") {
+					t.Fatalf("Get() = %v, want the whole %d byte body", err, size)
+				}
+				return
+			}
+			var tooLarge *xrplsale.ResponseTooLargeError
+			if !errors.As(err, &tooLarge) || !errors.Is(err, xrplsale.ErrResponseTooLarge) {
+				t.Fatalf("Get() = %v, want a ResponseTooLargeError", err)
+			}
+			if tooLarge.Limit != tt.wantLimit || tooLarge.Read != tt.wantLimit+1 {
+				t.Fatalf("limit %d, read %d; want the read stopped one byte past %d", tooLarge.Limit, tooLarge.Read, tt.wantLimit)
+			}
+			if hits != 1 {
+				t.Fatalf("sent %d times, want an oversized response never retried", hits)
+			}
+		})
+	}
+}
+
+func TestMaxResponseBytesDefault(t *testing.T) {
+	const size = 512 << 20
+	chunk := bytes.Repeat([]byte("x"), 64<<10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"proj_1","description":"`))
+		for written := 0; written < size && r.Context().Err() == nil; written += len(chunk) {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	_, err := client.Projects.Get(context.Background(), "proj_1")
+	runtime.ReadMemStats(&after)
+	
+	var tooLarge *xrplsale.ResponseTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("Get() = %v, want a ResponseTooLargeError", err)
+	}
+	if tooLarge.Limit != xrplsale.DefaultMaxResponseBytes || tooLarge.Method != http.MethodGet || tooLarge.Endpoint != "/projects/proj_1" {
+		t.Fatalf("error %+v, want the default limit of GET /projects/proj_1", tooLarge)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "GET /projects/proj_1") || !strings.Contains(msg, strconv.Itoa(xrplsale.DefaultMaxResponseBytes)) {
+		t.Fatalf("error %q, want the endpoint and the limit", msg)
+	}
+	// Buffering grows by doubling, and the race detector adds its own, but
+	// the read stops far short of the body
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > size/2 {
+		t.Fatalf("allocated %d MiB for a %d MiB body, want the read stopped at the %d MiB limit", allocated>>20, size>>20, xrplsale.DefaultMaxResponseBytes>>20)
+	}
+}
+
+func TestMaxStreamBytes(t *testing.T) {
+	srv := xrplsaletest.NewServer()
+	defer srv.Close()
+	project := fixtures.Project()
+	srv.AddProjects(project)
+	srv.AddInvestments(fixtures.Investments(project, 500)...)
+	
+	tests := []struct {
+		name       string
+		configured int64
+		opts       []xrplsale.RequestOption
+		wantErr    bool
+	}{
+		{"in-memory limit does not apply", 0, nil, false},
+		{"configured stream limit", 16 << 10, nil, true},
+		{"per-call limit", 0, []xrplsale.RequestOption{xrplsale.WithMaxResponseBytes(16 << 10)}, true},
+		{"per-call limit lifted", 16 << 10, []xrplsale.RequestOption{xrplsale.WithMaxResponseBytes(-1)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := xrplsaletest.NewClient(srv, func(c *xrplsale.Config) {
+				c.MaxResponseBytes = 1 << 10
+				c.MaxStreamBytes = tt.configured
+			})
+			stream, err := client.Investments.StreamByProject(context.Background(), project.ID, nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stream.Close()
+			n := 0
+			for stream.Next() {
+				n++
+			}
+			
+			if !tt.wantErr {
+				if stream.Err() != nil || n != 500 {
+					t.Fatalf("streamed %d investments, err %v; want all 500", n, stream.Err())
+				}
+				return
+			}
+			var tooLarge *xrplsale.ResponseTooLargeError
+			if !errors.As(stream.Err(), &tooLarge) || tooLarge.Read > 16<<10+1 {
+				t.Fatalf("Err() = %v, want a ResponseTooLargeError after at most 16KiB", stream.Err())
+			}
+			if n == 0 || n == 500 {
+				t.Fatalf("streamed %d investments, want the ones before the limit", n)
+			}
+		})
+	}
+}
+
+func TestDownloadLimit(t *testing.T) {
+	const size = 256 << 10
+	tests := []struct {
+		name        string
+		withLength  bool
+		configured  int64
+		opts        []xrplsale.RequestOption
+		wantWritten int64
+		wantErr     bool
+	}{
+		{"unlimited by default", false, 0, nil, size, false},
+		{"in-memory limit does not apply", true, 0, nil, size, false},
+		{"known length over the limit", true, 64 << 10, nil, 0, true},
+		{"unknown length over the limit", false, 64 << 10, nil, 64 << 10, true},
+		{"per-call limit", false, 0, []xrplsale.RequestOption{xrplsale.WithMaxResponseBytes(8 << 10)}, 8 << 10, true},
+		{"under the limit", true, size, nil, size, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := 0
+			handler := oversizedHandler(size, tt.withLength)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				if r.URL.Path != "/analytics/exports/exp_1/download" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				handler(w, r)
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey:           "key",
+				BaseURL:          srv.URL,
+				MaxResponseBytes: 1 << 10,
+				MaxStreamBytes:   tt.configured,
+			})
+			
+			var buf bytes.Buffer
+			written, err := client.Analytics.DownloadExport(context.Background(), "exp_1", &buf, tt.opts...)
+			if written != tt.wantWritten || int64(buf.Len()) != tt.wantWritten {
+				t.Errorf("wrote %d bytes (returned %d), want %d", buf.Len(), written, tt.wantWritten)
+			}
+			if tt.wantErr != errors.Is(err, xrplsale.ErrResponseTooLarge) {
+				t.Fatalf("DownloadExport() = %v, want ErrResponseTooLarge: %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("DownloadExport() = %v", err)
+			}
+			if hits != 1 {
+				t.Fatalf("sent %d requests, want an oversized download never resumed", hits)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,80 @@
+package xrplsale
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestIDHeader is the header correlating a request with the API's logs
+const RequestIDHeader = "X-Request-ID"
+
+// canonicalRequestIDHeader is RequestIDHeader in canonical form
+var canonicalRequestIDHeader = http.CanonicalHeaderKey(RequestIDHeader)
+
+// ResponseMeta holds the transport details of a completed call
+type ResponseMeta struct {
+	// StatusCode is zero when no response was received
+	StatusCode int
+	Header     http.Header
+	
+	// RequestID is the API's X-Request-ID for the call, or the one sent with
+	// WithRequestID if the API did not return one. Quote it to support.
+	RequestID string
+	
+	// APIVersion is the version the API answered with; compare it with the
+	// one sent to detect drift. Empty if the API did not report one.
+	APIVersion string
+	
+	// Attempts is zero for a response served from a client-side cache
+	Attempts int
+	Duration time.Duration
+	
+	// FromCache is set when the response came from a client-side cache
+	// (Config.CacheTTL, WithCache or ContextWithRequestCache) without
+	// reaching the API. Header and RequestID are then the cached call's.
+	FromCache bool
+}
+
+// WithResponseMeta fills meta once the call completes, whether or not it
+// succeeds, e.g. to log the request ID of a successful call
+func WithResponseMeta(meta *ResponseMeta) RequestOption {
+	return func(ro *requestOptions) {
+		ro.meta = meta
+	}
+}
+
+// WithRequestID sends id as the request's X-Request-ID, to correlate the
+// call with your own logs
+func WithRequestID(id string) RequestOption {
+	return WithHeader(RequestIDHeader, id)
+}
+
+// requestIDFrom returns the request ID of a response, falling back to the
+// one sent with the request
+func requestIDFrom(header http.Header, ro *requestOptions) string {
+	// Response headers are canonical, so the key is looked up directly
+	// rather than canonicalized on every call
+	if ids := header[canonicalRequestIDHeader]; len(ids) > 0 && ids[0] != "" {
+		return ids[0]
+	}
+	return ro.headers[RequestIDHeader]
+}
+
+// reportMeta fills the caller's WithResponseMeta target, if any, with the
+// outcome of a call
+func (ro *requestOptions) reportMeta(info *ResponseInfo, response *Response) {
+	if ro.meta == nil {
+		return
+	}
+	*ro.meta = ResponseMeta{
+		StatusCode: info.StatusCode,
+		RequestID:  info.RequestID,
+		Attempts:   info.Attempts,
+		Duration:   info.Duration,
+		FromCache:  info.FromCache,
+	}
+	if response != nil {
+		ro.meta.Header = response.Header
+		ro.meta.APIVersion = response.Header.Get(APIVersionHeader)
+	}
+}
\ No newline at end of file
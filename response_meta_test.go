@@ -0,0 +1,114 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestRequestID(t *testing.T) {
+	// The server echoes the caller's X-Request-ID, assigns one when none is
+	// sent, and answers /silent without one
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/silent" {
+			id := r.Header.Get("X-Request-Id")
+			if id == "" {
+				id = "req_assigned"
+			}
+			w.Header()["X-Request-Id"] = []string{id}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"not_found","message":"no such thing"}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	tests := []struct {
+		name     string
+		endpoint string
+		opts     []xrplsale.RequestOption
+		want     string
+		wantErr  bool
+	}{
+		{"assigned by the API", "/projects", nil, "req_assigned", false},
+		{"set by the caller", "/projects", []xrplsale.RequestOption{xrplsale.WithRequestID("job-7")}, "job-7", false},
+		{"not returned", "/silent", []xrplsale.RequestOption{xrplsale.WithRequestID("job-8")}, "job-8", false},
+		{"error assigned", "/missing", nil, "req_assigned", true},
+		{"error set by the caller", "/missing", []xrplsale.RequestOption{xrplsale.WithRequestID("job-9")}, "job-9", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var meta xrplsale.ResponseMeta
+			var info *xrplsale.ResponseInfo
+			client := client.Clone()
+			client.OnResponse(func(_ context.Context, i *xrplsale.ResponseInfo) { info = i })
+			
+			_, err := client.Do(context.Background(), http.MethodGet, tt.endpoint, nil, nil, append(tt.opts, xrplsale.WithResponseMeta(&meta))...)
+			if meta.RequestID != tt.want || info == nil || info.RequestID != tt.want {
+				t.Fatalf("meta request ID %q, hook %+v; want %q", meta.RequestID, info, tt.want)
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var apiErr *xrplsale.APIError
+			if !errors.As(err, &apiErr) || apiErr.RequestID != tt.want {
+				t.Fatalf("Do() = %v, want an APIError with request ID %q", err, tt.want)
+			}
+			if meta.StatusCode != http.StatusNotFound {
+				t.Fatalf("meta status %d, want 404", meta.StatusCode)
+			}
+		})
+	}
+}
+func TestAPIVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The API answers with the version it served, here one minor ahead
+		w.Header().Set(xrplsale.APIVersionHeader, r.Header.Get(xrplsale.APIVersionHeader)+".1")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	base := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	tests := []struct {
+		name   string
+		client *xrplsale.Client
+		want   string
+	}{
+		{"default", base, xrplsale.SupportedAPIVersion},
+		{"Config.APIVersion", xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, APIVersion: "1.1"}), "1.1"},
+		{"clone", base.Clone(xrplsale.WithAPIVersion("2.0")), "2.0"},
+	}
+	for _, tt := range tests {
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			t.Run(tt.name+"/"+method, func(t *testing.T) {
+				var meta xrplsale.ResponseMeta
+				if _, err := tt.client.Do(context.Background(), method, "/projects/proj_1", nil, nil, xrplsale.WithResponseMeta(&meta)); err != nil {
+					t.Fatal(err)
+				}
+				if meta.APIVersion != tt.want+".1" {
+					t.Fatalf("sent %s %q, want %q", xrplsale.APIVersionHeader, strings.TrimSuffix(meta.APIVersion, ".1"), tt.want)
+				}
+			})
+		}
+	}
+	
+	// Cloning with another version leaves the parent pinned
+	var meta xrplsale.ResponseMeta
+	if _, err := base.Do(context.Background(), http.MethodGet, "/projects", nil, nil, xrplsale.WithResponseMeta(&meta)); err != nil || meta.APIVersion != xrplsale.SupportedAPIVersion+".1" {
+		t.Fatalf("parent answered with %q, %v", meta.APIVersion, err)
+	}
+}
\ No newline at end of file
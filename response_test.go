@@ -0,0 +1,60 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		strict    bool
+		wantField string
+		wantErr   bool
+	}{
+		{"known fields", `{"id":"proj_1","name":"Alpha"}`, false, "", false},
+		{"known fields strict", `{"id":"proj_1","name":"Alpha"}`, true, "", false},
+		{"extra field", `{"id":"proj_1","name":"Alpha","display_name":"Alpha"}`, false, "", false},
+		{"extra field strict", `{"id":"proj_1","name":"Alpha","display_name":"Alpha"}`, true, "display_name", true},
+		{"wrong type", `{"id":"proj_1","name":42}`, false, "name", true},
+		{"wrong type strict", `{"id":"proj_1","name":42}`, true, "name", true},
+		{"truncated", `{"id":"proj_1","name":`, false, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, StrictDecoding: tt.strict})
+			
+			project, err := client.Projects.Get(context.Background(), "proj_1", xrplsale.WithNoRetry())
+			if !tt.wantErr {
+				if err != nil || project.ID != "proj_1" || project.Name != "Alpha" {
+					t.Fatalf("Get() = %+v, %v", project, err)
+				}
+				return
+			}
+			var decodeErr *xrplsale.DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("Get() = %v, want a DecodeError", err)
+			}
+			if decodeErr.Method != http.MethodGet || decodeErr.Endpoint != "/projects/proj_1" || decodeErr.Field != tt.wantField {
+				t.Fatalf("DecodeError = %s %s field %q, want GET /projects/proj_1 field %q", decodeErr.Method, decodeErr.Endpoint, decodeErr.Field, tt.wantField)
+			}
+			if decodeErr.Offset <= 0 || decodeErr.Offset > int64(len(tt.body)) {
+				t.Fatalf("Offset = %d, want a position within the %d byte body", decodeErr.Offset, len(tt.body))
+			}
+			if string(decodeErr.Body) != tt.body {
+				t.Fatalf("Body = %q, want the raw response %q", decodeErr.Body, tt.body)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,79 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RetryPolicy decides whether a failed attempt should be retried.
+// resp is nil when err is a transport error; attempt is the 1-based number
+// of the attempt that just failed. Config.MaxRetries still bounds the total.
+// The client always passes a req carrying the method and context, even when
+// the attempt failed before the HTTP request was built.
+type RetryPolicy interface {
+	ShouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool
+}
+
+// RetryPolicyFunc adapts an ordinary function to the RetryPolicy interface
+type RetryPolicyFunc func(req *http.Request, resp *http.Response, err error, attempt int) bool
+
+// ShouldRetry calls f
+func (f RetryPolicyFunc) ShouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	return f(req, resp, err, attempt)
+}
+
+// DefaultRetryPolicy retries network errors, 5xx and 429 responses for
+// idempotent methods. POST and PATCH are only retried when the request was
+// made with WithRetryNonIdempotent or carries an idempotency key, since the
+// server may have processed the first attempt before failing. A nil req
+// is never retried, since its method is unknown.
+type DefaultRetryPolicy struct{}
+
+// ShouldRetry implements RetryPolicy
+func (DefaultRetryPolicy) ShouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if req == nil || (!isIdempotent(req.Method) && !retryNonIdempotent(req.Context())) {
+		return false
+	}
+	if err != nil {
+		// An oversized response will be just as large next time, an open
+		// circuit stays open for longer than any backoff, and a pin mismatch
+		// must never be retried until it happens to pass
+		return !errors.Is(err, ErrResponseTooLarge) && !errors.Is(err, ErrCircuitOpen) &&
+			!errors.Is(err, ErrCertificatePinMismatch)
+	}
+	if resp == nil {
+		return false
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// isIdempotent reports whether repeating a request with method has no additional effect
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryNonIdempotentKey marks a request context whose non-idempotent request may be retried
+type retryNonIdempotentKey struct{}
+
+// retryNonIdempotent reports whether the request context opted in to retrying POST/PATCH
+func retryNonIdempotent(ctx context.Context) bool {
+	allowed, _ := ctx.Value(retryNonIdempotentKey{}).(bool)
+	return allowed
+}
+
+// retryRequest returns the request a RetryPolicy judges for r. resty only
+// sets RawRequest once the attempt got as far as building it, so an attempt
+// that failed earlier is described by its method, headers and context.
+func retryRequest(r *resty.Request) *http.Request {
+	if r.RawRequest != nil {
+		return r.RawRequest
+	}
+	return (&http.Request{Method: r.Method, Header: r.Header}).WithContext(r.Context())
+}
\ No newline at end of file
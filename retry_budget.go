@@ -0,0 +1,107 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrRetryBudgetExceeded is matched by the error of a call stopped by
+// Config.MaxElapsedTime or WithMaxElapsedTime
+var ErrRetryBudgetExceeded = errors.New("retry budget exceeded")
+
+// RetryBudgetExceededError is returned when a call used up its retry budget.
+// It matches both ErrRetryBudgetExceeded and Err, the failure of the last
+// attempt, so errors.As still finds an APIError.
+type RetryBudgetExceededError struct {
+	Budget   time.Duration
+	Attempts int
+	Err      error
+}
+
+// Error implements the error interface
+func (e *RetryBudgetExceededError) Error() string {
+	return fmt.Sprintf("retry budget of %s exceeded after %d attempt(s): %v", e.Budget, e.Attempts, e.Err)
+}
+
+// Unwrap returns ErrRetryBudgetExceeded and the last attempt's error
+func (e *RetryBudgetExceededError) Unwrap() []error {
+	return []error{ErrRetryBudgetExceeded, e.Err}
+}
+
+// WithMaxElapsedTime caps the total time spent on this request across all
+// attempts, overriding Config.MaxElapsedTime
+func WithMaxElapsedTime(d time.Duration) RequestOption {
+	return func(ro *requestOptions) {
+		ro.maxElapsedTime = d
+	}
+}
+
+// retryBudgetKey is the context key under which a call's retry budget is stored
+type retryBudgetKey struct{}
+
+// retryBudget records the last failure of a call with a time budget, which
+// is lost when the budget runs out during the backoff before a retry
+type retryBudget struct {
+	budget time.Duration
+	
+	mu      sync.Mutex
+	lastErr error
+}
+
+// errBudgetSpent is the cause of a call context whose budget ran out
+var errBudgetSpent = errors.New("retry budget spent")
+
+// withRetryBudget bounds ctx by the call's retry budget, if any
+func (c *Client) withRetryBudget(ctx context.Context, ro *requestOptions) (context.Context, context.CancelFunc) {
+	budget := c.config.MaxElapsedTime
+	if ro.maxElapsedTime != 0 {
+		budget = ro.maxElapsedTime
+	}
+	if budget <= 0 {
+		return ctx, func() {}
+	}
+	ctx = context.WithValue(ctx, retryBudgetKey{}, &retryBudget{budget: budget})
+	return context.WithTimeoutCause(ctx, budget, errBudgetSpent)
+}
+
+// recordAttempt keeps the transport error of a failed attempt for the
+// budget error. It is called from the retry condition.
+func recordAttempt(ctx context.Context, err error) {
+	b, _ := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	if b == nil || err == nil {
+		return
+	}
+	b.mu.Lock()
+	b.lastErr = err
+	b.mu.Unlock()
+}
+
+// budgetExceeded returns the error of a call whose retry budget ran out,
+// or nil when the budget did not end it. A deadline of the caller's context
+// that came first is reported as such.
+func budgetExceeded(ctx context.Context, resp *resty.Response, err error, attempts int) error {
+	if !errors.Is(context.Cause(ctx), errBudgetSpent) {
+		return nil
+	}
+	b, _ := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	last := err
+	switch {
+	case resp != nil && resp.RawResponse != nil && resp.IsError():
+		last = newResponseError(resp, "")
+	case errors.Is(err, context.DeadlineExceeded) || err == nil:
+		b.mu.Lock()
+		if b.lastErr != nil {
+			last = b.lastErr
+		}
+		b.mu.Unlock()
+	}
+	if last == nil {
+		last = context.DeadlineExceeded
+	}
+	return &RetryBudgetExceededError{Budget: b.budget, Attempts: max(attempts, 1), Err: last}
+}
\ No newline at end of file
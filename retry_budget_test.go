@@ -0,0 +1,88 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestRetryBudget(t *testing.T) {
+	tests := []struct {
+		name       string
+		delay      time.Duration
+		budget     time.Duration
+		opts       []xrplsale.RequestOption
+		ctxTimeout time.Duration
+		// wantWithin bounds the call's duration; zero means no budget applies
+		wantWithin time.Duration
+		wantBudget bool
+		wantStatus int
+	}{
+		{"config budget", 0, 300 * time.Millisecond, nil, 0, 300 * time.Millisecond, true, http.StatusServiceUnavailable},
+		{"per-request budget wins", 0, time.Minute, []xrplsale.RequestOption{xrplsale.WithMaxElapsedTime(200 * time.Millisecond)}, 0, 200 * time.Millisecond, true, http.StatusServiceUnavailable},
+		{"slow attempt cut short", time.Second, 150 * time.Millisecond, nil, 0, 150 * time.Millisecond, true, 0},
+		{"earlier context deadline wins", 0, time.Minute, nil, 100 * time.Millisecond, 100 * time.Millisecond, false, 0},
+		{"no budget", 0, 0, nil, 0, 0, false, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"error":{"code":"unavailable","message":"try later"}}`))
+			}))
+			defer srv.Close()
+			maxRetries := 1000
+			if tt.budget == 0 {
+				maxRetries = 2
+			}
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey: "key", BaseURL: srv.URL, MaxRetries: maxRetries, RetryWaitTime: 20 * time.Millisecond, MaxElapsedTime: tt.budget,
+			})
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+			
+			start := time.Now()
+			err := client.Get(ctx, "/projects", nil, nil, tt.opts...)
+			elapsed := time.Since(start)
+			
+			if tt.wantWithin > 0 && elapsed > tt.wantWithin+250*time.Millisecond {
+				t.Errorf("call took %v, want about %v", elapsed, tt.wantWithin)
+			}
+			if got := errors.Is(err, xrplsale.ErrRetryBudgetExceeded); got != tt.wantBudget {
+				t.Fatalf("Get() = %v, want ErrRetryBudgetExceeded %v", err, tt.wantBudget)
+			}
+			var apiErr *xrplsale.APIError
+			if tt.wantStatus != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus) {
+				t.Errorf("Get() = %v, want the last attempt's %d", err, tt.wantStatus)
+			}
+			if tt.ctxTimeout > 0 && !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Get() = %v, want the context's deadline", err)
+			}
+			var budgetErr *xrplsale.RetryBudgetExceededError
+			if tt.wantBudget && (!errors.As(err, &budgetErr) || budgetErr.Budget != tt.wantWithin || budgetErr.Attempts < 1) {
+				t.Errorf("Get() = %#v, want a RetryBudgetExceededError for %v", err, tt.wantWithin)
+			}
+			if tt.budget == 0 && attempts.Load() != 3 {
+				t.Errorf("%d attempts without a budget, want MaxRetries+1", attempts.Load())
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,123 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	const path = "/projects/proj_1"
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		opts     []xrplsale.RequestOption
+		wantHits int
+	}{
+		{"GET 502", http.MethodGet, http.StatusBadGateway, nil, 4},
+		{"GET 429", http.MethodGet, http.StatusTooManyRequests, nil, 4},
+		{"GET 404", http.MethodGet, http.StatusNotFound, nil, 1},
+		{"PUT 503", http.MethodPut, http.StatusServiceUnavailable, nil, 4},
+		{"DELETE 500", http.MethodDelete, http.StatusInternalServerError, nil, 4},
+		{"POST 502", http.MethodPost, http.StatusBadGateway, nil, 1},
+		{"PATCH 503", http.MethodPatch, http.StatusServiceUnavailable, nil, 1},
+		{"POST 502 opted in", http.MethodPost, http.StatusBadGateway, []xrplsale.RequestOption{xrplsale.WithRetryNonIdempotent()}, 4},
+		{"POST 502 with idempotency key", http.MethodPost, http.StatusBadGateway, []xrplsale.RequestOption{xrplsale.WithIdempotencyKey("key-1")}, 4},
+		{"GET 502 retries disabled", http.MethodGet, http.StatusBadGateway, []xrplsale.RequestOption{xrplsale.WithNoRetry()}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t)
+			srv.Fail(tt.method, path, xrplsaletest.Failure{Status: tt.status})
+			
+			_, err := client.Do(context.Background(), tt.method, path, nil, nil, tt.opts...)
+			var apiErr *xrplsale.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("Do() = %v, want an APIError with status %d", err, tt.status)
+			}
+			if got := hits.count(tt.method, path); got != tt.wantHits {
+				t.Fatalf("%s sent %d times, want %d", tt.method, got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryPolicyWithoutRequest(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusBadGateway}
+	if (xrplsale.DefaultRetryPolicy{}).ShouldRetry(nil, resp, nil, 1) {
+		t.Fatal("ShouldRetry(nil request) = true, want a request of unknown method left alone")
+	}
+}
+
+func TestCustomRetryPolicy(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		methods  []string
+		attempts []int
+	)
+	policy := xrplsale.RetryPolicyFunc(func(req *http.Request, resp *http.Response, err error, attempt int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		methods = append(methods, req.Method)
+		attempts = append(attempts, attempt)
+		return resp != nil && resp.StatusCode == http.StatusConflict && attempt < 2
+	})
+	srv, client, hits := newCountingClient(t, func(c *xrplsale.Config) { c.RetryPolicy = policy })
+	srv.Fail(http.MethodPost, "/projects", xrplsaletest.Failure{Status: http.StatusConflict})
+	
+	if err := client.Post(context.Background(), "/projects", map[string]string{}, nil); err == nil {
+		t.Fatal("Post() = nil, want the conflict")
+	}
+	if got := hits.count(http.MethodPost, "/projects"); got != 2 {
+		t.Fatalf("POST sent %d times, want 2", got)
+	}
+	if !equalStrings(methods, []string{http.MethodPost, http.MethodPost}) || len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Fatalf("policy saw methods %q, attempts %v; want two POSTs, attempts 1 and 2", methods, attempts)
+	}
+}
+func TestRetryBackoffCancelled(t *testing.T) {
+	const path = "/projects/proj_1"
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		wantErr error
+	}{
+		{"cancel", func() (context.Context, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(100*time.Millisecond, cancel)
+			return ctx, cancel
+		}, context.Canceled},
+		{"deadline", func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 100*time.Millisecond)
+		}, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t, func(c *xrplsale.Config) { c.RetryWaitTime = 2 * time.Second })
+			srv.Fail(http.MethodGet, path, xrplsaletest.Failure{Status: http.StatusServiceUnavailable})
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			
+			start := time.Now()
+			_, err := client.Do(ctx, http.MethodGet, path, nil, nil)
+			// The context ends 100ms in; the backoff must notice within ~50ms,
+			// with slack for the race detector
+			if elapsed := time.Since(start); elapsed > 350*time.Millisecond {
+				t.Fatalf("Do() returned after %v, want it to abort the 2s backoff", elapsed)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() = %v, want %v", err, tt.wantErr)
+			}
+			if got := hits.count(http.MethodGet, path); got != 1 {
+				t.Fatalf("GET sent %d times, want 1", got)
+			}
+		})
+	}
+}
\ No newline at end of file
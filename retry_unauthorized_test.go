@@ -0,0 +1,104 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// bodyRecorder is a transport recording the bodies sent to each path
+type bodyRecorder struct {
+	next   http.RoundTripper
+	mu     sync.Mutex
+	bodies map[string][]string
+}
+
+func (br *bodyRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	br.mu.Lock()
+	if br.bodies == nil {
+		br.bodies = make(map[string][]string)
+	}
+	br.bodies[req.URL.Path] = append(br.bodies[req.URL.Path], string(body))
+	br.mu.Unlock()
+	return br.next.RoundTrip(req)
+}
+
+func (br *bodyRecorder) sent(path string) []string {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	return br.bodies[path]
+}
+
+func TestRetryOnUnauthorized(t *testing.T) {
+	const webhook = `{"url":"https://example.com/hook","events":["investment.created"]}`
+	large := `{"url":"https://example.com/hook","events":["investment.created"],"description":"` +
+		strings.Repeat("x", xrplsale.MaxReplayBodyBytes) + `"}`
+	tests := []struct {
+		name   string
+		off    bool
+		method string
+		path   string
+		body   func() interface{}
+		// alwaysRejected makes the server reject the refreshed token too
+		alwaysRejected bool
+		wantAttempts   int
+		wantRefreshes  int
+		wantErr        bool
+	}{
+		{"GET", false, http.MethodGet, "/projects", func() interface{} { return nil }, false, 2, 1, false},
+		{"POST", false, http.MethodPost, "/webhooks", func() interface{} {
+			return map[string]interface{}{"url": "https://example.com/hook", "events": []string{"investment.created"}}
+		}, false, 2, 1, false},
+		{"POST with a streamed body", false, http.MethodPost, "/webhooks", func() interface{} { return strings.NewReader(webhook) }, false, 2, 1, false},
+		{"streamed body too large to replay", false, http.MethodPost, "/webhooks", func() interface{} { return strings.NewReader(large) }, false, 1, 1, true},
+		{"second 401 is returned", false, http.MethodGet, "/projects", func() interface{} { return nil }, true, 2, 1, true},
+		{"off", true, http.MethodGet, "/projects", func() interface{} { return nil }, false, 1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodies := &bodyRecorder{}
+			srv, client, hits := newCountingClient(t, func(c *xrplsale.Config) {
+				c.RetryOnUnauthorized = !tt.off
+				bodies.next, c.Transport = c.Transport, bodies
+			})
+			signIn(t, client)
+			srv.ExpireTokens()
+			if tt.alwaysRejected {
+				srv.Fail(tt.method, tt.path, xrplsaletest.Failure{Status: http.StatusUnauthorized})
+			}
+			
+			err := client.Request(context.Background(), tt.method, tt.path, tt.body(), nil, xrplsale.WithNoRetry())
+			if !tt.wantErr && err != nil {
+				t.Fatalf("%s %s across a token expiry = %v", tt.method, tt.path, err)
+			}
+			var authErr *xrplsale.AuthError
+			if tt.wantErr && !errors.As(err, &authErr) {
+				t.Fatalf("%s %s = %v, want an AuthError", tt.method, tt.path, err)
+			}
+			if got := hits.count(tt.method, tt.path); got != tt.wantAttempts {
+				t.Errorf("%d attempts, want %d", got, tt.wantAttempts)
+			}
+			if got := hits.count(http.MethodPost, "/auth/refresh"); got != tt.wantRefreshes {
+				t.Errorf("%d refreshes, want %d", got, tt.wantRefreshes)
+			}
+			// A replay sends the same body
+			if sent := bodies.sent(tt.path); tt.wantAttempts == 2 && sent[0] != sent[1] {
+				t.Errorf("replayed body %q, first sent %q", sent[1], sent[0])
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,70 @@
+package xrplsale
+
+import (
+	"context"
+	"slices"
+)
+
+// Scopes the API grants to auth tokens. A token without a scope gets a
+// PermissionError naming it from the routes that need it.
+const (
+	ScopeProjectsRead     = "projects:read"
+	ScopeProjectsWrite    = "projects:write"
+	ScopeInvestmentsRead  = "investments:read"
+	ScopeInvestmentsWrite = "investments:write"
+	ScopeAnalyticsRead    = "analytics:read"
+	ScopeWebhooksRead     = "webhooks:read"
+	ScopeWebhooksWrite    = "webhooks:write"
+	ScopeProfileRead      = "profile:read"
+	ScopeProfileWrite     = "profile:write"
+)
+
+// HasScope reports whether the current session was granted scope, so a
+// call can be skipped or a feature hidden instead of failing with a 403.
+// Scopes come from Authenticate, Refresh or LoadScopes, or else from the
+// token's claims; HasScope is false when none of them are known.
+func (as *AuthService) HasScope(scope string) bool {
+	return slices.Contains(as.Scopes(), scope)
+}
+
+// Scopes returns the scopes of the current session, see HasScope
+func (as *AuthService) Scopes() []string {
+	creds := as.client.credentials()
+	if len(creds.scopes) > 0 {
+		return slices.Clone(creds.scopes)
+	}
+	if info, err := ParseToken(creds.authToken.Reveal()); err == nil {
+		return info.Scopes
+	}
+	return nil
+}
+
+// LoadScopes fetches the current session's scopes from the profile, for
+// sessions whose token and sign-in response did not carry them
+func (as *AuthService) LoadScopes(ctx context.Context, reqOpts ...RequestOption) ([]string, error) {
+	token := as.client.credentials().authToken
+	var profile struct {
+		Scopes []string `json:"scopes"`
+	}
+	if err := as.client.Get(ctx, "/auth/profile", nil, &profile, reqOpts...); err != nil {
+		return nil, err
+	}
+	as.client.credsMu.Lock()
+	if as.client.creds.authToken == token {
+		as.client.creds.scopes = profile.Scopes
+	}
+	as.client.credsMu.Unlock()
+	return profile.Scopes, nil
+}
+
+// scopesOf returns the scopes of a sign-in response, from the token's claims
+// when the response lists none
+func (r *AuthResponse) scopesOf() []string {
+	if len(r.Scopes) > 0 {
+		return r.Scopes
+	}
+	if info, err := ParseToken(r.Token); err == nil {
+		return info.Scopes
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,129 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// jwtWith returns an unsigned JWT carrying claims
+func jwtWith(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2ln"
+}
+
+// scopeServer answers sign-ins with response and the profile with profile
+func scopeServer(t *testing.T, response, profile string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth/wallet":
+			w.Write([]byte(response))
+		case "/auth/profile":
+			w.Write([]byte(profile))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHasScope(t *testing.T) {
+	scopeClaim := jwtWith(t, map[string]interface{}{"sub": "rWallet", "scope": "projects:read investments:read"})
+	scopesClaim := jwtWith(t, map[string]interface{}{"sub": "rWallet", "scopes": []string{"projects:read", "investments:read"}})
+	tests := []struct {
+		name string
+		// token is set directly; otherwise the client signs in and gets response
+		token    string
+		response string
+		// loadScopes fetches the scopes from profile
+		loadScopes bool
+		profile    string
+		want       []string
+	}{
+		{"scope claim", scopeClaim, "", false, "", []string{xrplsale.ScopeProjectsRead, xrplsale.ScopeInvestmentsRead}},
+		{"scopes claim", scopesClaim, "", false, "", []string{xrplsale.ScopeProjectsRead, xrplsale.ScopeInvestmentsRead}},
+		{"opaque token", "token", "", false, "", nil},
+		{"profile", "token", "", true, `{"scopes":["profile:read","webhooks:write"]}`, []string{xrplsale.ScopeProfileRead, xrplsale.ScopeWebhooksWrite}},
+		{"listed in the sign-in response", "", `{"token":"` + scopeClaim + `","scopes":["analytics:read"]}`, false, "", []string{xrplsale.ScopeAnalyticsRead}},
+		{"claims of the sign-in response", "", `{"token":"` + scopesClaim + `"}`, false, "", []string{xrplsale.ScopeProjectsRead, xrplsale.ScopeInvestmentsRead}},
+	}
+	all := []string{
+		xrplsale.ScopeProjectsRead, xrplsale.ScopeProjectsWrite, xrplsale.ScopeInvestmentsRead, xrplsale.ScopeInvestmentsWrite,
+		xrplsale.ScopeAnalyticsRead, xrplsale.ScopeWebhooksRead, xrplsale.ScopeWebhooksWrite, xrplsale.ScopeProfileRead, xrplsale.ScopeProfileWrite,
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := scopeServer(t, tt.response, tt.profile)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			if tt.token != "" {
+				client.SetAuthToken(tt.token)
+			} else if _, err := client.Auth.Authenticate(context.Background(), &xrplsale.AuthRequest{WalletAddress: "rWallet", Signature: "00"}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.loadScopes {
+				if _, err := client.Auth.LoadScopes(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			
+			granted := map[string]bool{}
+			for _, scope := range tt.want {
+				granted[scope] = true
+			}
+			for _, scope := range all {
+				if got := client.Auth.HasScope(scope); got != granted[scope] {
+					t.Errorf("HasScope(%s) = %v, want %v", scope, got, granted[scope])
+				}
+			}
+			if got := client.Auth.Scopes(); len(got) != len(tt.want) {
+				t.Errorf("Scopes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionError(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantScope string
+	}{
+		{"required scope", `{"message":"forbidden","required_scope":"investments:write"}`, xrplsale.ScopeInvestmentsWrite},
+		{"scope in details", `{"message":"forbidden","details":{"required_scope":"projects:write"}}`, xrplsale.ScopeProjectsWrite},
+		{"no scope", `{"message":"forbidden"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			err := client.Post(context.Background(), "/investments", nil, nil, xrplsale.WithNoRetry())
+			var permErr *xrplsale.PermissionError
+			if !errors.As(err, &permErr) || permErr.RequiredScope != tt.wantScope || permErr.StatusCode != http.StatusForbidden {
+				t.Fatalf("Post() = %#v, want a PermissionError requiring %q", err, tt.wantScope)
+			}
+			var authErr *xrplsale.AuthError
+			if !errors.As(err, &authErr) {
+				t.Errorf("Post() = %v, want it to match AuthError too", err)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,62 @@
+package xrplsale
+
+import (
+	"fmt"
+	"io"
+)
+
+// redacted replaces a Secret wherever it is printed or serialized
+const redacted = "[redacted]"
+
+// Secret holds credential material such as an API key, token or webhook
+// secret. Printing it with any fmt verb, logging it or marshaling it to JSON
+// yields "[redacted]", so a Config or error that embeds one never leaks it.
+// Reveal returns the actual value.
+type Secret string
+
+// Reveal returns the secret value. Only call it where the value is sent
+// or used for signing.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String implements fmt.Stringer
+func (s Secret) String() string {
+	return redacted
+}
+
+// GoString implements fmt.GoStringer
+func (s Secret) GoString() string {
+	return redacted
+}
+
+// Format implements fmt.Formatter, so %s, %v, %q, %x and friends are all redacted
+func (s Secret) Format(f fmt.State, verb rune) {
+	io.WriteString(f, redacted)
+}
+
+// MarshalJSON implements json.Marshaler
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+// apiKey returns the configured API key, preferring APIKeySecret
+func (c *Config) apiKey() Secret {
+	if c.APIKeySecret != "" {
+		return c.APIKeySecret
+	}
+	return Secret(c.APIKey)
+}
+
+// webhookSecret returns the configured webhook secret, preferring WebhookSigningSecret
+func (c *Config) webhookSecret() Secret {
+	if c.WebhookSigningSecret != "" {
+		return c.WebhookSigningSecret
+	}
+	return Secret(c.WebhookSecret)
+}
+
+// MarshalText implements encoding.TextMarshaler, covering map keys and text encoders
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
\ No newline at end of file
@@ -0,0 +1,74 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestSecretRedacted(t *testing.T) {
+	const value = "sk_live_0123456789"
+	secret := xrplsale.Secret(value)
+	if secret.Reveal() != value {
+		t.Fatalf("Reveal() = %q, want %q", secret.Reveal(), value)
+	}
+	
+	type holder struct {
+		Key   xrplsale.Secret
+		ByKey map[xrplsale.Secret]int
+	}
+	h := holder{Key: secret, ByKey: map[xrplsale.Secret]int{secret: 1}}
+	encoded, err := json.Marshal(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs := map[string]string{"json": string(encoded)}
+	for _, verb := range []string{"%s", "%v", "%+v", "%#v", "%q", "%x", "%X", "%d", "%10s"} {
+		outputs[verb] = fmt.Sprintf(verb, secret)
+		outputs[verb+" in a struct"] = fmt.Sprintf(verb, h)
+	}
+	outputs["config"] = fmt.Sprintf("%+v", xrplsale.Config{APIKeySecret: secret, WebhookSigningSecret: secret})
+	for name, output := range outputs {
+		if strings.Contains(output, value) || strings.Contains(output, fmt.Sprintf("%x", value)) || !strings.Contains(output, "[redacted]") {
+			t.Errorf("%s = %q, want the secret redacted", name, output)
+		}
+	}
+}
+
+func TestConfigAPIKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key":"` + r.Header.Get("X-API-Key") + `"}`))
+	}))
+	defer srv.Close()
+	
+	tests := []struct {
+		name   string
+		config xrplsale.Config
+		want   string
+	}{
+		{"string", xrplsale.Config{APIKey: "plain"}, "plain"},
+		{"secret", xrplsale.Config{APIKeySecret: "hidden"}, "hidden"},
+		{"secret wins", xrplsale.Config{APIKey: "plain", APIKeySecret: "hidden"}, "hidden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.BaseURL = srv.URL
+			client := xrplsale.NewClientWithConfig(&config)
+			var got struct{ Key string }
+			if err := client.Get(context.Background(), "/projects", nil, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Key != tt.want {
+				t.Fatalf("sent API key %q, want %q", got.Key, tt.want)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,89 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ServiceAccount holds a service account's credentials, for backend
+// integrations that have no wallet to sign challenges with
+type ServiceAccount struct {
+	ClientID     string
+	ClientSecret Secret
+}
+
+// clientCredentialsGrant is the grant exchanging a service account's
+// credentials for tokens
+const clientCredentialsGrant = "client_credentials"
+
+// errNoServiceAccount is returned when a service account's ID or secret is empty
+var errNoServiceAccount = errors.New("service account client ID and secret are required")
+
+// AuthenticateServiceAccount exchanges a service account's client ID and
+// secret for an auth token and makes it the client's. The credentials are
+// kept so the session can be renewed: Refresh uses the refresh token when
+// the API issued one and otherwise exchanges the credentials again.
+func (as *AuthService) AuthenticateServiceAccount(ctx context.Context, clientID, clientSecret string, reqOpts ...RequestOption) (*AuthResponse, error) {
+	account := &ServiceAccount{ClientID: clientID, ClientSecret: Secret(clientSecret)}
+	response, err := as.exchangeServiceAccount(ctx, account, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	as.client.credsMu.Lock()
+	as.client.creds.serviceAccount = account
+	as.client.credsMu.Unlock()
+	return response, as.signedIn(ctx, response)
+}
+
+// exchangeServiceAccount posts account's credentials to the token endpoint
+func (as *AuthService) exchangeServiceAccount(ctx context.Context, account *ServiceAccount, reqOpts ...RequestOption) (*AuthResponse, error) {
+	if account.ClientID == "" || account.ClientSecret == "" {
+		return nil, errNoServiceAccount
+	}
+	req := map[string]string{
+		"grant_type":    clientCredentialsGrant,
+		"client_id":     account.ClientID,
+		"client_secret": account.ClientSecret.Reveal(),
+	}
+	var response AuthResponse
+	if err := as.client.Post(ctx, "/auth/token", req, &response, reqOpts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// renewServiceAccount exchanges account's credentials for a new auth token.
+// Renewals of the same token share one exchange, like refreshes.
+func (c *Client) renewServiceAccount(ctx context.Context, account *ServiceAccount, reqOpts ...RequestOption) (*AuthResponse, error) {
+	key := clientCredentialsGrant + "\x00" + c.credentials().authToken.Reveal()
+	return c.refreshShared(ctx, key, func(ctx context.Context) (*AuthResponse, error) {
+		// The exchange itself must not sign in or renew again
+		ctx = context.WithValue(ctx, autoRefreshKey{}, true)
+		response, err := c.services.auth.exchangeServiceAccount(ctx, account, reqOpts...)
+		if err != nil {
+			return nil, err
+		}
+		response.setDerived(time.Now())
+		if err := c.storeTokens(ctx, response); err != nil {
+			return response, err
+		}
+		return response, nil
+	})
+}
+
+// signInServiceAccount authenticates with Config.ServiceAccount before a
+// request when the client has no auth token yet. Failures go to
+// Config.OnAuthError; the request then goes out unauthenticated.
+func (c *Client) signInServiceAccount(ctx context.Context) {
+	if ctx.Value(autoRefreshKey{}) != nil {
+		return
+	}
+	creds := c.credentials()
+	if creds.serviceAccount == nil || creds.authToken != "" {
+		return
+	}
+	if _, err := c.renewServiceAccount(ctx, creds.serviceAccount); err != nil {
+		c.authFailed("service account authentication failed", err)
+	}
+}
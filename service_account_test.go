@@ -0,0 +1,91 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestServiceAccount(t *testing.T) {
+	tests := []struct {
+		name string
+		// lazy sets Config.ServiceAccount instead of calling
+		// AuthenticateServiceAccount up front
+		lazy    bool
+		secret  string
+		callers int
+		// expire rejects the first token after the first call
+		expire        bool
+		wantExchanges int
+		wantErr       bool
+	}{
+		{"lazy sign-in on the first call", true, "secret", 1, false, 1, false},
+		{"concurrent first calls sign in once", true, "secret", 20, false, 1, false},
+		{"re-authenticates after a 401", false, "secret", 1, true, 2, false},
+		{"lazy sign-in re-authenticates after a 401", true, "secret", 1, true, 2, false},
+		{"wrong secret", true, "wrong", 1, false, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authErrors atomic.Int32
+			srv, client, hits := newCountingClient(t, func(c *xrplsale.Config) {
+				c.RetryOnUnauthorized = true
+				c.OnAuthError = func(error) { authErrors.Add(1) }
+				if tt.lazy {
+					c.ServiceAccount = &xrplsale.ServiceAccount{ClientID: "backend", ClientSecret: xrplsale.Secret(tt.secret)}
+				}
+			})
+			srv.AddServiceAccount("backend", "secret")
+			if !tt.lazy {
+				if _, err := client.Auth.AuthenticateServiceAccount(context.Background(), "backend", tt.secret); err != nil {
+					t.Fatal(err)
+				}
+			}
+			
+			call := func() error {
+				_, err := client.Auth.GetProfile(context.Background(), xrplsale.WithNoRetry())
+				return err
+			}
+			var wg sync.WaitGroup
+			errs := make(chan error, tt.callers)
+			for i := 0; i < tt.callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs <- call()
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if tt.wantErr != errors.Is(err, xrplsale.ErrUnauthorized) || !tt.wantErr && err != nil {
+					t.Fatalf("first call = %v, want unauthorized %v", err, tt.wantErr)
+				}
+			}
+			if tt.wantErr {
+				if authErrors.Load() == 0 || client.IsAuthenticated() {
+					t.Errorf("failed sign-in: %d auth errors, authenticated %v; want it reported and no token", authErrors.Load(), client.IsAuthenticated())
+				}
+				return
+			}
+			if tt.expire {
+				srv.ExpireTokens()
+			}
+			if err := call(); err != nil {
+				t.Fatalf("second call = %v", err)
+			}
+			if got := hits.count(http.MethodPost, "/auth/token"); got != tt.wantExchanges {
+				t.Errorf("%d credential exchanges, want %d", got, tt.wantExchanges)
+			}
+			// Service account tokens come without a refresh token
+			if got := hits.count(http.MethodPost, "/auth/refresh"); got != 0 {
+				t.Errorf("%d refresh requests, want the credentials exchanged instead", got)
+			}
+		})
+	}
+}
\ No newline at end of file
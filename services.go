@@ -2,7 +2,10 @@ package xrplsale
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"time"
 )
 
@@ -13,125 +16,283 @@ type ProjectsService struct {
 
 // ListProjectsOptions represents options for listing projects
 type ListProjectsOptions struct {
-	Status   string `url:"status,omitempty"`
-	Page     int    `url:"page,omitempty"`
-	Limit    int    `url:"limit,omitempty"`
-	SortBy   string `url:"sort_by,omitempty"`
-	SortOrder string `url:"sort_order,omitempty"`
-}
-
-// List retrieves a list of projects
-func (ps *ProjectsService) List(ctx context.Context, opts *ListProjectsOptions) (*PaginatedResponse[Project], error) {
-	params := make(map[string]string)
-	if opts != nil {
-		if opts.Status != "" {
-			params["status"] = opts.Status
-		}
-		if opts.Page > 0 {
-			params["page"] = fmt.Sprintf("%d", opts.Page)
-		}
-		if opts.Limit > 0 {
-			params["limit"] = fmt.Sprintf("%d", opts.Limit)
-		}
-		if opts.SortBy != "" {
-			params["sort_by"] = opts.SortBy
-		}
-		if opts.SortOrder != "" {
-			params["sort_order"] = opts.SortOrder
-		}
+	Status    ProjectStatus `url:"status,omitempty"`
+	Search    string        `url:"q,omitempty"`
+	Page      int           `url:"page,positive"`
+	Limit     int           `url:"limit,positive"`
+	SortBy    string        `url:"sort_by,omitempty"`
+	SortOrder string        `url:"sort_order,omitempty"`
+}
+
+// validate checks the filters the API would silently ignore
+func (o *ListProjectsOptions) validate() error {
+	if o == nil || o.Status == "" {
+		return nil
+	}
+	return o.Status.Validate()
+}
+
+// List retrieves a list of projects. An unknown opts.Status fails with an
+// error matching ErrValidation before any request is sent.
+func (ps *ProjectsService) List(ctx context.Context, opts *ListProjectsOptions, reqOpts ...RequestOption) (*PaginatedResponse[Project], error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
 	}
-	
 	var result PaginatedResponse[Project]
-	err := ps.client.Get(ctx, "/projects", params, &result)
+	err := ps.client.Get(ctx, "/projects", encodeQuery(opts), &result, reqOpts...)
 	return &result, err
 }
 
 // GetActive retrieves active projects
-func (ps *ProjectsService) GetActive(ctx context.Context, page, limit int) (*PaginatedResponse[Project], error) {
+func (ps *ProjectsService) GetActive(ctx context.Context, page, limit int, reqOpts ...RequestOption) (*PaginatedResponse[Project], error) {
 	return ps.List(ctx, &ListProjectsOptions{
-		Status: "active",
+		Status: StatusActive,
 		Page:   page,
 		Limit:  limit,
-	})
+	}, reqOpts...)
 }
 
 // Get retrieves a specific project
-func (ps *ProjectsService) Get(ctx context.Context, projectID string) (*Project, error) {
+func (ps *ProjectsService) Get(ctx context.Context, projectID string, reqOpts ...RequestOption) (*Project, error) {
 	var project Project
-	err := ps.client.Get(ctx, fmt.Sprintf("/projects/%s", projectID), nil, &project)
+	err := ps.client.Get(ctx, fmt.Sprintf("/projects/%s", projectID), nil, &project, reqOpts...)
 	return &project, err
 }
 
+// Exists reports whether a project exists without downloading it
+func (ps *ProjectsService) Exists(ctx context.Context, projectID string, reqOpts ...RequestOption) (bool, error) {
+	return ps.client.exists(ctx, fmt.Sprintf("/projects/%s", projectID), reqOpts)
+}
+
 // Create creates a new project
-func (ps *ProjectsService) Create(ctx context.Context, project *CreateProjectRequest) (*Project, error) {
+func (ps *ProjectsService) Create(ctx context.Context, project *CreateProjectRequest, reqOpts ...RequestOption) (*Project, error) {
 	var result Project
-	err := ps.client.Post(ctx, "/projects", project, &result)
+	err := ps.client.Post(ctx, "/projects", project, &result, reqOpts...)
 	return &result, err
 }
 
 // Update updates a project
-func (ps *ProjectsService) Update(ctx context.Context, projectID string, updates map[string]interface{}) (*Project, error) {
+func (ps *ProjectsService) Update(ctx context.Context, projectID string, updates map[string]interface{}, reqOpts ...RequestOption) (*Project, error) {
 	var result Project
-	err := ps.client.Patch(ctx, fmt.Sprintf("/projects/%s", projectID), updates, &result)
+	err := ps.client.Patch(ctx, fmt.Sprintf("/projects/%s", projectID), updates, &result, reqOpts...)
 	return &result, err
 }
 
 // Launch launches a project
-func (ps *ProjectsService) Launch(ctx context.Context, projectID string) (*Project, error) {
+func (ps *ProjectsService) Launch(ctx context.Context, projectID string, reqOpts ...RequestOption) (*Project, error) {
 	var result Project
-	err := ps.client.Post(ctx, fmt.Sprintf("/projects/%s/launch", projectID), nil, &result)
+	err := ps.client.Post(ctx, fmt.Sprintf("/projects/%s/launch", projectID), nil, &result, reqOpts...)
 	return &result, err
 }
 
 // GetStats retrieves project statistics
-func (ps *ProjectsService) GetStats(ctx context.Context, projectID string) (*ProjectStats, error) {
+func (ps *ProjectsService) GetStats(ctx context.Context, projectID string, reqOpts ...RequestOption) (*ProjectStats, error) {
 	var stats ProjectStats
-	err := ps.client.Get(ctx, fmt.Sprintf("/projects/%s/stats", projectID), nil, &stats)
+	err := ps.client.Get(ctx, fmt.Sprintf("/projects/%s/stats", projectID), nil, &stats, reqOpts...)
 	return &stats, err
 }
 
+// GetStatsHistory retrieves project statistics over period, grouped into
+// granularity buckets. Combinations rejected by CheckGranularity are
+// returned as ErrInvalidTimeframe before anything is sent.
+func (ps *ProjectsService) GetStatsHistory(ctx context.Context, projectID string, period Period, granularity Granularity, reqOpts ...RequestOption) (*StatsHistory, error) {
+	if err := CheckGranularity(period, granularity); err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"period":      string(period),
+		"granularity": string(granularity),
+	}
+	var history StatsHistory
+	err := ps.client.Get(ctx, fmt.Sprintf("/projects/%s/stats/history", projectID), params, &history, reqOpts...)
+	return &history, err
+}
+
+// GetTokenDetails retrieves the recorded issuance settings of a project's token
+func (ps *ProjectsService) GetTokenDetails(ctx context.Context, projectID string, reqOpts ...RequestOption) (*TokenDetails, error) {
+	var details TokenDetails
+	err := ps.client.Get(ctx, fmt.Sprintf("/projects/%s/token", projectID), nil, &details, reqOpts...)
+	return &details, err
+}
+
+// ListAnnouncements retrieves a project's announcements
+func (ps *ProjectsService) ListAnnouncements(ctx context.Context, projectID string, opts *ListAnnouncementsOptions, reqOpts ...RequestOption) (*PaginatedResponse[Announcement], error) {
+	var result PaginatedResponse[Announcement]
+	err := ps.client.Get(ctx, fmt.Sprintf("/projects/%s/announcements", projectID), opts.params(), &result, reqOpts...)
+	return &result, err
+}
+
+// CreateAnnouncement publishes an announcement on a project's sale page
+func (ps *ProjectsService) CreateAnnouncement(ctx context.Context, projectID string, announcement *CreateAnnouncementRequest, reqOpts ...RequestOption) (*Announcement, error) {
+	var result Announcement
+	err := ps.client.Post(ctx, fmt.Sprintf("/projects/%s/announcements", projectID), announcement, &result, reqOpts...)
+	return &result, err
+}
+
+// UpdateAnnouncement updates an announcement
+func (ps *ProjectsService) UpdateAnnouncement(ctx context.Context, projectID, announcementID string, updates map[string]interface{}, reqOpts ...RequestOption) (*Announcement, error) {
+	var result Announcement
+	err := ps.client.Patch(ctx, fmt.Sprintf("/projects/%s/announcements/%s", projectID, announcementID), updates, &result, reqOpts...)
+	return &result, err
+}
+
+// DeleteAnnouncement deletes an announcement
+func (ps *ProjectsService) DeleteAnnouncement(ctx context.Context, projectID, announcementID string, reqOpts ...RequestOption) error {
+	return ps.client.Delete(ctx, fmt.Sprintf("/projects/%s/announcements/%s", projectID, announcementID), nil, reqOpts...)
+}
+
+// GetMyPermissions retrieves what the current token may do on a project
+func (ps *ProjectsService) GetMyPermissions(ctx context.Context, projectID string, reqOpts ...RequestOption) (*ProjectPermissions, error) {
+	var permissions ProjectPermissions
+	err := ps.client.Get(ctx, fmt.Sprintf("/projects/%s/permissions/me", projectID), nil, &permissions, reqOpts...)
+	return &permissions, err
+}
+
+// ListCollaborators retrieves a project's collaborators and pending invitations
+func (ps *ProjectsService) ListCollaborators(ctx context.Context, projectID string, reqOpts ...RequestOption) (*Collaborators, error) {
+	var result Collaborators
+	err := ps.client.Get(ctx, fmt.Sprintf("/projects/%s/collaborators", projectID), nil, &result, reqOpts...)
+	return &result, err
+}
+
+// InviteCollaborator invites a user to a project. The invitation is usually
+// pending until the invitee accepts it; check Pending before assuming access.
+func (ps *ProjectsService) InviteCollaborator(ctx context.Context, projectID string, invite *InviteCollaboratorRequest, reqOpts ...RequestOption) (*CollaboratorInvitation, error) {
+	var result CollaboratorInvitation
+	err := ps.client.Post(ctx, fmt.Sprintf("/projects/%s/collaborators", projectID), invite, &result, reqOpts...)
+	return &result, err
+}
+
+// UpdateCollaboratorRole changes an active collaborator's role
+func (ps *ProjectsService) UpdateCollaboratorRole(ctx context.Context, projectID, collaboratorID string, role CollaboratorRole, reqOpts ...RequestOption) (*Collaborator, error) {
+	var result Collaborator
+	err := ps.client.Patch(ctx, fmt.Sprintf("/projects/%s/collaborators/%s", projectID, collaboratorID), map[string]CollaboratorRole{"role": role}, &result, reqOpts...)
+	return &result, err
+}
+
+// RemoveCollaborator removes a collaborator from a project
+func (ps *ProjectsService) RemoveCollaborator(ctx context.Context, projectID, collaboratorID string, reqOpts ...RequestOption) error {
+	return ps.client.Delete(ctx, fmt.Sprintf("/projects/%s/collaborators/%s", projectID, collaboratorID), nil, reqOpts...)
+}
+
+// UploadDocument uploads a logo, whitepaper or other document of a project
+func (ps *ProjectsService) UploadDocument(ctx context.Context, projectID, filename string, r io.Reader, docType DocumentType, reqOpts ...RequestOption) (*ProjectDocument, error) {
+	var result ProjectDocument
+	err := ps.client.Upload(ctx, fmt.Sprintf("/projects/%s/documents", projectID), map[string]io.Reader{filename: r}, map[string]string{"type": string(docType)}, &result, reqOpts...)
+	return &result, err
+}
+
 // InvestmentsService handles investment-related operations
 type InvestmentsService struct {
 	client *Client
 }
 
 // Create creates a new investment
-func (is *InvestmentsService) Create(ctx context.Context, investment *CreateInvestmentRequest) (*Investment, error) {
+func (is *InvestmentsService) Create(ctx context.Context, investment *CreateInvestmentRequest, reqOpts ...RequestOption) (*Investment, error) {
+	if newRequestOptions(reqOpts).balancePrecheck {
+		if err := is.precheckBalance(ctx, investment, reqOpts); err != nil {
+			return nil, err
+		}
+	}
+	
+	var result Investment
+	err := is.client.Post(ctx, "/investments", investment, &result, reqOpts...)
+	return &result, err
+}
+
+// CreateManual records an investment settled off-platform. Only the project
+// owner may record manual investments; other callers get an error matching ErrForbidden.
+func (is *InvestmentsService) CreateManual(ctx context.Context, investment *CreateManualInvestmentRequest, reqOpts ...RequestOption) (*Investment, error) {
+	if err := investment.Validate(); err != nil {
+		return nil, err
+	}
+	
 	var result Investment
-	err := is.client.Post(ctx, "/investments", investment, &result)
+	err := is.client.Post(ctx, "/investments/manual", investment, &result, reqOpts...)
 	return &result, err
 }
 
 // Get retrieves a specific investment
-func (is *InvestmentsService) Get(ctx context.Context, investmentID string) (*Investment, error) {
+func (is *InvestmentsService) Get(ctx context.Context, investmentID string, reqOpts ...RequestOption) (*Investment, error) {
 	var investment Investment
-	err := is.client.Get(ctx, fmt.Sprintf("/investments/%s", investmentID), nil, &investment)
+	err := is.client.Get(ctx, fmt.Sprintf("/investments/%s", investmentID), nil, &investment, reqOpts...)
 	return &investment, err
 }
 
 // GetByProject retrieves investments for a project
-func (is *InvestmentsService) GetByProject(ctx context.Context, projectID string, page, limit int) (*PaginatedResponse[Investment], error) {
-	params := map[string]string{
-		"page":  fmt.Sprintf("%d", page),
-		"limit": fmt.Sprintf("%d", limit),
-	}
+func (is *InvestmentsService) GetByProject(ctx context.Context, projectID string, page, limit int, reqOpts ...RequestOption) (*PaginatedResponse[Investment], error) {
+	params := encodeQuery(pageParams{Page: page, Limit: limit})
 	
 	var result PaginatedResponse[Investment]
-	err := is.client.Get(ctx, fmt.Sprintf("/projects/%s/investments", projectID), params, &result)
+	err := is.client.Get(ctx, fmt.Sprintf("/projects/%s/investments", projectID), params, &result, reqOpts...)
 	return &result, err
 }
 
+// StreamByProject streams every investment for a project as NDJSON.
+// The caller must Close the stream if it stops reading before the end.
+func (is *InvestmentsService) StreamByProject(ctx context.Context, projectID string, opts *StreamOptions, reqOpts ...RequestOption) (*Stream[Investment], error) {
+	body, cancel, err := is.client.openStream(ctx, fmt.Sprintf("/projects/%s/investments", projectID), opts.params(), reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	return newStream[Investment](body, cancel), nil
+}
+
 // GetInvestorSummary retrieves an investor's summary
-func (is *InvestmentsService) GetInvestorSummary(ctx context.Context, investorAccount string) (*InvestorSummary, error) {
+func (is *InvestmentsService) GetInvestorSummary(ctx context.Context, investorAccount string, reqOpts ...RequestOption) (*InvestorSummary, error) {
 	var summary InvestorSummary
-	err := is.client.Get(ctx, fmt.Sprintf("/investors/%s/summary", investorAccount), nil, &summary)
+	err := is.client.Get(ctx, fmt.Sprintf("/investors/%s/summary", investorAccount), nil, &summary, reqOpts...)
 	return &summary, err
 }
 
 // Simulate simulates an investment
-func (is *InvestmentsService) Simulate(ctx context.Context, simulation *SimulateInvestmentRequest) (*SimulationResult, error) {
+func (is *InvestmentsService) Simulate(ctx context.Context, simulation *SimulateInvestmentRequest, reqOpts ...RequestOption) (*SimulationResult, error) {
 	var result SimulationResult
-	err := is.client.Post(ctx, "/investments/simulate", simulation, &result)
+	err := is.client.Post(ctx, "/investments/simulate", simulation, &result, reqOpts...)
+	return &result, err
+}
+
+// FlagDispute raises a dispute on a confirmed investment, e.g. when the
+// investor never received their tokens. Unconfirmed investments are rejected
+// with ErrInvestmentNotConfirmed before anything is sent.
+func (is *InvestmentsService) FlagDispute(ctx context.Context, investmentID string, dispute *DisputeRequest, reqOpts ...RequestOption) (*Dispute, error) {
+	investment, err := is.Get(ctx, investmentID, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if investment.Status != InvestmentConfirmed {
+		return nil, fmt.Errorf("%w: %s is %s", ErrInvestmentNotConfirmed, investmentID, investment.Status)
+	}
+	
+	var result Dispute
+	err = is.client.Post(ctx, fmt.Sprintf("/investments/%s/disputes", investmentID), dispute, &result, reqOpts...)
+	return &result, err
+}
+
+// GetDispute retrieves a specific dispute
+func (is *InvestmentsService) GetDispute(ctx context.Context, disputeID string, reqOpts ...RequestOption) (*Dispute, error) {
+	var dispute Dispute
+	err := is.client.Get(ctx, fmt.Sprintf("/disputes/%s", disputeID), nil, &dispute, reqOpts...)
+	return &dispute, err
+}
+
+// ListDisputes retrieves the disputes raised on a project's investments.
+// Only the project owner may list them.
+func (is *InvestmentsService) ListDisputes(ctx context.Context, projectID string, opts *ListDisputesOptions, reqOpts ...RequestOption) (*PaginatedResponse[Dispute], error) {
+	var result PaginatedResponse[Dispute]
+	err := is.client.Get(ctx, fmt.Sprintf("/projects/%s/disputes", projectID), opts.params(), &result, reqOpts...)
+	return &result, err
+}
+
+// AttachEvidence uploads a receipt, screenshot or other file supporting a
+// dispute. Like every upload it is streamed and never retried.
+func (is *InvestmentsService) AttachEvidence(ctx context.Context, disputeID, filename string, r io.Reader, description string, reqOpts ...RequestOption) (*DisputeEvidence, error) {
+	fields := map[string]string{}
+	if description != "" {
+		fields["description"] = description
+	}
+	var result DisputeEvidence
+	err := is.client.Upload(ctx, fmt.Sprintf("/disputes/%s/evidence", disputeID), map[string]io.Reader{filename: r}, fields, &result, reqOpts...)
 	return &result, err
 }
 
@@ -141,36 +302,60 @@ type AnalyticsService struct {
 }
 
 // GetPlatformAnalytics retrieves platform-wide analytics
-func (as *AnalyticsService) GetPlatformAnalytics(ctx context.Context) (*PlatformAnalytics, error) {
+func (as *AnalyticsService) GetPlatformAnalytics(ctx context.Context, reqOpts ...RequestOption) (*PlatformAnalytics, error) {
 	var analytics PlatformAnalytics
-	err := as.client.Get(ctx, "/analytics/platform", nil, &analytics)
+	err := as.client.Get(ctx, "/analytics/platform", nil, &analytics, reqOpts...)
 	return &analytics, err
 }
 
 // GetProjectAnalytics retrieves project-specific analytics
-func (as *AnalyticsService) GetProjectAnalytics(ctx context.Context, projectID string, startDate, endDate time.Time) (*ProjectAnalytics, error) {
+func (as *AnalyticsService) GetProjectAnalytics(ctx context.Context, projectID string, startDate, endDate time.Time, reqOpts ...RequestOption) (*ProjectAnalytics, error) {
 	params := map[string]string{
 		"start_date": startDate.Format("2006-01-02"),
 		"end_date":   endDate.Format("2006-01-02"),
 	}
 	
 	var analytics ProjectAnalytics
-	err := as.client.Get(ctx, fmt.Sprintf("/analytics/projects/%s", projectID), params, &analytics)
+	err := as.client.Get(ctx, fmt.Sprintf("/analytics/projects/%s", projectID), params, &analytics, reqOpts...)
 	return &analytics, err
 }
 
+// GetGeoDistribution retrieves the geographical distribution of a project's investors
+func (as *AnalyticsService) GetGeoDistribution(ctx context.Context, projectID string, dateRange *DateRange, reqOpts ...RequestOption) (*GeoDistribution, error) {
+	var distribution GeoDistribution
+	err := as.client.Get(ctx, fmt.Sprintf("/analytics/projects/%s/geo", projectID), dateRange.params(), &distribution, reqOpts...)
+	return &distribution, err
+}
+
+// GetTrends retrieves market trends over period. Unknown periods are
+// rejected with ErrInvalidTimeframe before anything is sent.
+func (as *AnalyticsService) GetTrends(ctx context.Context, period Period, reqOpts ...RequestOption) (*MarketTrends, error) {
+	if err := period.Validate(); err != nil {
+		return nil, err
+	}
+	return as.getTrends(ctx, string(period), reqOpts)
+}
+
 // GetMarketTrends retrieves market trends
-func (as *AnalyticsService) GetMarketTrends(ctx context.Context, period string) (*MarketTrends, error) {
+//
+// Deprecated: Use GetTrends, which takes a typed Period and validates it.
+func (as *AnalyticsService) GetMarketTrends(ctx context.Context, period string, reqOpts ...RequestOption) (*MarketTrends, error) {
+	return as.getTrends(ctx, period, reqOpts)
+}
+
+// getTrends fetches market trends without validating the period, so the
+// deprecated string form keeps passing periods through unchanged
+func (as *AnalyticsService) getTrends(ctx context.Context, period string, reqOpts []RequestOption) (*MarketTrends, error) {
 	params := map[string]string{"period": period}
 	var trends MarketTrends
-	err := as.client.Get(ctx, "/analytics/trends", params, &trends)
+	err := as.client.Get(ctx, "/analytics/trends", params, &trends, reqOpts...)
 	return &trends, err
 }
 
 // ExportData exports analytics data
-func (as *AnalyticsService) ExportData(ctx context.Context, exportReq *ExportDataRequest) (*ExportResult, error) {
+func (as *AnalyticsService) ExportData(ctx context.Context, exportReq *ExportDataRequest, reqOpts ...RequestOption) (*ExportResult, error) {
 	var result ExportResult
-	err := as.client.Post(ctx, "/analytics/export", exportReq, &result)
+	err := as.client.Post(ctx, "/analytics/export", exportReq, &result, reqOpts...)
 	return &result, err
 }
 
@@ -180,43 +365,109 @@ type AuthService struct {
 }
 
 // GenerateChallenge generates an authentication challenge
-func (as *AuthService) GenerateChallenge(ctx context.Context, walletAddress string) (*AuthChallenge, error) {
+func (as *AuthService) GenerateChallenge(ctx context.Context, walletAddress string, reqOpts ...RequestOption) (*AuthChallenge, error) {
 	req := map[string]string{"wallet_address": walletAddress}
 	var challenge AuthChallenge
-	err := as.client.Post(ctx, "/auth/challenge", req, &challenge)
+	err := as.client.Post(ctx, "/auth/challenge", req, &challenge, reqOpts...)
 	return &challenge, err
 }
 
-// Authenticate authenticates with wallet signature
-func (as *AuthService) Authenticate(ctx context.Context, authReq *AuthRequest) (*AuthResponse, error) {
-	var response AuthResponse
-	err := as.client.Post(ctx, "/auth/wallet", authReq, &response)
-	if err == nil && response.Token != "" {
-		as.client.SetAuthToken(response.Token)
+// Authenticate authenticates with wallet signature. For accounts with
+// two-factor authentication it returns a *TwoFactorRequiredError; finish
+// with Complete2FA.
+func (as *AuthService) Authenticate(ctx context.Context, authReq *AuthRequest, reqOpts ...RequestOption) (*AuthResponse, error) {
+	var response signInResponse
+	err := as.client.Post(ctx, "/auth/wallet", authReq, &response, reqOpts...)
+	if tfErr := twoFactorError(&response, err); tfErr != nil {
+		return nil, tfErr
 	}
-	return &response, err
+	if err == nil {
+		err = as.signedIn(ctx, &response.AuthResponse)
+	}
+	return &response.AuthResponse, err
 }
 
-// Refresh refreshes the authentication token
-func (as *AuthService) Refresh(ctx context.Context, refreshToken string) (*AuthResponse, error) {
-	req := map[string]string{"refresh_token": refreshToken}
-	var response AuthResponse
-	err := as.client.Post(ctx, "/auth/refresh", req, &response)
-	if err == nil && response.Token != "" {
-		as.client.SetAuthToken(response.Token)
+// signedIn makes the tokens of a new session the client's credentials
+func (as *AuthService) signedIn(ctx context.Context, response *AuthResponse) error {
+	if response.Token == "" {
+		return nil
+	}
+	response.setDerived(time.Now())
+	as.client.setTokens(response.tokenSet())
+	return as.client.saveTokens(ctx)
+}
+
+// Refresh refreshes the authentication token. An empty refreshToken uses the
+// one from the last Authenticate or Refresh; after
+// AuthenticateServiceAccount, the credentials are exchanged again when there
+// is none or it was rejected. Concurrent calls share a single refresh
+// request, and a recently failed refresh is not retried for a few seconds.
+// Config.OnTokenRefresh is called before any caller returns. A rejected
+// refresh token is reported as ErrSessionExpired.
+func (as *AuthService) Refresh(ctx context.Context, refreshToken string, reqOpts ...RequestOption) (*AuthResponse, error) {
+	var account *ServiceAccount
+	own := refreshToken == ""
+	if own {
+		creds := as.client.credentials()
+		refreshToken, account = creds.refreshToken.Reveal(), creds.serviceAccount
+	}
+	if refreshToken == "" && account != nil {
+		return as.client.renewServiceAccount(ctx, account, reqOpts...)
 	}
-	return &response, err
+	if refreshToken == "" {
+		return nil, errNoRefreshToken
+	}
+	
+	response, err := as.refresh(ctx, refreshToken, reqOpts...)
+	if errors.Is(err, ErrSessionExpired) && own {
+		// Another client sharing the token store may have rotated it first
+		if stored, ok := as.client.storedRotation(ctx, refreshToken); ok {
+			if stored.Token != "" {
+				return stored.authResponse(), nil
+			}
+			response, err = as.refresh(ctx, stored.RefreshToken, reqOpts...)
+		}
+	}
+	if errors.Is(err, ErrSessionExpired) && account != nil {
+		return as.client.renewServiceAccount(ctx, account, reqOpts...)
+	}
+	return response, err
+}
+
+// refresh exchanges refreshToken for new tokens
+func (as *AuthService) refresh(ctx context.Context, refreshToken string, reqOpts ...RequestOption) (*AuthResponse, error) {
+	return as.client.refreshShared(ctx, refreshToken, func(ctx context.Context) (*AuthResponse, error) {
+		req := map[string]string{"refresh_token": refreshToken}
+		var response AuthResponse
+		if err := as.client.Post(ctx, "/auth/refresh", req, &response, reqOpts...); err != nil {
+			return nil, refreshError(err)
+		}
+		if response.Token != "" {
+			response.setDerived(time.Now())
+			if err := as.client.storeTokens(ctx, &response); err != nil {
+				return &response, err
+			}
+		}
+		return &response, nil
+	})
 }
 
-// Logout logs out the current session
-func (as *AuthService) Logout(ctx context.Context) error {
-	return as.client.Post(ctx, "/auth/logout", nil, nil)
+// Logout revokes the current session on the API, then forgets its tokens:
+// the client, and its TokenStore, drop the auth and refresh tokens even when
+// the API call fails, so later requests never carry a revoked token. An
+// error means the session may still be valid on the API until it expires.
+func (as *AuthService) Logout(ctx context.Context, reqOpts ...RequestOption) error {
+	err := as.client.Post(ctx, "/auth/logout", nil, nil, reqOpts...)
+	if clearErr := as.client.clearTokens(ctx); err == nil {
+		err = clearErr
+	}
+	return err
 }
 
 // GetProfile retrieves the current user profile
-func (as *AuthService) GetProfile(ctx context.Context) (*UserProfile, error) {
+func (as *AuthService) GetProfile(ctx context.Context, reqOpts ...RequestOption) (*UserProfile, error) {
 	var profile UserProfile
-	err := as.client.Get(ctx, "/auth/profile", nil, &profile)
+	err := as.client.Get(ctx, "/auth/profile", nil, &profile, reqOpts...)
 	return &profile, err
 }
 
@@ -226,51 +477,91 @@ type WebhooksService struct {
 }
 
 // Register registers a new webhook
-func (ws *WebhooksService) Register(ctx context.Context, webhook *RegisterWebhookRequest) (*Webhook, error) {
+func (ws *WebhooksService) Register(ctx context.Context, webhook *RegisterWebhookRequest, reqOpts ...RequestOption) (*Webhook, error) {
 	var result Webhook
-	err := ws.client.Post(ctx, "/webhooks", webhook, &result)
+	err := ws.client.Post(ctx, "/webhooks", webhook, &result, reqOpts...)
 	return &result, err
 }
 
-// List retrieves all webhooks
-func (ws *WebhooksService) List(ctx context.Context) ([]*Webhook, error) {
+// List retrieves all webhooks, following pagination
+func (ws *WebhooksService) List(ctx context.Context, reqOpts ...RequestOption) ([]*Webhook, error) {
 	var webhooks []*Webhook
-	err := ws.client.Get(ctx, "/webhooks", nil, &webhooks)
-	return webhooks, err
+	for page := 1; ; page++ {
+		result, err := ws.ListPaged(ctx, page, webhookPageSize, reqOpts...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range result.Data {
+			webhooks = append(webhooks, &result.Data[i])
+		}
+		if page >= result.Pagination.TotalPages || len(result.Data) == 0 {
+			return webhooks, nil
+		}
+	}
+}
+
+// ListPaged retrieves a page of webhooks. While the API still returns the
+// deprecated unpaginated list, every webhook is returned as page 1.
+func (ws *WebhooksService) ListPaged(ctx context.Context, page, limit int, reqOpts ...RequestOption) (*PaginatedResponse[Webhook], error) {
+	params := map[string]string{
+		"page":  fmt.Sprintf("%d", page),
+		"limit": fmt.Sprintf("%d", limit),
+	}
+	
+	resp, err := ws.client.Do(ctx, http.MethodGet, "/webhooks", params, nil, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return ws.client.decodeWebhookPage(resp)
 }
 
 // Get retrieves a specific webhook
-func (ws *WebhooksService) Get(ctx context.Context, webhookID string) (*Webhook, error) {
+func (ws *WebhooksService) Get(ctx context.Context, webhookID string, reqOpts ...RequestOption) (*Webhook, error) {
 	var webhook Webhook
-	err := ws.client.Get(ctx, fmt.Sprintf("/webhooks/%s", webhookID), nil, &webhook)
+	err := ws.client.Get(ctx, fmt.Sprintf("/webhooks/%s", webhookID), nil, &webhook, reqOpts...)
 	return &webhook, err
 }
 
+// Exists reports whether a webhook exists without downloading it
+func (ws *WebhooksService) Exists(ctx context.Context, webhookID string, reqOpts ...RequestOption) (bool, error) {
+	return ws.client.exists(ctx, fmt.Sprintf("/webhooks/%s", webhookID), reqOpts)
+}
+
 // Update updates a webhook
-func (ws *WebhooksService) Update(ctx context.Context, webhookID string, updates map[string]interface{}) (*Webhook, error) {
+func (ws *WebhooksService) Update(ctx context.Context, webhookID string, updates map[string]interface{}, reqOpts ...RequestOption) (*Webhook, error) {
 	var webhook Webhook
-	err := ws.client.Patch(ctx, fmt.Sprintf("/webhooks/%s", webhookID), updates, &webhook)
+	err := ws.client.Patch(ctx, fmt.Sprintf("/webhooks/%s", webhookID), updates, &webhook, reqOpts...)
 	return &webhook, err
 }
 
 // Delete deletes a webhook
-func (ws *WebhooksService) Delete(ctx context.Context, webhookID string) error {
-	return ws.client.Delete(ctx, fmt.Sprintf("/webhooks/%s", webhookID), nil)
+func (ws *WebhooksService) Delete(ctx context.Context, webhookID string, reqOpts ...RequestOption) error {
+	return ws.client.Delete(ctx, fmt.Sprintf("/webhooks/%s", webhookID), nil, reqOpts...)
 }
 
 // Test tests a webhook delivery
-func (ws *WebhooksService) Test(ctx context.Context, webhookID string) error {
-	return ws.client.Post(ctx, fmt.Sprintf("/webhooks/%s/test", webhookID), nil, nil)
+func (ws *WebhooksService) Test(ctx context.Context, webhookID string, reqOpts ...RequestOption) error {
+	return ws.client.Post(ctx, fmt.Sprintf("/webhooks/%s/test", webhookID), nil, nil, reqOpts...)
 }
 
 // GetDeliveries retrieves webhook delivery logs
-func (ws *WebhooksService) GetDeliveries(ctx context.Context, webhookID string, page, limit int) (*PaginatedResponse[WebhookDelivery], error) {
+func (ws *WebhooksService) GetDeliveries(ctx context.Context, webhookID string, page, limit int, reqOpts ...RequestOption) (*PaginatedResponse[WebhookDelivery], error) {
 	params := map[string]string{
 		"page":  fmt.Sprintf("%d", page),
 		"limit": fmt.Sprintf("%d", limit),
 	}
 	
 	var result PaginatedResponse[WebhookDelivery]
-	err := ws.client.Get(ctx, fmt.Sprintf("/webhooks/%s/deliveries", webhookID), params, &result)
+	err := ws.client.Get(ctx, fmt.Sprintf("/webhooks/%s/deliveries", webhookID), params, &result, reqOpts...)
 	return &result, err
+}
+
+// StreamDeliveries streams every delivery log entry for a webhook as NDJSON.
+// The caller must Close the stream if it stops reading before the end.
+func (ws *WebhooksService) StreamDeliveries(ctx context.Context, webhookID string, opts *StreamOptions, reqOpts ...RequestOption) (*Stream[WebhookDelivery], error) {
+	body, cancel, err := ws.client.openStream(ctx, fmt.Sprintf("/webhooks/%s/deliveries", webhookID), opts.params(), reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	return newStream[WebhookDelivery](body, cancel), nil
 }
\ No newline at end of file
@@ -0,0 +1,120 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Session is a signed-in session of the current user, on this or another
+// device
+type Session struct {
+	ID        string `json:"id"`
+	Device    string `json:"device,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+	
+	CreatedAt  Timestamp `json:"created_at"`
+	LastSeenAt Timestamp `json:"last_seen_at"`
+	ExpiresAt  Timestamp `json:"expires_at"`
+	
+	// Current is set for the session of the client's own token
+	Current bool `json:"current"`
+}
+
+// SessionStatus is the API's view of the client's auth token
+type SessionStatus struct {
+	Valid bool `json:"valid"`
+	
+	// The other fields are only set when Valid is
+	WalletAddress string    `json:"wallet_address,omitempty"`
+	SessionID     string    `json:"session_id,omitempty"`
+	ExpiresAt     Timestamp `json:"expires_at"`
+	Scopes        []string  `json:"scopes,omitempty"`
+}
+
+// VerifySession asks the API whether the client's auth token is still
+// valid, e.g. before starting a long job. It has no side effects: it never
+// triggers AutoRefresh. An invalid, expired or missing token is reported
+// as Valid false, not as an error.
+func (as *AuthService) VerifySession(ctx context.Context, reqOpts ...RequestOption) (*SessionStatus, error) {
+	if !as.client.IsAuthenticated() {
+		return &SessionStatus{}, nil
+	}
+	var status SessionStatus
+	err := as.client.Get(context.WithValue(ctx, autoRefreshKey{}, true), "/auth/session", nil, &status, reqOpts...)
+	if errors.Is(err, ErrUnauthorized) {
+		return &SessionStatus{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ListSessions lists the current user's active sessions
+func (as *AuthService) ListSessions(ctx context.Context, reqOpts ...RequestOption) ([]*Session, error) {
+	var result struct {
+		Sessions []*Session `json:"sessions"`
+	}
+	if err := as.client.Get(ctx, "/auth/sessions", nil, &result, reqOpts...); err != nil {
+		return nil, err
+	}
+	return result.Sessions, nil
+}
+
+// RevokeSession signs a session out. Revoking the client's own session
+// clears its tokens, as Logout does.
+func (as *AuthService) RevokeSession(ctx context.Context, sessionID string, reqOpts ...RequestOption) error {
+	// Whether the session is the client's own must be known before it is
+	// revoked: afterwards the token can no longer list sessions
+	current, err := as.isCurrentSession(ctx, sessionID, reqOpts)
+	if err != nil {
+		return err
+	}
+	if err := as.client.Delete(ctx, fmt.Sprintf("/auth/sessions/%s", sessionID), nil, reqOpts...); err != nil {
+		return err
+	}
+	if current {
+		return as.client.clearTokens(ctx)
+	}
+	return nil
+}
+
+// RevokeAllSessions signs out every session of the current user, except
+// the client's own when exceptCurrent is set. Otherwise the client's tokens
+// are cleared, as Logout does.
+func (as *AuthService) RevokeAllSessions(ctx context.Context, exceptCurrent bool, reqOpts ...RequestOption) error {
+	params := map[string]string{"except_current": strconv.FormatBool(exceptCurrent)}
+	if _, err := as.client.Do(ctx, http.MethodDelete, "/auth/sessions", params, nil, reqOpts...); err != nil {
+		return err
+	}
+	if !exceptCurrent {
+		return as.client.clearTokens(ctx)
+	}
+	return nil
+}
+
+// isCurrentSession reports whether sessionID is the client's own session,
+// from the token's sid claim or else the session list
+func (as *AuthService) isCurrentSession(ctx context.Context, sessionID string, reqOpts []RequestOption) (bool, error) {
+	token := as.client.credentials().authToken.Reveal()
+	if token == "" {
+		return false, nil
+	}
+	if info, err := ParseToken(token); err == nil && info.SessionID != "" {
+		return info.SessionID == sessionID, nil
+	}
+	sessions, err := as.ListSessions(ctx, reqOpts...)
+	if err != nil {
+		return false, err
+	}
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			return session.Current, nil
+		}
+	}
+	return false, nil
+}
\ No newline at end of file
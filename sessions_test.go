@@ -0,0 +1,193 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestListSessionsDecoding(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		mu.Unlock()
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"sessions":[{"id":"sess_1","device":"iPhone","user_agent":"Xaman/3.0","ip_address":"203.0.113.7",` +
+			`"created_at":"2024-01-02T03:04:05Z","last_seen_at":"2024-01-03T03:04:05Z","expires_at":"2024-02-02T03:04:05Z","current":true},` +
+			`{"id":"sess_2","created_at":"2024-01-01T00:00:00Z","last_seen_at":"2024-01-01T00:00:00Z","expires_at":"2024-02-01T00:00:00Z","current":false}]}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	client.SetAuthToken("token")
+	
+	sessions, err := client.Auth.ListSessions(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := xrplsale.Session{
+		ID: "sess_1", Device: "iPhone", UserAgent: "Xaman/3.0", IPAddress: "203.0.113.7",
+		CreatedAt:  xrplsale.Timestamp{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		LastSeenAt: xrplsale.Timestamp{Time: time.Date(2024, 1, 3, 3, 4, 5, 0, time.UTC)},
+		ExpiresAt:  xrplsale.Timestamp{Time: time.Date(2024, 2, 2, 3, 4, 5, 0, time.UTC)},
+		Current:    true,
+	}
+	if len(sessions) != 2 || sessions[0].ID != want.ID || sessions[0].Device != want.Device || sessions[0].UserAgent != want.UserAgent ||
+		sessions[0].IPAddress != want.IPAddress || !sessions[0].CreatedAt.Equal(want.CreatedAt.Time) ||
+		!sessions[0].LastSeenAt.Equal(want.LastSeenAt.Time) || !sessions[0].ExpiresAt.Equal(want.ExpiresAt.Time) || !sessions[0].Current || sessions[1].Current {
+		t.Fatalf("ListSessions() = %+v, want %+v first", sessions, want)
+	}
+	
+	for _, exceptCurrent := range []bool{true, false} {
+		client.SetAuthToken("token")
+		if err := client.Auth.RevokeAllSessions(context.Background(), exceptCurrent); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if got := queries[len(queries)-2:]; got[0] != "DELETE /auth/sessions?except_current=true" || got[1] != "DELETE /auth/sessions?except_current=false" {
+		t.Errorf("RevokeAllSessions() sent %q", got)
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	tests := []struct {
+		name string
+		// revoke revokes sessions through self; other is the session of
+		// another device of the same wallet
+		revoke              func(self xrplsale.AuthAPI, selfID, otherID string) error
+		wantSelf, wantOther bool
+	}{
+		{"another session", func(self xrplsale.AuthAPI, _, otherID string) error {
+			return self.RevokeSession(context.Background(), otherID)
+		}, true, false},
+		{"own session", func(self xrplsale.AuthAPI, selfID, _ string) error {
+			return self.RevokeSession(context.Background(), selfID)
+		}, false, true},
+		{"all but the current", func(self xrplsale.AuthAPI, _, _ string) error {
+			return self.RevokeAllSessions(context.Background(), true)
+		}, true, false},
+		{"all", func(self xrplsale.AuthAPI, _, _ string) error {
+			return self.RevokeAllSessions(context.Background(), false)
+		}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, self, _ := newCountingClient(t)
+			other := xrplsaletest.NewClient(srv)
+			wallet := anySigner{fixtures.Address()}
+			for _, client := range []*xrplsale.Client{self, other} {
+				if _, err := client.Auth.AuthenticateWithSigner(context.Background(), wallet); err != nil {
+					t.Fatal(err)
+				}
+			}
+			sessions, err := self.Auth.ListSessions(context.Background())
+			if err != nil || len(sessions) != 2 || sessions[0].Current == sessions[1].Current {
+				t.Fatalf("ListSessions() = %v, %v, want two sessions, one current", sessions, err)
+			}
+			selfID, otherID := sessions[0].ID, sessions[1].ID
+			if !sessions[0].Current {
+				selfID, otherID = otherID, selfID
+			}
+			
+			if err := tt.revoke(self.Auth, selfID, otherID); err != nil {
+				t.Fatalf("revoking = %v", err)
+			}
+			for _, c := range []struct {
+				name   string
+				client *xrplsale.Client
+				want   bool
+			}{{"own", self, tt.wantSelf}, {"other", other, tt.wantOther}} {
+				_, err := c.client.Auth.GetProfile(context.Background())
+				if signedIn := err == nil; signedIn != c.want || !signedIn && !errors.Is(err, xrplsale.ErrUnauthorized) {
+					t.Errorf("%s session after revoking: GetProfile() = %v, want signed in %v", c.name, err, c.want)
+				}
+			}
+			// Revoking the own session leaves no token to send
+			if self.IsAuthenticated() != tt.wantSelf {
+				t.Errorf("IsAuthenticated() = %v, want %v", self.IsAuthenticated(), tt.wantSelf)
+			}
+		})
+	}
+}
+func TestVerifySession(t *testing.T) {
+	tests := []struct {
+		name   string
+		signIn bool
+		// expiring issues tokens within the refresh skew of their expiry;
+		// expired makes the server reject the signed-in token
+		expiring, expired bool
+		status            int
+		wantValid         bool
+		wantErr           bool
+	}{
+		{"valid", true, false, false, 0, true, false},
+		{"expiring token is not refreshed", true, true, false, 0, true, false},
+		{"rejected token", true, false, true, 0, false, false},
+		{"signed out", false, false, false, 0, false, false},
+		{"server error", true, false, false, http.StatusInternalServerError, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t, func(c *xrplsale.Config) {
+				c.AutoRefresh, c.RetryOnUnauthorized = true, true
+			})
+			if tt.expiring {
+				srv.SetTokenTTL(xrplsale.DefaultRefreshSkew / 2)
+			}
+			wallet := fixtures.Address()
+			if tt.signIn {
+				if _, err := client.Auth.AuthenticateWithSigner(context.Background(), anySigner{wallet}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.expired {
+				srv.ExpireTokens()
+			}
+			if tt.status != 0 {
+				srv.Fail(http.MethodGet, "/auth/session", xrplsaletest.Failure{Status: tt.status})
+			}
+			if client.IsAuthenticated() != tt.signIn {
+				t.Fatalf("IsAuthenticated() = %v, want %v", client.IsAuthenticated(), tt.signIn)
+			}
+			
+			status, err := client.Auth.VerifySession(context.Background(), xrplsale.WithNoRetry())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifySession() = %+v, %v, want an error %v", status, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if status.Valid != tt.wantValid {
+				t.Errorf("VerifySession() valid %v, want %v", status.Valid, tt.wantValid)
+			}
+			if tt.wantValid && (status.WalletAddress != wallet || status.SessionID == "" || !status.ExpiresAt.After(time.Now())) {
+				t.Errorf("VerifySession() = %+v, want the session of %s", status, wallet)
+			}
+			// Verifying never changes the session
+			if n := hits.count(http.MethodPost, "/auth/refresh"); n != 0 {
+				t.Errorf("VerifySession() refreshed %d times", n)
+			}
+			if !tt.signIn && hits.count(http.MethodGet, "/auth/session") != 0 {
+				t.Error("VerifySession() without a token sent a request")
+			}
+			if client.IsAuthenticated() != tt.signIn {
+				t.Errorf("IsAuthenticated() = %v after VerifySession(), want %v", client.IsAuthenticated(), tt.signIn)
+			}
+		})
+	}
+}
\ No newline at end of file
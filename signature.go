@@ -0,0 +1,33 @@
+package xrplsale
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// hmacSHA256 returns the HMAC-SHA256 of message under secret. It is the
+// primitive shared by request signing and webhook verification.
+func hmacSHA256(secret Secret, message []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret.Reveal()))
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// hmacSHA256Hex returns the lower-case hex HMAC-SHA256 of message under secret
+func hmacSHA256Hex(secret Secret, message []byte) string {
+	return hex.EncodeToString(hmacSHA256(secret, message))
+}
+
+// canonicalRequest returns the string a request signature covers:
+//
+//	METHOD + "\n" + path?query + "\n" + timestamp + "\n" + hex(SHA-256(body))
+func canonicalRequest(method, uri, timestamp string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	return canonicalRequestDigest(method, uri, timestamp, bodyHash[:])
+}
+
+// canonicalRequestDigest is canonicalRequest for a body already hashed
+func canonicalRequestDigest(method, uri, timestamp string, bodySHA256 []byte) []byte {
+	return []byte(method + "\n" + uri + "\n" + timestamp + "\n" + hex.EncodeToString(bodySHA256))
+}
\ No newline at end of file
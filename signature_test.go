@@ -0,0 +1,113 @@
+package xrplsale
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHMACSHA256(t *testing.T) {
+	// RFC 4231 test cases 1 and 2, and a webhook payload
+	tests := []struct {
+		secret  Secret
+		message string
+		want    string
+	}{
+		{Secret(strings.Repeat("\x0b", 20)), "Hi There", "b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7"},
+		{"Jefe", "what do ya want for nothing?", "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"},
+		{"whsec_test", `{"type":"investment.created"}`, "8a172c76c7c5b964d997d566cc225fe2dd5eb7670ea62f9ac6ca88d3946efe12"},
+	}
+	for _, tt := range tests {
+		if got := hmacSHA256Hex(tt.secret, []byte(tt.message)); got != tt.want {
+			t.Errorf("hmacSHA256Hex(%q) = %s, want %s", tt.message, got, tt.want)
+		}
+		if got := hex.EncodeToString(hmacSHA256(tt.secret, []byte(tt.message))); got != tt.want {
+			t.Errorf("hmacSHA256(%q) = %s, want %s", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalRequest(t *testing.T) {
+	const emptyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	tests := []struct {
+		method, uri string
+		body        string
+		want        string
+	}{
+		{"GET", "/projects?limit=1", "", "GET\n/projects?limit=1\n1700000000\n" + emptyHash},
+		{"DELETE", "/webhooks/wh_1", "", "DELETE\n/webhooks/wh_1\n1700000000\n" + emptyHash},
+		{"POST", "/projects", "{}", "POST\n/projects\n1700000000\n44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"},
+	}
+	for _, tt := range tests {
+		if got := string(canonicalRequest(tt.method, tt.uri, "1700000000", []byte(tt.body))); got != tt.want {
+			t.Errorf("canonicalRequest(%s %s) = %q, want %q", tt.method, tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookVerificationSharesHelper(t *testing.T) {
+	client := NewClientWithConfig(&Config{APIKey: "key", WebhookSecret: "whsec_test"})
+	payload := []byte(`{"type":"investment.created"}`)
+	header := "sha256=8a172c76c7c5b964d997d566cc225fe2dd5eb7670ea62f9ac6ca88d3946efe12"
+	if !client.VerifyWebhookSignature(payload, header) {
+		t.Fatal("VerifyWebhookSignature() rejected a valid signature")
+	}
+	if diag, err := client.DebugVerifyWebhookSignature(payload, header); err != nil || !diag.Valid {
+		t.Fatalf("DebugVerifyWebhookSignature() = %+v, %v; want valid", diag, err)
+	}
+}
+
+// TestRequestSigningSecret checks Config.RequestSigningSecret on the wire
+// against a server computing the HMAC itself
+func TestRequestSigningSecret(t *testing.T) {
+	const secret = "shared_secret"
+	var mu sync.Mutex
+	var problems []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get(SignatureTimestampHeader)
+		bodyHash := sha256.Sum256(body)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(r.Method + "\n" + r.URL.RequestURI() + "\n" + timestamp + "\n" + hex.EncodeToString(bodyHash[:])))
+		mu.Lock()
+		if got, want := r.Header.Get(SignatureHeader), hex.EncodeToString(mac.Sum(nil)); got != want {
+			problems = append(problems, r.Method+" signature "+got+", want "+want)
+		}
+		if unix, err := strconv.ParseInt(timestamp, 10, 64); err != nil || time.Since(time.Unix(unix, 0)).Abs() > time.Minute {
+			problems = append(problems, r.Method+" timestamp "+strconv.Quote(timestamp))
+		}
+		if r.Header.Get(KeyIDHeader) != "" {
+			problems = append(problems, r.Method+" sent a key ID")
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, RequestSigningSecret: secret})
+	ctx := context.Background()
+	
+	if err := client.Get(ctx, "/projects", map[string]string{"limit": "1"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Post(ctx, "/projects", map[string]string{"name": "Signed"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Delete(ctx, "/webhooks/wh_1", nil); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, problem := range problems {
+		t.Error(problem)
+	}
+}
\ No newline at end of file
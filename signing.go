@@ -0,0 +1,169 @@
+package xrplsale
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers set by HMACSigner and SharedSecretSigner
+const (
+	KeyIDHeader              = "X-Key-ID"
+	TimestampHeader          = "X-Timestamp"
+	SignatureHeader          = "X-Signature"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+)
+
+// RequestSigner signs each attempt of a request just before it is sent.
+// body holds the exact bytes that will be sent, after compression, and is
+// empty for requests without a body. Sign may set headers on req; an
+// error fails the attempt.
+type RequestSigner interface {
+	Sign(req *http.Request, body []byte) error
+}
+
+// DigestSigner is implemented by RequestSigners that only need the SHA-256
+// digest of the body. Upload signs with it, so a signed upload is hashed as
+// it is spooled to disk instead of being held in memory; HMACSigner and
+// SharedSecretSigner implement it.
+type DigestSigner interface {
+	SignDigest(req *http.Request, bodySHA256 []byte) error
+}
+
+// RequestSignerFunc adapts an ordinary function to the RequestSigner interface
+type RequestSignerFunc func(req *http.Request, body []byte) error
+
+// Sign calls f
+func (f RequestSignerFunc) Sign(req *http.Request, body []byte) error {
+	return f(req, body)
+}
+
+// HMACSigner implements the partner-tier request signature. It sets
+// X-Key-ID, X-Timestamp (Unix seconds) and X-Signature, the hex HMAC-SHA256
+// under Secret of
+//
+//	METHOD + "\n" + path?query + "\n" + timestamp + "\n" + hex(SHA-256(body))
+//
+// where body is the bytes actually sent. Every attempt is signed with a fresh timestamp.
+type HMACSigner struct {
+	KeyID  string
+	Secret Secret
+	
+	// Now returns the signing time; defaults to time.Now
+	Now func() time.Time
+}
+
+// Sign implements RequestSigner
+func (s *HMACSigner) Sign(req *http.Request, body []byte) error {
+	bodyHash := sha256.Sum256(body)
+	return s.SignDigest(req, bodyHash[:])
+}
+
+// SignDigest implements DigestSigner
+func (s *HMACSigner) SignDigest(req *http.Request, bodySHA256 []byte) error {
+	if s.KeyID == "" || s.Secret == "" {
+		return errors.New("request signing requires a key ID and a secret")
+	}
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	timestamp := strconv.FormatInt(now().Unix(), 10)
+	
+	req.Header.Set(KeyIDHeader, s.KeyID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, hmacSHA256Hex(s.Secret, canonicalRequestDigest(req.Method, req.URL.RequestURI(), timestamp, bodySHA256)))
+	return nil
+}
+
+// SharedSecretSigner signs requests with a secret shared with the API and
+// no key ID, mirroring webhook signatures in the other direction. It sets
+// X-Signature-Timestamp (Unix seconds) and X-Signature, the hex
+// HMAC-SHA256 under Secret of the same string HMACSigner signs.
+type SharedSecretSigner struct {
+	Secret Secret
+	
+	// Now returns the signing time; defaults to time.Now
+	Now func() time.Time
+}
+
+// Sign implements RequestSigner
+func (s *SharedSecretSigner) Sign(req *http.Request, body []byte) error {
+	bodyHash := sha256.Sum256(body)
+	return s.SignDigest(req, bodyHash[:])
+}
+
+// SignDigest implements DigestSigner
+func (s *SharedSecretSigner) SignDigest(req *http.Request, bodySHA256 []byte) error {
+	if s.Secret == "" {
+		return errors.New("request signing requires a secret")
+	}
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	timestamp := strconv.FormatInt(now().Unix(), 10)
+	
+	req.Header.Set(SignatureTimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, hmacSHA256Hex(s.Secret, canonicalRequestDigest(req.Method, req.URL.RequestURI(), timestamp, bodySHA256)))
+	return nil
+}
+
+// errDigestSignerRequired is the error of a streamed body whose signer
+// needs the whole body
+func errDigestSignerRequired(signer RequestSigner) error {
+	return fmt.Errorf("signing an upload requires a DigestSigner; %T needs the whole body in memory", signer)
+}
+
+// signTransport signs every attempt. It sits directly above the base
+// transport, below compression, so the signature covers the bytes on the wire.
+type signTransport struct {
+	base   http.RoundTripper
+	signer RequestSigner
+}
+
+// bodyDigestKey carries the SHA-256 digest of a request body that must not
+// be read to be signed, see Upload
+type bodyDigestKey struct{}
+
+// RoundTrip implements http.RoundTripper
+func (t *signTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if digest, ok := req.Context().Value(bodyDigestKey{}).([]byte); ok {
+		signer, ok := t.signer.(DigestSigner)
+		if !ok {
+			return nil, errDigestSignerRequired(t.signer)
+		}
+		out := req.Clone(req.Context())
+		if err := signer.SignDigest(out, digest); err != nil {
+			return nil, err
+		}
+		return t.base.RoundTrip(out)
+	}
+	
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	if err := t.signer.Sign(out, body); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(out)
+}
\ No newline at end of file
@@ -0,0 +1,237 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// signingTime is the fixed clock of the signature test vectors
+func signingTime() time.Time { return time.Unix(1700000000, 0) }
+
+func TestRequestSignerVectors(t *testing.T) {
+	hmacSigner := &xrplsale.HMACSigner{KeyID: "pk_test", Secret: "partner_secret", Now: signingTime}
+	sharedSigner := &xrplsale.SharedSecretSigner{Secret: "shared_secret", Now: signingTime}
+	tests := []struct {
+		name        string
+		signer      xrplsale.RequestSigner
+		method      string
+		url         string
+		body        string
+		wantHeaders map[string]string
+	}{
+		{"HMAC GET with query", hmacSigner, http.MethodGet, "https://api.example/projects?limit=1&status=active", "", map[string]string{
+			xrplsale.KeyIDHeader:     "pk_test",
+			xrplsale.TimestampHeader: "1700000000",
+			xrplsale.SignatureHeader: "aeb4a744acc209aa704f551f8a3dca4ddf76ce64c235c8c631d899cbe373c89b",
+		}},
+		{"HMAC POST with body", hmacSigner, http.MethodPost, "https://api.example/projects/proj_1/investments", `{"amount_xrp":"100"}`, map[string]string{
+			xrplsale.KeyIDHeader:     "pk_test",
+			xrplsale.TimestampHeader: "1700000000",
+			xrplsale.SignatureHeader: "e57ed0ee62103fe9f868ea095529d74ca290568d0c4cbea78acbeeeb851053f2",
+		}},
+		{"shared secret DELETE", sharedSigner, http.MethodDelete, "https://api.example/webhooks/wh_1", "", map[string]string{
+			xrplsale.KeyIDHeader:              "",
+			xrplsale.SignatureTimestampHeader: "1700000000",
+			xrplsale.SignatureHeader:          "74e878b8d2be409b5b70c74407deff6c63018db80def9b82e9491a5aa86b9b3e",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.signer.Sign(req, []byte(tt.body)); err != nil {
+				t.Fatal(err)
+			}
+			for header, want := range tt.wantHeaders {
+				if got := req.Header.Get(header); got != want {
+					t.Errorf("%s = %q, want %q", header, got, want)
+				}
+			}
+		})
+	}
+	
+	for _, signer := range []xrplsale.RequestSigner{&xrplsale.HMACSigner{Secret: "s"}, &xrplsale.HMACSigner{KeyID: "k"}, &xrplsale.SharedSecretSigner{}} {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.example/projects", nil)
+		if err := signer.Sign(req, nil); err == nil {
+			t.Errorf("%+v signed without credentials", signer)
+		}
+	}
+}
+
+func TestRequestSigningOnTheWire(t *testing.T) {
+	const secret = "partner_secret"
+	type attempt struct {
+		timestamp string
+		encoding  string
+		valid     bool
+	}
+	var (
+		mu       sync.Mutex
+		attempts []attempt
+		fail     int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The signature must cover the bytes received, before any decompression
+		body, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get(xrplsale.TimestampHeader)
+		bodyHash := sha256.Sum256(body)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(r.Method + "\n" + r.URL.RequestURI() + "\n" + timestamp + "\n" + hex.EncodeToString(bodyHash[:])))
+		valid := r.Header.Get(xrplsale.KeyIDHeader) == "pk_test" && hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(r.Header.Get(xrplsale.SignatureHeader)))
+		
+		mu.Lock()
+		attempts = append(attempts, attempt{timestamp, r.Header.Get("Content-Encoding"), valid})
+		retry := fail > 0
+		fail--
+		mu.Unlock()
+		if retry {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	
+	tests := []struct {
+		name         string
+		method       string
+		body         interface{}
+		compress     bool
+		failures     int
+		wantEncoding string
+	}{
+		{"GET", http.MethodGet, nil, false, 0, ""},
+		{"POST", http.MethodPost, map[string]string{"name": "Alpha"}, false, 0, ""},
+		{"POST compressed", http.MethodPost, map[string]string{"description": strings.Repeat("tokens ", 200)}, true, 0, "gzip"},
+		{"GET retried", http.MethodGet, nil, false, 2, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			attempts, fail = nil, tt.failures
+			mu.Unlock()
+			// Every signature gets a later timestamp, so re-signed retries are visible
+			var clockMu sync.Mutex
+			clock := signingTime()
+			now := func() time.Time {
+				clockMu.Lock()
+				defer clockMu.Unlock()
+				clock = clock.Add(time.Second)
+				return clock
+			}
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey:               "key",
+				BaseURL:              srv.URL,
+				RetryWaitTime:        time.Millisecond,
+				CompressRequests:     tt.compress,
+				CompressionThreshold: 64,
+				RequestSigner:        &xrplsale.HMACSigner{KeyID: "pk_test", Secret: secret, Now: now},
+			})
+			
+			if _, err := client.Do(context.Background(), tt.method, "/projects", map[string]string{"page": "2"}, tt.body); err != nil {
+				t.Fatal(err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(attempts) != tt.failures+1 {
+				t.Fatalf("%d attempts, want %d", len(attempts), tt.failures+1)
+			}
+			seen := map[string]bool{}
+			for i, a := range attempts {
+				if !a.valid || a.encoding != tt.wantEncoding {
+					t.Fatalf("attempt %d: valid %v, Content-Encoding %q; want a valid signature with %q", i, a.valid, a.encoding, tt.wantEncoding)
+				}
+				if seen[a.timestamp] {
+					t.Fatalf("attempt %d reused timestamp %s", i, a.timestamp)
+				}
+				seen[a.timestamp] = true
+			}
+		})
+	}
+}
+func TestSignedUpload(t *testing.T) {
+	const secret = "partner_secret"
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get(xrplsale.TimestampHeader)
+		bodyHash := sha256.Sum256(body)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(r.Method + "\n" + r.URL.RequestURI() + "\n" + timestamp + "\n" + hex.EncodeToString(bodyHash[:])))
+		if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(r.Header.Get(xrplsale.SignatureHeader))) {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		part, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).NextPart()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		content, _ := io.ReadAll(part)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"filename": part.FileName(), "content": string(content), "length": r.ContentLength})
+	}))
+	defer srv.Close()
+	
+	tests := []struct {
+		name    string
+		signer  xrplsale.RequestSigner
+		wantErr bool
+	}{
+		{"HMACSigner", &xrplsale.HMACSigner{KeyID: "pk_test", Secret: secret}, false},
+		{"signer without SignDigest", xrplsale.RequestSignerFunc(func(*http.Request, []byte) error { return nil }), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The spooled body must not outlive the upload
+			tmp := t.TempDir()
+			t.Setenv("TMPDIR", tmp)
+			requests.Store(0)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, RequestSigner: tt.signer})
+			
+			var got struct {
+				Filename, Content string
+				Length            int64
+			}
+			content := strings.Repeat("evidence ", 1000)
+			err := client.Upload(context.Background(), "/uploads", map[string]io.Reader{"report.pdf": strings.NewReader(content)}, nil, &got)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "DigestSigner") || requests.Load() != 0 {
+					t.Fatalf("Upload() = %v after %d requests, want a DigestSigner error before sending", err, requests.Load())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Filename != "report.pdf" || got.Content != content || got.Length <= int64(len(content)) {
+				t.Errorf("server read %q (%d bytes, Content-Length %d)", got.Filename, len(got.Content), got.Length)
+			}
+			if left, _ := os.ReadDir(tmp); len(left) != 0 {
+				t.Errorf("temporary files left behind: %v", left)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,150 @@
+package xrplsale
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// NDJSONContentType is the media type of the API's streaming list endpoints
+const NDJSONContentType = "application/x-ndjson"
+
+// maxStreamErrorBody bounds how much of a failed stream response is read
+const maxStreamErrorBody = 64 << 10
+
+// StreamOptions filters a streaming list request
+type StreamOptions struct {
+	Status string    `url:"status,omitempty"`
+	Since  time.Time `url:"since,omitempty"`
+}
+
+// params returns the options as query parameters
+func (so *StreamOptions) params() map[string]string {
+	return encodeQuery(so)
+}
+
+// StreamError is returned by Stream.Err when the API aborts a stream with a
+// trailing error object, as opposed to the stream ending normally
+type StreamError struct {
+	*APIError
+}
+
+// Unwrap returns the underlying API error
+func (e *StreamError) Unwrap() error { return e.APIError }
+
+// Stream decodes an NDJSON response one value at a time. Only the current
+// value is held in memory, so results of any size can be consumed.
+//
+//	for stream.Next() {
+//		investment := stream.Value()
+//	}
+//	if err := stream.Err(); err != nil { ... }
+type Stream[T any] struct {
+	body   io.ReadCloser
+	cancel context.CancelFunc
+	dec    *json.Decoder
+	value  T
+	err    error
+	closed bool
+}
+
+// newStream wraps an NDJSON response body
+func newStream[T any](body io.ReadCloser, cancel context.CancelFunc) *Stream[T] {
+	return &Stream[T]{
+		body:   body,
+		cancel: cancel,
+		dec:    json.NewDecoder(body),
+	}
+}
+
+// Next advances to the next value, returning false at the end of the stream or on error
+func (s *Stream[T]) Next() bool {
+	if s.closed {
+		return false
+	}
+	
+	var raw json.RawMessage
+	if err := s.dec.Decode(&raw); err != nil {
+		if !errors.Is(err, io.EOF) {
+			s.err = err
+		}
+		s.Close()
+		return false
+	}
+	
+	var trailer struct {
+		Error *APIError `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &trailer); err == nil && trailer.Error != nil {
+		s.err = &StreamError{APIError: trailer.Error}
+		s.Close()
+		return false
+	}
+	
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		s.err = fmt.Errorf("decoding stream value: %w", err)
+		s.Close()
+		return false
+	}
+	s.value = value
+	return true
+}
+
+// Value returns the value decoded by the last call to Next
+func (s *Stream[T]) Value() T {
+	return s.value
+}
+
+// Err returns the error that ended the stream, or nil if it ended normally
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// Close releases the underlying connection. It is safe to call more than once.
+func (s *Stream[T]) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	err := s.body.Close()
+	s.cancel()
+	return err
+}
+
+// openStream issues a streaming GET and returns the unread response body.
+// Config.Timeout bounds the wait for the response headers and
+// Config.StreamIdleTimeout each wait for more of the body.
+func (c *Client) openStream(ctx context.Context, endpoint string, params map[string]string, opts []RequestOption) (io.ReadCloser, context.CancelFunc, error) {
+	if err := c.usable(); err != nil {
+		return nil, nil, err
+	}
+	c.prepareAuth(ctx)
+	ro := newRequestOptions(opts)
+	// Streams are consumed incrementally, so the in-memory limit does not apply
+	ro.maxResponseBytes = c.streamLimit(ro)
+	ctx, cancel := ro.context(ctx)
+	ctx = context.WithValue(ctx, streamKey{}, true)
+	
+	resp, err := c.newRequest(ctx, ro).
+		SetQueryParams(params).
+		SetHeader("Accept", NDJSONContentType).
+		SetDoNotParseResponse(true).
+		Get(endpoint)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	
+	body := resp.RawBody()
+	if resp.IsError() {
+		defer cancel()
+		defer body.Close()
+		raw, _ := io.ReadAll(io.LimitReader(body, maxStreamErrorBody))
+		return nil, nil, newStatusError(resp.StatusCode(), resp.Header(), raw, nil)
+	}
+	return body, cancel, nil
+}
\ No newline at end of file
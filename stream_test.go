@@ -0,0 +1,196 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// heapInUse returns the live heap after a collection
+func heapInUse() uint64 {
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+func TestStreamByProjectLarge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("streams 100k rows")
+	}
+	const rows = 100000
+	srv := xrplsaletest.NewServer()
+	defer srv.Close()
+	project := fixtures.Project()
+	srv.AddProjects(project)
+	srv.AddInvestments(fixtures.Investments(project, rows)...)
+	client := xrplsaletest.NewClient(srv)
+	
+	stream, err := client.Investments.StreamByProject(context.Background(), project.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	
+	n := 0
+	var early, late uint64
+	for stream.Next() {
+		if stream.Value().ProjectID != project.ID {
+			t.Fatalf("row %d belongs to project %q", n, stream.Value().ProjectID)
+		}
+		n++
+		switch n {
+		case 1000:
+			early = heapInUse()
+		case rows - 1000:
+			late = heapInUse()
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if n != rows {
+		t.Fatalf("streamed %d rows, want %d", n, rows)
+	}
+	// Buffering the rows would take tens of megabytes
+	if late > early+4<<20 {
+		t.Fatalf("heap grew from %d to %d bytes while streaming", early, late)
+	}
+}
+
+func TestStreamEnd(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    int
+		wantErr func(error) bool
+	}{
+		{"end of stream", `{"id":"inv_1"}` + "\n" + `{"id":"inv_2"}` + "\n", 2, func(err error) bool { return err == nil }},
+		{"trailing error", `{"id":"inv_1"}` + "\n" + `{"error":{"message":"export aborted","code":"internal"}}` + "\n", 1, func(err error) bool {
+			var streamErr *xrplsale.StreamError
+			return errors.As(err, &streamErr) && streamErr.Code == "internal"
+		}},
+		{"truncated line", `{"id":"inv_1"}` + "\n" + `{"id":"in`, 1, func(err error) bool { return err != nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept") != xrplsale.NDJSONContentType {
+					t.Errorf("Accept = %q, want %q", r.Header.Get("Accept"), xrplsale.NDJSONContentType)
+				}
+				w.Header().Set("Content-Type", xrplsale.NDJSONContentType)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			stream, err := client.Investments.StreamByProject(context.Background(), "proj_1", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := 0
+			for stream.Next() {
+				n++
+			}
+			if n != tt.want || !tt.wantErr(stream.Err()) {
+				t.Fatalf("streamed %d rows ending with %v, want %d rows", n, stream.Err(), tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamTimeouts(t *testing.T) {
+	tests := []struct {
+		name        string
+		headerDelay time.Duration
+		rows        int
+		rowDelay    time.Duration
+		stall       time.Duration
+		wantOpenErr error
+		wantErr     error
+	}{
+		{"flows for longer than Timeout", 0, 15, 20 * time.Millisecond, 0, nil, nil},
+		{"stalls mid-stream", 0, 2, 0, time.Second, nil, xrplsale.ErrStreamIdle},
+		{"slow headers", 300 * time.Millisecond, 1, 0, 0, context.DeadlineExceeded, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(tt.headerDelay):
+				case <-r.Context().Done():
+					return
+				}
+				w.Header().Set("Content-Type", xrplsale.NDJSONContentType)
+				for i := 0; i < tt.rows; i++ {
+					fmt.Fprintf(w, "{\"id\":\"inv_%d\"}\n", i)
+					w.(http.Flusher).Flush()
+					time.Sleep(tt.rowDelay)
+				}
+				select {
+				case <-time.After(tt.stall):
+				case <-r.Context().Done():
+				}
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey:            "key",
+				BaseURL:           srv.URL,
+				Timeout:           100 * time.Millisecond,
+				StreamIdleTimeout: 50 * time.Millisecond,
+			})
+			
+			stream, err := client.Investments.StreamByProject(context.Background(), "proj_1", nil, xrplsale.WithNoRetry())
+			if tt.wantOpenErr != nil {
+				if !errors.Is(err, tt.wantOpenErr) {
+					t.Fatalf("StreamByProject() = %v, want %v", err, tt.wantOpenErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := 0
+			for stream.Next() {
+				n++
+			}
+			if n != tt.rows {
+				t.Errorf("streamed %d rows, want %d", n, tt.rows)
+			}
+			if err := stream.Err(); tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Err() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTimeoutBoundsOrdinaryRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"a":`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(`1}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, Timeout: 100 * time.Millisecond})
+	
+	start := time.Now()
+	var out map[string]interface{}
+	err := client.Get(context.Background(), "/projects", nil, &out, xrplsale.WithNoRetry())
+	if err == nil || time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("Get() = %v after %v, want a timeout after about 100ms", err, time.Since(start))
+	}
+}
\ No newline at end of file
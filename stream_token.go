@@ -0,0 +1,66 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrStreamAuthRequired is matched by the error CreateStreamToken returns
+// when the API refuses a stream token to a client without a signed-in
+// user. The error also matches the API's AuthError.
+var ErrStreamAuthRequired = errors.New("stream tokens require a signed-in user: authenticate before creating one")
+
+// StreamToken authenticates a connection to the live-update WebSocket. It
+// is short-lived and distinct from the REST auth token.
+type StreamToken struct {
+	Token string `json:"token"`
+	
+	// URL is the wss:// endpoint to connect to with Token
+	URL string `json:"url"`
+	
+	// ExpiresAt is when Token expires; zero when unknown. It comes from
+	// expires_at, expires_in or the token's exp claim.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	ExpiresIn int       `json:"expires_in,omitempty"`
+}
+
+// Expired reports whether the token has expired. A token without a known
+// expiry never does.
+func (t *StreamToken) Expired() bool {
+	return !t.ExpiresAt.IsZero() && !time.Now().Before(t.ExpiresAt)
+}
+
+// Refresh replaces t with a new stream token from client, e.g. before
+// reconnecting once it expired
+func (t *StreamToken) Refresh(ctx context.Context, client *Client) error {
+	fresh, err := client.Auth.CreateStreamToken(ctx)
+	if err != nil {
+		return err
+	}
+	*t = *fresh
+	return nil
+}
+
+// CreateStreamToken issues a stream token for the live-update WebSocket.
+// It needs a signed-in user: when the client only has an API key and the
+// API refuses, the error matches ErrStreamAuthRequired.
+func (as *AuthService) CreateStreamToken(ctx context.Context, reqOpts ...RequestOption) (*StreamToken, error) {
+	var token StreamToken
+	err := as.client.Post(ctx, "/auth/stream-token", nil, &token, reqOpts...)
+	if (errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrForbidden)) && !as.client.IsAuthenticated() {
+		return nil, fmt.Errorf("%w: %w", ErrStreamAuthRequired, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if token.ExpiresAt.IsZero() {
+		if token.ExpiresIn > 0 {
+			token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+		} else {
+			token.ExpiresAt = tokenExpiry(token.Token)
+		}
+	}
+	return &token, nil
+}
\ No newline at end of file
@@ -0,0 +1,145 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// streamTokenServer answers stream token requests with status and body
+func streamTokenServer(t *testing.T, status int, body func(call int) string) *httptest.Server {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/auth/stream-token" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body(int(calls.Add(1)))))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCreateStreamTokenRefused(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		authenticated bool
+		wantWrapped   bool
+	}{
+		{"401 with an API key only", http.StatusUnauthorized, false, true},
+		{"403 with an API key only", http.StatusForbidden, false, true},
+		{"401 when signed in", http.StatusUnauthorized, true, false},
+		{"403 when signed in", http.StatusForbidden, true, false},
+		{"500 with an API key only", http.StatusInternalServerError, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := streamTokenServer(t, tt.status, func(int) string { return `{"message":"no"}` })
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, MaxRetries: 1, RetryWaitTime: time.Millisecond})
+			if tt.authenticated {
+				client.SetAuthToken("tok_user")
+			}
+			
+			token, err := client.Auth.CreateStreamToken(context.Background())
+			if err == nil || token != nil {
+				t.Fatalf("CreateStreamToken() = %+v, %v; want an error", token, err)
+			}
+			if got := errors.Is(err, xrplsale.ErrStreamAuthRequired); got != tt.wantWrapped {
+				t.Fatalf("CreateStreamToken() = %v, matches ErrStreamAuthRequired: %v, want %v", err, got, tt.wantWrapped)
+			}
+			// The API's error stays reachable either way
+			var apiErr *xrplsale.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("CreateStreamToken() = %v, want it to wrap the API's %d", err, tt.status)
+			}
+		})
+	}
+}
+
+func TestCreateStreamTokenExpiry(t *testing.T) {
+	exp := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		body     string
+		want     time.Time     // exact expiry
+		wantIn   time.Duration // or about this long from now
+		wantZero bool
+	}{
+		{"expires_at", fmt.Sprintf(`{"token":%q,"url":"wss://x","expires_at":%q,"expires_in":60}`,
+			jwtWith(t, map[string]interface{}{"exp": exp.Unix()}), expiresAt.Format(time.RFC3339)), expiresAt, 0, false},
+		{"expires_in", fmt.Sprintf(`{"token":%q,"url":"wss://x","expires_in":60}`, jwtWith(t, map[string]interface{}{"exp": exp.Unix()})), time.Time{}, time.Minute, false},
+		{"exp claim", fmt.Sprintf(`{"token":%q,"url":"wss://x"}`, jwtWith(t, map[string]interface{}{"exp": exp.Unix()})), exp, 0, false},
+		{"unknown", `{"token":"stream_opaque","url":"wss://x"}`, time.Time{}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := streamTokenServer(t, http.StatusOK, func(int) string { return tt.body })
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			client.SetAuthToken("tok_user")
+			
+			before := time.Now()
+			token, err := client.Auth.CreateStreamToken(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch {
+			case tt.wantZero:
+				if !token.ExpiresAt.IsZero() || token.Expired() {
+					t.Errorf("ExpiresAt = %v, Expired %v; want an unknown expiry that never expires", token.ExpiresAt, token.Expired())
+				}
+			case tt.wantIn > 0:
+				if token.ExpiresAt.Before(before.Add(tt.wantIn)) || token.ExpiresAt.After(time.Now().Add(tt.wantIn)) {
+					t.Errorf("ExpiresAt = %v, want %v from now", token.ExpiresAt, tt.wantIn)
+				}
+			default:
+				if !token.ExpiresAt.Equal(tt.want) {
+					t.Errorf("ExpiresAt = %v, want %v", token.ExpiresAt, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamTokenRefresh(t *testing.T) {
+	srv := streamTokenServer(t, http.StatusOK, func(call int) string {
+		return fmt.Sprintf(`{"token":"stream_%d","url":"wss://stream/%d","expires_in":60}`, call, call)
+	})
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	client.SetAuthToken("tok_user")
+	ctx := context.Background()
+	
+	token, err := client.Auth.CreateStreamToken(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	held := token
+	token.ExpiresAt = time.Now().Add(-time.Second)
+	if !token.Expired() {
+		t.Fatal("Expired() = false for a token past its expiry")
+	}
+	if err := token.Refresh(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	// Refresh replaces the token in place, so holders of the pointer see it
+	if held.Token != "stream_2" || held.URL != "wss://stream/2" || held.Expired() {
+		t.Errorf("after Refresh() the token is %+v, want stream_2", held)
+	}
+	
+	// A failed refresh leaves the token as it was
+	failing := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: streamTokenServer(t, http.StatusUnauthorized, func(int) string { return `{}` }).URL})
+	if err := token.Refresh(ctx, failing); !errors.Is(err, xrplsale.ErrStreamAuthRequired) || token.Token != "stream_2" {
+		t.Errorf("Refresh() = %v leaving %q, want ErrStreamAuthRequired and stream_2 kept", err, token.Token)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,154 @@
+package xrplsale
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Period is a trailing time window accepted by the analytics endpoints
+type Period string
+
+const (
+	Period24Hours Period = "24h"
+	Period7Days   Period = "7d"
+	Period30Days  Period = "30d"
+	Period90Days  Period = "90d"
+	Period1Year   Period = "1y"
+)
+
+// ErrInvalidTimeframe is returned for an unknown Period or Granularity, or
+// a granularity that does not fit a period
+var ErrInvalidTimeframe = errors.New("invalid timeframe")
+
+// MaxBuckets is the largest number of buckets a period may be split into
+const MaxBuckets = 1000
+
+// periodDurations holds the length of every known period
+var periodDurations = map[Period]time.Duration{
+	Period24Hours: 24 * time.Hour,
+	Period7Days:   7 * 24 * time.Hour,
+	Period30Days:  30 * 24 * time.Hour,
+	Period90Days:  90 * 24 * time.Hour,
+	Period1Year:   365 * 24 * time.Hour,
+}
+
+// Validate returns an error matching ErrInvalidTimeframe if p is unknown
+func (p Period) Validate() error {
+	if _, ok := periodDurations[p]; !ok {
+		return fmt.Errorf("%w: unknown period %q", ErrInvalidTimeframe, string(p))
+	}
+	return nil
+}
+
+// Duration returns the length of p, or zero if p is unknown. A year is
+// 365 days.
+func (p Period) Duration() time.Duration {
+	return periodDurations[p]
+}
+
+// Start returns the beginning of the period ending at end
+func (p Period) Start(end time.Time) time.Time {
+	return end.Add(-p.Duration())
+}
+
+// Granularity is the width of the buckets a time series is grouped into
+type Granularity string
+
+const (
+	GranularityMinute Granularity = "minute"
+	GranularityHour   Granularity = "hour"
+	GranularityDay    Granularity = "day"
+	GranularityWeek   Granularity = "week"
+	GranularityMonth  Granularity = "month"
+)
+
+// granularityDurations holds the nominal width of every known granularity
+var granularityDurations = map[Granularity]time.Duration{
+	GranularityMinute: time.Minute,
+	GranularityHour:   time.Hour,
+	GranularityDay:    24 * time.Hour,
+	GranularityWeek:   7 * 24 * time.Hour,
+	GranularityMonth:  30 * 24 * time.Hour,
+}
+
+// Validate returns an error matching ErrInvalidTimeframe if g is unknown
+func (g Granularity) Validate() error {
+	if _, ok := granularityDurations[g]; !ok {
+		return fmt.Errorf("%w: unknown granularity %q", ErrInvalidTimeframe, string(g))
+	}
+	return nil
+}
+
+// Duration returns the nominal width of a g bucket, or zero if g is
+// unknown. Days are 24 hours and months 30 days; use AlignToBucket for
+// calendar-exact boundaries.
+func (g Granularity) Duration() time.Duration {
+	return granularityDurations[g]
+}
+
+// CheckGranularity returns an error matching ErrInvalidTimeframe unless g
+// is shorter than p and splits it into at most MaxBuckets buckets
+func CheckGranularity(p Period, g Granularity) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := g.Validate(); err != nil {
+		return err
+	}
+	if g.Duration() >= p.Duration() {
+		return fmt.Errorf("%w: %s buckets do not fit a %s period", ErrInvalidTimeframe, g, p)
+	}
+	if p.Duration()/g.Duration() > MaxBuckets {
+		return fmt.Errorf("%w: %s buckets split a %s period into more than %d", ErrInvalidTimeframe, g, p, MaxBuckets)
+	}
+	return nil
+}
+
+// AlignToBucket returns the start of the g bucket containing t, in t's
+// location. Days start at local midnight, weeks on Monday and months on
+// the 1st, so buckets follow the calendar across DST changes and may be
+// shorter or longer than g.Duration. Unknown granularities return t.
+func AlignToBucket(t time.Time, g Granularity) time.Time {
+	year, month, day := t.Date()
+	loc := t.Location()
+	switch g {
+	case GranularityMinute, GranularityHour:
+		// Truncating the absolute time keeps the two instants of a repeated
+		// wall-clock hour apart. It only matches local boundaries when the
+		// zone offset is a whole number of buckets, both at t and at the
+		// bucket start.
+		if _, offset := t.Zone(); wholeBuckets(offset, g) {
+			start := t.Truncate(g.Duration())
+			if _, offset := start.Zone(); wholeBuckets(offset, g) {
+				return start
+			}
+		}
+		minutes := t.Minute()
+		if g == GranularityMinute {
+			minutes = 0
+		}
+		elapsed := time.Duration(minutes)*time.Minute + time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+		start := time.Date(year, month, day, t.Hour(), t.Minute()-minutes, 0, 0, loc)
+		if start.After(t) {
+			// The wall-clock time repeats and time.Date picked the later
+			// one; step back within t's own offset instead
+			return t.Add(-elapsed)
+		}
+		return start
+	case GranularityDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	case GranularityWeek:
+		offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, loc)
+	case GranularityMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	}
+	return t
+}
+
+// wholeBuckets reports whether a zone offset in seconds is a whole number
+// of g buckets
+func wholeBuckets(offset int, g Granularity) bool {
+	return time.Duration(offset)*time.Second%g.Duration() == 0
+}
\ No newline at end of file
@@ -0,0 +1,274 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestTimeframeValidate(t *testing.T) {
+	periods := []struct {
+		period xrplsale.Period
+		want   time.Duration
+	}{
+		{xrplsale.Period24Hours, 24 * time.Hour},
+		{xrplsale.Period7Days, 7 * 24 * time.Hour},
+		{xrplsale.Period30Days, 30 * 24 * time.Hour},
+		{xrplsale.Period90Days, 90 * 24 * time.Hour},
+		{xrplsale.Period1Year, 365 * 24 * time.Hour},
+		{"", 0},
+		{"2w", 0},
+		{"30D", 0},
+	}
+	for _, tt := range periods {
+		err := tt.period.Validate()
+		if (err == nil) != (tt.want != 0) || (err != nil && !errors.Is(err, xrplsale.ErrInvalidTimeframe)) {
+			t.Errorf("Period(%q).Validate() = %v", tt.period, err)
+		}
+		if got := tt.period.Duration(); got != tt.want {
+			t.Errorf("Period(%q).Duration() = %v, want %v", tt.period, got, tt.want)
+		}
+	}
+	
+	granularities := []struct {
+		granularity xrplsale.Granularity
+		want        time.Duration
+	}{
+		{xrplsale.GranularityMinute, time.Minute},
+		{xrplsale.GranularityHour, time.Hour},
+		{xrplsale.GranularityDay, 24 * time.Hour},
+		{xrplsale.GranularityWeek, 7 * 24 * time.Hour},
+		{xrplsale.GranularityMonth, 30 * 24 * time.Hour},
+		{"", 0},
+		{"second", 0},
+		{"Hour", 0},
+	}
+	for _, tt := range granularities {
+		err := tt.granularity.Validate()
+		if (err == nil) != (tt.want != 0) || (err != nil && !errors.Is(err, xrplsale.ErrInvalidTimeframe)) {
+			t.Errorf("Granularity(%q).Validate() = %v", tt.granularity, err)
+		}
+		if got := tt.granularity.Duration(); got != tt.want {
+			t.Errorf("Granularity(%q).Duration() = %v, want %v", tt.granularity, got, tt.want)
+		}
+	}
+	
+	end := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if got, want := xrplsale.Period7Days.Start(end), time.Date(2024, 2, 23, 12, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Period7Days.Start(%v) = %v, want %v", end, got, want)
+	}
+}
+
+func TestCheckGranularity(t *testing.T) {
+	tests := []struct {
+		period      xrplsale.Period
+		granularity xrplsale.Granularity
+		ok          bool
+	}{
+		{xrplsale.Period24Hours, xrplsale.GranularityMinute, false}, // 1440 buckets
+		{xrplsale.Period24Hours, xrplsale.GranularityHour, true},
+		{xrplsale.Period24Hours, xrplsale.GranularityDay, false}, // not shorter than the period
+		{xrplsale.Period7Days, xrplsale.GranularityHour, true},   // 168 buckets
+		{xrplsale.Period7Days, xrplsale.GranularityWeek, false},
+		{xrplsale.Period30Days, xrplsale.GranularityHour, true}, // 720 buckets
+		{xrplsale.Period30Days, xrplsale.GranularityWeek, true},
+		{xrplsale.Period30Days, xrplsale.GranularityMonth, false},
+		{xrplsale.Period90Days, xrplsale.GranularityHour, false}, // 2160 buckets
+		{xrplsale.Period90Days, xrplsale.GranularityMonth, true},
+		{xrplsale.Period1Year, xrplsale.GranularityDay, true}, // 365 buckets
+		{xrplsale.Period1Year, xrplsale.GranularityMonth, true},
+		{"2w", xrplsale.GranularityDay, false},
+		{xrplsale.Period30Days, "second", false},
+	}
+	for _, tt := range tests {
+		err := xrplsale.CheckGranularity(tt.period, tt.granularity)
+		if tt.ok && err != nil {
+			t.Errorf("CheckGranularity(%s, %s) = %v, want nil", tt.period, tt.granularity, err)
+		}
+		if !tt.ok && !errors.Is(err, xrplsale.ErrInvalidTimeframe) {
+			t.Errorf("CheckGranularity(%s, %s) = %v, want ErrInvalidTimeframe", tt.period, tt.granularity, err)
+		}
+	}
+}
+
+func TestAlignToBucket(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	newYork := mustLoad(t, "America/New_York")
+	kolkata := mustLoad(t, "Asia/Kolkata")
+	adelaide := mustLoad(t, "Australia/Adelaide")
+	lordHowe := mustLoad(t, "Australia/Lord_Howe")
+	utc := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+	
+	tests := []struct {
+		name        string
+		t           time.Time
+		granularity xrplsale.Granularity
+		want        time.Time
+	}{
+		{"utc minute", time.Date(2024, 5, 15, 14, 45, 30, 500, time.UTC), xrplsale.GranularityMinute, utc(2024, 5, 15, 14, 45)},
+		{"utc hour", time.Date(2024, 5, 15, 14, 45, 30, 0, time.UTC), xrplsale.GranularityHour, utc(2024, 5, 15, 14, 0)},
+		{"utc week on a Monday", utc(2024, 5, 13, 0, 0), xrplsale.GranularityWeek, utc(2024, 5, 13, 0, 0)},
+		{"utc week on a Sunday", utc(2024, 5, 19, 23, 59), xrplsale.GranularityWeek, utc(2024, 5, 13, 0, 0)},
+		{"utc week across a month", utc(2024, 6, 2, 8, 0), xrplsale.GranularityWeek, utc(2024, 5, 27, 0, 0)},
+		{"utc month", utc(2024, 2, 29, 23, 0), xrplsale.GranularityMonth, utc(2024, 2, 1, 0, 0)},
+		{"unknown granularity", utc(2024, 5, 15, 14, 45), "second", utc(2024, 5, 15, 14, 45)},
+		
+		// Berlin falls back from 03:00 CEST to 02:00 CET on 2024-10-27
+		{"berlin first 02:30", utc(2024, 10, 27, 0, 30).In(berlin), xrplsale.GranularityHour, utc(2024, 10, 27, 0, 0)},
+		{"berlin second 02:30", utc(2024, 10, 27, 1, 30).In(berlin), xrplsale.GranularityHour, utc(2024, 10, 27, 1, 0)},
+		{"berlin day after fall back", time.Date(2024, 10, 27, 23, 0, 0, 0, berlin), xrplsale.GranularityDay, utc(2024, 10, 26, 22, 0)},
+		{"berlin week over fall back", time.Date(2024, 10, 27, 23, 0, 0, 0, berlin), xrplsale.GranularityWeek, utc(2024, 10, 20, 22, 0)},
+		{"berlin month over fall back", time.Date(2024, 10, 31, 12, 0, 0, 0, berlin), xrplsale.GranularityMonth, utc(2024, 9, 30, 22, 0)},
+		
+		// New York springs forward from 02:00 EST to 03:00 EDT on 2024-03-10
+		{"new york hour after the gap", time.Date(2024, 3, 10, 3, 30, 0, 0, newYork), xrplsale.GranularityHour, utc(2024, 3, 10, 7, 0)},
+		{"new york day after spring forward", time.Date(2024, 3, 10, 12, 0, 0, 0, newYork), xrplsale.GranularityDay, utc(2024, 3, 10, 5, 0)},
+		{"new york week over spring forward", time.Date(2024, 3, 11, 1, 0, 0, 0, newYork), xrplsale.GranularityWeek, utc(2024, 3, 11, 4, 0)},
+		{"new york sunday before the week", time.Date(2024, 3, 10, 23, 0, 0, 0, newYork), xrplsale.GranularityWeek, utc(2024, 3, 4, 5, 0)},
+		
+		// Kolkata is UTC+05:30, so hours start on the local wall clock
+		{"kolkata minute", time.Date(2024, 5, 15, 14, 45, 30, 0, kolkata), xrplsale.GranularityMinute, utc(2024, 5, 15, 9, 15)},
+		{"kolkata hour", time.Date(2024, 5, 15, 14, 45, 30, 0, kolkata), xrplsale.GranularityHour, utc(2024, 5, 15, 8, 30)},
+		{"kolkata hour before utc midnight", time.Date(2024, 5, 16, 5, 10, 0, 0, kolkata), xrplsale.GranularityHour, utc(2024, 5, 15, 23, 30)},
+		{"kolkata day", time.Date(2024, 5, 16, 3, 0, 0, 0, kolkata), xrplsale.GranularityDay, utc(2024, 5, 15, 18, 30)},
+		
+		// Adelaide falls back from 03:00 +10:30 to 02:00 +09:30 on 2024-04-07
+		{"adelaide first 02:03", utc(2024, 4, 6, 15, 33).In(adelaide), xrplsale.GranularityHour, utc(2024, 4, 6, 15, 30)},
+		{"adelaide second 02:03", utc(2024, 4, 6, 16, 33).In(adelaide), xrplsale.GranularityHour, utc(2024, 4, 6, 16, 30)},
+		
+		// Lord Howe springs forward half an hour, from 02:00 +10:30 to 02:30 +11
+		// on 2024-10-06, so its 02:00 hour starts at the change
+		{"lord howe short hour", utc(2024, 10, 5, 15, 45).In(lordHowe), xrplsale.GranularityHour, utc(2024, 10, 5, 15, 30)},
+		{"lord howe hour before", utc(2024, 10, 5, 15, 15).In(lordHowe), xrplsale.GranularityHour, utc(2024, 10, 5, 14, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := xrplsale.AlignToBucket(tt.t, tt.granularity)
+			if !got.Equal(tt.want) {
+				t.Fatalf("AlignToBucket(%v, %s) = %v, want %v", tt.t, tt.granularity, got, tt.want.In(tt.t.Location()))
+			}
+			if got.Location() != tt.t.Location() {
+				t.Fatalf("AlignToBucket(%v, %s) is in %v, want %v", tt.t, tt.granularity, got.Location(), tt.t.Location())
+			}
+		})
+	}
+	
+	// The fall-back day has 25 hours and the spring-forward day 23
+	for _, tt := range []struct {
+		day  time.Time
+		want time.Duration
+	}{
+		{time.Date(2024, 10, 27, 12, 0, 0, 0, berlin), 25 * time.Hour},
+		{time.Date(2024, 3, 10, 12, 0, 0, 0, newYork), 23 * time.Hour},
+	} {
+		start := xrplsale.AlignToBucket(tt.day, xrplsale.GranularityDay)
+		next := xrplsale.AlignToBucket(start.Add(tt.want), xrplsale.GranularityDay)
+		if got := next.Sub(start); got != tt.want || !next.Equal(start.Add(tt.want)) {
+			t.Errorf("day of %v lasts %v, want %v", tt.day, got, tt.want)
+		}
+	}
+}
+
+// TestAlignToBucketSweep checks that every instant around the DST changes
+// lies in a bucket that starts at or before it, less than a bucket and an
+// hour earlier, and that aligning a bucket start is a no-op
+func TestAlignToBucketSweep(t *testing.T) {
+	zones := []string{"UTC", "Europe/Berlin", "America/New_York", "Asia/Kolkata", "Asia/Kathmandu", "Australia/Adelaide", "Australia/Lord_Howe"}
+	windows := []time.Time{
+		time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 10, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 10, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+	granularities := []xrplsale.Granularity{xrplsale.GranularityMinute, xrplsale.GranularityHour, xrplsale.GranularityDay, xrplsale.GranularityWeek, xrplsale.GranularityMonth}
+	for _, name := range zones {
+		loc := mustLoad(t, name)
+		for _, start := range windows {
+			for at := start; at.Before(start.Add(4 * 24 * time.Hour)); at = at.Add(7 * time.Minute) {
+				local := at.In(loc)
+				for _, g := range granularities {
+					bucket := xrplsale.AlignToBucket(local, g)
+					// Months are up to 31 days; allow an hour for DST
+					limit := g.Duration() + time.Hour
+					if g == xrplsale.GranularityMonth {
+						limit = 31*24*time.Hour + time.Hour
+					}
+					if bucket.After(local) || local.Sub(bucket) >= limit {
+						t.Fatalf("%s: AlignToBucket(%v, %s) = %v", name, local, g, bucket)
+					}
+					if again := xrplsale.AlignToBucket(bucket, g); !again.Equal(bucket) {
+						t.Fatalf("%s: AlignToBucket(%v, %s) = %v, not a fixed point", name, bucket, g, again)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGetStatsHistory(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path != "/projects/proj_1/stats/history" || r.URL.Query().Get("period") != "7d" || r.URL.Query().Get("granularity") != "day" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"project_id":"proj_1","period":"7d","granularity":"day","points":[
+			{"time":"2024-05-13T00:00:00Z","total_raised_xrp":"100","tokens_sold":"1000","investor_count":2,"investment_count":3},
+			{"time":"2024-05-14T00:00:00Z","total_raised_xrp":"250.5","tokens_sold":"2505","investor_count":4,"investment_count":6}
+		]}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	ctx := context.Background()
+	
+	history, err := client.Projects.GetStatsHistory(ctx, "proj_1", xrplsale.Period7Days, xrplsale.GranularityDay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.Period != xrplsale.Period7Days || history.Granularity != xrplsale.GranularityDay || len(history.Points) != 2 {
+		t.Fatalf("GetStatsHistory() = %+v", history)
+	}
+	last := history.Points[1]
+	if !last.Time.Equal(time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC)) || last.TotalRaisedXRP.String() != "250.5" || last.InvestorCount != 4 {
+		t.Fatalf("Points[1] = %+v", last)
+	}
+	
+	for _, tt := range []struct {
+		period      xrplsale.Period
+		granularity xrplsale.Granularity
+	}{
+		{xrplsale.Period1Year, xrplsale.GranularityMinute},
+		{xrplsale.Period7Days, xrplsale.GranularityMonth},
+		{"2w", xrplsale.GranularityDay},
+		{xrplsale.Period7Days, ""},
+	} {
+		if _, err := client.Projects.GetStatsHistory(ctx, "proj_1", tt.period, tt.granularity); !errors.Is(err, xrplsale.ErrInvalidTimeframe) {
+			t.Errorf("GetStatsHistory(%q, %q) = %v, want ErrInvalidTimeframe", tt.period, tt.granularity, err)
+		}
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("%d requests sent, want invalid timeframes rejected locally", got)
+	}
+}
\ No newline at end of file
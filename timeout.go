@@ -0,0 +1,123 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrStreamIdle is matched by errors returned when a stream receives no
+// data for Config.StreamIdleTimeout
+var ErrStreamIdle = errors.New("stream idle timeout")
+
+// errHeaderTimeout cancels an attempt whose response headers took longer
+// than Config.Timeout
+var errHeaderTimeout = fmt.Errorf("%w awaiting response headers", context.DeadlineExceeded)
+
+// streamKey marks a request context whose response body is streamed
+type streamKey struct{}
+
+// timeoutTransport applies Config.Timeout to each attempt. It takes the
+// place of http.Client.Timeout, which also bounds reading the body: the
+// body of a streamed response is bounded by StreamIdleTimeout between
+// reads instead, so a long stream that keeps flowing is never cut off.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+	idle    time.Duration
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	streamed, _ := req.Context().Value(streamKey{}).(bool)
+	if !streamed {
+		if t.timeout <= 0 {
+			return t.base.RoundTrip(req)
+		}
+		ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+		resp, err := t.base.RoundTrip(req.WithContext(ctx))
+		if err != nil {
+			cancel()
+			return resp, err
+		}
+		resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+		return resp, nil
+	}
+	
+	ctx, cancel := context.WithCancelCause(req.Context())
+	var timer *time.Timer
+	if t.timeout > 0 {
+		timer = time.AfterFunc(t.timeout, func() { cancel(errHeaderTimeout) })
+	}
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if timer != nil {
+		timer.Stop()
+	}
+	if err != nil {
+		if cause := context.Cause(ctx); errors.Is(cause, errHeaderTimeout) && !errors.Is(err, errHeaderTimeout) {
+			err = fmt.Errorf("%w: %w", cause, err)
+		}
+		cancel(nil)
+		return resp, err
+	}
+	body := &idleBody{body: resp.Body, ctx: ctx, cancel: cancel}
+	if t.idle > 0 {
+		body.timer = time.AfterFunc(t.idle, func() { cancel(ErrStreamIdle) })
+		body.idle = t.idle
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+// cancelBody releases the attempt's context when the body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// idleBody cancels a streamed response when no read completes within idle
+type idleBody struct {
+	body   io.ReadCloser
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	timer  *time.Timer
+	idle   time.Duration
+	once   sync.Once
+}
+
+// Read implements io.Reader
+func (b *idleBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if b.timer != nil && n > 0 {
+		b.timer.Reset(b.idle)
+	}
+	if err != nil && err != io.EOF && !errors.Is(err, ErrStreamIdle) {
+		if cause := context.Cause(b.ctx); errors.Is(cause, ErrStreamIdle) {
+			err = fmt.Errorf("%w after %s: %w", ErrStreamIdle, b.idle, err)
+		}
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (b *idleBody) Close() error {
+	err := b.body.Close()
+	b.once.Do(func() {
+		if b.timer != nil {
+			b.timer.Stop()
+		}
+		b.cancel(nil)
+	})
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,83 @@
+package xrplsale
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// unixMillisThreshold separates Unix seconds from Unix milliseconds: a
+// seconds value this large would be thousands of years away
+const unixMillisThreshold = 1e11
+
+// maxUnixMillis bounds epoch timestamps to roughly ±30,000 years
+const maxUnixMillis = 1e15
+
+// Timestamp is a time decoded leniently from the formats the API uses:
+// RFC 3339 with or without fractional seconds, Unix seconds or milliseconds
+// (as a number or a string), and "" or null for the zero time. It always
+// encodes as RFC 3339 in UTC, or null when zero.
+type Timestamp struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339Nano))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	return t.unmarshalString(s)
+}
+
+// unmarshalString decodes a timestamp sent as a string
+func (t *Timestamp) unmarshalString(s string) error {
+	parsed, err := parseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = Timestamp{Time: parsed}
+	return nil
+}
+
+// parseTimestamp parses any of the formats Timestamp accepts
+func parseTimestamp(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if epoch, err := strconv.ParseFloat(s, 64); err == nil {
+		// Also rejects NaN and infinities, which ParseFloat accepts
+		if !(math.Abs(epoch) < maxUnixMillis) {
+			return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+		}
+		if epoch >= unixMillisThreshold || epoch <= -unixMillisThreshold {
+			return time.UnixMilli(int64(epoch)).UTC(), nil
+		}
+		sec := int64(epoch)
+		return time.Unix(sec, int64((epoch-float64(sec))*1e9)).UTC(), nil
+	}
+	// RFC3339 parsing accepts fractional seconds too
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+	}
+	return parsed, nil
+}
\ No newline at end of file
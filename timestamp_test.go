@@ -0,0 +1,83 @@
+package xrplsale_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestTimestampUnmarshal(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		in   string
+		want time.Time
+	}{
+		{"RFC 3339", `"2024-03-01T12:00:00Z"`, base},
+		{"RFC 3339 with offset", `"2024-03-01T14:00:00+02:00"`, base},
+		{"RFC 3339 nano", `"2024-03-01T12:00:00.123456789Z"`, base.Add(123456789 * time.Nanosecond)},
+		{"RFC 3339 millis", `"2024-03-01T12:00:00.5Z"`, base.Add(500 * time.Millisecond)},
+		{"unix seconds", `1709294400`, base},
+		{"unix seconds as string", `"1709294400"`, base},
+		{"unix seconds with fraction", `1709294400.25`, base.Add(250 * time.Millisecond)},
+		{"unix millis", `1709294400123`, base.Add(123 * time.Millisecond)},
+		{"unix millis as string", `"1709294400123"`, base.Add(123 * time.Millisecond)},
+		{"epoch", `0`, time.Unix(0, 0)},
+		{"empty string", `""`, time.Time{}},
+		{"null", `null`, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts xrplsale.Timestamp
+			if err := json.Unmarshal([]byte(tt.in), &ts); err != nil {
+				t.Fatal(err)
+			}
+			if !ts.Equal(tt.want) || ts.IsZero() != tt.want.IsZero() {
+				t.Fatalf("decoded %s as %v, want %v", tt.in, ts.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestampUnmarshalInvalid(t *testing.T) {
+	for _, in := range []string{`"yesterday"`, `"2024-03-01"`, `"2024-03-01 12:00:00"`, `"NaN"`, `"Inf"`, `1e20`, `true`, `{}`} {
+		var ts xrplsale.Timestamp
+		if err := json.Unmarshal([]byte(in), &ts); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want an error", in, ts.Time)
+		}
+	}
+}
+
+func TestTimestampMarshal(t *testing.T) {
+	paris := time.FixedZone("CET", 3600)
+	tests := []struct {
+		name string
+		in   xrplsale.Timestamp
+		want string
+	}{
+		{"UTC", xrplsale.Timestamp{Time: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}, `"2024-03-01T12:00:00Z"`},
+		{"converted to UTC", xrplsale.Timestamp{Time: time.Date(2024, 3, 1, 13, 0, 0, 0, paris)}, `"2024-03-01T12:00:00Z"`},
+		{"fractional seconds kept", xrplsale.Timestamp{Time: time.Date(2024, 3, 1, 12, 0, 0, 5e8, time.UTC)}, `"2024-03-01T12:00:00.5Z"`},
+		{"zero", xrplsale.Timestamp{}, `null`},
+	}
+	for _, tt := range tests {
+		out, err := json.Marshal(tt.in)
+		if err != nil || string(out) != tt.want {
+			t.Errorf("%s: Marshal() = %s, %v; want %s", tt.name, out, err, tt.want)
+		}
+	}
+}
+
+func TestTimestampFields(t *testing.T) {
+	// One bad format used to fail the whole response
+	var project xrplsale.Project
+	err := json.Unmarshal([]byte(`{"id":"proj_1","created_at":1709294400123,"sale_start_date":"2024-03-01T12:00:00.000Z","sale_end_date":null}`), &project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project.CreatedAt.UnixMilli() != 1709294400123 || project.SaleStartDate.Unix() != 1709294400 || !project.SaleEndDate.IsZero() {
+		t.Fatalf("decoded %+v", project)
+	}
+}
\ No newline at end of file
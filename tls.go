@@ -0,0 +1,152 @@
+package xrplsale
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+)
+
+// ErrCertificatePinMismatch is returned when none of the server's certificates
+// match Config.TLS.PinnedPublicKeys. The connection is always refused.
+var ErrCertificatePinMismatch = errors.New("server certificate does not match any pinned public key")
+
+// TLSOptions hardens the TLS connections made by the client
+type TLSOptions struct {
+	// MinVersion is the minimum TLS version; defaults to TLS 1.2
+	MinVersion uint16
+	
+	// RootCAs replaces the system roots used to verify the server
+	RootCAs *x509.CertPool
+	
+	// RootCAFile is a PEM bundle of CA certificates added to RootCAs, or
+	// replacing the system roots if RootCAs is nil, e.g. to trust only the
+	// xrpl.sale CA
+	RootCAFile string
+	
+	// ClientCertificates are presented to servers that request a client
+	// certificate (mutual TLS)
+	ClientCertificates []tls.Certificate
+	
+	// ClientCertFile and ClientKeyFile are a PEM certificate and key loaded
+	// when the client is created and added to ClientCertificates
+	ClientCertFile string
+	ClientKeyFile  string
+	
+	// InsecureSkipVerify disables certificate verification. It is refused
+	// for the production API and intended only for testnet or local setups.
+	InsecureSkipVerify bool
+	
+	// PinnedPublicKeys lists base64-encoded SHA-256 hashes of the accepted
+	// servers' SubjectPublicKeyInfo. Listing the next key alongside the current
+	// one allows rotation without downtime.
+	PinnedPublicKeys []string
+}
+
+// validate checks the options against the API base URL
+func (o *TLSOptions) validate(baseURL string) error {
+	if o.InsecureSkipVerify && isProductionURL(baseURL) {
+		return errors.New("TLS InsecureSkipVerify is not allowed for the production API")
+	}
+	for _, pin := range o.PinnedPublicKeys {
+		if decoded, err := base64.StdEncoding.DecodeString(pin); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("invalid pinned public key %q: want base64 SHA-256 hash", pin)
+		}
+	}
+	if (o.ClientCertFile == "") != (o.ClientKeyFile == "") {
+		return errors.New("TLS ClientCertFile and ClientKeyFile must be set together")
+	}
+	return nil
+}
+
+// tlsConfig builds the crypto/tls configuration for the options, loading
+// the certificate files they name
+func (o *TLSOptions) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         o.MinVersion,
+		RootCAs:            o.RootCAs,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+		Certificates:       slices.Clone(o.ClientCertificates),
+	}
+	if o.RootCAFile != "" {
+		pem, err := os.ReadFile(o.RootCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading TLS RootCAFile: %w", err)
+		}
+		if cfg.RootCAs != nil {
+			cfg.RootCAs = cfg.RootCAs.Clone()
+		} else {
+			cfg.RootCAs = x509.NewCertPool()
+		}
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("TLS RootCAFile %s contains no PEM certificates", o.RootCAFile)
+		}
+	}
+	if o.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.ClientCertFile, o.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading TLS client certificate: %w", err)
+		}
+		cfg.Certificates = append(cfg.Certificates, cert)
+	}
+	if cfg.MinVersion == 0 {
+		cfg.MinVersion = tls.VersionTLS12
+	}
+	if len(o.PinnedPublicKeys) > 0 {
+		pins := make(map[string]bool, len(o.PinnedPublicKeys))
+		for _, pin := range o.PinnedPublicKeys {
+			pins[pin] = true
+		}
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			return verifyPins(rawCerts, verifiedChains, pins)
+		}
+	}
+	return cfg, nil
+}
+
+// verifyPins accepts the connection if a certificate of a verified chain
+// matches a pin. Unverified certificates the server merely sent along are
+// ignored, so a pinned certificate appended to a foreign chain does not
+// pass. Without verified chains (InsecureSkipVerify) only the leaf counts.
+func verifyPins(rawCerts [][]byte, verifiedChains [][]*x509.Certificate, pins map[string]bool) error {
+	if len(verifiedChains) == 0 {
+		if len(rawCerts) == 0 {
+			return ErrCertificatePinMismatch
+		}
+		leaf, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil || !pins[PublicKeyPin(leaf)] {
+			return ErrCertificatePinMismatch
+		}
+		return nil
+	}
+	for _, chain := range verifiedChains {
+		for _, cert := range chain {
+			if pins[PublicKeyPin(cert)] {
+				return nil
+			}
+		}
+	}
+	return ErrCertificatePinMismatch
+}
+
+// PublicKeyPin returns the pin of a certificate's public key in the format
+// expected by TLSOptions.PinnedPublicKeys
+func PublicKeyPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// isProductionURL reports whether baseURL points at the production API host
+func isProductionURL(baseURL string) bool {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return false
+	}
+	prod, _ := url.Parse(ProductionBaseURL)
+	return u.Hostname() == prod.Hostname()
+}
\ No newline at end of file
@@ -0,0 +1,319 @@
+package xrplsale
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testCert is a generated certificate with its key
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert generates a certificate for 127.0.0.1 signed by parent, or a
+// self-signed CA when parent is nil
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		tmpl.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+		tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{cert: cert, key: key}
+}
+
+// newPinnedTestServer starts a TLS server presenting leaf followed by extra
+// certificates it sends along with its chain
+func newPinnedTestServer(t *testing.T, leaf *testCert, extra ...*testCert) *httptest.Server {
+	t.Helper()
+	chain := tls.Certificate{Certificate: [][]byte{leaf.cert.Raw}, PrivateKey: leaf.key, Leaf: leaf.cert}
+	for _, cert := range extra {
+		chain.Certificate = append(chain.Certificate, cert.cert.Raw)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{chain}}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVerifyPins(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	leaf := newTestCert(t, "leaf", ca)
+	foreignCA := newTestCert(t, "foreign ca", nil)
+	foreign := newTestCert(t, "foreign", foreignCA)
+	
+	tests := []struct {
+		name     string
+		pins     []*testCert
+		raw      []*testCert
+		verified [][]*testCert
+		wantErr  bool
+	}{
+		{"leaf pinned", []*testCert{leaf}, []*testCert{leaf}, [][]*testCert{{leaf, ca}}, false},
+		{"CA pinned", []*testCert{ca}, []*testCert{leaf}, [][]*testCert{{leaf, ca}}, false},
+		{"rotation, second pin", []*testCert{foreign, leaf}, []*testCert{leaf}, [][]*testCert{{leaf, ca}}, false},
+		{"mismatch", []*testCert{foreign}, []*testCert{leaf}, [][]*testCert{{leaf, ca}}, true},
+		{"pinned cert appended to foreign chain", []*testCert{leaf}, []*testCert{foreign, leaf}, [][]*testCert{{foreign, foreignCA}}, true},
+		{"insecure, leaf pinned", []*testCert{leaf}, []*testCert{leaf, ca}, nil, false},
+		{"insecure, only chain pinned", []*testCert{ca}, []*testCert{leaf, ca}, nil, true},
+		{"insecure, pinned cert appended", []*testCert{leaf}, []*testCert{foreign, leaf}, nil, true},
+		{"no certificates", []*testCert{leaf}, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pins := make(map[string]bool)
+			for _, pin := range tt.pins {
+				pins[PublicKeyPin(pin.cert)] = true
+			}
+			var raw [][]byte
+			for _, cert := range tt.raw {
+				raw = append(raw, cert.cert.Raw)
+			}
+			var verified [][]*x509.Certificate
+			for _, chain := range tt.verified {
+				var certs []*x509.Certificate
+				for _, cert := range chain {
+					certs = append(certs, cert.cert)
+				}
+				verified = append(verified, certs)
+			}
+			
+			err := verifyPins(raw, verified, pins)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("verifyPins() = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrCertificatePinMismatch) {
+				t.Fatalf("verifyPins() = %v, want ErrCertificatePinMismatch", err)
+			}
+		})
+	}
+}
+
+func TestTLSPinning(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	leaf := newTestCert(t, "leaf", ca)
+	next := newTestCert(t, "next", ca)
+	other := newTestCert(t, "other", newTestCert(t, "other ca", nil))
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	
+	tests := []struct {
+		name     string
+		extra    []*testCert
+		pins     []*testCert
+		insecure bool
+		wantErr  bool
+	}{
+		{"current key pinned", nil, []*testCert{leaf}, false, false},
+		{"rotation to the next key", nil, []*testCert{next, leaf}, false, false},
+		{"mismatch", nil, []*testCert{other}, false, true},
+		{"pinned cert sent along", []*testCert{other}, []*testCert{other}, false, true},
+		{"insecure, leaf pinned", nil, []*testCert{leaf}, true, false},
+		{"insecure, pinned cert sent along", []*testCert{other}, []*testCert{other}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newPinnedTestServer(t, leaf, tt.extra...)
+			opts := &TLSOptions{InsecureSkipVerify: tt.insecure}
+			if !tt.insecure {
+				opts.RootCAs = roots
+			}
+			for _, pin := range tt.pins {
+				opts.PinnedPublicKeys = append(opts.PinnedPublicKeys, PublicKeyPin(pin.cert))
+			}
+			client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, TLS: opts})
+			
+			var out map[string]interface{}
+			err := client.Get(context.Background(), "/health", nil, &out)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Get() = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrCertificatePinMismatch) {
+				t.Fatalf("Get() = %v, want ErrCertificatePinMismatch", err)
+			}
+		})
+	}
+}
+
+// writePEM writes cert and, when withKey is set, its key to files in dir,
+// returning their paths
+func writePEM(t *testing.T, dir string, name string, cert *testCert, withKey bool) (certFile, keyFile string) {
+	t.Helper()
+	certFile = filepath.Join(dir, name+".crt")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.cert.Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if !withKey {
+		return certFile, ""
+	}
+	der, err := x509.MarshalECPrivateKey(cert.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile = filepath.Join(dir, name+".key")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// newMutualTLSServer starts a TLS server that requires a client certificate
+// signed by ca. It lists one project, and sets a cookie that it expects
+// back on later requests when the client keeps cookies.
+func newMutualTLSServer(t *testing.T, ca, leaf *testCert) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if cookie, err := r.Cookie("session"); err == nil {
+			w.Write([]byte(`{"data":[{"id":"proj_` + cookie.Value + `"}]}`))
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie", Path: "/"})
+		w.Write([]byte(`{"data":[{"id":"proj_1"}]}`))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{leaf.cert.Raw}, PrivateKey: leaf.key, Leaf: leaf.cert}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	srv, _ := newMutualTLSServer(t, ca, newTestCert(t, "server", ca))
+	dir := t.TempDir()
+	caFile, _ := writePEM(t, dir, "ca", ca, false)
+	certFile, keyFile := writePEM(t, dir, "client", newTestCert(t, "client", ca), true)
+	otherCA := newTestCert(t, "other ca", nil)
+	otherFile, otherKey := writePEM(t, dir, "other", newTestCert(t, "other", otherCA), true)
+	
+	tests := []struct {
+		name    string
+		opts    *TLSOptions
+		wantErr bool
+	}{
+		{"client certificate files", &TLSOptions{RootCAFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile}, false},
+		{"no client certificate", &TLSOptions{RootCAFile: caFile}, true},
+		{"certificate from another CA", &TLSOptions{RootCAFile: caFile, ClientCertFile: otherFile, ClientKeyFile: otherKey}, true},
+		{"server not trusted", &TLSOptions{ClientCertFile: certFile, ClientKeyFile: keyFile}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, TLS: tt.opts})
+			projects, err := client.Projects.List(context.Background(), nil, WithNoRetry())
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Projects.List() = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && (len(projects.Data) != 1 || projects.Data[0].ID != "proj_1") {
+				t.Fatalf("Projects.List() = %+v", projects)
+			}
+		})
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	srv, hits := newMutualTLSServer(t, ca, newTestCert(t, "server", ca))
+	dir := t.TempDir()
+	caFile, _ := writePEM(t, dir, "ca", ca, false)
+	certFile, keyFile := writePEM(t, dir, "client", newTestCert(t, "client", ca), true)
+	mtls := &TLSOptions{RootCAFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile}
+	
+	// The caller's client keeps its cookie jar, while TLS goes on a clone
+	// of its transport
+	jar, _ := cookiejar.New(nil)
+	transport := &http.Transport{MaxIdleConnsPerHost: 7}
+	own := &http.Client{Jar: jar, Transport: transport, Timeout: 10 * time.Second}
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, TLS: mtls, HTTPClient: own})
+	ctx := context.Background()
+	var ids []string
+	for i := 0; i < 2; i++ {
+		projects, err := client.Projects.List(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, projects.Data[0].ID)
+	}
+	if ids[0] != "proj_1" || ids[1] != "proj_cookie" {
+		t.Fatalf("listed %q, want the session cookie sent back by the caller's jar", ids)
+	}
+	// Clone sets up HTTP/2 on the original, so only the SDK's own settings
+	// must be absent from it
+	if own.Transport != transport || transport.MaxIdleConnsPerHost != 7 || (transport.TLSClientConfig != nil && (transport.TLSClientConfig.RootCAs != nil || len(transport.TLSClientConfig.Certificates) > 0)) {
+		t.Fatal("NewClientWithConfig modified the caller's HTTPClient")
+	}
+	if clone, ok := client.transport.(*http.Transport); !ok || clone == transport || clone.MaxIdleConnsPerHost != 7 {
+		t.Fatalf("client transport %T, want a clone keeping the caller's pool settings", client.transport)
+	}
+	
+	// TLS needs an *http.Transport, and HTTPClient replaces Transport
+	sent := hits.Load()
+	custom := struct{ http.RoundTripper }{http.DefaultTransport}
+	for _, config := range []*Config{
+		{HTTPClient: &http.Client{Transport: custom}, TLS: mtls},
+		{HTTPClient: &http.Client{Transport: custom}, ProxyURL: "http://proxy.internal:3128"},
+		{HTTPClient: &http.Client{}, Transport: custom},
+	} {
+		config.APIKey, config.BaseURL = "key", srv.URL
+		if _, err := NewClientWithConfig(config).Projects.List(ctx, nil, WithNoRetry()); err == nil {
+			t.Errorf("Projects.List() succeeded with an invalid HTTPClient config %+v", config)
+		}
+	}
+	if got := hits.Load(); got != sent {
+		t.Fatalf("%d requests sent with invalid configs, want none", got-sent)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,30 @@
+package xrplsale
+
+// TokenFlags are the issuer account settings that govern a project's token
+type TokenFlags struct {
+	// DefaultRipple lets the token ripple between holders' trust lines,
+	// which issued tokens need to be transferable
+	DefaultRipple bool `json:"default_ripple"`
+	RequireAuth   bool `json:"require_auth"`
+	GlobalFreeze  bool `json:"global_freeze"`
+	
+	// NoFreeze permanently gives up the issuer's ability to freeze holders
+	NoFreeze bool `json:"no_freeze"`
+}
+
+// TokenDetails is the platform's record of how a project's token is issued
+// on the XRP Ledger. Use ledger.Client.VerifyOnLedger to check it against
+// the live issuer account.
+type TokenDetails struct {
+	ProjectID     string `json:"project_id"`
+	IssuerAddress string `json:"issuer_address"`
+	
+	// CurrencyCode is a 3-character code or a 40-character hex code
+	CurrencyCode string `json:"currency_code"`
+	TotalSupply  Amount `json:"total_supply"`
+	
+	// TransferFee is the percentage charged on transfers between holders,
+	// from 0 to 100
+	TransferFee Amount     `json:"transfer_fee"`
+	Flags       TokenFlags `json:"flags"`
+}
\ No newline at end of file
@@ -0,0 +1,115 @@
+package xrplsale
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrMalformedToken is matched by the error ParseToken returns for a token
+// that is not a JWT
+var ErrMalformedToken = errors.New("malformed token")
+
+// TokenInfo is what an auth token says about itself
+type TokenInfo struct {
+	// Subject is the wallet address the token was issued to
+	Subject string
+	Scopes  []string
+	
+	// SessionID is the ID of the session the token belongs to, as listed by
+	// ListSessions; empty when the token has no sid claim
+	SessionID string
+	
+	// IssuedAt and ExpiresAt are zero when the token has no iat or exp claim
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the token has expired. A token without an exp
+// claim never does.
+func (ti *TokenInfo) Expired() bool {
+	return !ti.ExpiresAt.IsZero() && !time.Now().Before(ti.ExpiresAt)
+}
+
+// tokenClaims are the JWT claims ParseToken reads. The API sends scopes as
+// a space-separated scope claim; a scopes array is accepted too.
+type tokenClaims struct {
+	Subject   string          `json:"sub"`
+	SessionID string          `json:"sid"`
+	IssuedAt  json.Number     `json:"iat"`
+	ExpiresAt json.Number     `json:"exp"`
+	Scope     string          `json:"scope"`
+	Scopes    json.RawMessage `json:"scopes"`
+}
+
+// ParseToken decodes the claims of a JWT such as AuthResponse.Token. The
+// signature is not verified, so the result is for display and scheduling
+// only, never for access decisions.
+func ParseToken(token string) (*TokenInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 segments, got %d", ErrMalformedToken, len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("%w: decoding payload: %v", ErrMalformedToken, err)
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: decoding claims: %v", ErrMalformedToken, err)
+	}
+	
+	info := &TokenInfo{Subject: claims.Subject, SessionID: claims.SessionID, Scopes: strings.Fields(claims.Scope)}
+	if len(claims.Scopes) > 0 && string(claims.Scopes) != "null" {
+		var scopes []string
+		if err := json.Unmarshal(claims.Scopes, &scopes); err != nil {
+			return nil, fmt.Errorf("%w: decoding scopes: %v", ErrMalformedToken, err)
+		}
+		info.Scopes = append(info.Scopes, scopes...)
+	}
+	if info.IssuedAt, err = claimTime(claims.IssuedAt); err != nil {
+		return nil, fmt.Errorf("%w: iat: %v", ErrMalformedToken, err)
+	}
+	if info.ExpiresAt, err = claimTime(claims.ExpiresAt); err != nil {
+		return nil, fmt.Errorf("%w: exp: %v", ErrMalformedToken, err)
+	}
+	return info, nil
+}
+
+// claimTime converts a NumericDate claim, in possibly fractional seconds
+// since the epoch; an absent claim is the zero time
+func claimTime(n json.Number) (time.Time, error) {
+	if n == "" {
+		return time.Time{}, nil
+	}
+	secs, err := n.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(int64(secs * 1000)), nil
+}
+
+// tokenExpiry returns when token expires according to its exp claim; zero
+// when it is not a JWT or has none
+func tokenExpiry(token string) time.Time {
+	info, err := ParseToken(token)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ExpiresAt
+}
+
+// setDerived fills in the fields of a response received at now that are not
+// sent as-is: ExpiresAt, from ExpiresIn or else the token's exp claim, and
+// Scopes from the claims when the API did not list them
+func (r *AuthResponse) setDerived(now time.Time) {
+	r.Scopes = r.scopesOf()
+	if r.ExpiresIn > 0 {
+		r.ExpiresAt = now.Add(time.Duration(r.ExpiresIn) * time.Second)
+		return
+	}
+	r.ExpiresAt = tokenExpiry(r.Token)
+}
\ No newline at end of file
@@ -0,0 +1,111 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestParseToken(t *testing.T) {
+	exp := time.Unix(1900000000, 0)
+	tests := []struct {
+		name    string
+		token   string
+		want    xrplsale.TokenInfo
+		wantErr bool
+	}{
+		{"all claims", jwtWith(t, map[string]interface{}{"sub": "rWallet", "sid": "sess_1", "iat": 1700000000, "exp": 1900000000, "scope": "projects:read investments:write"}),
+			xrplsale.TokenInfo{Subject: "rWallet", SessionID: "sess_1", Scopes: []string{"projects:read", "investments:write"}, IssuedAt: time.Unix(1700000000, 0), ExpiresAt: exp}, false},
+		{"no exp claim", jwtWith(t, map[string]interface{}{"sub": "rWallet"}), xrplsale.TokenInfo{Subject: "rWallet"}, false},
+		{"fractional iat and exp", jwtWith(t, map[string]interface{}{"iat": 1700000000.25, "exp": 1900000000.5}),
+			xrplsale.TokenInfo{IssuedAt: time.UnixMilli(1700000000250), ExpiresAt: time.UnixMilli(1900000000500)}, false},
+		{"scopes array", jwtWith(t, map[string]interface{}{"scopes": []string{"projects:read", "projects:write"}}),
+			xrplsale.TokenInfo{Scopes: []string{"projects:read", "projects:write"}}, false},
+		{"scope string and scopes array", jwtWith(t, map[string]interface{}{"scope": "projects:read", "scopes": []string{"projects:write"}}),
+			xrplsale.TokenInfo{Scopes: []string{"projects:read", "projects:write"}}, false},
+		{"null scopes", jwtWith(t, map[string]interface{}{"scopes": nil}), xrplsale.TokenInfo{}, false},
+		{"padded payload", "eyJhbGciOiJIUzI1NiJ9." + base64.URLEncoding.EncodeToString([]byte(`{"sub":"rPad"}`)) + ".c2ln",
+			xrplsale.TokenInfo{Subject: "rPad"}, false},
+		{"two segments", "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJyIn0", xrplsale.TokenInfo{}, true},
+		{"opaque token", "tok_opaque", xrplsale.TokenInfo{}, true},
+		{"malformed base64", "eyJhbGciOiJIUzI1NiJ9.!!not-base64!!.c2ln", xrplsale.TokenInfo{}, true},
+		{"payload not JSON", "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte("plain")) + ".c2ln", xrplsale.TokenInfo{}, true},
+		{"scopes not an array", jwtWith(t, map[string]interface{}{"scopes": "projects:read"}), xrplsale.TokenInfo{}, true},
+		{"exp not a number", jwtWith(t, map[string]interface{}{"exp": "tomorrow"}), xrplsale.TokenInfo{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := xrplsale.ParseToken(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, xrplsale.ErrMalformedToken) {
+					t.Fatalf("ParseToken() = %+v, %v; want ErrMalformedToken", info, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			sameScopes := len(info.Scopes) == 0 && len(tt.want.Scopes) == 0 || reflect.DeepEqual(info.Scopes, tt.want.Scopes)
+			if info.Subject != tt.want.Subject || info.SessionID != tt.want.SessionID || !sameScopes ||
+				!info.IssuedAt.Equal(tt.want.IssuedAt) || !info.ExpiresAt.Equal(tt.want.ExpiresAt) {
+				t.Errorf("ParseToken() = %+v, want %+v", info, tt.want)
+			}
+			if info.Expired() {
+				t.Errorf("Expired() = true for a token expiring at %v", info.ExpiresAt)
+			}
+		})
+	}
+	
+	info, err := xrplsale.ParseToken(jwtWith(t, map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()}))
+	if err != nil || !info.Expired() {
+		t.Errorf("ParseToken() of an expired token = %+v, %v; want Expired", info, err)
+	}
+}
+
+func TestAuthResponseExpiresAt(t *testing.T) {
+	exp := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	withExp := jwtWith(t, map[string]interface{}{"sub": "rWallet", "exp": exp.Unix()})
+	tests := []struct {
+		name      string
+		response  string
+		wantAfter time.Duration // ExpiresAt is about this long from now
+		wantExp   bool          // or exactly the token's exp claim
+		wantZero  bool
+	}{
+		{"ExpiresIn wins over exp", fmt.Sprintf(`{"token":%q,"expires_in":600}`, withExp), 10 * time.Minute, false, false},
+		{"exp without ExpiresIn", fmt.Sprintf(`{"token":%q}`, withExp), 0, true, false},
+		{"opaque token without ExpiresIn", `{"token":"tok_opaque"}`, 0, false, true},
+		{"opaque token with ExpiresIn", `{"token":"tok_opaque","expires_in":60}`, time.Minute, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := scopeServer(t, tt.response, `{}`)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			before := time.Now()
+			auth, err := client.Auth.Authenticate(context.Background(), &xrplsale.AuthRequest{WalletAddress: "rWallet", Signature: "00"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch {
+			case tt.wantZero:
+				if !auth.ExpiresAt.IsZero() {
+					t.Errorf("ExpiresAt = %v, want zero", auth.ExpiresAt)
+				}
+			case tt.wantExp:
+				if !auth.ExpiresAt.Equal(exp) {
+					t.Errorf("ExpiresAt = %v, want the exp claim %v", auth.ExpiresAt, exp)
+				}
+			default:
+				if auth.ExpiresAt.Before(before.Add(tt.wantAfter)) || auth.ExpiresAt.After(time.Now().Add(tt.wantAfter)) {
+					t.Errorf("ExpiresAt = %v, want %v after the sign-in", auth.ExpiresAt, tt.wantAfter)
+				}
+			}
+		})
+	}
+}
\ No newline at end of file
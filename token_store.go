@@ -0,0 +1,182 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TokenSet is a session's tokens as kept in a TokenStore
+type TokenSet struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	
+	// ExpiresAt is when Token expires; zero when unknown
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	
+	// Scopes are Token's scopes, when known
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// TokenStore keeps a session's tokens outside the client, so replicas
+// sharing a session pick up each other's refreshes. The client calls Get
+// before every request and Set after Authenticate and every refresh.
+type TokenStore interface {
+	// Get returns the stored tokens; a zero TokenSet when there are none
+	Get(ctx context.Context) (TokenSet, error)
+	Set(ctx context.Context, tokens TokenSet) error
+}
+
+// ErrTokenStoreCorrupt is wrapped by the errors of token stores holding
+// tokens they cannot decode. Custom stores should wrap it too, so the client
+// emits ClientEventCacheCorrupted.
+var ErrTokenStoreCorrupt = errors.New("token store corrupt")
+
+// MemoryTokenStore is a TokenStore shared by clients of one process. The
+// zero value is an empty store.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens TokenSet
+}
+
+// Get implements TokenStore
+func (s *MemoryTokenStore) Get(context.Context) (TokenSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tokens, nil
+}
+
+// Set implements TokenStore
+func (s *MemoryTokenStore) Set(_ context.Context, tokens TokenSet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = tokens
+	return nil
+}
+
+// OnTokenRefresh registers fn to be called with the new tokens after every
+// refresh, e.g. to log session lifetimes. Unlike Config.OnTokenRefresh it
+// cannot fail the refresh. It is shared with clients derived from this one.
+func (c *Client) OnTokenRefresh(fn func(TokenSet)) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.tokenListeners = append(c.tokenListeners, fn)
+}
+
+// tokenSet returns the client's current tokens
+func (c *Client) tokenSet() TokenSet {
+	creds := c.credentials()
+	return TokenSet{
+		Token:        creds.authToken.Reveal(),
+		RefreshToken: creds.refreshToken.Reveal(),
+		ExpiresAt:    creds.expiresAt,
+		Scopes:       creds.scopes,
+	}
+}
+
+// tokenSet returns the tokens of an auth response
+func (r *AuthResponse) tokenSet() TokenSet {
+	return TokenSet{Token: r.Token, RefreshToken: r.RefreshToken, ExpiresAt: r.ExpiresAt, Scopes: r.Scopes}
+}
+
+// authResponse returns the tokens as an auth response
+func (t TokenSet) authResponse() *AuthResponse {
+	return &AuthResponse{Token: t.Token, RefreshToken: t.RefreshToken, ExpiresAt: t.ExpiresAt, Scopes: t.Scopes}
+}
+
+// prepareAuth brings the client's tokens up to date before a request: it
+// adopts tokens another client wrote to the token store, signs in with
+// Config.ServiceAccount if there are none, then refreshes them if they are
+// about to expire
+func (c *Client) prepareAuth(ctx context.Context) {
+	c.loadTokens(ctx)
+	c.signInServiceAccount(ctx)
+	c.refreshIfExpiring(ctx)
+}
+
+// loadTokens adopts the tokens in the token store when another client
+// wrote them. An expired token is ignored, but its refresh token is taken
+// unless the client's own tokens are newer, so the session can still be
+// refreshed after another client rotated the refresh token.
+func (c *Client) loadTokens(ctx context.Context) {
+	if c.tokenStore == nil {
+		return
+	}
+	tokens, err := c.tokenStore.Get(ctx)
+	if errors.Is(err, ErrTokenStoreCorrupt) && !c.tokenStoreCorrupt.Swap(true) {
+		c.events.emit(ClientEventCacheCorrupted, map[string]string{"cache": "token_store", "error": err.Error()})
+	}
+	if err != nil {
+		c.logger.Warnf("reading token store: %v", err)
+		return
+	}
+	c.tokenStoreCorrupt.Store(false)
+	creds := c.credentials()
+	if tokens.Token == "" || tokens.Token == creds.authToken.Reveal() {
+		return
+	}
+	if !tokens.ExpiresAt.IsZero() && !time.Now().Before(tokens.ExpiresAt) {
+		if tokens.RefreshToken != "" && (creds.refreshToken == "" || !creds.expiresAt.After(tokens.ExpiresAt)) {
+			c.credsMu.Lock()
+			c.creds.refreshToken = Secret(tokens.RefreshToken)
+			c.credsMu.Unlock()
+		}
+		return
+	}
+	c.setTokens(tokens)
+}
+
+// storedRotation re-reads the token store after a refresh with used was
+// rejected, since another client sharing the store may have rotated it
+// first. It reports false when the store holds no other refresh token. The
+// stored token is adopted when still valid; otherwise the returned set
+// carries only the refresh token to retry with.
+func (c *Client) storedRotation(ctx context.Context, used string) (TokenSet, bool) {
+	if c.tokenStore == nil {
+		return TokenSet{}, false
+	}
+	tokens, err := c.tokenStore.Get(ctx)
+	if err != nil || tokens.RefreshToken == "" || tokens.RefreshToken == used {
+		return TokenSet{}, false
+	}
+	if tokens.Token == "" || !tokens.ExpiresAt.IsZero() && !time.Now().Before(tokens.ExpiresAt) {
+		return TokenSet{RefreshToken: tokens.RefreshToken}, true
+	}
+	c.setTokens(tokens)
+	return tokens, true
+}
+
+// clearTokens forgets the client's session: its tokens, the refresher's
+// memory of past rotations and the token store's copy
+func (c *Client) clearTokens(ctx context.Context) error {
+	c.credsMu.Lock()
+	c.creds.authToken, c.creds.refreshToken, c.creds.expiresAt = "", "", time.Time{}
+	c.creds.scopes = nil
+	c.creds.serviceAccount = nil
+	c.credsMu.Unlock()
+	c.refresher.reset()
+	return c.saveTokens(ctx)
+}
+
+// saveTokens writes the client's current tokens to its token store
+func (c *Client) saveTokens(ctx context.Context) error {
+	if c.tokenStore == nil {
+		return nil
+	}
+	if err := c.tokenStore.Set(ctx, c.tokenSet()); err != nil {
+		return fmt.Errorf("token store: %w", err)
+	}
+	return nil
+}
+
+// notifyTokenRefresh calls the listeners registered with OnTokenRefresh
+func (c *Client) notifyTokenRefresh(tokens TokenSet) {
+	c.hooksMu.RLock()
+	listeners := c.tokenListeners
+	c.hooksMu.RUnlock()
+	for _, fn := range listeners {
+		fn(tokens)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,120 @@
+package xrplsale
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileTokenStore is a TokenStore keeping the tokens as JSON in a file only
+// its owner can read, so a CLI keeps its session between runs. Writes
+// replace the file atomically, and processes sharing the file take turns
+// through a lock file next to it (advisory, and only on Unix systems).
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore returns a store for the file at path, which is created
+// on the first Set along with its directory
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// Get implements TokenStore. A file that cannot be decoded is reported as
+// ErrTokenStoreCorrupt; the next Set replaces it.
+func (s *FileTokenStore) Get(context.Context) (TokenSet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock, err := s.lock(false)
+	if err != nil {
+		return TokenSet{}, err
+	}
+	defer unlock()
+	
+	var tokens TokenSet
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return tokens, err
+	}
+	if err := json.Unmarshal(raw, &tokens); err != nil {
+		return TokenSet{}, fmt.Errorf("%w: decoding token file %s: %w", ErrTokenStoreCorrupt, s.path, err)
+	}
+	return tokens, nil
+}
+
+// Set implements TokenStore
+func (s *FileTokenStore) Set(_ context.Context, tokens TokenSet) error {
+	raw, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	unlock, err := s.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return writeFileAtomic(s.path, raw, 0o600)
+}
+
+// lock takes the lock file of the store, exclusively for writing. The
+// returned func releases it.
+func (s *FileTokenStore) lock(exclusive bool) (func(), error) {
+	f, err := os.OpenFile(s.path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if errors.Is(err, fs.ErrNotExist) && !exclusive {
+		// No directory yet, so nothing to read and no writer to wait for
+		return func() {}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening token file lock: %w", err)
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("locking token file: %w", err)
+	}
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// writeFileAtomic replaces the file at path with data: it is written to a
+// temporary file in the same directory, synced, then renamed over path, so
+// readers see the old or the new contents and never a partial write
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
\ No newline at end of file
@@ -0,0 +1,102 @@
+//go:build keyring
+
+package xrplsale
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrKeyringUnsupported is returned by KeyringTokenStore on systems without
+// a supported keyring
+var ErrKeyringUnsupported = errors.New("keyring not supported on " + runtime.GOOS)
+
+// KeyringTokenStore is a TokenStore keeping the tokens in the OS keyring:
+// the login keychain on macOS, through the security tool, and the Secret
+// Service (GNOME Keyring, KWallet) on Linux, through secret-tool. Tokens
+// never appear on a command line. It is only built with the keyring build
+// tag.
+type KeyringTokenStore struct {
+	// Service and Account identify the keyring item
+	Service string
+	Account string
+}
+
+// NewKeyringTokenStore returns a store for the keyring item of service and
+// account, e.g. your application's name and the wallet address
+func NewKeyringTokenStore(service, account string) *KeyringTokenStore {
+	return &KeyringTokenStore{Service: service, Account: account}
+}
+
+// Get implements TokenStore
+func (s *KeyringTokenStore) Get(ctx context.Context) (TokenSet, error) {
+	var tokens TokenSet
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "security", "find-generic-password", "-s", s.Service, "-a", s.Account, "-w")
+	case "linux":
+		cmd = exec.CommandContext(ctx, "secret-tool", "lookup", "service", s.Service, "account", s.Account)
+	default:
+		return tokens, ErrKeyringUnsupported
+	}
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// Both tools exit non-zero when the item does not exist
+		return tokens, nil
+	}
+	if err != nil {
+		return tokens, fmt.Errorf("reading keyring: %w", err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+	if err != nil {
+		return tokens, fmt.Errorf("%w: decoding keyring item: %w", ErrTokenStoreCorrupt, err)
+	}
+	if err := json.Unmarshal(raw, &tokens); err != nil {
+		return TokenSet{}, fmt.Errorf("%w: decoding keyring item: %w", ErrTokenStoreCorrupt, err)
+	}
+	return tokens, nil
+}
+
+// Set implements TokenStore
+func (s *KeyringTokenStore) Set(ctx context.Context, tokens TokenSet) error {
+	raw, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	secret := base64.StdEncoding.EncodeToString(raw)
+	
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		// Commands read by security -i do not show up in the process list
+		cmd = exec.CommandContext(ctx, "security", "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+			quoteSecurityArg(s.Service), quoteSecurityArg(s.Account), hex.EncodeToString([]byte(secret))))
+	case "linux":
+		cmd = exec.CommandContext(ctx, "secret-tool", "store", "--label", s.Service+" ("+s.Account+")", "service", s.Service, "account", s.Account)
+		cmd.Stdin = strings.NewReader(secret)
+	default:
+		return ErrKeyringUnsupported
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("writing keyring: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// quoteSecurityArg quotes an argument for a command read by security -i
+func quoteSecurityArg(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
\ No newline at end of file
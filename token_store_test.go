@@ -0,0 +1,187 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestTokenStoreRotation(t *testing.T) {
+	const ttl = time.Second
+	tests := []struct {
+		name        string
+		autoRefresh bool
+		// expire waits for the rotated token to expire before b's call
+		expire bool
+		call   func(*xrplsale.Client) error
+		// wantRefreshes counts the refresh requests of both clients
+		wantRefreshes int
+		wantRotated   bool
+	}{
+		{"request adopts the rotated refresh token", true, true, func(c *xrplsale.Client) error {
+			_, err := c.Auth.GetProfile(context.Background())
+			return err
+		}, 2, false},
+		{"rejected refresh adopts the stored token", false, false, func(c *xrplsale.Client) error {
+			_, err := c.Auth.Refresh(context.Background(), "")
+			return err
+		}, 2, true},
+		{"rejected refresh retries with the stored refresh token", false, true, func(c *xrplsale.Client) error {
+			_, err := c.Auth.Refresh(context.Background(), "")
+			return err
+		}, 3, false},
+	}
+	type setup struct {
+		b       *xrplsale.Client
+		hits    *hitCounter
+		store   *xrplsale.MemoryTokenStore
+		rotated string
+	}
+	// Client a rotates the refresh token b holds; a single wait then expires
+	// the rotated tokens of every case
+	setups := make([]setup, len(tests))
+	for i, tt := range tests {
+		store := &xrplsale.MemoryTokenStore{}
+		srv, a, hits := newCountingClient(t, func(c *xrplsale.Config) { c.TokenStore = store })
+		if tt.expire {
+			srv.SetTokenTTL(ttl)
+		}
+		b := xrplsaletest.NewClient(srv, func(c *xrplsale.Config) {
+			c.Transport, c.TokenStore = hits, store
+			c.AutoRefresh = tt.autoRefresh
+			c.RefreshSkew = 200 * time.Millisecond
+		})
+		signIn(t, a)
+		if _, err := b.Auth.GetProfile(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		rotated, err := a.Auth.Refresh(context.Background(), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		setups[i] = setup{b: b, hits: hits, store: store, rotated: rotated.Token}
+	}
+	time.Sleep(ttl + 100*time.Millisecond)
+	
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := setups[i]
+			if err := tt.call(s.b); err != nil {
+				t.Fatalf("call with a rotated refresh token = %v", err)
+			}
+			if got := s.hits.count(http.MethodPost, "/auth/refresh"); got != tt.wantRefreshes {
+				t.Errorf("%d refresh requests, want %d", got, tt.wantRefreshes)
+			}
+			stored, err := s.store.Get(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rotated := stored.Token == s.rotated; rotated != tt.wantRotated {
+				t.Errorf("store holds a's rotated token %v, want %v", rotated, tt.wantRotated)
+			}
+			if !stored.ExpiresAt.After(time.Now()) {
+				t.Errorf("store holds a token expired at %v", stored.ExpiresAt)
+			}
+		})
+	}
+}
+func TestFileTokenStoreAtConstruction(t *testing.T) {
+	valid, _ := json.Marshal(xrplsale.TokenSet{Token: "stored", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour)})
+	expired, _ := json.Marshal(xrplsale.TokenSet{Token: "stored", RefreshToken: "refresh", ExpiresAt: time.Now().Add(-time.Minute)})
+	tests := []struct {
+		name string
+		// contents is written to the token file; nil leaves it missing
+		contents    []byte
+		wantToken   bool
+		wantCorrupt bool
+	}{
+		{"unexpired token", valid, true, false},
+		{"expired token is ignored", expired, false, false},
+		{"no expiry", []byte(`{"token":"stored"}`), true, false},
+		{"corrupt file", []byte(`{"token":`), false, true},
+		{"missing file", nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tokens.json")
+			if tt.contents != nil {
+				if err := os.WriteFile(path, tt.contents, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			store := xrplsale.NewFileTokenStore(path)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: "http://127.0.0.1:0", TokenStore: store})
+			
+			if client.IsAuthenticated() != tt.wantToken {
+				t.Errorf("IsAuthenticated() = %v after construction, want %v", client.IsAuthenticated(), tt.wantToken)
+			}
+			if _, err := store.Get(context.Background()); errors.Is(err, xrplsale.ErrTokenStoreCorrupt) != tt.wantCorrupt {
+				t.Errorf("Get() = %v, want corrupt %v", err, tt.wantCorrupt)
+			}
+			
+			// Writing replaces even a corrupt file, readable by its owner only
+			if err := store.Set(context.Background(), xrplsale.TokenSet{Token: "new"}); err != nil {
+				t.Fatal(err)
+			}
+			info, err := os.Stat(path)
+			if err != nil || info.Mode().Perm() != 0o600 {
+				t.Fatalf("token file mode %v, %v, want 0600", info.Mode().Perm(), err)
+			}
+			if tokens, err := store.Get(context.Background()); err != nil || tokens.Token != "new" {
+				t.Errorf("Get() after Set() = %+v, %v", tokens, err)
+			}
+			entries, _ := os.ReadDir(filepath.Dir(path))
+			for _, entry := range entries {
+				if name := entry.Name(); name != "tokens.json" && name != "tokens.json.lock" {
+					t.Errorf("%s left next to the token file", name)
+				}
+			}
+		})
+	}
+}
+
+// TestFileTokenStoreConcurrentWriters has two stores on one file, as two
+// processes would, so only the file lock and atomic writes keep it whole
+func TestFileTokenStoreConcurrentWriters(t *testing.T) {
+	const writes = 50
+	path := filepath.Join(t.TempDir(), "session", "tokens.json")
+	stores := []*xrplsale.FileTokenStore{xrplsale.NewFileTokenStore(path), xrplsale.NewFileTokenStore(path)}
+	
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*len(stores)*writes)
+	for i, store := range stores {
+		for j := 0; j < writes; j++ {
+			wg.Add(2)
+			go func(store *xrplsale.FileTokenStore, n string) {
+				defer wg.Done()
+				errs <- store.Set(context.Background(), xrplsale.TokenSet{Token: "token-" + n, RefreshToken: "refresh-" + n})
+			}(store, fmt.Sprintf("%d-%d", i, j))
+			go func(store *xrplsale.FileTokenStore) {
+				defer wg.Done()
+				tokens, err := store.Get(context.Background())
+				// Every read sees nothing yet or one whole write
+				if err == nil && tokens.Token != "" && tokens.RefreshToken != "refresh-"+tokens.Token[len("token-"):] {
+					err = fmt.Errorf("read a torn token set %+v", tokens)
+				}
+				errs <- err
+			}(store)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,83 @@
+package xrplsale
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Tracer creates spans for SDK calls. It is a thin interface so the SDK does
+// not depend on a tracing library; the README shows an OpenTelemetry adapter.
+type Tracer interface {
+	// Start starts a client span named name as a child of any span in ctx
+	Start(ctx context.Context, name string) (context.Context, Span)
+	
+	// Inject writes the trace context of ctx into the outgoing headers,
+	// e.g. as a W3C traceparent header
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is a single traced SDK call
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// startSpan starts the span for an API call, or returns a nil span when
+// tracing is disabled
+func (c *Client) startSpan(ctx context.Context, method, endpoint string) (context.Context, Span) {
+	if c.config.Tracer == nil {
+		return ctx, nil
+	}
+	
+	route := routeTemplate(endpoint)
+	ctx, span := c.config.Tracer.Start(ctx, method+" "+route)
+	span.SetAttribute("http.request.method", method)
+	span.SetAttribute("http.route", route)
+	if u, err := url.Parse(c.config.BaseURL); err == nil {
+		span.SetAttribute("server.address", u.Hostname())
+	}
+	return ctx, span
+}
+
+// endSpan records the outcome of the call and ends span
+func endSpan(span Span, info *ResponseInfo) {
+	if span == nil {
+		return
+	}
+	if info.StatusCode != 0 {
+		span.SetAttribute("http.response.status_code", info.StatusCode)
+	}
+	if info.Attempts > 1 {
+		span.SetAttribute("http.request.resend_count", info.Attempts-1)
+	}
+	if info.Err != nil {
+		span.RecordError(info.Err)
+	}
+	span.End()
+}
+
+// identifierShape matches the path segments that identify a resource:
+// numbers, UUIDs, prefixed API IDs such as proj_abc123, XRPL addresses and
+// transaction hashes. Route words such as 2fa never match.
+var identifierShape = regexp.MustCompile(`^(?:[0-9]+` +
+	`|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}` +
+	`|[a-z]+_[A-Za-z0-9]{4,}` +
+	`|r[1-9A-HJ-NP-Za-km-z]{24,34}` +
+	`|[0-9a-fA-F]{64})$`)
+
+// routeTemplate replaces the identifiers in endpoint with {id}, so spans
+// and metrics for the same route share a name
+func routeTemplate(endpoint string) string {
+	path, _, _ := strings.Cut(endpoint, "?")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if identifierShape.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
\ No newline at end of file
@@ -0,0 +1,140 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+)
+
+// recordingTracer keeps every span it starts and injects a fixed traceparent
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+type spanKey struct{}
+
+func (tr *recordingTracer) Start(ctx context.Context, name string) (context.Context, xrplsale.Span) {
+	span := &recordedSpan{name: name, attrs: map[string]interface{}{}}
+	tr.mu.Lock()
+	tr.spans = append(tr.spans, span)
+	tr.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (tr *recordingTracer) Inject(ctx context.Context, header http.Header) {
+	if _, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+		header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	}
+}
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *recordedSpan) RecordError(err error)                      { s.err = err }
+func (s *recordedSpan) End()                                       { s.ended = true }
+
+func TestTracingSpan(t *testing.T) {
+	tracer := &recordingTracer{}
+	srv, client, _ := newCountingClient(t, func(c *xrplsale.Config) { c.Tracer = tracer })
+	project := fixtures.Project()
+	srv.AddProjects(project)
+	
+	if _, err := client.Projects.Get(context.Background(), project.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Projects.Get(context.Background(), "proj_missing0"); !errors.Is(err, xrplsale.ErrNotFound) {
+		t.Fatalf("Get() = %v, want ErrNotFound", err)
+	}
+	
+	if len(tracer.spans) != 2 {
+		t.Fatalf("%d spans, want one per call", len(tracer.spans))
+	}
+	for i, wantStatus := range []int{http.StatusOK, http.StatusNotFound} {
+		span := tracer.spans[i]
+		if span.name != "GET /projects/{id}" || !span.ended {
+			t.Errorf("span %d = %q (ended %t), want an ended GET /projects/{id}", i, span.name, span.ended)
+		}
+		if span.attrs["http.request.method"] != http.MethodGet || span.attrs["http.route"] != "/projects/{id}" ||
+			span.attrs["http.response.status_code"] != wantStatus || span.attrs["server.address"] != "127.0.0.1" {
+			t.Errorf("span %d attributes = %v", i, span.attrs)
+		}
+		if (span.err != nil) != (wantStatus != http.StatusOK) {
+			t.Errorf("span %d recorded error %v", i, span.err)
+		}
+	}
+}
+
+func TestTracingPropagatesContext(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, Tracer: &recordingTracer{}})
+	
+	if err := client.Get(context.Background(), "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if traceparent == "" {
+		t.Fatal("no traceparent header sent")
+	}
+}
+
+func TestRouteTemplate(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"/projects/proj_00000001", "/projects/{id}"},
+		{"/projects/proj_u62wqm6n0ch6/investments?page=2", "/projects/{id}/investments"},
+		{"/webhooks/wh_abc123/deliveries/42", "/webhooks/{id}/deliveries/{id}"},
+		{"/exports/3f2b8c1e-9a4d-4e2f-b6a1-0c9d8e7f6a5b", "/exports/{id}"},
+		{"/accounts/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh/spendable", "/accounts/{id}/spendable"},
+		{"/transactions/E08D6E9754025BA2534A78707605E0601F03ACE063687A0CA1BDDACFCD1698C7", "/transactions/{id}"},
+		{"/auth/2fa/enable", "/auth/2fa/enable"},
+		{"/auth/stream-token", "/auth/stream-token"},
+		{"/projects/café-token", "/projects/café-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			tracer := &recordingTracer{}
+			metrics := &xrplsale.InMemoryMetrics{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{
+				APIKey:           "key",
+				BaseURL:          srv.URL,
+				Tracer:           tracer,
+				MetricsCollector: metrics,
+			})
+			
+			if err := client.Get(context.Background(), tt.endpoint, nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			if name := tracer.spans[0].name; name != "GET "+tt.want {
+				t.Errorf("span name = %q, want %q", name, "GET "+tt.want)
+			}
+			routes := metrics.Snapshot()
+			if len(routes) != 1 || routes[0].Route != tt.want {
+				t.Errorf("metric routes = %+v, want %q", routes, tt.want)
+			}
+		})
+	}
+}
\ No newline at end of file
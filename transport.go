@@ -0,0 +1,59 @@
+package xrplsale
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConns is the default size of the idle connection pool
+	DefaultMaxIdleConns = 100
+	
+	// DefaultMaxIdleConnsPerHost is the default number of idle connections
+	// kept per host. The client talks to a single API host, so it matches
+	// DefaultMaxIdleConns instead of net/http's default of 2.
+	DefaultMaxIdleConnsPerHost = DefaultMaxIdleConns
+	
+	// DefaultIdleConnTimeout is how long an idle connection is kept by default
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// tuneTransport applies the connection pool settings to the SDK's transport
+func (config *Config) tuneTransport(t *http.Transport) {
+	if config.MaxIdleConns == 0 {
+		config.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost == 0 {
+		config.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if config.IdleConnTimeout == 0 {
+		config.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	
+	t.MaxIdleConns = config.MaxIdleConns
+	t.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	t.IdleConnTimeout = config.IdleConnTimeout
+	t.DisableKeepAlives = config.DisableKeepAlives
+	if config.ForceHTTP2 {
+		t.ForceAttemptHTTP2 = true
+	}
+	if config.DisableHTTP2 {
+		// A non-nil, empty TLSNextProto is how net/http turns HTTP/2 off
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+}
+
+// httpClient returns a copy of Config.HTTPClient whose *http.Transport, if
+// any, is cloned so the SDK can configure it
+func (config *Config) httpClient() *http.Client {
+	hc := *config.HTTPClient
+	if hc.Transport == nil {
+		hc.Transport = http.DefaultTransport
+	}
+	if t, ok := hc.Transport.(*http.Transport); ok {
+		hc.Transport = t.Clone()
+	}
+	return &hc
+}
\ No newline at end of file
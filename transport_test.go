@@ -0,0 +1,149 @@
+package xrplsale
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countDials makes the client's transport count the connections it opens
+func countDials(t *testing.T, client *Client) *atomic.Int32 {
+	t.Helper()
+	transport, ok := client.transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("client transport is %T, want *http.Transport", client.transport)
+	}
+	var dials atomic.Int32
+	dialer := &net.Dialer{}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials.Add(1)
+		return dialer.DialContext(ctx, network, addr)
+	}
+	return &dials
+}
+
+func TestConnectionReuse(t *testing.T) {
+	const rounds, concurrency = 5, 40
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	
+	tests := []struct {
+		name      string
+		configure func(*Config)
+		wantDials func(dials int32) bool
+	}{
+		{"defaults keep a connection per concurrent request", func(*Config) {}, func(dials int32) bool { return dials <= concurrency }},
+		{"small per-host pool redials", func(c *Config) { c.MaxIdleConnsPerHost = 2 }, func(dials int32) bool { return dials > 2*concurrency }},
+		{"keep-alives disabled", func(c *Config) { c.DisableKeepAlives = true }, func(dials int32) bool { return dials == rounds*concurrency }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{APIKey: "key", BaseURL: srv.URL}
+			tt.configure(config)
+			client := NewClientWithConfig(config)
+			defer client.Close()
+			dials := countDials(t, client)
+			
+			for round := 0; round < rounds; round++ {
+				var wg sync.WaitGroup
+				errs := make(chan error, concurrency)
+				for i := 0; i < concurrency; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						errs <- client.Get(context.Background(), "/projects", nil, nil)
+					}()
+				}
+				wg.Wait()
+				close(errs)
+				for err := range errs {
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			if got := dials.Load(); !tt.wantDials(got) {
+				t.Fatalf("%d dials for %d rounds of %d requests", got, rounds, concurrency)
+			}
+		})
+	}
+}
+
+func TestTransportSettings(t *testing.T) {
+	config := &Config{APIKey: "key", BaseURL: "http://127.0.0.1:1"}
+	transport := NewClientWithConfig(config).transport.(*http.Transport)
+	if transport.MaxIdleConns != DefaultMaxIdleConns || transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost || transport.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Fatalf("default pool %d/%d/%v, want %d/%d/%v", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout,
+			DefaultMaxIdleConns, DefaultMaxIdleConnsPerHost, DefaultIdleConnTimeout)
+	}
+	
+	config = &Config{APIKey: "key", BaseURL: "http://127.0.0.1:1", MaxIdleConns: 10, MaxIdleConnsPerHost: 5, IdleConnTimeout: time.Second, DisableKeepAlives: true}
+	transport = NewClientWithConfig(config).transport.(*http.Transport)
+	if transport.MaxIdleConns != 10 || transport.MaxIdleConnsPerHost != 5 || transport.IdleConnTimeout != time.Second || !transport.DisableKeepAlives {
+		t.Fatalf("configured pool %d/%d/%v/%v, want 10/5/1s/true", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout, transport.DisableKeepAlives)
+	}
+	
+	// Injected transports are used as they are
+	for name, inject := range map[string]func(*Config, *http.Transport){
+		"Transport":  func(c *Config, t *http.Transport) { c.Transport = t },
+		"HTTPClient": func(c *Config, t *http.Transport) { c.HTTPClient = &http.Client{Transport: t} },
+	} {
+		own := &http.Transport{MaxIdleConnsPerHost: 3, IdleConnTimeout: time.Minute}
+		config := &Config{APIKey: "key", BaseURL: "http://127.0.0.1:1", MaxIdleConnsPerHost: 50, IdleConnTimeout: time.Second, DisableKeepAlives: true}
+		inject(config, own)
+		used := NewClientWithConfig(config).transport.(*http.Transport)
+		for _, transport := range []*http.Transport{own, used} {
+			if transport.MaxIdleConnsPerHost != 3 || transport.IdleConnTimeout != time.Minute || transport.DisableKeepAlives {
+				t.Errorf("%s: pool settings applied to an injected transport", name)
+			}
+		}
+	}
+}
+
+func TestHTTP2Settings(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"proto":"` + r.Proto + `"}`))
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	
+	for _, tt := range []struct {
+		force, disable bool
+		want           string
+	}{
+		{false, false, "HTTP/2.0"},
+		{true, false, "HTTP/2.0"},
+		{false, true, "HTTP/1.1"},
+	} {
+		client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, TLS: &TLSOptions{RootCAs: roots}, ForceHTTP2: tt.force, DisableHTTP2: tt.disable})
+		if transport := client.transport.(*http.Transport); tt.force && !transport.ForceAttemptHTTP2 {
+			t.Errorf("ForceHTTP2: transport has ForceAttemptHTTP2 unset")
+		}
+		var got struct{ Proto string }
+		if err := client.Get(context.Background(), "/projects", nil, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Proto != tt.want {
+			t.Errorf("ForceHTTP2 %v, DisableHTTP2 %v: server saw %s, want %s", tt.force, tt.disable, got.Proto, tt.want)
+		}
+	}
+	
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, ForceHTTP2: true, DisableHTTP2: true})
+	if err := client.Get(context.Background(), "/projects", nil, nil); err == nil {
+		t.Error("ForceHTTP2 with DisableHTTP2 was accepted")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,146 @@
+package xrplsale
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ttlCacheMaxEntries bounds the number of responses the TTL cache holds
+const ttlCacheMaxEntries = 1024
+
+// ttlEntry is a cached response and its expiry
+type ttlEntry struct {
+	cachedResponse
+	expires time.Time
+}
+
+// ttlCache holds successful GET response bodies for a fixed time. Keys
+// include the caller's credentials, so clients derived with different
+// credentials never see each other's responses.
+type ttlCache struct {
+	mu      sync.RWMutex
+	entries map[string]ttlEntry
+}
+
+// newTTLCache returns an empty cache
+func newTTLCache() *ttlCache {
+	return &ttlCache{entries: make(map[string]ttlEntry)}
+}
+
+// WithCache serves this GET from the client's cache if an identical request
+// (same endpoint, parameters and credentials) succeeded within ttl, and
+// caches its response otherwise. It overrides Config.CacheTTL; a negative
+// ttl bypasses the cache.
+func WithCache(ttl time.Duration) RequestOption {
+	return func(ro *requestOptions) {
+		ro.cacheTTL = ttl
+	}
+}
+
+// cacheTTL returns how long a GET made with ro may be cached, zero for not at all
+func (c *Client) cacheTTL(ro *requestOptions) time.Duration {
+	ttl := c.config.CacheTTL
+	if ro.cacheTTL != 0 {
+		ttl = ro.cacheTTL
+	}
+	return max(ttl, 0)
+}
+
+// clientIdentity is the identity computed for a pair of credentials
+type clientIdentity struct {
+	apiKey, authToken Secret
+	id                string
+}
+
+// ttlCacheKey builds the cache key of a GET made with the client's
+// credentials and default headers and the per-request headers, which may
+// change the response, e.g. WithOnBehalfOf
+func (c *Client) ttlCacheKey(endpoint string, params map[string]string, headers map[string]string) string {
+	if len(params) == 0 && len(headers) == 0 {
+		return endpoint + "?\x00" + c.identity()
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimPrefix(requestCacheKeyFor(endpoint, params), "GET ") + "\x00" + c.identity())
+	for _, key := range sortedKeys(headers) {
+		b.WriteString("\x00" + key + ":" + headers[key])
+	}
+	return b.String()
+}
+
+// identity returns a short hash of the client's credentials and default
+// headers, which distinguishes derived clients sharing a core. The hash is
+// recomputed only when the credentials change.
+func (c *Client) identity() string {
+	creds := c.credentials()
+	if last := c.lastIdentity.Load(); last != nil && last.apiKey == creds.apiKey && last.authToken == creds.authToken {
+		return last.id
+	}
+	h := sha256.New()
+	h.Write([]byte(creds.apiKey.Reveal() + "\x00" + creds.authToken.Reveal()))
+	keys := make([]string, 0, len(c.headers))
+	for key := range c.headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		h.Write([]byte("\x00" + key + ":" + c.headers[key]))
+	}
+	id := hex.EncodeToString(h.Sum(nil)[:8])
+	c.lastIdentity.Store(&clientIdentity{apiKey: creds.apiKey, authToken: creds.authToken, id: id})
+	return id
+}
+
+// get returns the unexpired response cached under key
+func (tc *ttlCache) get(key string, now time.Time) (cachedResponse, bool) {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	entry, ok := tc.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		return cachedResponse{}, false
+	}
+	return entry.cachedResponse, true
+}
+
+// put caches resp under key for ttl. When the cache is full, expired
+// entries are dropped first, then arbitrary ones.
+func (tc *ttlCache) put(key string, resp cachedResponse, ttl time.Duration, now time.Time) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	
+	if _, ok := tc.entries[key]; !ok && len(tc.entries) >= ttlCacheMaxEntries {
+		for k, entry := range tc.entries {
+			if !now.Before(entry.expires) {
+				delete(tc.entries, k)
+			}
+		}
+		for k := range tc.entries {
+			if len(tc.entries) < ttlCacheMaxEntries {
+				break
+			}
+			delete(tc.entries, k)
+		}
+	}
+	tc.entries[key] = ttlEntry{cachedResponse: resp, expires: now.Add(ttl)}
+}
+
+// invalidate drops the entries whose endpoint starts with prefix
+func (tc *ttlCache) invalidate(prefix string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	for key := range tc.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(tc.entries, key)
+		}
+	}
+}
+
+// InvalidateCache drops cached responses for endpoints starting with prefix,
+// e.g. "/projects/proj_123" after updating that project. An empty prefix
+// clears the cache. The cache is shared with clients derived from this one.
+func (c *Client) InvalidateCache(prefix string) {
+	c.ttlCache.invalidate(prefix)
+}
\ No newline at end of file
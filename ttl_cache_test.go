@@ -0,0 +1,231 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ttlServer answers every GET with the caller's API key and path, failing
+// while fail is set
+func ttlServer(t testing.TB) (*httptest.Server, *atomic.Int32, *atomic.Bool) {
+	t.Helper()
+	var hits atomic.Int32
+	var fail atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":{"code":"internal","message":"boom"}}`))
+			return
+		}
+		w.Write([]byte(`{"key":"` + r.Header.Get("X-API-Key") + `","path":"` + r.URL.Path + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits, &fail
+}
+
+type ttlBody struct {
+	Key  string `json:"key"`
+	Path string `json:"path"`
+}
+
+func TestTTLCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   time.Duration
+		opts     []RequestOption
+		wantHits int32
+	}{
+		{"off", 0, nil, 3},
+		{"Config.CacheTTL", time.Minute, nil, 1},
+		{"WithCache", 0, []RequestOption{WithCache(time.Minute)}, 1},
+		{"WithCache bypass", time.Minute, []RequestOption{WithCache(-1)}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hits, _ := ttlServer(t)
+			client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, CacheTTL: tt.config})
+			for i := 0; i < 3; i++ {
+				var got ttlBody
+				if err := client.Get(context.Background(), "/projects", nil, &got, tt.opts...); err != nil {
+					t.Fatal(err)
+				}
+				if got.Path != "/projects" {
+					t.Fatalf("call %d decoded %+v", i, got)
+				}
+			}
+			if got := hits.Load(); got != tt.wantHits {
+				t.Fatalf("%d requests sent, want %d", got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestTTLCacheExpiry(t *testing.T) {
+	srv, hits, _ := ttlServer(t)
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, CacheTTL: 200 * time.Millisecond})
+	get := func() {
+		t.Helper()
+		if err := client.Get(context.Background(), "/projects", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	get()
+	get()
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("%d requests before expiry, want 1", got)
+	}
+	time.Sleep(250 * time.Millisecond)
+	get()
+	get()
+	if got := hits.Load(); got != 2 {
+		t.Fatalf("%d requests after expiry, want 2", got)
+	}
+}
+
+func TestTTLCacheKeys(t *testing.T) {
+	srv, hits, fail := ttlServer(t)
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, CacheTTL: time.Minute})
+	get := func(c *Client, endpoint string, params map[string]string) (ttlBody, error) {
+		var got ttlBody
+		err := c.Get(context.Background(), endpoint, params, &got, WithNoRetry())
+		return got, err
+	}
+	
+	// Errors are never cached
+	fail.Store(true)
+	if _, err := get(client, "/projects", nil); err == nil {
+		t.Fatal("Get() succeeded against a failing server")
+	}
+	fail.Store(false)
+	if _, err := get(client, "/projects", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Fatalf("%d requests, want the error to be retried from the network", got)
+	}
+	
+	// Parameters and credentials are part of the key
+	other := client.Clone(WithAPIKey("other"))
+	if _, err := get(client, "/projects", map[string]string{"page": "2"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := get(other, "/projects", nil)
+	if err != nil || got.Key != "other" {
+		t.Fatalf("clone read %+v, %v; want its own response", got, err)
+	}
+	if got := hits.Load(); got != 4 {
+		t.Fatalf("%d requests, want separate entries per params and credentials", got)
+	}
+	
+	// A cancelled context fails even when the response is cached
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.Get(ctx, "/projects", nil, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get() with a cancelled context = %v, want context.Canceled", err)
+	}
+	
+	// InvalidateCache drops entries by endpoint prefix, for every identity
+	if _, err := get(client, "/stats", nil); err != nil {
+		t.Fatal(err)
+	}
+	client.InvalidateCache("/projects")
+	for _, c := range []*Client{client, other} {
+		if _, err := get(c, "/projects", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := get(client, "/stats", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := hits.Load(); got != 7 {
+		t.Fatalf("%d requests, want /projects refetched by both clients and /stats still cached", got)
+	}
+	client.InvalidateCache("")
+	if _, err := get(client, "/stats", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := hits.Load(); got != 8 {
+		t.Fatalf("%d requests, want an empty prefix to clear the cache", got)
+	}
+}
+
+func TestTTLCacheHitAllocs(t *testing.T) {
+	tc := newTTLCache()
+	now := time.Now()
+	tc.put("/projects\x00id", cachedResponse{body: []byte(`{}`)}, time.Minute, now)
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, ok := tc.get("/projects\x00id", now); !ok {
+			t.Fatal("cache miss")
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("cache hit allocated %v times, want 0", allocs)
+	}
+}
+
+// cachedGetAllocs is what a cache hit through Do allocates: the request
+// options, the cache key, the caller's Response and its copy of the cached
+// header (three allocations), and the ResponseInfo passed to hooks. Each is handed to code outside the cache, so none can be shared.
+const cachedGetAllocs = 7
+
+func TestCachedGetAllocs(t *testing.T) {
+	srv, _, _ := ttlServer(t)
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, CacheTTL: time.Hour})
+	ctx := context.Background()
+	if _, err := client.Do(ctx, http.MethodGet, "/projects", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		if resp, err := client.Do(ctx, http.MethodGet, "/projects", nil, nil); err != nil || !resp.FromCache {
+			t.Fatalf("Do() = %v, %v, want a cache hit", resp, err)
+		}
+	})
+	if allocs > cachedGetAllocs {
+		t.Fatalf("cache hit allocated %v times, want at most %d", allocs, cachedGetAllocs)
+	}
+}
+
+func BenchmarkTTLCacheHit(b *testing.B) {
+	srv, hits, _ := ttlServer(b)
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, CacheTTL: time.Hour})
+	ctx := context.Background()
+	if _, err := client.Do(ctx, http.MethodGet, "/projects", nil, nil); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.Do(ctx, http.MethodGet, "/projects", nil, nil); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	if hits.Load() != 1 {
+		b.Fatalf("%d requests sent, want 1", hits.Load())
+	}
+}
+
+func BenchmarkCachedGet(b *testing.B) {
+	srv, hits, _ := ttlServer(b)
+	client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, CacheTTL: time.Hour})
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.Do(ctx, http.MethodGet, "/projects", nil, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if hits.Load() != 1 {
+		b.Fatalf("%d requests sent, want 1", hits.Load())
+	}
+}
\ No newline at end of file
@@ -0,0 +1,105 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTwoFactorRequired is matched by the TwoFactorRequiredError returned
+// by Authenticate for accounts with two-factor authentication
+var ErrTwoFactorRequired = errors.New("two-factor authentication required")
+
+// codeTwoFactorRequired is the API error code of a sign-in that needs a
+// second factor
+const codeTwoFactorRequired = "two_factor_required"
+
+// TwoFactorRequiredError is returned by Authenticate when the wallet
+// signature was accepted but the account also requires a TOTP code. Pass
+// ChallengeToken and the code to Auth.Complete2FA to finish signing in;
+// until then the client's auth token is left unchanged.
+type TwoFactorRequiredError struct {
+	ChallengeToken string
+	
+	// Err is the API error the requirement came with, if it came as one
+	Err error
+}
+
+// Error implements the error interface
+func (e *TwoFactorRequiredError) Error() string { return ErrTwoFactorRequired.Error() }
+
+// Is reports whether target is ErrTwoFactorRequired
+func (e *TwoFactorRequiredError) Is(target error) bool { return target == ErrTwoFactorRequired }
+
+// Unwrap returns the underlying API error, if any
+func (e *TwoFactorRequiredError) Unwrap() error { return e.Err }
+
+// TwoFactorSetup is what the user needs to add the account to an
+// authenticator app
+type TwoFactorSetup struct {
+	// Secret is the base32 TOTP secret, for manual entry
+	Secret string `json:"secret"`
+	
+	// OTPAuthURI is the otpauth:// URI to show as a QR code
+	OTPAuthURI string `json:"otpauth_uri"`
+	
+	// BackupCodes each sign in once in place of a TOTP code. They are only
+	// returned here.
+	BackupCodes []string `json:"backup_codes"`
+}
+
+// signInResponse is an /auth/wallet response, which either carries tokens
+// or asks for a second factor
+type signInResponse struct {
+	AuthResponse
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+}
+
+// twoFactorError returns the TwoFactorRequiredError for a sign-in response
+// or error, or nil when no second factor was asked for
+func twoFactorError(response *signInResponse, err error) error {
+	if err == nil {
+		if response.TwoFactorRequired {
+			return &TwoFactorRequiredError{ChallengeToken: response.ChallengeToken}
+		}
+		return nil
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == codeTwoFactorRequired {
+		token, _ := apiErr.Details["challenge_token"].(string)
+		return &TwoFactorRequiredError{ChallengeToken: token, Err: err}
+	}
+	return nil
+}
+
+// Enable2FA starts enabling two-factor authentication. It takes effect once
+// Confirm2FA is called with a code from the authenticator app.
+func (as *AuthService) Enable2FA(ctx context.Context, reqOpts ...RequestOption) (*TwoFactorSetup, error) {
+	var setup TwoFactorSetup
+	err := as.client.Post(ctx, "/auth/2fa/enable", nil, &setup, reqOpts...)
+	return &setup, err
+}
+
+// Confirm2FA turns on two-factor authentication with a first TOTP code
+func (as *AuthService) Confirm2FA(ctx context.Context, code string, reqOpts ...RequestOption) error {
+	return as.client.Post(ctx, "/auth/2fa/confirm", map[string]string{"code": code}, nil, reqOpts...)
+}
+
+// Disable2FA turns off two-factor authentication; code is a TOTP or
+// backup code
+func (as *AuthService) Disable2FA(ctx context.Context, code string, reqOpts ...RequestOption) error {
+	return as.client.Post(ctx, "/auth/2fa/disable", map[string]string{"code": code}, nil, reqOpts...)
+}
+
+// Complete2FA finishes a sign-in that returned a TwoFactorRequiredError;
+// code is a TOTP or backup code. The client then uses the new session as
+// after Authenticate.
+func (as *AuthService) Complete2FA(ctx context.Context, challengeToken, code string, reqOpts ...RequestOption) (*AuthResponse, error) {
+	req := map[string]string{"challenge_token": challengeToken, "code": code}
+	var response AuthResponse
+	err := as.client.Post(ctx, "/auth/2fa/verify", req, &response, reqOpts...)
+	if err == nil {
+		err = as.signedIn(ctx, &response)
+	}
+	return &response, err
+}
\ No newline at end of file
@@ -0,0 +1,170 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// twoFactorServer signs wallets in with the given /auth/wallet status and
+// body, accepts code 123456 for the 2FA endpoints and records each
+// request's body and Authorization header by path
+func twoFactorServer(t *testing.T, status int, signIn string) (*httptest.Server, func() map[string]map[string]string) {
+	t.Helper()
+	var mu sync.Mutex
+	bodies := map[string]map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&body)
+		body["authorization"] = r.Header.Get("Authorization")
+		mu.Lock()
+		bodies[r.URL.Path] = body
+		mu.Unlock()
+		
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth/wallet":
+			w.WriteHeader(status)
+			w.Write([]byte(signIn))
+			return
+		case "/projects":
+			w.Write([]byte(`{}`))
+			return
+		case "/auth/2fa/enable":
+			w.Write([]byte(`{"secret":"JBSWY3DPEHPK3PXP","otpauth_uri":"otpauth://totp/XRPL.Sale:rWallet?secret=JBSWY3DPEHPK3PXP","backup_codes":["a1","b2"]}`))
+			return
+		}
+		if body["code"] != "123456" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"invalid code","code":"invalid_code"}`))
+			return
+		}
+		if r.URL.Path == "/auth/2fa/verify" {
+			if body["challenge_token"] != "chal_1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"unknown challenge"}`))
+				return
+			}
+			w.Write([]byte(`{"token":"tok_2fa","refresh_token":"ref_2fa","expires_in":3600}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() map[string]map[string]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return bodies
+	}
+}
+
+func TestAuthenticateTwoFactor(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		signIn  string
+		wantAPI bool // the requirement came as an API error
+	}{
+		{"flag in the body", http.StatusOK, `{"two_factor_required":true,"challenge_token":"chal_1"}`, false},
+		{"API error code", http.StatusUnauthorized, `{"message":"second factor needed","code":"two_factor_required","details":{"challenge_token":"chal_1"}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, bodies := twoFactorServer(t, tt.status, tt.signIn)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			ctx := context.Background()
+			
+			auth, err := client.Auth.Authenticate(ctx, &xrplsale.AuthRequest{WalletAddress: "rWallet", Signature: "00"})
+			var tfErr *xrplsale.TwoFactorRequiredError
+			if !errors.Is(err, xrplsale.ErrTwoFactorRequired) || !errors.As(err, &tfErr) || auth != nil {
+				t.Fatalf("Authenticate() = %+v, %v; want a TwoFactorRequiredError", auth, err)
+			}
+			if tfErr.ChallengeToken != "chal_1" {
+				t.Errorf("ChallengeToken = %q, want chal_1", tfErr.ChallengeToken)
+			}
+			var apiErr *xrplsale.APIError
+			if tt.wantAPI != errors.As(err, &apiErr) {
+				t.Errorf("Authenticate() = %v, want it to wrap an APIError: %v", err, tt.wantAPI)
+			}
+			if client.IsAuthenticated() {
+				t.Fatal("IsAuthenticated() = true before the second factor")
+			}
+			
+			// A wrong code leaves the client signed out
+			if _, err := client.Auth.Complete2FA(ctx, tfErr.ChallengeToken, "000000"); err == nil || client.IsAuthenticated() {
+				t.Fatalf("Complete2FA() with a wrong code = %v, authenticated %v", err, client.IsAuthenticated())
+			}
+			auth, err = client.Auth.Complete2FA(ctx, tfErr.ChallengeToken, "123456")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := map[string]string{"challenge_token": "chal_1", "code": "123456", "authorization": ""}; !reflect.DeepEqual(bodies()["/auth/2fa/verify"], want) {
+				t.Errorf("verify body %v, want %v", bodies()["/auth/2fa/verify"], want)
+			}
+			if auth.Token != "tok_2fa" || auth.ExpiresAt.IsZero() || !client.IsAuthenticated() {
+				t.Errorf("Complete2FA() = %+v, want the client signed in", auth)
+			}
+			if err := client.Get(ctx, "/projects", nil, nil); err != nil || bodies()["/projects"]["authorization"] != "Bearer tok_2fa" {
+				t.Errorf("next request sent %q (%v), want the new session's token", bodies()["/projects"]["authorization"], err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateWithoutTwoFactor(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		status int
+		signIn string
+	}{
+		{"signed in", http.StatusOK, `{"token":"tok_1","two_factor_required":false}`},
+		{"other API error", http.StatusUnauthorized, `{"message":"bad signature","code":"invalid_signature"}`},
+	} {
+		srv, _ := twoFactorServer(t, tt.status, tt.signIn)
+		client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+		_, err := client.Auth.Authenticate(context.Background(), &xrplsale.AuthRequest{WalletAddress: "rWallet", Signature: "00"})
+		if errors.Is(err, xrplsale.ErrTwoFactorRequired) {
+			t.Errorf("%s: Authenticate() = %v, want no second factor asked for", tt.name, err)
+		}
+		if (tt.status == http.StatusOK) != client.IsAuthenticated() {
+			t.Errorf("%s: IsAuthenticated() = %v", tt.name, client.IsAuthenticated())
+		}
+	}
+}
+
+func TestTwoFactorSetup(t *testing.T) {
+	srv, bodies := twoFactorServer(t, http.StatusOK, `{}`)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	ctx := context.Background()
+	
+	setup, err := client.Auth.Enable2FA(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setup.Secret != "JBSWY3DPEHPK3PXP" || setup.OTPAuthURI == "" || !reflect.DeepEqual(setup.BackupCodes, []string{"a1", "b2"}) {
+		t.Errorf("Enable2FA() = %+v", setup)
+	}
+	
+	for name, call := range map[string]func(code string) error{
+		"/auth/2fa/confirm": func(code string) error { return client.Auth.Confirm2FA(ctx, code) },
+		"/auth/2fa/disable": func(code string) error { return client.Auth.Disable2FA(ctx, code) },
+	} {
+		if err := call("123456"); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := bodies()[name]; got["code"] != "123456" {
+			t.Errorf("%s sent %v, want the code", name, got)
+		}
+		var apiErr *xrplsale.APIError
+		if err := call("000000"); !errors.As(err, &apiErr) || apiErr.Code != "invalid_code" {
+			t.Errorf("%s with a wrong code = %v, want the API's invalid_code error", name, err)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,215 @@
+package xrplsale
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// UploadFieldName is the form field name of every file part sent by Upload
+const UploadFieldName = "file"
+
+// quoteEscaper escapes a quoted Content-Disposition parameter the way
+// multipart.Writer.CreateFormFile does
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// errUploadFinished fails the writing of an upload body nobody reads anymore
+var errUploadFinished = errors.New("upload request finished")
+
+// Upload POSTs files and form fields to endpoint as multipart/form-data and
+// decodes the JSON response into result. files is keyed by file name; each
+// file's content type is guessed from its extension. Parts are written
+// fields first, then in name order.
+//
+// The body is streamed as files are read, so they are never held in memory
+// whole. With Config.RequestSigner set, the body is first written to a
+// temporary file while it is hashed, and the signer, which must implement
+// DigestSigner, signs the digest. Like
+// Download, it bypasses resty: Config.Timeout and request hooks do not
+// apply, and since a stream cannot be replayed, uploads are never retried.
+func (c *Client) Upload(ctx context.Context, endpoint string, files map[string]io.Reader, fields map[string]string, result interface{}, reqOpts ...RequestOption) error {
+	if err := c.usable(); err != nil {
+		return err
+	}
+	if err := c.unsupportedSDK(); err != nil {
+		return err
+	}
+	c.prepareAuth(ctx)
+	
+	method := http.MethodPost
+	ro := newRequestOptions(reqOpts)
+	ro.idempotencyKey = c.idempotencyKey(method, ro)
+	ctx, cancel := ro.context(ctx)
+	defer cancel()
+	ctx = c.withDryRun(ctx, method, ro)
+	
+	u, err := c.resolveURL(endpoint)
+	if err != nil {
+		return err
+	}
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	
+	var body io.Reader
+	var contentType string
+	size := int64(-1)
+	if c.config.RequestSigner != nil {
+		if _, ok := c.config.RequestSigner.(DigestSigner); !ok {
+			return errDigestSignerRequired(c.config.RequestSigner)
+		}
+		spool, digest, err := spoolMultipart(files, fields)
+		if err != nil {
+			return err
+		}
+		defer spool.close()
+		body, contentType, size = spool.file, spool.contentType, spool.size
+		ctx = context.WithValue(ctx, bodyDigestKey{}, digest)
+	} else {
+		pr, pw := io.Pipe()
+		mw := multipart.NewWriter(pw)
+		go func() {
+			pw.CloseWithError(writeMultipart(mw, files, fields))
+		}()
+		// Unblocks the writer when the request ends before reading the body
+		defer pr.CloseWithError(errUploadFinished)
+		body, contentType = pr, mw.FormDataContentType()
+	}
+	
+	ctx, span := c.startSpan(ctx, method, endpoint)
+	req, err := c.newRawRequest(ctx, method, u, body, ro)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	
+	info := &ResponseInfo{
+		Method:         method,
+		Endpoint:       endpoint,
+		Attempts:       1,
+		IdempotencyKey: ro.idempotencyKey,
+	}
+	start := time.Now()
+	var response *Response
+	resp, err := c.rawHTTPClient().Do(req)
+	if err == nil {
+		var raw []byte
+		raw, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err == nil {
+			response = c.newResponse(method, endpoint, resp.StatusCode, resp.Header, raw)
+			info.StatusCode = response.StatusCode
+			info.RequestID = requestIDFrom(response.Header, ro)
+			if resp.StatusCode >= 400 {
+				err = newStatusError(resp.StatusCode, resp.Header, raw, nil)
+			}
+		}
+	} else if errors.Is(err, ErrDryRun) {
+		err = fmt.Errorf("%w: %s %s", ErrDryRun, method, endpoint)
+	}
+	info.Duration = time.Since(start)
+	err = withIdempotencyKey(err, ro.idempotencyKey)
+	info.Err = err
+	c.runResponseHooks(ctx, info)
+	endSpan(span, info)
+	c.observe(info)
+	
+	if err != nil {
+		return err
+	}
+	return response.decodeResult(result)
+}
+
+// writeMultipart writes the form fields and files of an upload
+func writeMultipart(mw *multipart.Writer, files map[string]io.Reader, fields map[string]string) error {
+	for _, key := range sortedKeys(fields) {
+		if err := mw.WriteField(key, fields[key]); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(files) {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			quoteEscaper.Replace(UploadFieldName), quoteEscaper.Replace(filepath.Base(name))))
+		header.Set("Content-Type", uploadContentType(name))
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, files[name]); err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+	}
+	return mw.Close()
+}
+
+// spooledUpload is a multipart body written to a temporary file
+type spooledUpload struct {
+	file        *os.File
+	contentType string
+	size        int64
+}
+
+// spoolMultipart writes the multipart body of an upload to a temporary
+// file and returns it with the body's SHA-256 digest
+func spoolMultipart(files map[string]io.Reader, fields map[string]string) (*spooledUpload, []byte, error) {
+	file, err := os.CreateTemp("", "xrplsale-upload-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	spool := &spooledUpload{file: file}
+	hash := sha256.New()
+	mw := multipart.NewWriter(io.MultiWriter(file, hash))
+	err = writeMultipart(mw, files, fields)
+	if err == nil {
+		// The write offset is the size of the body
+		spool.size, err = file.Seek(0, io.SeekCurrent)
+	}
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		spool.close()
+		return nil, nil, err
+	}
+	spool.contentType = mw.FormDataContentType()
+	return spool, hash.Sum(nil), nil
+}
+
+// close removes the temporary file
+func (s *spooledUpload) close() {
+	s.file.Close()
+	os.Remove(s.file.Name())
+}
+
+// uploadContentType returns the content type of a file from its extension
+func uploadContentType(name string) string {
+	if contentType := mime.TypeByExtension(strings.ToLower(filepath.Ext(name))); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
\ No newline at end of file
@@ -0,0 +1,223 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// uploadedPart is a part of a multipart request as the server read it
+type uploadedPart struct {
+	Field, Filename, ContentType, Content string
+}
+
+// multipartServer answers uploads with the parts it read, in order
+func multipartServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+			http.Error(w, "not multipart", http.StatusBadRequest)
+			return
+		}
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		var parts []uploadedPart
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			content, _ := io.ReadAll(part)
+			parts = append(parts, uploadedPart{part.FormName(), part.FileName(), part.Header.Get("Content-Type"), string(content)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"parts": parts})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestUpload(t *testing.T) {
+	srv := multipartServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	// The same upload built in different orders is sent the same way: fields
+	// first, then files by name
+	want := []uploadedPart{
+		{"project", "", "", "proj_1"},
+		{"type", "", "", "kyc"},
+		{"file", "logo.PNG", "image/png", "PNG"},
+		{"file", "id.pdf", "application/pdf", "%PDF"},
+		{"file", "notes", "application/octet-stream", "plain"},
+	}
+	tests := []struct {
+		name   string
+		files  func() map[string]io.Reader
+		fields map[string]string
+	}{
+		{"in order", func() map[string]io.Reader {
+			return map[string]io.Reader{"id.pdf": strings.NewReader("%PDF"), "dir/logo.PNG": strings.NewReader("PNG"), "notes": strings.NewReader("plain")}
+		}, map[string]string{"project": "proj_1", "type": "kyc"}},
+		{"reversed", func() map[string]io.Reader {
+			return map[string]io.Reader{"notes": strings.NewReader("plain"), "dir/logo.PNG": strings.NewReader("PNG"), "id.pdf": strings.NewReader("%PDF")}
+		}, map[string]string{"type": "kyc", "project": "proj_1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct{ Parts []uploadedPart }
+			if err := client.Upload(context.Background(), "/uploads", tt.files(), tt.fields, &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Parts) != len(want) {
+				t.Fatalf("server read %+v, want %+v", got.Parts, want)
+			}
+			for i := range want {
+				if got.Parts[i] != want[i] {
+					t.Errorf("part %d = %+v, want %+v", i, got.Parts[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUploadFilenames(t *testing.T) {
+	srv := multipartServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	// Go's %q escapes would reach the server as literal letters, e.g. a
+	// non-breaking space as "u00a0"
+	for _, name := range []string{`say "hi".pdf`, `back\slash.pdf`, "résumé.pdf", "non\u00a0breaking.pdf", "tab\there.pdf"} {
+		var got struct{ Parts []uploadedPart }
+		if err := client.Upload(context.Background(), "/uploads", map[string]io.Reader{name: strings.NewReader("x")}, nil, &got); err != nil {
+			t.Fatalf("%q: %v", name, err)
+		}
+		if len(got.Parts) != 1 || got.Parts[0].Filename != name {
+			t.Errorf("server read %+v, want filename %q", got.Parts, name)
+		}
+	}
+}
+
+func TestUploadDocument(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile(xrplsale.UploadFieldName)
+		if err != nil || r.URL.Path != "/projects/proj_1/documents" {
+			http.Error(w, "bad upload", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		size, _ := io.Copy(io.Discard, file)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(xrplsale.ProjectDocument{
+			ID: "doc_1", ProjectID: "proj_1", Type: xrplsale.DocumentType(r.FormValue("type")),
+			Filename: header.Filename, ContentType: header.Header.Get("Content-Type"), Size: size,
+		})
+	}))
+	defer srv.Close()
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	
+	doc, err := client.Projects.UploadDocument(context.Background(), "proj_1", "whitepaper.pdf", strings.NewReader("%PDF-1.7"), xrplsale.DocumentWhitepaper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Type != xrplsale.DocumentWhitepaper || doc.Filename != "whitepaper.pdf" || doc.ContentType != "application/pdf" || doc.Size != 8 {
+		t.Fatalf("UploadDocument() = %+v", doc)
+	}
+	
+	// Errors decode like any other call's
+	_, err = client.Projects.UploadDocument(context.Background(), "proj_2", "logo.png", strings.NewReader("x"), xrplsale.DocumentLogo)
+	var apiErr *xrplsale.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("UploadDocument() = %v, want a 400 APIError", err)
+	}
+}
+
+// generatedFile produces size bytes on demand, counting how many were read
+type generatedFile struct {
+	size     int64
+	produced atomic.Int64
+}
+
+func (f *generatedFile) Read(p []byte) (int, error) {
+	remaining := f.size - f.produced.Load()
+	if remaining <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	for i := range p {
+		p[i] = 'x'
+	}
+	f.produced.Add(int64(len(p)))
+	return len(p), nil
+}
+
+// TestUploadStreams holds the server's reading back and checks the file is
+// only read as far as the connection's buffers allow, not buffered whole.
+// Socket buffers are kept small so the bound holds whatever the kernel's
+// defaults.
+func TestUploadStreams(t *testing.T) {
+	const socketBuffer = 64 << 10
+	file := &generatedFile{size: 10 << 20}
+	release := make(chan struct{})
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		n, _ := io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int64{"received": n})
+	}))
+	srv.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conn.(*net.TCPConn).SetReadBuffer(socketBuffer)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+	dialer := &net.Dialer{}
+	transport := &http.Transport{DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err == nil {
+			conn.(*net.TCPConn).SetWriteBuffer(socketBuffer)
+		}
+		return conn, err
+	}}
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, Transport: transport})
+	
+	done := make(chan error, 1)
+	var got struct{ Received int64 }
+	go func() {
+		done <- client.Upload(context.Background(), "/uploads", map[string]io.Reader{"big.bin": file}, nil, &got)
+	}()
+	
+	// Wait for reading to stall on the unread connection
+	var stalled int64
+	for last := int64(-1); stalled == 0 || stalled != last; time.Sleep(50 * time.Millisecond) {
+		last, stalled = stalled, file.produced.Load()
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if stalled >= file.size/4 {
+		t.Errorf("%d of %d bytes read before the server read any, want the upload streamed", stalled, file.size)
+	}
+	if got.Received <= file.size || file.produced.Load() != file.size {
+		t.Errorf("server received %d bytes for a %d byte file", got.Received, file.size)
+	}
+}
\ No newline at end of file
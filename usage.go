@@ -0,0 +1,133 @@
+package xrplsale
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+)
+
+// maxUsageDays is the longest date range, in days, the usage endpoint
+// accepts in one call
+const maxUsageDays = 31
+
+// UsageGroupBy selects how API usage is grouped
+type UsageGroupBy string
+
+const (
+	UsageByDayAndEndpoint UsageGroupBy = "day_endpoint"
+	UsageByAPIKey         UsageGroupBy = "api_key"
+)
+
+// UsageRow is the usage of one group
+type UsageRow struct {
+	Date       string `json:"date,omitempty"`
+	Endpoint   string `json:"endpoint,omitempty"`
+	APIKeyID   string `json:"api_key_id,omitempty"`
+	Requests   int64  `json:"requests"`
+	Errors     int64  `json:"errors"`
+	QuotaUnits int64  `json:"quota_units"`
+}
+
+// UsageReport is the account's API usage over a date range
+type UsageReport struct {
+	From    time.Time    `json:"from"`
+	To      time.Time    `json:"to"`
+	GroupBy UsageGroupBy `json:"group_by"`
+	Rows    []UsageRow   `json:"rows"`
+	
+	TotalRequests   int64 `json:"total_requests"`
+	TotalErrors     int64 `json:"total_errors"`
+	TotalQuotaUnits int64 `json:"total_quota_units"`
+	
+	// CompletedThrough is the last day whose usage was fetched. It is before
+	// To when GetUsage failed or timed out part way; call it again from the
+	// next day to fetch the rest.
+	CompletedThrough time.Time `json:"-"`
+}
+
+// WriteCSV writes the report rows to w as CSV
+func (r *UsageReport) WriteCSV(w io.Writer) error {
+	header := []string{"date", "endpoint", "api_key_id", "requests", "errors", "quota_units"}
+	return writeCSV(w, header, func(write func([]string) error) error {
+		for _, row := range r.Rows {
+			err := write([]string{
+				row.Date,
+				row.Endpoint,
+				row.APIKeyID,
+				strconv.FormatInt(row.Requests, 10),
+				strconv.FormatInt(row.Errors, 10),
+				strconv.FormatInt(row.QuotaUnits, 10),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetUsage retrieves request counts, error counts and quota consumption
+// between from and to (inclusive dates). Only the calendar dates of from and
+// to count, read in their own locations; the report holds them as UTC
+// midnights. Ranges longer than the API allows are fetched in chunks and
+// merged. If a chunk fails, the report fetched so far is returned with the
+// error.
+func (c *Client) GetUsage(ctx context.Context, from, to time.Time, groupBy UsageGroupBy, opts ...RequestOption) (*UsageReport, error) {
+	ctx, cancel := operationContext(ctx, opts)
+	defer cancel()
+	
+	from, to = utcDate(from), utcDate(to)
+	report := &UsageReport{From: from, To: to, GroupBy: groupBy}
+	index := make(map[string]int)
+	
+	// Days are stepped with AddDate on UTC dates, so chunks never overlap
+	// or skip a day across daylight saving changes
+	for start := from; !start.After(to); {
+		end := start.AddDate(0, 0, maxUsageDays-1)
+		if end.After(to) {
+			end = to
+		}
+		
+		params := map[string]string{
+			"from":     start.Format("2006-01-02"),
+			"to":       end.Format("2006-01-02"),
+			"group_by": string(groupBy),
+		}
+		var chunk UsageReport
+		if err := c.Get(ctx, "/usage", params, &chunk, opts...); err != nil {
+			return report, err
+		}
+		report.merge(chunk.Rows, index)
+		report.CompletedThrough = end
+		
+		start = end.AddDate(0, 0, 1)
+	}
+	
+	return report, nil
+}
+
+// utcDate returns the calendar date of t, in t's location, as midnight UTC
+func utcDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// merge adds rows to the report, summing rows of the same group
+func (r *UsageReport) merge(rows []UsageRow, index map[string]int) {
+	for _, row := range rows {
+		key := row.Date + "\x00" + row.Endpoint + "\x00" + row.APIKeyID
+		if i, ok := index[key]; ok {
+			r.Rows[i].Requests += row.Requests
+			r.Rows[i].Errors += row.Errors
+			r.Rows[i].QuotaUnits += row.QuotaUnits
+		} else {
+			index[key] = len(r.Rows)
+			r.Rows = append(r.Rows, row)
+		}
+		
+		r.TotalRequests += row.Requests
+		r.TotalErrors += row.Errors
+		r.TotalQuotaUnits += row.QuotaUnits
+	}
+}
\ No newline at end of file
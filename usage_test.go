@@ -0,0 +1,169 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// usageServer answers every usage call with one row per day and a row for
+// the API key covering the range. Calls listed in failing fail with 500.
+func usageServer(t *testing.T, failing ...int) (*httptest.Server, func() [][2]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var chunks [][2]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+		mu.Lock()
+		chunks = append(chunks, [2]string{from, to})
+		call := len(chunks)
+		mu.Unlock()
+		for _, f := range failing {
+			if call == f {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		
+		start, _ := time.Parse("2006-01-02", from)
+		end, _ := time.Parse("2006-01-02", to)
+		var rows []xrplsale.UsageRow
+		days := int64(0)
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			rows = append(rows, xrplsale.UsageRow{Date: day.Format("2006-01-02"), Endpoint: "/projects", Requests: 10, Errors: 1, QuotaUnits: 20})
+			days++
+		}
+		rows = append(rows, xrplsale.UsageRow{APIKeyID: "key_1", Requests: days})
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(xrplsale.UsageReport{Rows: rows})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() [][2]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][2]string(nil), chunks...)
+	}
+}
+
+func TestGetUsageChunks(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := func(loc *time.Location, year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     [][2]string
+	}{
+		{"single day", date(time.UTC, 2024, 5, 1), date(time.UTC, 2024, 5, 1), [][2]string{{"2024-05-01", "2024-05-01"}}},
+		{"exactly the maximum", date(time.UTC, 2024, 5, 1), date(time.UTC, 2024, 5, 31), [][2]string{{"2024-05-01", "2024-05-31"}}},
+		{"one day over", date(time.UTC, 2024, 5, 1), date(time.UTC, 2024, 6, 1), [][2]string{{"2024-05-01", "2024-05-31"}, {"2024-06-01", "2024-06-01"}}},
+		{"across both daylight saving changes", date(newYork, 2024, 3, 1), date(newYork, 2024, 11, 30), [][2]string{
+			{"2024-03-01", "2024-03-31"}, {"2024-04-01", "2024-05-01"}, {"2024-05-02", "2024-06-01"}, {"2024-06-02", "2024-07-02"},
+			{"2024-07-03", "2024-08-02"}, {"2024-08-03", "2024-09-02"}, {"2024-09-03", "2024-10-03"}, {"2024-10-04", "2024-11-03"},
+			{"2024-11-04", "2024-11-30"},
+		}},
+		// Stepping 24 hours from just after midnight on the day clocks go back
+		// lands on the same date again
+		{"chunk ending on the day clocks go back", time.Date(2024, 10, 4, 0, 30, 0, 0, newYork), date(newYork, 2024, 11, 10),
+			[][2]string{{"2024-10-04", "2024-11-03"}, {"2024-11-04", "2024-11-10"}}},
+		{"late evening local times keep their dates", time.Date(2024, 3, 9, 23, 30, 0, 0, newYork), time.Date(2024, 3, 10, 23, 30, 0, 0, newYork),
+			[][2]string{{"2024-03-09", "2024-03-10"}}},
+		{"empty range", date(time.UTC, 2024, 5, 2), date(time.UTC, 2024, 5, 1), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, chunks := usageServer(t)
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+			
+			report, err := client.GetUsage(context.Background(), tt.from, tt.to, xrplsale.UsageByDayAndEndpoint)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := chunks(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("requested chunks %v, want %v", got, tt.want)
+			}
+			if report.From.Location() != time.UTC || report.From.Format("2006-01-02") != tt.from.Format("2006-01-02") {
+				t.Errorf("report From = %v, want %s as a UTC date", report.From, tt.from.Format("2006-01-02"))
+			}
+			
+			// Every day appears once, and the key row is merged across chunks
+			seen := map[string]bool{}
+			var days int64
+			var keyRows []xrplsale.UsageRow
+			for _, row := range report.Rows {
+				if row.APIKeyID != "" {
+					keyRows = append(keyRows, row)
+					continue
+				}
+				if seen[row.Date] || row.Requests != 10 {
+					t.Errorf("day %s counted twice", row.Date)
+				}
+				seen[row.Date] = true
+				days++
+			}
+			if len(tt.want) > 0 && (len(keyRows) != 1 || keyRows[0].Requests != days) {
+				t.Errorf("key rows %+v, want one with %d requests merged over %d chunks", keyRows, days, len(tt.want))
+			}
+			if report.TotalRequests != 11*days || report.TotalErrors != days || report.TotalQuotaUnits != 20*days {
+				t.Errorf("totals %d/%d/%d for %d days", report.TotalRequests, report.TotalErrors, report.TotalQuotaUnits, days)
+			}
+			if len(tt.want) > 0 && !report.CompletedThrough.Equal(report.To) {
+				t.Errorf("CompletedThrough = %v, want %v", report.CompletedThrough, report.To)
+			}
+		})
+	}
+}
+func TestGetUsagePartialFailure(t *testing.T) {
+	srv, chunks := usageServer(t, 2)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	
+	report, err := client.GetUsage(context.Background(), from, from.AddDate(0, 0, 89), xrplsale.UsageByDayAndEndpoint, xrplsale.WithNoRetry())
+	var apiErr *xrplsale.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("GetUsage() = %v, want the second chunk's 500", err)
+	}
+	if got := len(chunks()); got != 2 {
+		t.Fatalf("%d chunks requested, want to stop after the failed second", got)
+	}
+	// The first chunk is kept, and the caller can resume the day after it
+	if want := from.AddDate(0, 0, 30); !report.CompletedThrough.Equal(want) {
+		t.Errorf("CompletedThrough = %v, want %v", report.CompletedThrough, want)
+	}
+	if len(report.Rows) != 32 || report.TotalRequests != 31*11 {
+		t.Errorf("partial report has %d rows and %d requests, want the first chunk's", len(report.Rows), report.TotalRequests)
+	}
+}
+
+func TestUsageReportWriteCSV(t *testing.T) {
+	report := &xrplsale.UsageReport{Rows: []xrplsale.UsageRow{
+		{Date: "2024-05-01", Endpoint: "/projects", Requests: 10, Errors: 1, QuotaUnits: 20},
+		{APIKeyID: "key_1", Requests: 1 << 40},
+		{Date: "2024-05-02", Endpoint: "/search?q=a,b", Requests: 3},
+	}}
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "date,endpoint,api_key_id,requests,errors,quota_units\n" +
+		"2024-05-01,/projects,,10,1,20\n" +
+		",,key_1,1099511627776,0,0\n" +
+		"2024-05-02,\"/search?q=a,b\",,3,0,0\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteCSV() wrote\n%s\nwant\n%s", got, want)
+	}
+}
\ No newline at end of file
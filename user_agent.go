@@ -0,0 +1,53 @@
+package xrplsale
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// userAgent returns the User-Agent sent with every request
+func userAgent(suffix string) string {
+	ua := "XRPL.Sale-Go-SDK/" + Version
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
+
+// identify validates the client identification and sets the X-Client-Name
+// and X-Client-Version headers, defaulting them from UserAgentSuffix
+func (config *Config) identify(httpClient *resty.Client) error {
+	fields := []struct{ name, value string }{
+		{"UserAgentSuffix", config.UserAgentSuffix},
+		{"ClientName", config.ClientName},
+		{"ClientVersion", config.ClientVersion},
+	}
+	for _, field := range fields {
+		if strings.ContainsFunc(field.value, isControl) {
+			return fmt.Errorf("%s must not contain control characters such as CR or LF", field.name)
+		}
+	}
+	
+	name, version := config.ClientName, config.ClientVersion
+	if name == "" {
+		product, _, _ := strings.Cut(strings.TrimSpace(config.UserAgentSuffix), " ")
+		name, version, _ = strings.Cut(product, "/")
+		if config.ClientVersion != "" {
+			version = config.ClientVersion
+		}
+	}
+	if name != "" {
+		httpClient.SetHeader(ClientNameHeader, name)
+	}
+	if version != "" {
+		httpClient.SetHeader(ClientVersionHeader, version)
+	}
+	return nil
+}
+
+// isControl reports whether r is an ASCII control character
+func isControl(r rune) bool {
+	return r < ' ' || r == 0x7f
+}
\ No newline at end of file
@@ -0,0 +1,70 @@
+package xrplsale_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       xrplsale.Config
+		wantUA       string
+		wantName     string
+		wantVersion  string
+		wantRejected bool
+	}{
+		// Without a suffix the header is exactly what it always was
+		{"default", xrplsale.Config{}, "XRPL.Sale-Go-SDK/1.0.0", "", "", false},
+		{"suffix", xrplsale.Config{UserAgentSuffix: "treasury-sync/2.3"}, "XRPL.Sale-Go-SDK/1.0.0 treasury-sync/2.3", "treasury-sync", "2.3", false},
+		{"suffix with comment", xrplsale.Config{UserAgentSuffix: " treasury-sync/2.3 (eu-west) "}, "XRPL.Sale-Go-SDK/1.0.0 treasury-sync/2.3 (eu-west)", "treasury-sync", "2.3", false},
+		{"suffix without version", xrplsale.Config{UserAgentSuffix: "treasury-sync"}, "XRPL.Sale-Go-SDK/1.0.0 treasury-sync", "treasury-sync", "", false},
+		{"explicit name", xrplsale.Config{UserAgentSuffix: "treasury-sync/2.3", ClientName: "treasury", ClientVersion: "7"}, "XRPL.Sale-Go-SDK/1.0.0 treasury-sync/2.3", "treasury", "7", false},
+		{"explicit version", xrplsale.Config{UserAgentSuffix: "treasury-sync/2.3", ClientVersion: "2.3.1"}, "XRPL.Sale-Go-SDK/1.0.0 treasury-sync/2.3", "treasury-sync", "2.3.1", false},
+		{"name without suffix", xrplsale.Config{ClientName: "treasury"}, "XRPL.Sale-Go-SDK/1.0.0", "treasury", "", false},
+		{"CRLF in suffix", xrplsale.Config{UserAgentSuffix: "treasury\r\nX-Evil: 1"}, "", "", "", true},
+		{"LF in client name", xrplsale.Config{ClientName: "treasury\n"}, "", "", "", true},
+		{"NUL in client version", xrplsale.Config{ClientVersion: "2\x00"}, "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			var got http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				got = r.Header.Clone()
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+			config := tt.config
+			config.APIKey, config.BaseURL = "key", srv.URL
+			client := xrplsale.NewClientWithConfig(&config)
+			
+			err := client.Get(context.Background(), "/projects", nil, nil)
+			if tt.wantRejected {
+				if err == nil || hits.Load() != 0 {
+					t.Fatalf("Get() = %v after %d requests, want an error and nothing sent", err, hits.Load())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for header, want := range map[string]string{
+				"User-Agent":                 tt.wantUA,
+				xrplsale.ClientNameHeader:    tt.wantName,
+				xrplsale.ClientVersionHeader: tt.wantVersion,
+			} {
+				if values := got.Values(header); want == "" && len(values) != 0 || want != "" && (len(values) != 1 || values[0] != want) {
+					t.Errorf("%s = %q, want %q", header, values, want)
+				}
+			}
+		})
+	}
+}
\ No newline at end of file
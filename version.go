@@ -0,0 +1,254 @@
+package xrplsale
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Headers the API uses to announce which SDK versions it supports
+const (
+	MinSDKVersionHeader    = "X-Min-SDK-Version"
+	LatestSDKVersionHeader = "X-Latest-SDK-Version"
+)
+
+// VersionStatus compares this SDK's Version with the versions the API announced
+type VersionStatus string
+
+const (
+	// VersionOK means no newer or minimum version has been announced above Version
+	VersionOK VersionStatus = "ok"
+	
+	// VersionOutdated means a newer SDK is available but this one is still supported
+	VersionOutdated VersionStatus = "outdated"
+	
+	// VersionUnsupported means Version is below the minimum the API supports
+	VersionUnsupported VersionStatus = "unsupported"
+)
+
+// ErrSDKUnsupported is matched by UnsupportedSDKError
+var ErrSDKUnsupported = errors.New("SDK version no longer supported")
+
+// UnsupportedSDKError is returned for every request once the API has
+// announced a minimum SDK version above Version and Config.StrictSDKVersion is set
+type UnsupportedSDKError struct {
+	Version string
+	Minimum string
+}
+
+// Error implements the error interface
+func (e *UnsupportedSDKError) Error() string {
+	return fmt.Sprintf("SDK version %s is below the minimum supported version %s", e.Version, e.Minimum)
+}
+
+// Is reports whether target is ErrSDKUnsupported
+func (e *UnsupportedSDKError) Is(target error) bool { return target == ErrSDKUnsupported }
+
+// sdkVersions records the highest minimum and latest versions the API announced
+type sdkVersions struct {
+	mu      sync.Mutex
+	minimum string
+	latest  string
+	warned  map[VersionStatus]bool
+}
+
+// observe records the versions announced in header and returns the new
+// status when it just got worse
+func (v *sdkVersions) observe(header http.Header, current string) (VersionStatus, bool) {
+	minimum := strings.TrimSpace(header.Get(MinSDKVersionHeader))
+	latest := strings.TrimSpace(header.Get(LatestSDKVersionHeader))
+	if minimum == "" && latest == "" {
+		return VersionOK, false
+	}
+	
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if minimum != "" && newerVersion(minimum, v.minimum) {
+		v.minimum = minimum
+	}
+	if latest != "" && newerVersion(latest, v.latest) {
+		v.latest = latest
+	}
+	status := v.statusLocked(current)
+	if status == VersionOK || v.warned[status] {
+		return status, false
+	}
+	if v.warned == nil {
+		v.warned = make(map[VersionStatus]bool)
+	}
+	v.warned[status] = true
+	return status, true
+}
+
+// status compares current with the recorded versions
+func (v *sdkVersions) status(current string) (VersionStatus, string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.statusLocked(current), v.minimum
+}
+
+// statusLocked is status with v.mu held
+func (v *sdkVersions) statusLocked(current string) VersionStatus {
+	switch {
+	case v.minimum != "" && newerVersion(v.minimum, current):
+		return VersionUnsupported
+	case v.latest != "" && newerVersion(v.latest, current):
+		return VersionOutdated
+	}
+	return VersionOK
+}
+
+// VersionStatus reports how this SDK's Version compares with the minimum and
+// latest versions the API has announced so far. It is VersionOK until a
+// response carries the version headers.
+func (c *Client) VersionStatus() VersionStatus {
+	status, _ := c.versions.status(Version)
+	return status
+}
+
+// checkSDKVersion records the versions announced by a response and warns
+// once per status when this SDK falls behind
+func (c *Client) checkSDKVersion(header http.Header) {
+	status, changed := c.versions.observe(header, Version)
+	if !changed {
+		return
+	}
+	
+	c.versions.mu.Lock()
+	minimum, latest := c.versions.minimum, c.versions.latest
+	c.versions.mu.Unlock()
+	
+	if status == VersionUnsupported {
+		c.logger.Errorf("XRPL.Sale Go SDK %s is below the minimum version %s supported by the API; upgrade now", Version, minimum)
+	} else {
+		c.logger.Warnf("XRPL.Sale Go SDK %s is outdated; version %s is available", Version, latest)
+	}
+	c.events.emit(ClientEventSDKVersion, map[string]string{
+		"status":  string(status),
+		"version": Version,
+		"minimum": minimum,
+		"latest":  latest,
+	})
+}
+
+// unsupportedSDK returns an UnsupportedSDKError when strict version checking
+// is enabled and the API no longer supports this SDK
+func (c *Client) unsupportedSDK() error {
+	if !c.config.StrictSDKVersion {
+		return nil
+	}
+	if status, minimum := c.versions.status(Version); status == VersionUnsupported {
+		return &UnsupportedSDKError{Version: Version, Minimum: minimum}
+	}
+	return nil
+}
+
+// semver is a parsed semantic version; build metadata is dropped
+type semver struct {
+	major, minor, patch int
+	pre                 []string
+}
+
+// parseSemver parses a semantic version such as "1.4.0", "v2.0.0-rc.1" or
+// "1.2" (missing components are zero)
+func parseSemver(s string) (semver, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	var v semver
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.pre = strings.Split(s[i+1:], ".")
+		s = s[:i]
+		for _, id := range v.pre {
+			if id == "" {
+				return semver{}, false
+			}
+		}
+	}
+	
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return semver{}, false
+	}
+	nums := [3]int{}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		nums[i] = n
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+	return v, true
+}
+
+// compareSemver returns -1, 0 or 1 as a is lower than, equal to or higher
+// than b, following semantic versioning precedence
+func compareSemver(a, b semver) int {
+	for _, d := range [3]int{a.major - b.major, a.minor - b.minor, a.patch - b.patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	
+	// A pre-release is lower than the release itself
+	switch {
+	case len(a.pre) == 0 && len(b.pre) == 0:
+		return 0
+	case len(a.pre) == 0:
+		return 1
+	case len(b.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(a.pre) && i < len(b.pre); i++ {
+		if c := comparePrerelease(a.pre[i], b.pre[i]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(a.pre) - len(b.pre))
+}
+
+// comparePrerelease compares pre-release identifiers: numeric ones
+// numerically and below alphanumeric ones, which compare as strings
+func comparePrerelease(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return sign(na - nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// newerVersion reports whether version a is higher than b. An empty or
+// unparseable b is lower than any valid a; an unparseable a is never higher.
+func newerVersion(a, b string) bool {
+	va, ok := parseSemver(a)
+	if !ok {
+		return false
+	}
+	vb, ok := parseSemver(b)
+	if !ok {
+		return true
+	}
+	return compareSemver(va, vb) > 0
+}
+
+// sign returns -1, 0 or 1 matching the sign of n
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
\ No newline at end of file
@@ -0,0 +1,161 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCompareSemver(t *testing.T) {
+	// The precedence examples from the semver specification, in ascending order
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.2", "1.10.0", "2.0.0", "2.1.0", "2.1.1",
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			va, okA := parseSemver(a)
+			vb, okB := parseSemver(b)
+			if !okA || !okB {
+				t.Fatalf("parseSemver(%q) = %v, parseSemver(%q) = %v", a, okA, b, okB)
+			}
+			if got, want := compareSemver(va, vb), sign(i-j); got != want {
+				t.Errorf("compareSemver(%s, %s) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		in   string
+		want semver
+		ok   bool
+	}{
+		{"1.4.0", semver{major: 1, minor: 4}, true},
+		{"v2.0.0-rc.1", semver{major: 2, pre: []string{"rc", "1"}}, true},
+		{" 1.2 ", semver{major: 1, minor: 2}, true},
+		{"3", semver{major: 3}, true},
+		{"1.0.0+build.5", semver{major: 1}, true},
+		{"1.0.0-rc.1+build.5", semver{major: 1, pre: []string{"rc", "1"}}, true},
+		{"", semver{}, false},
+		{"1.2.3.4", semver{}, false},
+		{"1.x.0", semver{}, false},
+		{"1.-1.0", semver{}, false},
+		{"1.0.0-", semver{}, false},
+		{"1.0.0-rc..1", semver{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseSemver(tt.in)
+		if ok != tt.ok || compareSemver(got, tt.want) != 0 || len(got.pre) != len(tt.want.pre) {
+			t.Errorf("parseSemver(%q) = %+v, %v; want %+v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNewerVersion(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1.1.0", "1.0.0", true},
+		{"1.0.0", "1.0.0", false},
+		{"1.0.0", "1.0.0-rc.1", true},
+		{"1.0.0", "", true},
+		{"1.0.0", "garbage", true},
+		{"garbage", "1.0.0", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := newerVersion(tt.a, tt.b); got != tt.want {
+			t.Errorf("newerVersion(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestVersionStatus(t *testing.T) {
+	var minimum, latest atomic.Value
+	minimum.Store("")
+	latest.Store("")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := minimum.Load().(string); v != "" {
+			w.Header().Set(MinSDKVersionHeader, v)
+		}
+		if v := latest.Load().(string); v != "" {
+			w.Header().Set(LatestSDKVersionHeader, v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	
+	tests := []struct {
+		name       string
+		minimum    string
+		latest     string
+		wantStatus VersionStatus
+		wantEvent  bool
+	}{
+		{"no headers", "", "", VersionOK, false},
+		{"current", Version, Version, VersionOK, false},
+		{"older minimum", "0.9.0", "", VersionOK, false},
+		{"newer latest", "0.9.0", "1.1.0", VersionOutdated, true},
+		{"outdated again", "", "1.2.0", VersionOutdated, false},
+		{"newer minimum", "1.0.1", "1.2.0", VersionUnsupported, true},
+		// Announced versions only ever go up
+		{"lower minimum later", "0.1.0", "", VersionUnsupported, false},
+	}
+	for _, strict := range []bool{false, true} {
+		minimum.Store("")
+		latest.Store("")
+		client := NewClientWithConfig(&Config{APIKey: "key", BaseURL: srv.URL, StrictSDKVersion: strict})
+		events := client.Events()
+		for _, tt := range tests {
+			minimum.Store(tt.minimum)
+			latest.Store(tt.latest)
+			refused := strict && client.VersionStatus() == VersionUnsupported
+			err := client.Get(context.Background(), "/projects", nil, nil)
+			if refused {
+				// A strict client stops sending once unsupported
+				if !errors.Is(err, ErrSDKUnsupported) {
+					t.Fatalf("%s: Get() = %v, want ErrSDKUnsupported", tt.name, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("strict %v, %s: %v", strict, tt.name, err)
+			}
+			if got := client.VersionStatus(); got != tt.wantStatus {
+				t.Fatalf("strict %v, %s: VersionStatus() = %q, want %q", strict, tt.name, got, tt.wantStatus)
+			}
+			var got []ClientEvent
+			for len(events) > 0 {
+				got = append(got, <-events)
+			}
+			if tt.wantEvent != (len(got) == 1) || len(got) > 1 {
+				t.Fatalf("strict %v, %s: events %+v, want event %v", strict, tt.name, got, tt.wantEvent)
+			}
+			if tt.wantEvent && (got[0].Kind != ClientEventSDKVersion || got[0].Details["status"] != string(tt.wantStatus)) {
+				t.Fatalf("strict %v, %s: event %+v, want an sdk_version %s event", strict, tt.name, got[0], tt.wantStatus)
+			}
+		}
+		
+		err := client.Get(context.Background(), "/projects", nil, nil)
+		var unsupported *UnsupportedSDKError
+		if strict != errors.As(err, &unsupported) || strict != errors.Is(err, ErrSDKUnsupported) {
+			t.Fatalf("strict %v: Get() once unsupported = %v", strict, err)
+		}
+		if strict && (unsupported.Version != Version || unsupported.Minimum != "1.0.1") {
+			t.Fatalf("UnsupportedSDKError = %+v", unsupported)
+		}
+		err = client.Upload(context.Background(), "/uploads", map[string]io.Reader{"a.txt": strings.NewReader("a")}, nil, nil)
+		if strict != errors.Is(err, ErrSDKUnsupported) {
+			t.Fatalf("strict %v: Upload() once unsupported = %v", strict, err)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,261 @@
+package xrplsale
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Watch defaults
+const (
+	DefaultWatchInterval = 5 * time.Second
+	DefaultWatchBuffer   = 16
+)
+
+// WatchOptions configures a watch subscription
+type WatchOptions struct {
+	// Interval is how often the resource is polled; defaults to
+	// DefaultWatchInterval. Subscriptions share a poll loop only when
+	// their intervals match.
+	Interval time.Duration
+	
+	// Buffer is the number of undelivered updates kept for this subscriber;
+	// defaults to DefaultWatchBuffer
+	Buffer int
+}
+
+// WatchUpdate is a change in a watched resource, or a failed poll
+type WatchUpdate[T any] struct {
+	Value T
+	Err   error
+	At    time.Time
+}
+
+// Subscription delivers updates of a watched resource. Identical
+// subscriptions on a client share one poll loop, which stops when the last
+// of them is closed.
+type Subscription[T any] struct {
+	// C receives the latest value on subscribing, then every change and
+	// every failed poll. It is closed when the subscription ends.
+	C <-chan WatchUpdate[T]
+	
+	sub  *watchSubscriber[T]
+	loop *watchLoop[T]
+	stop func() bool
+}
+
+// Dropped returns the number of updates dropped because the subscriber fell
+// behind; the oldest undelivered update is dropped first
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.sub.dropped.Load()
+}
+
+// Close ends the subscription and closes C. It is also called when the
+// context passed to the watch method is done.
+func (s *Subscription[T]) Close() {
+	s.stop()
+	s.loop.unsubscribe(s.sub)
+}
+
+// watchSubscriber is one subscriber of a poll loop
+type watchSubscriber[T any] struct {
+	ch      chan WatchUpdate[T]
+	dropped atomic.Uint64
+}
+
+// send delivers update without blocking, dropping the oldest buffered
+// update when the subscriber is full. It is called with the loop locked.
+func (s *watchSubscriber[T]) send(update WatchUpdate[T]) {
+	for {
+		select {
+		case s.ch <- update:
+			return
+		default:
+		}
+		select {
+		case <-s.ch:
+			s.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// watchMux tracks the client's running poll loops by subscription key
+type watchMux struct {
+	mu     sync.Mutex
+	loops  map[string]watchCloser
+	closed bool
+}
+
+// watchCloser is a poll loop of any resource type
+type watchCloser interface {
+	close()
+}
+
+// closeAll stops every poll loop, ending their subscriptions, and makes
+// new subscriptions end at once
+func (m *watchMux) closeAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	for key, loop := range m.loops {
+		loop.close()
+		delete(m.loops, key)
+	}
+}
+
+// watchLoop polls one resource for all of its subscribers
+type watchLoop[T any] struct {
+	mux    *watchMux
+	key    string
+	cancel context.CancelFunc
+	
+	mu          sync.Mutex
+	subscribers map[*watchSubscriber[T]]struct{}
+	last        *WatchUpdate[T]
+}
+
+// watchResource subscribes to the resource polled by poll. Subscriptions
+// with the same key share a loop; the key must identify the resource and
+// every parameter of poll.
+func watchResource[T any](ctx context.Context, c *Client, key string, opts *WatchOptions, poll func(context.Context) (T, error)) *Subscription[T] {
+	interval, buffer := DefaultWatchInterval, DefaultWatchBuffer
+	if opts != nil {
+		if opts.Interval > 0 {
+			interval = opts.Interval
+		}
+		if opts.Buffer > 0 {
+			buffer = opts.Buffer
+		}
+	}
+	key = fmt.Sprintf("%s@%s/%s", key, interval, c.identity())
+	sub := &watchSubscriber[T]{ch: make(chan WatchUpdate[T], buffer)}
+	
+	mux := c.watches
+	mux.mu.Lock()
+	if mux.closed {
+		mux.mu.Unlock()
+		sub.send(WatchUpdate[T]{Err: ErrClientClosed, At: time.Now()})
+		close(sub.ch)
+		return &Subscription[T]{C: sub.ch, sub: sub, loop: &watchLoop[T]{mux: mux}, stop: func() bool { return false }}
+	}
+	loop, ok := mux.loops[key].(*watchLoop[T])
+	if !ok {
+		loopCtx, cancel := context.WithCancel(context.Background())
+		loop = &watchLoop[T]{
+			mux:         mux,
+			key:         key,
+			cancel:      cancel,
+			subscribers: make(map[*watchSubscriber[T]]struct{}),
+		}
+		if mux.loops == nil {
+			mux.loops = make(map[string]watchCloser)
+		}
+		mux.loops[key] = loop
+		go loop.run(loopCtx, interval, poll)
+	}
+	loop.subscribe(sub)
+	mux.mu.Unlock()
+	
+	s := &Subscription[T]{C: sub.ch, sub: sub, loop: loop}
+	s.stop = context.AfterFunc(ctx, func() { loop.unsubscribe(sub) })
+	return s
+}
+
+// subscribe adds sub, replaying the latest update to it
+func (l *watchLoop[T]) subscribe(sub *watchSubscriber[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.subscribers[sub] = struct{}{}
+	if l.last != nil {
+		sub.send(*l.last)
+	}
+}
+
+// unsubscribe removes sub and closes its channel, stopping the loop when
+// sub was the last subscriber. Removing a subscriber twice is a no-op.
+func (l *watchLoop[T]) unsubscribe(sub *watchSubscriber[T]) {
+	// The mux lock orders teardown against new subscriptions joining this loop
+	l.mux.mu.Lock()
+	defer l.mux.mu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	
+	if _, ok := l.subscribers[sub]; !ok {
+		return
+	}
+	delete(l.subscribers, sub)
+	close(sub.ch)
+	if len(l.subscribers) == 0 {
+		l.cancel()
+		delete(l.mux.loops, l.key)
+	}
+}
+
+// close stops the loop, ending every subscription with ErrClientClosed. It
+// is called with the mux locked.
+func (l *watchLoop[T]) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cancel()
+	for sub := range l.subscribers {
+		sub.send(WatchUpdate[T]{Err: ErrClientClosed, At: time.Now()})
+		close(sub.ch)
+		delete(l.subscribers, sub)
+	}
+}
+
+// run polls until ctx is cancelled, fanning out changes and errors
+func (l *watchLoop[T]) run(ctx context.Context, interval time.Duration, poll func(context.Context) (T, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		value, err := poll(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		l.publish(WatchUpdate[T]{Value: value, Err: err, At: time.Now()})
+		
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish sends update to every subscriber unless it repeats the last value
+func (l *watchLoop[T]) publish(update WatchUpdate[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if update.Err == nil && l.last != nil && l.last.Err == nil && reflect.DeepEqual(l.last.Value, update.Value) {
+		return
+	}
+	l.last = &update
+	for sub := range l.subscribers {
+		sub.send(update)
+	}
+}
+
+// WatchStats polls a project's statistics, e.g. to follow tier progress,
+// and delivers every change. Identical watches on the client share one poll
+// loop. The subscription ends when ctx is done or it is closed.
+func (ps *ProjectsService) WatchStats(ctx context.Context, projectID string, opts *WatchOptions) *Subscription[ProjectStats] {
+	return watchResource(ctx, ps.client, "project-stats:"+projectID, opts, func(ctx context.Context) (ProjectStats, error) {
+		stats, err := ps.GetStats(ctx, projectID)
+		return *stats, err
+	})
+}
+
+// WatchInvestment polls an investment and delivers every change, e.g. of its
+// status. Identical watches on the client share one poll loop. The
+// subscription ends when ctx is done or it is closed.
+func (is *InvestmentsService) WatchInvestment(ctx context.Context, investmentID string, opts *WatchOptions) *Subscription[Investment] {
+	return watchResource(ctx, is.client, "investment:"+investmentID, opts, func(ctx context.Context) (Investment, error) {
+		investment, err := is.Get(ctx, investmentID)
+		return *investment, err
+	})
+}
\ No newline at end of file
@@ -0,0 +1,256 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// watchServer answers GET /investments/{id} with an amount that grows by
+// one on every request for that investment, so every poll is a change
+type watchServer struct {
+	*httptest.Server
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func newWatchServer(t *testing.T) *watchServer {
+	t.Helper()
+	ws := &watchServer{hits: map[string]int{}}
+	ws.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/investments/")
+		ws.mu.Lock()
+		ws.hits[id]++
+		n := ws.hits[id]
+		ws.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"` + id + `","amount_xrp":"` + strconv.Itoa(n) + `"}`))
+	}))
+	t.Cleanup(ws.Close)
+	return ws
+}
+
+func (ws *watchServer) count(id string) int {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.hits[id]
+}
+
+// amountOf returns the whole XRP amount of an update
+func amountOf(t *testing.T, update xrplsale.WatchUpdate[xrplsale.Investment]) int {
+	t.Helper()
+	if update.Err != nil {
+		t.Fatalf("update failed: %v", update.Err)
+	}
+	n, err := strconv.Atoi(update.Value.AmountXRP.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestWatchShared(t *testing.T) {
+	const subscribers, updates = 20, 5
+	srv := newWatchServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	opts := &xrplsale.WatchOptions{Interval: 10 * time.Millisecond, Buffer: 64}
+	
+	subs := make([]*xrplsale.Subscription[xrplsale.Investment], subscribers)
+	for i := range subs {
+		subs[i] = client.Investments.WatchInvestment(context.Background(), "inv_1", opts)
+	}
+	// One shared loop means every subscriber sees the same unbroken sequence
+	// of amounts; separate loops would each skip the others' requests
+	var wg sync.WaitGroup
+	for i, sub := range subs {
+		wg.Add(1)
+		go func(i int, sub *xrplsale.Subscription[xrplsale.Investment]) {
+			defer wg.Done()
+			prev := 0
+			for n := 0; n < updates; n++ {
+				update, ok := <-sub.C
+				if !ok {
+					t.Errorf("subscriber %d closed early", i)
+					return
+				}
+				amount := amountOf(t, update)
+				if prev != 0 && amount != prev+1 {
+					t.Errorf("subscriber %d got %d after %d, want consecutive polls of one loop", i, amount, prev)
+					return
+				}
+				prev = amount
+			}
+		}(i, sub)
+	}
+	wg.Wait()
+	if hits := srv.count("inv_1"); hits > 2*updates+subscribers/2 {
+		t.Fatalf("%d requests for %d subscribers, want them to share a poll loop", hits, subscribers)
+	}
+	
+	// A different interval is a separate loop, which polls at once
+	before := srv.count("inv_1")
+	other := client.Investments.WatchInvestment(context.Background(), "inv_1", &xrplsale.WatchOptions{Interval: time.Hour})
+	defer other.Close()
+	if amount := amountOf(t, <-other.C); amount <= before {
+		t.Fatalf("hourly watch got amount %d, want a poll of its own after %d", amount, before)
+	}
+	
+	for _, sub := range subs {
+		sub.Close()
+		sub.Close()
+		for range sub.C {
+		}
+	}
+	// Only the hourly loop remains, and it polled once; a poll already on
+	// the wire when the loop stopped may still land
+	time.Sleep(20 * time.Millisecond)
+	settled := srv.count("inv_1")
+	time.Sleep(50 * time.Millisecond)
+	if got := srv.count("inv_1"); got != settled {
+		t.Fatalf("%d requests after the last subscriber left, want the loop stopped at %d", got, settled)
+	}
+}
+
+func TestWatchSlowSubscriber(t *testing.T) {
+	srv := newWatchServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	interval := 5 * time.Millisecond
+	slow := client.Investments.WatchInvestment(context.Background(), "inv_1", &xrplsale.WatchOptions{Interval: interval, Buffer: 2})
+	defer slow.Close()
+	fast := client.Investments.WatchInvestment(context.Background(), "inv_1", &xrplsale.WatchOptions{Interval: interval, Buffer: 2})
+	defer fast.Close()
+	
+	// Nobody reads slow while fast keeps up
+	prev := 0
+	for n := 0; n < 10; n++ {
+		select {
+		case update := <-fast.C:
+			amount := amountOf(t, update)
+			if prev != 0 && amount != prev+1 {
+				t.Fatalf("fast subscriber got %d after %d", amount, prev)
+			}
+			prev = amount
+		case <-time.After(5 * time.Second):
+			t.Fatal("a full subscriber stalled the others")
+		}
+	}
+	if slow.Dropped() == 0 {
+		t.Fatal("Dropped() = 0 for a subscriber that never read")
+	}
+	if fast.Dropped() != 0 {
+		t.Fatalf("Dropped() = %d for a subscriber that kept up", fast.Dropped())
+	}
+	// The slow subscriber kept the newest updates
+	slow.Close()
+	var kept []int
+	for update := range slow.C {
+		kept = append(kept, amountOf(t, update))
+	}
+	if len(kept) != 2 || kept[1] != kept[0]+1 || kept[0] < prev-2 {
+		t.Fatalf("slow subscriber kept %v, want the two newest of %d", kept, prev)
+	}
+}
+
+func TestWatchEnds(t *testing.T) {
+	srv := newWatchServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	opts := &xrplsale.WatchOptions{Interval: time.Hour}
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := client.Investments.WatchInvestment(ctx, "inv_1", opts)
+	closed := client.Investments.WatchInvestment(context.Background(), "inv_2", opts)
+	cancel()
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+	late := client.Investments.WatchInvestment(context.Background(), "inv_3", opts)
+	
+	tests := []struct {
+		name    string
+		sub     *xrplsale.Subscription[xrplsale.Investment]
+		wantErr error
+	}{
+		{"context done", cancelled, nil},
+		{"client closed", closed, xrplsale.ErrClientClosed},
+		{"after close", late, xrplsale.ErrClientClosed},
+	}
+	for _, tt := range tests {
+		var last error
+		timeout := time.After(5 * time.Second)
+	read:
+		for {
+			select {
+			case update, ok := <-tt.sub.C:
+				if !ok {
+					break read
+				}
+				last = update.Err
+			case <-timeout:
+				t.Fatalf("%s: subscription still open", tt.name)
+			}
+		}
+		if tt.wantErr != nil && !errors.Is(last, tt.wantErr) {
+			t.Errorf("%s: last update error %v, want %v", tt.name, last, tt.wantErr)
+		}
+		tt.sub.Close()
+	}
+}
+
+func TestWatchChurn(t *testing.T) {
+	const workers, cycles = 50, 10
+	srv := newWatchServer(t)
+	client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+	opts := &xrplsale.WatchOptions{Interval: time.Millisecond, Buffer: 1}
+	ids := []string{"inv_a", "inv_b", "inv_c"}
+	
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for c := 0; c < cycles; c++ {
+				ctx, cancel := context.WithCancel(context.Background())
+				sub := client.Investments.WatchInvestment(ctx, ids[(w+c)%len(ids)], opts)
+				if c%2 == 0 {
+					<-sub.C
+				}
+				// Half end by context, half by Close, some by both
+				if (w+c)%2 == 0 {
+					cancel()
+				} else {
+					sub.Close()
+				}
+				if c%3 == 0 {
+					sub.Close()
+					cancel()
+				}
+				for range sub.C {
+				}
+				cancel()
+			}
+		}(w)
+	}
+	wg.Wait()
+	
+	// Every loop stopped with its last subscriber, once polls already on
+	// the wire have landed
+	time.Sleep(20 * time.Millisecond)
+	settled := map[string]int{}
+	for _, id := range ids {
+		settled[id] = srv.count(id)
+	}
+	time.Sleep(50 * time.Millisecond)
+	for _, id := range ids {
+		if got := srv.count(id); got != settled[id] {
+			t.Errorf("%s polled %d more times after every subscriber left", id, got-settled[id])
+		}
+	}
+}
\ No newline at end of file
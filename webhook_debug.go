@@ -0,0 +1,177 @@
+package xrplsale
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// signaturePrefix precedes the hex HMAC in webhook signature headers
+const signaturePrefix = "sha256="
+
+// VerificationStep identifies where webhook signature verification failed
+type VerificationStep string
+
+const (
+	VerifyOK       VerificationStep = "ok"
+	VerifyHeader   VerificationStep = "header"
+	VerifyPayload  VerificationStep = "payload"
+	VerifySecret   VerificationStep = "secret"
+	VerifyMismatch VerificationStep = "mismatch"
+)
+
+// VerificationDiagnostic explains the outcome of a webhook signature check.
+// It never contains the expected signature.
+type VerificationDiagnostic struct {
+	Valid bool
+	Step  VerificationStep
+	
+	// Problem describes what failed in one sentence
+	Problem string
+	
+	// Suggestions lists likely causes to check
+	Suggestions []string
+	
+	PayloadLength int
+	
+	// PayloadSHA256 is the hex SHA-256 of the payload as received, to compare
+	// with the body the platform reports sending
+	PayloadSHA256 string
+}
+
+// payloadVariant is a transformation a web framework commonly applies to a body
+type payloadVariant struct {
+	problem string
+	apply   func([]byte) ([]byte, bool)
+}
+
+var payloadVariants = []payloadVariant{
+	{"the payload has a UTF-8 byte order mark the platform did not send", func(p []byte) ([]byte, bool) {
+		trimmed := bytes.TrimPrefix(p, []byte("\xef\xbb\xbf"))
+		return trimmed, len(trimmed) != len(p)
+	}},
+	{"the payload has a trailing newline the platform did not send", func(p []byte) ([]byte, bool) {
+		trimmed := bytes.TrimRight(p, "\r\n")
+		return trimmed, len(trimmed) != len(p)
+	}},
+	{"the payload is missing the trailing newline the platform sent", func(p []byte) ([]byte, bool) {
+		return append(append([]byte{}, p...), '\n'), true
+	}},
+	{"the payload was re-serialized from parsed JSON instead of using the raw body", func(p []byte) ([]byte, bool) {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, p); err != nil {
+			return nil, false
+		}
+		return compact.Bytes(), !bytes.Equal(compact.Bytes(), p)
+	}},
+}
+
+// DebugVerify checks a webhook signature like VerifyWebhookSignature but
+// reports why verification failed, to help debug an integration. It returns
+// an error only if secret is empty.
+func DebugVerify(secret string, payload []byte, header string) (*VerificationDiagnostic, error) {
+	if secret == "" {
+		return nil, errors.New("webhook secret is empty")
+	}
+	
+	sum := sha256.Sum256(payload)
+	diag := &VerificationDiagnostic{
+		PayloadLength: len(payload),
+		PayloadSHA256: hex.EncodeToString(sum[:]),
+	}
+	
+	if trimmed := strings.TrimSpace(header); trimmed != header && trimmed != "" {
+		diag.Step = VerifyHeader
+		diag.Problem = "the signature header has leading or trailing whitespace; it is compared exactly"
+		diag.Suggestions = []string{
+			"check that the header value is not read from a padded or line-wrapped source",
+			"check that a proxy is not rewriting the header",
+		}
+		return diag, nil
+	}
+	
+	got, problem := parseSignatureHeader(header)
+	if problem != "" {
+		diag.Step = VerifyHeader
+		diag.Problem = problem
+		diag.Suggestions = []string{
+			"pass the value of the signature header unchanged, including the sha256= prefix",
+			"check that a proxy is not stripping or rewriting the header",
+		}
+		return diag, nil
+	}
+	
+	if signatureMatches(secret, payload, got) {
+		diag.Valid = true
+		diag.Step = VerifyOK
+		return diag, nil
+	}
+	
+	for _, variant := range payloadVariants {
+		if altered, ok := variant.apply(payload); ok && signatureMatches(secret, altered, got) {
+			diag.Step = VerifyPayload
+			diag.Problem = variant.problem
+			diag.Suggestions = []string{"verify the raw request body bytes before any parsing or decoding"}
+			return diag, nil
+		}
+	}
+	
+	if trimmed := strings.TrimSpace(secret); trimmed != secret && signatureMatches(trimmed, payload, got) {
+		diag.Step = VerifySecret
+		diag.Problem = "the configured secret has leading or trailing whitespace"
+		diag.Suggestions = []string{"trim the secret when loading it from a file or environment variable"}
+		return diag, nil
+	}
+	
+	diag.Step = VerifyMismatch
+	diag.Problem = "the signature does not match the payload"
+	diag.Suggestions = []string{
+		"check the secret belongs to this webhook and environment (testnet and production differ)",
+		"check the secret was not rotated since the delivery was sent",
+		fmt.Sprintf("compare the payload SHA-256 %s with the delivery log", diag.PayloadSHA256),
+	}
+	return diag, nil
+}
+
+// DebugVerifyWebhookSignature runs DebugVerify with the client's webhook secret
+func (c *Client) DebugVerifyWebhookSignature(payload []byte, signature string) (*VerificationDiagnostic, error) {
+	return DebugVerify(c.config.webhookSecret().Reveal(), payload, signature)
+}
+
+// parseSignatureHeader decodes the HMAC from a signature header, or
+// describes why it cannot. Like VerifyWebhookSignature it takes the header
+// exactly as received.
+func parseSignatureHeader(header string) ([]byte, string) {
+	if strings.TrimSpace(header) == "" {
+		return nil, "the signature header is empty"
+	}
+	if !strings.HasPrefix(header, signaturePrefix) {
+		if scheme, _, ok := strings.Cut(header, "="); ok && !strings.ContainsAny(scheme, " ,") {
+			return nil, fmt.Sprintf("the signature uses scheme %q, want sha256", scheme)
+		}
+		return nil, "the signature header is missing the sha256= prefix"
+	}
+	
+	encoded := strings.TrimPrefix(header, signaturePrefix)
+	sig, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, "the signature is not valid hex"
+	}
+	if len(sig) != sha256.Size {
+		return nil, fmt.Sprintf("the signature is %d bytes, want %d", len(sig), sha256.Size)
+	}
+	if encoded != strings.ToLower(encoded) {
+		return nil, "the signature hex is upper-case; it is compared exactly"
+	}
+	return sig, ""
+}
+
+// signatureMatches reports whether sig is the HMAC-SHA256 of payload under secret
+func signatureMatches(secret string, payload, sig []byte) bool {
+	return hmac.Equal(hmacSHA256(Secret(secret), payload), sig)
+}
\ No newline at end of file
@@ -0,0 +1,162 @@
+package xrplsale_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// sign returns the signature header for payload under secret
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestDebugVerify(t *testing.T) {
+	const secret = "whsec_test"
+	payload := []byte(`{"id":"evt_1","type":"project.launched"}`)
+	valid := sign(secret, payload)
+	pretty := []byte("{\n  \"id\": \"evt_1\",\n  \"type\": \"project.launched\"\n}")
+	
+	tests := []struct {
+		name        string
+		secret      string
+		payload     []byte
+		header      string
+		wantStep    xrplsale.VerificationStep
+		wantProblem string
+	}{
+		{"valid", secret, payload, valid, xrplsale.VerifyOK, ""},
+		{"empty header", secret, payload, "", xrplsale.VerifyHeader, "empty"},
+		{"blank header", secret, payload, " \t", xrplsale.VerifyHeader, "empty"},
+		{"leading whitespace", secret, payload, " " + valid, xrplsale.VerifyHeader, "whitespace"},
+		{"trailing newline in header", secret, payload, valid + "\r\n", xrplsale.VerifyHeader, "whitespace"},
+		{"other scheme", secret, payload, "sha1=abcdef", xrplsale.VerifyHeader, `scheme "sha1"`},
+		{"missing prefix", secret, payload, strings.TrimPrefix(valid, "sha256="), xrplsale.VerifyHeader, "prefix"},
+		{"not hex", secret, payload, "sha256=xyz", xrplsale.VerifyHeader, "hex"},
+		{"truncated", secret, payload, valid[:len(valid)-2], xrplsale.VerifyHeader, "bytes"},
+		{"upper-case hex", secret, payload, "sha256=" + strings.ToUpper(strings.TrimPrefix(valid, "sha256=")), xrplsale.VerifyHeader, "upper-case"},
+		{"byte order mark", secret, append([]byte("\xef\xbb\xbf"), payload...), valid, xrplsale.VerifyPayload, "byte order mark"},
+		{"added newline", secret, append(append([]byte{}, payload...), '\n'), valid, xrplsale.VerifyPayload, "has a trailing newline"},
+		{"dropped newline", secret, payload, sign(secret, append(append([]byte{}, payload...), '\n')), xrplsale.VerifyPayload, "missing the trailing newline"},
+		{"re-serialized", secret, pretty, valid, xrplsale.VerifyPayload, "re-serialized"},
+		{"padded secret", secret + "\n", payload, valid, xrplsale.VerifySecret, "whitespace"},
+		{"wrong secret", "whsec_other", payload, valid, xrplsale.VerifyMismatch, "does not match"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diag, err := xrplsale.DebugVerify(tt.secret, tt.payload, tt.header)
+			if err != nil {
+				t.Fatalf("DebugVerify() = %v", err)
+			}
+			if diag.Step != tt.wantStep || !strings.Contains(diag.Problem, tt.wantProblem) {
+				t.Fatalf("DebugVerify() = %s %q, want %s %q", diag.Step, diag.Problem, tt.wantStep, tt.wantProblem)
+			}
+			if diag.Valid != (tt.wantStep == xrplsale.VerifyOK) || (diag.Step != xrplsale.VerifyOK && len(diag.Suggestions) == 0) {
+				t.Fatalf("Valid = %t with suggestions %q", diag.Valid, diag.Suggestions)
+			}
+			
+			// The diagnostic agrees with the real check, whichever field holds the secret
+			for _, config := range []*xrplsale.Config{
+				{APIKey: "key", WebhookSecret: tt.secret},
+				{APIKey: "key", WebhookSecret: "whsec_stale", WebhookSigningSecret: xrplsale.Secret(tt.secret)},
+			} {
+				client := xrplsale.NewClientWithConfig(config)
+				if verified := client.VerifyWebhookSignature(tt.payload, tt.header); verified != diag.Valid {
+					t.Fatalf("VerifyWebhookSignature() = %t, DebugVerify() Valid = %t", verified, diag.Valid)
+				}
+				if debug, err := client.DebugVerifyWebhookSignature(tt.payload, tt.header); err != nil || debug.Step != diag.Step {
+					t.Fatalf("DebugVerifyWebhookSignature() = %+v, %v; want step %s", debug, err, diag.Step)
+				}
+			}
+			
+			expected := strings.TrimPrefix(sign(tt.secret, tt.payload), "sha256=")
+			if expected != strings.TrimPrefix(tt.header, "sha256=") && strings.Contains(fmt.Sprintf("%+v", diag), expected) {
+				t.Fatal("the diagnostic reveals the expected signature")
+			}
+		})
+	}
+	
+	if _, err := xrplsale.DebugVerify("", payload, valid); err == nil {
+		t.Fatal("DebugVerify() with an empty secret succeeded")
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	const secret = "whsec_test"
+	payload := []byte(`{"id":"evt_1","type":"project.launched","data":{"project_id":"proj_1"}}`)
+	notJSON := []byte("not json")
+	errHandle := errors.New("database down")
+	
+	tests := []struct {
+		name        string
+		method      string
+		body        []byte
+		header      string
+		diagnose    bool
+		handleErr   error
+		wantStatus  int
+		wantHandled bool
+		wantLog     string
+	}{
+		{"valid", http.MethodPost, payload, sign(secret, payload), false, nil, http.StatusOK, true, ""},
+		{"bad signature", http.MethodPost, payload, sign("whsec_other", payload), false, nil, http.StatusUnauthorized, false, ""},
+		{"bad signature diagnosed", http.MethodPost, payload, sign("whsec_other", payload), true, nil, http.StatusUnauthorized, false, "step mismatch"},
+		{"padded header diagnosed", http.MethodPost, payload, sign(secret, payload) + " ", true, nil, http.StatusUnauthorized, false, "whitespace"},
+		{"handler error", http.MethodPost, payload, sign(secret, payload), false, errHandle, http.StatusInternalServerError, true, "database down"},
+		{"invalid payload", http.MethodPost, notJSON, sign(secret, notJSON), false, nil, http.StatusBadRequest, false, ""},
+		{"too large", http.MethodPost, bytes.Repeat([]byte("x"), 1<<20+1), "", false, nil, http.StatusRequestEntityTooLarge, false, ""},
+		{"wrong method", http.MethodGet, nil, "", false, nil, http.StatusMethodNotAllowed, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &bufferLogger{}
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", WebhookSecret: secret, Logger: logger})
+			var handled *xrplsale.WebhookEvent
+			var opts []xrplsale.WebhookHandlerOption
+			if tt.diagnose {
+				opts = append(opts, xrplsale.WithRejectDiagnostics())
+			}
+			handler := client.WebhookHandler(func(_ context.Context, event *xrplsale.WebhookEvent) error {
+				handled = event
+				return tt.handleErr
+			}, opts...)
+			
+			req := httptest.NewRequest(tt.method, "/webhooks", bytes.NewReader(tt.body))
+			req.Header.Set(xrplsale.WebhookSignatureHeader, tt.header)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if (handled != nil) != tt.wantHandled {
+				t.Fatalf("handled = %+v, want handled %t", handled, tt.wantHandled)
+			}
+			if handled != nil && (handled.ID != "evt_1" || handled.Type != xrplsale.EventProjectLaunched) {
+				t.Fatalf("handled %+v, want evt_1", handled)
+			}
+			output := logger.String()
+			if tt.wantLog == "" && output != "" {
+				t.Fatalf("logged %q, want nothing", output)
+			}
+			if !strings.Contains(output, tt.wantLog) {
+				t.Fatalf("logged %q, want %q", output, tt.wantLog)
+			}
+			if strings.Contains(output, strings.TrimPrefix(sign(secret, tt.body), "sha256=")) {
+				t.Fatal("the log reveals the expected signature")
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,40 @@
+package xrplsale
+
+import "encoding/json"
+
+// Webhook event types
+const (
+	EventInvestmentCreated            = "investment.created"
+	EventProjectLaunched              = "project.launched"
+	EventTierCompleted                = "tier.completed"
+	EventProjectAnnouncementPublished = "project.announcement_published"
+	EventDisputeUpdated               = "dispute.updated"
+)
+
+// DecodeData decodes the event's data into one of the typed event payloads
+func (e *WebhookEvent) DecodeData(v interface{}) error {
+	raw, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// AnnouncementPublishedPayload is the data of a project.announcement_published event
+type AnnouncementPublishedPayload struct {
+	ProjectID           string `json:"project_id"`
+	AnnouncementID      string `json:"announcement_id"`
+	Title               string `json:"title"`
+	Pinned              bool   `json:"pinned"`
+	NotifiedSubscribers int    `json:"notified_subscribers"`
+}
+
+// DisputeUpdatedPayload is the data of a dispute.updated event
+type DisputeUpdatedPayload struct {
+	DisputeID      string        `json:"dispute_id"`
+	InvestmentID   string        `json:"investment_id"`
+	ProjectID      string        `json:"project_id"`
+	Status         DisputeStatus `json:"status"`
+	PreviousStatus DisputeStatus `json:"previous_status"`
+	Resolution     string        `json:"resolution,omitempty"`
+}
\ No newline at end of file
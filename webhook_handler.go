@@ -0,0 +1,99 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WebhookSignatureHeader carries the signature of a webhook delivery
+const WebhookSignatureHeader = "X-XRPL-Sale-Signature"
+
+// maxWebhookBytes caps the body of a webhook delivery read by WebhookHandler
+const maxWebhookBytes = 1 << 20
+
+// WebhookEventHandler handles a verified webhook event. Returning an error
+// answers the delivery with a 500, so the platform sends it again.
+type WebhookEventHandler func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookHandlerOption configures a WebhookHandler
+type WebhookHandlerOption func(*webhookHandler)
+
+// WithRejectDiagnostics logs a DebugVerify diagnostic at debug level, through
+// Config.Logger, for every delivery rejected for its signature
+func WithRejectDiagnostics() WebhookHandlerOption {
+	return func(h *webhookHandler) {
+		h.diagnose = true
+	}
+}
+
+// webhookHandler is the http.Handler returned by WebhookHandler
+type webhookHandler struct {
+	client   *Client
+	handle   WebhookEventHandler
+	diagnose bool
+}
+
+// WebhookHandler returns an http.Handler that verifies each webhook delivery
+// with VerifyWebhookSignature, parses it and passes the event to handle.
+// Deliveries with an invalid signature get a 401 and never reach handle.
+func (c *Client) WebhookHandler(handle WebhookEventHandler, opts ...WebhookHandlerOption) http.Handler {
+	h := &webhookHandler{client: c, handle: handle}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	
+	signature := r.Header.Get(WebhookSignatureHeader)
+	if !h.client.VerifyWebhookSignature(body, signature) {
+		if h.diagnose {
+			h.logDiagnostic(body, signature)
+		}
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	
+	event, err := h.client.ParseWebhookEvent(body)
+	if err != nil {
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+	if err := h.handle(r.Context(), event); err != nil {
+		h.client.logger.Errorf("handling webhook event %s (%s): %v", event.ID, event.Type, err)
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// logDiagnostic logs why a delivery's signature was rejected
+func (h *webhookHandler) logDiagnostic(body []byte, signature string) {
+	diag, err := h.client.DebugVerifyWebhookSignature(body, signature)
+	if err != nil {
+		h.client.logger.Debugf("webhook signature rejected: %v", err)
+		return
+	}
+	h.client.logger.Debugf("webhook signature rejected at step %s: %s (payload %d bytes, sha256 %s); check: %s",
+		diag.Step, diag.Problem, diag.PayloadLength, diag.PayloadSHA256, strings.Join(diag.Suggestions, "; "))
+}
\ No newline at end of file
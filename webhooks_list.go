@@ -0,0 +1,42 @@
+package xrplsale
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// webhookPageSize is the page size List uses to fetch every webhook
+const webhookPageSize = 100
+
+// decodeWebhookPage decodes a webhook list in the paginated envelope or in
+// the deprecated bare array shape, which is returned as a single page
+func (c *Client) decodeWebhookPage(resp *Response) (*PaginatedResponse[Webhook], error) {
+	if len(bytes.TrimSpace(resp.Body)) == 0 {
+		return &PaginatedResponse[Webhook]{}, nil
+	}
+	if !resp.isArray() {
+		var page PaginatedResponse[Webhook]
+		if err := resp.Decode(&page); err != nil {
+			return nil, err
+		}
+		return &page, nil
+	}
+	
+	c.legacyWebhookListOnce.Do(func() {
+		c.logger.Warnf("GET /webhooks returned a bare array; this response shape is deprecated and the API is moving to the paginated envelope")
+		c.events.deprecation("GET /webhooks", map[string]string{"method": http.MethodGet, "reason": "bare array response"})
+	})
+	var webhooks []Webhook
+	if err := resp.Decode(&webhooks); err != nil {
+		return nil, err
+	}
+	return &PaginatedResponse[Webhook]{
+		Data: webhooks,
+		Pagination: Pagination{
+			Page:       1,
+			Limit:      len(webhooks),
+			Total:      len(webhooks),
+			TotalPages: 1,
+		},
+	}, nil
+}
\ No newline at end of file
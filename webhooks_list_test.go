@@ -0,0 +1,104 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+)
+
+func TestListWebhooksShapes(t *testing.T) {
+	var webhooks []xrplsale.Webhook
+	for i := 0; i < 250; i++ {
+		webhooks = append(webhooks, *fixtures.Webhook("investment.created"))
+	}
+	tests := []struct {
+		name string
+		// legacy serves every webhook as a bare array, ignoring the page
+		legacy        bool
+		wantRequests  int32
+		wantPage1     int
+		wantWarnings  int
+		wantPageTotal int
+	}{
+		{"paginated envelope", false, 3, 100, 0, 3},
+		{"legacy bare array", true, 1, 250, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				if tt.legacy {
+					json.NewEncoder(w).Encode(webhooks)
+					return
+				}
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+				json.NewEncoder(w).Encode(fixtures.Page(webhooks, page, limit))
+			}))
+			defer srv.Close()
+			logger := &bufferLogger{}
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, Logger: logger})
+			events := client.Events()
+			
+			// List twice: the deprecation is reported once per client
+			for i := 0; i < 2; i++ {
+				got, err := client.Webhooks.List(context.Background())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(got) != len(webhooks) || got[0].ID != webhooks[0].ID || got[len(got)-1].ID != webhooks[len(webhooks)-1].ID {
+					t.Fatalf("List() returned %d webhooks, want all %d in order", len(got), len(webhooks))
+				}
+			}
+			if got := requests.Load(); got != 2*tt.wantRequests {
+				t.Errorf("%d requests for two lists, want %d", got, 2*tt.wantRequests)
+			}
+			
+			page, err := client.Webhooks.ListPaged(context.Background(), 1, 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page.Data) != tt.wantPage1 || page.Pagination.TotalPages != tt.wantPageTotal || page.Pagination.Total != len(webhooks) {
+				t.Fatalf("ListPaged() = %d webhooks, %+v; want %d of %d pages", len(page.Data), page.Pagination, tt.wantPage1, tt.wantPageTotal)
+			}
+			
+			if got := strings.Count(logger.String(), "deprecated"); got != tt.wantWarnings {
+				t.Errorf("%d deprecation warnings logged, want %d:\n%s", got, tt.wantWarnings, logger)
+			}
+			deprecations := 0
+			for len(events) > 0 {
+				if event := <-events; event.Kind == xrplsale.ClientEventDeprecation && event.Details["route"] == "GET /webhooks" {
+					deprecations++
+				}
+			}
+			if deprecations != tt.wantWarnings {
+				t.Errorf("%d deprecation events, want %d", deprecations, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestListWebhooksEmpty(t *testing.T) {
+	for _, body := range []string{``, `[]`, `{"data":[],"pagination":{"page":1,"limit":100,"total":0,"total_pages":0}}`} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body))
+		}))
+		client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL})
+		got, err := client.Webhooks.List(context.Background())
+		srv.Close()
+		if err != nil || len(got) != 0 {
+			t.Fatalf("List() on %q = %d webhooks, %v; want none", body, len(got), err)
+		}
+	}
+}
\ No newline at end of file
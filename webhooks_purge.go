@@ -0,0 +1,273 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultPurgeMaxDeletions caps the deliveries PurgeDeliveries deletes per call
+	DefaultPurgeMaxDeletions = 1000
+	
+	defaultPurgePageSize  = 100
+	maxRateLimitedRetries = 3
+)
+
+// PurgeOptions controls PurgeDeliveries
+type PurgeOptions struct {
+	// DryRun counts what would be deleted without deleting anything
+	DryRun bool
+	
+	// MaxDeletions stops the purge once this many deliveries are selected for
+	// deletion; run it again to continue. Defaults to DefaultPurgeMaxDeletions.
+	MaxDeletions int
+	
+	// PageSize is the number of deliveries listed and deleted per request.
+	// Defaults to 100.
+	PageSize int
+	
+	// OnProgress is called after each page is processed
+	OnProgress func(PurgeProgress)
+}
+
+// PurgeProgress reports how far a purge has got
+type PurgeProgress struct {
+	Counted int
+	Deleted int
+	Skipped int
+	Failed  int
+}
+
+// PurgeFailure is a delivery that could not be deleted
+type PurgeFailure struct {
+	ID  string
+	Err error
+}
+
+// PurgeResult reports what PurgeDeliveries did
+type PurgeResult struct {
+	DryRun bool
+	
+	// Counted is the number of deliveries older than the cutoff that were examined
+	Counted int
+	
+	// Deleted lists the deleted deliveries, or those that would be in dry-run mode
+	Deleted []string
+	
+	// Skipped lists deliveries kept because the platform will still retry them
+	Skipped []string
+	
+	Failed []PurgeFailure
+	
+	// CapReached is set when MaxDeletions stopped the purge early
+	CapReached bool
+}
+
+// purgeDeliveriesRequest is the body of the bulk delete endpoint
+type purgeDeliveriesRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// purgeDeliveriesResponse is the bulk delete endpoint's report
+type purgeDeliveriesResponse struct {
+	Deleted []string `json:"deleted"`
+	Skipped []string `json:"skipped"`
+}
+
+// PurgeDeliveries deletes a webhook's delivery logs created before olderThan,
+// e.g. to enforce a retention policy. Deliveries the platform will still
+// retry are skipped. Deletion uses the bulk endpoint, falling back to
+// deleting one delivery at a time where it is unavailable, and waits out
+// rate limits. An error is returned only if listing fails or the operation
+// times out; the result then holds the work done so far.
+func (ws *WebhooksService) PurgeDeliveries(ctx context.Context, webhookID string, olderThan time.Time, opts *PurgeOptions, reqOpts ...RequestOption) (*PurgeResult, error) {
+	if opts == nil {
+		opts = &PurgeOptions{}
+	}
+	maxDeletions := opts.MaxDeletions
+	if maxDeletions <= 0 {
+		maxDeletions = DefaultPurgeMaxDeletions
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPurgePageSize
+	}
+	
+	ctx, cancel := operationContext(ctx, reqOpts)
+	defer cancel()
+	
+	result := &PurgeResult{DryRun: opts.DryRun}
+	p := &deliveryPurger{ws: ws, webhookID: webhookID, reqOpts: reqOpts, bulk: true}
+	
+	// Deleting shifts later pages forward, so deliveries that remain (skipped
+	// or failed) are stepped over with an offset instead of a page number
+	offset := 0
+	selected := 0
+	for !result.CapReached {
+		page, err := p.list(ctx, olderThan, offset, pageSize)
+		if err != nil {
+			return result, err
+		}
+		
+		var ids []string
+		for _, d := range page {
+			if !d.CreatedAt.Before(olderThan) {
+				offset++
+				continue
+			}
+			if selected == maxDeletions {
+				result.CapReached = true
+				break
+			}
+			result.Counted++
+			if d.PendingRetry() {
+				result.Skipped = append(result.Skipped, d.ID)
+				offset++
+				continue
+			}
+			ids = append(ids, d.ID)
+			selected++
+		}
+		
+		if opts.DryRun {
+			result.Deleted = append(result.Deleted, ids...)
+			offset += len(ids)
+		} else if len(ids) > 0 {
+			deleted, skipped, failed := p.delete(ctx, ids)
+			result.Deleted = append(result.Deleted, deleted...)
+			result.Skipped = append(result.Skipped, skipped...)
+			result.Failed = append(result.Failed, failed...)
+			offset += len(skipped) + len(failed)
+		}
+		
+		if opts.OnProgress != nil {
+			opts.OnProgress(PurgeProgress{
+				Counted: result.Counted,
+				Deleted: len(result.Deleted),
+				Skipped: len(result.Skipped),
+				Failed:  len(result.Failed),
+			})
+		}
+		if len(page) < pageSize {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// deliveryPurger lists and deletes the deliveries of one webhook
+type deliveryPurger struct {
+	ws        *WebhooksService
+	webhookID string
+	reqOpts   []RequestOption
+	
+	// bulk is cleared once the bulk endpoint turns out to be unavailable
+	bulk bool
+}
+
+// list returns up to limit deliveries created before olderThan, starting at offset
+func (p *deliveryPurger) list(ctx context.Context, olderThan time.Time, offset, limit int) ([]WebhookDelivery, error) {
+	params := map[string]string{
+		"created_before": olderThan.UTC().Format(time.RFC3339),
+		"offset":         fmt.Sprintf("%d", offset),
+		"limit":          fmt.Sprintf("%d", limit),
+	}
+	
+	var page PaginatedResponse[WebhookDelivery]
+	err := withRateLimitRetry(ctx, func() error {
+		return p.ws.client.Get(ctx, fmt.Sprintf("/webhooks/%s/deliveries", p.webhookID), params, &page, p.reqOpts...)
+	})
+	return page.Data, err
+}
+
+// delete deletes ids, in bulk where possible
+func (p *deliveryPurger) delete(ctx context.Context, ids []string) (deleted, skipped []string, failed []PurgeFailure) {
+	if p.bulk {
+		var resp purgeDeliveriesResponse
+		err := withRateLimitRetry(ctx, func() error {
+			return p.ws.client.Post(ctx, fmt.Sprintf("/webhooks/%s/deliveries/purge", p.webhookID),
+				&purgeDeliveriesRequest{IDs: ids}, &resp, p.reqOpts...)
+		})
+		if err == nil {
+			return resp.Deleted, resp.Skipped, unreported(ids, resp)
+		}
+		if !endpointUnavailable(err) {
+			for _, id := range ids {
+				failed = append(failed, PurgeFailure{ID: id, Err: err})
+			}
+			return nil, nil, failed
+		}
+		p.bulk = false
+	}
+	
+	for _, id := range ids {
+		err := withRateLimitRetry(ctx, func() error {
+			return p.ws.client.Delete(ctx, fmt.Sprintf("/webhooks/%s/deliveries/%s", p.webhookID, id), nil, p.reqOpts...)
+		})
+		if err != nil {
+			failed = append(failed, PurgeFailure{ID: id, Err: err})
+			continue
+		}
+		deleted = append(deleted, id)
+	}
+	return deleted, nil, failed
+}
+
+// unreported returns a failure for each of ids the bulk endpoint neither
+// deleted nor skipped
+func unreported(ids []string, resp purgeDeliveriesResponse) []PurgeFailure {
+	reported := make(map[string]bool, len(resp.Deleted)+len(resp.Skipped))
+	for _, id := range resp.Deleted {
+		reported[id] = true
+	}
+	for _, id := range resp.Skipped {
+		reported[id] = true
+	}
+	
+	var failed []PurgeFailure
+	for _, id := range ids {
+		if !reported[id] {
+			failed = append(failed, PurgeFailure{ID: id, Err: errors.New("not deleted by the bulk endpoint")})
+		}
+	}
+	return failed
+}
+
+// endpointUnavailable reports whether err means the API does not offer the endpoint
+func endpointUnavailable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed
+}
+
+// withRateLimitRetry calls fn, waiting out the API's Retry-After and trying
+// again when it is rate limited
+func withRateLimitRetry(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		var rateErr *RateLimitError
+		if attempt == maxRateLimitedRetries || !errors.As(err, &rateErr) {
+			return err
+		}
+		
+		wait := rateErr.RetryAfter
+		if wait <= 0 {
+			wait = time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,209 @@
+package xrplsale_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// deliveryServer stores the deliveries of webhook wh_1 and serves the
+// list, bulk purge and single delete endpoints
+type deliveryServer struct {
+	mu          sync.Mutex
+	deliveries  []xrplsale.WebhookDelivery
+	noBulk      bool
+	failDelete  string
+	rateLimited int
+	deletes     int
+}
+
+func newDeliveryServer(cutoff time.Time) *deliveryServer {
+	s := &deliveryServer{}
+	for i := 0; i < 13; i++ {
+		d := xrplsale.WebhookDelivery{
+			ID:        fmt.Sprintf("dlv_%02d", i),
+			WebhookID: "wh_1",
+			Status:    "delivered",
+			CreatedAt: xrplsale.Timestamp{Time: cutoff.Add(time.Duration(i-10) * time.Hour)},
+		}
+		if i == 3 || i == 7 {
+			d.Status = xrplsale.DeliveryPending
+		}
+		s.deliveries = append(s.deliveries, d)
+	}
+	return s
+}
+
+// remaining returns the IDs still stored
+func (s *deliveryServer) remaining() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, d := range s.deliveries {
+		ids = append(ids, d.ID)
+	}
+	return ids
+}
+
+// remove deletes the delivery id, reporting whether it existed
+func (s *deliveryServer) remove(id string) bool {
+	for i, d := range s.deliveries {
+		if d.ID == id {
+			s.deliveries = append(s.deliveries[:i], s.deliveries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *deliveryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	const base = "/webhooks/wh_1/deliveries"
+	
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == base:
+		before, _ := time.Parse(time.RFC3339, r.URL.Query().Get("created_before"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var old []xrplsale.WebhookDelivery
+		for _, d := range s.deliveries {
+			if d.CreatedAt.Before(before) {
+				old = append(old, d)
+			}
+		}
+		page := []xrplsale.WebhookDelivery{}
+		if offset < len(old) {
+			page = old[offset:min(offset+limit, len(old))]
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": page, "pagination": map[string]int{"total": len(old)}})
+	
+	case r.Method == http.MethodPost && r.URL.Path == base+"/purge":
+		if s.noBulk {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"not_found","message":"no such route"}}`))
+			return
+		}
+		if s.rateLimited > 0 {
+			s.rateLimited--
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":{"code":"rate_limited","message":"slow down"}}`))
+			return
+		}
+		var req struct {
+			IDs []string `json:"ids"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		deleted := []string{}
+		for _, id := range req.IDs {
+			if id != s.failDelete && s.remove(id) {
+				deleted = append(deleted, id)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"deleted": deleted, "skipped": []string{}})
+	
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, base+"/"):
+		s.deletes++
+		id := strings.TrimPrefix(r.URL.Path, base+"/")
+		if id == s.failDelete || !s.remove(id) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":{"code":"conflict","message":"cannot delete"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"not_found","message":"no such route"}}`))
+	}
+}
+
+func TestPurgeDeliveries(t *testing.T) {
+	cutoff := time.Date(2026, 7, 19, 0, 0, 0, 0, time.UTC)
+	oldDelivered := []string{"dlv_00", "dlv_01", "dlv_02", "dlv_04", "dlv_05", "dlv_06", "dlv_08", "dlv_09"}
+	pendingAndNew := []string{"dlv_03", "dlv_07", "dlv_10", "dlv_11", "dlv_12"}
+	all := append(append([]string{}, oldDelivered...), pendingAndNew...)
+	
+	tests := []struct {
+		name          string
+		opts          xrplsale.PurgeOptions
+		setup         func(*deliveryServer)
+		wantDeleted   []string
+		wantFailed    []string
+		wantRemaining []string
+		wantCap       bool
+		wantDeletes   int
+	}{
+		{"dry run", xrplsale.PurgeOptions{DryRun: true, PageSize: 3}, nil, oldDelivered, nil, all, false, 0},
+		{"bulk", xrplsale.PurgeOptions{PageSize: 3}, nil, oldDelivered, nil, pendingAndNew, false, 0},
+		{"one page", xrplsale.PurgeOptions{}, nil, oldDelivered, nil, pendingAndNew, false, 0},
+		{"per-item fallback", xrplsale.PurgeOptions{PageSize: 3}, func(s *deliveryServer) { s.noBulk = true }, oldDelivered, nil, pendingAndNew, false, 8},
+		{"cap", xrplsale.PurgeOptions{PageSize: 3, MaxDeletions: 5}, nil, oldDelivered[:5], nil,
+			append([]string{"dlv_06", "dlv_08", "dlv_09"}, pendingAndNew...), true, 0},
+		{"failed delete", xrplsale.PurgeOptions{PageSize: 3}, func(s *deliveryServer) { s.noBulk, s.failDelete = true, "dlv_05" },
+			[]string{"dlv_00", "dlv_01", "dlv_02", "dlv_04", "dlv_06", "dlv_08", "dlv_09"}, []string{"dlv_05"},
+			append([]string{"dlv_05"}, pendingAndNew...), false, 8},
+		{"rate limited", xrplsale.PurgeOptions{PageSize: 100}, func(s *deliveryServer) { s.rateLimited = 1 }, oldDelivered, nil, pendingAndNew, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newDeliveryServer(cutoff)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			srv := httptest.NewServer(api)
+			defer srv.Close()
+			client := xrplsale.NewClientWithConfig(&xrplsale.Config{APIKey: "key", BaseURL: srv.URL, RetryWaitTime: time.Millisecond})
+			
+			var progress []xrplsale.PurgeProgress
+			opts := tt.opts
+			opts.OnProgress = func(p xrplsale.PurgeProgress) { progress = append(progress, p) }
+			result, err := client.Webhooks.PurgeDeliveries(context.Background(), "wh_1", cutoff, &opts)
+			if err != nil {
+				t.Fatalf("PurgeDeliveries() = %v", err)
+			}
+			
+			if !equalStrings(result.Deleted, tt.wantDeleted) {
+				t.Errorf("Deleted = %q, want %q", result.Deleted, tt.wantDeleted)
+			}
+			if !equalStrings(result.Skipped, []string{"dlv_03", "dlv_07"}) && !tt.wantCap {
+				t.Errorf("Skipped = %q, want the pending deliveries", result.Skipped)
+			}
+			var failed []string
+			for _, f := range result.Failed {
+				failed = append(failed, f.ID)
+			}
+			if !equalStrings(failed, tt.wantFailed) {
+				t.Errorf("Failed = %q, want %q", failed, tt.wantFailed)
+			}
+			remaining := api.remaining()
+			sort.Strings(remaining)
+			want := append([]string{}, tt.wantRemaining...)
+			sort.Strings(want)
+			if !equalStrings(remaining, want) {
+				t.Errorf("server kept %q, want %q", remaining, want)
+			}
+			if result.CapReached != tt.wantCap || result.DryRun != tt.opts.DryRun {
+				t.Errorf("CapReached = %t, DryRun = %t", result.CapReached, result.DryRun)
+			}
+			if api.deletes != tt.wantDeletes {
+				t.Errorf("%d single deletes, want %d", api.deletes, tt.wantDeletes)
+			}
+			if len(progress) == 0 || progress[len(progress)-1].Deleted != len(result.Deleted) {
+				t.Errorf("progress = %+v, want it to end at the result", progress)
+			}
+		})
+	}
+}
\ No newline at end of file
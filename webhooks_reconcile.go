@@ -0,0 +1,266 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ReconcileActionType identifies what Reconcile does to converge a webhook
+type ReconcileActionType string
+
+const (
+	ReconcileCreate ReconcileActionType = "create"
+	ReconcileUpdate ReconcileActionType = "update"
+	ReconcileDelete ReconcileActionType = "delete"
+)
+
+// ReconcileOptions controls how Reconcile converges the registered webhooks
+type ReconcileOptions struct {
+	// DryRun computes the actions without applying them
+	DryRun bool
+	
+	// DeleteUnmanaged deletes registered webhooks whose URL is not in the
+	// desired set. Without it they are only reported in ReconcileResult.Unmanaged.
+	DeleteUnmanaged bool
+}
+
+// ReconcileAction is a single change made (or planned, in dry-run mode) by Reconcile
+type ReconcileAction struct {
+	Type      ReconcileActionType
+	URL       string
+	WebhookID string
+	
+	// Changes maps each updated field to its desired value (updates only)
+	Changes map[string]interface{}
+	
+	// Webhook is the webhook returned by the API once the action is applied
+	Webhook *Webhook
+}
+
+// ReconcileResult is the structured diff between the desired and registered webhooks
+type ReconcileResult struct {
+	DryRun  bool
+	Actions []ReconcileAction
+	
+	// Unmanaged lists registered webhooks left in place because DeleteUnmanaged was not set
+	Unmanaged []*Webhook
+	
+	// Pending lists the actions not applied because an action failed or the
+	// operation timed out, starting with the one that stopped it. Running
+	// Reconcile again picks them up.
+	Pending []ReconcileAction
+}
+
+// HasChanges reports whether any action was needed
+func (r *ReconcileResult) HasChanges() bool {
+	return len(r.Actions) > 0
+}
+
+// Reconcile converges the registered webhooks on the desired set, matching by URL.
+// Missing webhooks are registered and webhooks whose events or description differ
+// are updated. If applying an action fails, the result holds the actions applied so far.
+func (ws *WebhooksService) Reconcile(ctx context.Context, desired []RegisterWebhookRequest, opts *ReconcileOptions, reqOpts ...RequestOption) (*ReconcileResult, error) {
+	if opts == nil {
+		opts = &ReconcileOptions{}
+	}
+	ctx, cancel := operationContext(ctx, reqOpts)
+	defer cancel()
+	
+	existing, err := ws.List(ctx, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	
+	plan := planReconcile(desired, existing, opts.DeleteUnmanaged)
+	result := &ReconcileResult{DryRun: opts.DryRun, Unmanaged: plan.unmanaged}
+	if opts.DryRun {
+		result.Actions = plan.actions
+		return result, nil
+	}
+	
+	for i, action := range plan.actions {
+		switch action.Type {
+		case ReconcileCreate:
+			action.Webhook, err = ws.Register(ctx, plan.requests[action.URL], reqOpts...)
+			if err == nil {
+				action.WebhookID = action.Webhook.ID
+			}
+		case ReconcileUpdate:
+			action.Webhook, err = ws.Update(ctx, action.WebhookID, action.Changes, reqOpts...)
+		case ReconcileDelete:
+			err = ws.Delete(ctx, action.WebhookID, reqOpts...)
+		}
+		if err != nil {
+			result.Pending = plan.actions[i:]
+			return result, err
+		}
+		result.Actions = append(result.Actions, action)
+	}
+	
+	return result, nil
+}
+
+// EnsureRegistered makes sure a webhook is registered for req.URL, e.g. at
+// every service start. An existing webhook is returned as is, or updated if
+// its events or description differ; one is only registered when none
+// exists. Replicas racing to register the same URL may all find none, so
+// after registering each lists again and, unless its webhook is the first
+// for the URL, deletes it and returns the first one. A conflict from the API
+// is handled the same way, without relying on the API to reject duplicates.
+func (ws *WebhooksService) EnsureRegistered(ctx context.Context, req RegisterWebhookRequest, reqOpts ...RequestOption) (*Webhook, error) {
+	ctx, cancel := operationContext(ctx, reqOpts)
+	defer cancel()
+	
+	existing, err := ws.List(ctx, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if first := firstWebhookFor(existing, req.URL); first != nil {
+		return ws.ensureMatches(ctx, first, &req, reqOpts)
+	}
+	
+	webhook, registerErr := ws.Register(ctx, &req, reqOpts...)
+	if registerErr != nil {
+		if !errors.Is(registerErr, ErrConflict) {
+			return nil, registerErr
+		}
+		webhook = nil
+	}
+	
+	existing, err = ws.List(ctx, reqOpts...)
+	if err != nil {
+		if registerErr != nil {
+			return nil, registerErr
+		}
+		return webhook, fmt.Errorf("checking for concurrent registrations of %s: %w", req.URL, err)
+	}
+	first := firstWebhookFor(existing, req.URL)
+	switch {
+	case first == nil && registerErr != nil:
+		return nil, registerErr
+	case first == nil || (webhook != nil && first.ID == webhook.ID):
+		// Ours is the first, or the list does not show it yet
+		return webhook, nil
+	}
+	if webhook != nil {
+		if err := ws.Delete(ctx, webhook.ID, reqOpts...); err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("removing duplicate webhook %s: %w", webhook.ID, err)
+		}
+	}
+	return ws.ensureMatches(ctx, first, &req, reqOpts)
+}
+
+// firstWebhookFor returns the first webhook registered for url, or nil
+func firstWebhookFor(webhooks []*Webhook, url string) *Webhook {
+	for _, webhook := range webhooks {
+		if webhook.URL == url {
+			return webhook
+		}
+	}
+	return nil
+}
+
+// ensureMatches updates webhook to match req if its fields differ
+func (ws *WebhooksService) ensureMatches(ctx context.Context, webhook *Webhook, req *RegisterWebhookRequest, reqOpts []RequestOption) (*Webhook, error) {
+	if changes := webhookChanges(webhook, req); len(changes) > 0 {
+		return ws.Update(ctx, webhook.ID, changes, reqOpts...)
+	}
+	return webhook, nil
+}
+
+// reconcilePlan holds the actions computed by planReconcile
+type reconcilePlan struct {
+	actions   []ReconcileAction
+	requests  map[string]*RegisterWebhookRequest
+	unmanaged []*Webhook
+}
+
+// planReconcile computes the actions converging existing on desired
+func planReconcile(desired []RegisterWebhookRequest, existing []*Webhook, deleteUnmanaged bool) *reconcilePlan {
+	plan := &reconcilePlan{requests: make(map[string]*RegisterWebhookRequest, len(desired))}
+	
+	byURL := make(map[string]*Webhook, len(existing))
+	for _, webhook := range existing {
+		if _, dup := byURL[webhook.URL]; dup {
+			// Only the first registration of a URL is matched; duplicates are surplus
+			plan.addRemoval(webhook, deleteUnmanaged)
+			continue
+		}
+		byURL[webhook.URL] = webhook
+	}
+	
+	for i := range desired {
+		req := &desired[i]
+		if _, seen := plan.requests[req.URL]; seen {
+			continue
+		}
+		plan.requests[req.URL] = req
+		
+		current, ok := byURL[req.URL]
+		if !ok {
+			plan.actions = append(plan.actions, ReconcileAction{Type: ReconcileCreate, URL: req.URL})
+			continue
+		}
+		
+		if changes := webhookChanges(current, req); len(changes) > 0 {
+			plan.actions = append(plan.actions, ReconcileAction{
+				Type:      ReconcileUpdate,
+				URL:       req.URL,
+				WebhookID: current.ID,
+				Changes:   changes,
+			})
+		}
+	}
+	
+	for _, webhook := range existing {
+		if _, managed := plan.requests[webhook.URL]; !managed && byURL[webhook.URL] == webhook {
+			plan.addRemoval(webhook, deleteUnmanaged)
+		}
+	}
+	
+	return plan
+}
+
+// webhookChanges returns the fields of current that differ from req
+func webhookChanges(current *Webhook, req *RegisterWebhookRequest) map[string]interface{} {
+	changes := make(map[string]interface{})
+	if !sameEvents(current.Events, req.Events) {
+		changes["events"] = req.Events
+	}
+	if current.Description != req.Description {
+		changes["description"] = req.Description
+	}
+	return changes
+}
+
+// addRemoval plans the deletion of webhook, or records it as unmanaged
+func (p *reconcilePlan) addRemoval(webhook *Webhook, deleteUnmanaged bool) {
+	if !deleteUnmanaged {
+		p.unmanaged = append(p.unmanaged, webhook)
+		return
+	}
+	p.actions = append(p.actions, ReconcileAction{
+		Type:      ReconcileDelete,
+		URL:       webhook.URL,
+		WebhookID: webhook.ID,
+	})
+}
+
+// sameEvents reports whether two event lists contain the same events in any order
+func sameEvents(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
\ No newline at end of file
@@ -0,0 +1,255 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+// actionTypes returns the types of actions, sorted
+func actionTypes(actions []xrplsale.ReconcileAction) []string {
+	types := make([]string, 0, len(actions))
+	for _, action := range actions {
+		types = append(types, string(action.Type)+" "+action.URL)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func TestReconcile(t *testing.T) {
+	desired := []xrplsale.RegisterWebhookRequest{
+		{URL: "https://a.example.com/hook", Events: []string{"investment.created"}},
+		{URL: "https://b.example.com/hook", Events: []string{"project.launched", "project.completed"}, Description: "b"},
+	}
+	
+	tests := []struct {
+		name     string
+		existing []*xrplsale.Webhook
+		opts     *xrplsale.ReconcileOptions
+		want     []string
+		wantLeft []string
+	}{
+		{"empty", nil, nil, []string{"create https://a.example.com/hook", "create https://b.example.com/hook"}, nil},
+		{"in sync", []*xrplsale.Webhook{
+			{URL: "https://a.example.com/hook", Events: []string{"investment.created"}},
+			{URL: "https://b.example.com/hook", Events: []string{"project.completed", "project.launched"}, Description: "b"},
+		}, nil, nil, nil},
+		{"drifted", []*xrplsale.Webhook{
+			{URL: "https://a.example.com/hook", Events: []string{"investment.confirmed"}},
+		}, nil, []string{"create https://b.example.com/hook", "update https://a.example.com/hook"}, nil},
+		{"unmanaged kept", []*xrplsale.Webhook{
+			{URL: "https://old.example.com/hook", Events: []string{"investment.created"}},
+		}, nil, []string{"create https://a.example.com/hook", "create https://b.example.com/hook"}, []string{"https://old.example.com/hook"}},
+		{"unmanaged deleted", []*xrplsale.Webhook{
+			{URL: "https://old.example.com/hook", Events: []string{"investment.created"}},
+			{URL: "https://a.example.com/hook", Events: []string{"investment.created"}},
+			{URL: "https://a.example.com/hook", Events: []string{"investment.created"}},
+		}, &xrplsale.ReconcileOptions{DeleteUnmanaged: true}, []string{"create https://b.example.com/hook", "delete https://a.example.com/hook", "delete https://old.example.com/hook"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := xrplsaletest.NewServer()
+			defer srv.Close()
+			srv.AddWebhooks(tt.existing...)
+			client := xrplsaletest.NewClient(srv)
+			
+			dryRun := &xrplsale.ReconcileOptions{DryRun: true}
+			if tt.opts != nil {
+				dryRun.DeleteUnmanaged = tt.opts.DeleteUnmanaged
+			}
+			planned, err := client.Webhooks.Reconcile(context.Background(), desired, dryRun)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := actionTypes(planned.Actions); !equalStrings(got, tt.want) {
+				t.Fatalf("dry run planned %q, want %q", got, tt.want)
+			}
+			if len(srv.Webhooks()) != len(tt.existing) {
+				t.Fatalf("dry run changed the webhooks: %d registered, want %d", len(srv.Webhooks()), len(tt.existing))
+			}
+			
+			result, err := client.Webhooks.Reconcile(context.Background(), desired, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := actionTypes(result.Actions); !equalStrings(got, tt.want) {
+				t.Fatalf("Reconcile() applied %q, want %q", got, tt.want)
+			}
+			var left []string
+			for _, webhook := range result.Unmanaged {
+				left = append(left, webhook.URL)
+			}
+			if !equalStrings(left, tt.wantLeft) {
+				t.Fatalf("Unmanaged = %q, want %q", left, tt.wantLeft)
+			}
+			
+			again, err := client.Webhooks.Reconcile(context.Background(), desired, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again.HasChanges() {
+				t.Fatalf("second Reconcile() applied %q, want nothing", actionTypes(again.Actions))
+			}
+		})
+	}
+}
+
+func TestReconcilePending(t *testing.T) {
+	srv := xrplsaletest.NewServer()
+	defer srv.Close()
+	client := xrplsaletest.NewClient(srv)
+	desired := []xrplsale.RegisterWebhookRequest{
+		{URL: "https://a.example.com/hook", Events: []string{"investment.created"}},
+		{URL: "https://b.example.com/hook", Events: []string{"investment.created"}},
+	}
+	srv.Fail(http.MethodPost, "/webhooks", xrplsaletest.Failure{Status: http.StatusBadRequest, Times: 1})
+	
+	result, err := client.Webhooks.Reconcile(context.Background(), desired, nil)
+	if err == nil {
+		t.Fatal("Reconcile() succeeded with a failing registration")
+	}
+	if len(result.Actions) != 0 || len(result.Pending) != 2 {
+		t.Fatalf("Reconcile() applied %d and left %d pending, want 0 and 2", len(result.Actions), len(result.Pending))
+	}
+	
+	result, err = client.Webhooks.Reconcile(context.Background(), desired, nil)
+	if err != nil || len(result.Actions) != 2 {
+		t.Fatalf("retried Reconcile() = %v with %d actions, want the 2 pending ones", err, len(result.Actions))
+	}
+}
+
+func TestEnsureRegistered(t *testing.T) {
+	const url = "https://a.example.com/hook"
+	req := xrplsale.RegisterWebhookRequest{URL: url, Events: []string{"investment.created"}}
+	winner := &xrplsale.Webhook{ID: "wh_winner", URL: url, Events: []string{"investment.created"}}
+	
+	tests := []struct {
+		name     string
+		existing []*xrplsale.Webhook
+		// race runs just before the registration is sent, as another replica
+		race     func(srv *xrplsaletest.Server)
+		wantID   string
+		wantErr  error
+		wantSent []string
+		wantLeft int
+	}{
+		{"absent", []*xrplsale.Webhook{{ID: "wh_other", URL: "https://b.example.com/hook", Events: []string{"investment.created"}}}, nil,
+			"", nil, []string{"GET /webhooks", "POST /webhooks", "GET /webhooks"}, 2},
+		{"in sync", []*xrplsale.Webhook{winner}, nil,
+			"wh_winner", nil, []string{"GET /webhooks"}, 1},
+		{"drifted", []*xrplsale.Webhook{{ID: "wh_winner", URL: url, Events: []string{"investment.confirmed"}}}, nil,
+			"wh_winner", nil, []string{"GET /webhooks", "PATCH /webhooks/wh_winner"}, 1},
+		{"duplicates", []*xrplsale.Webhook{winner, {ID: "wh_dup", URL: url, Events: []string{"investment.confirmed"}}}, nil,
+			"wh_winner", nil, []string{"GET /webhooks"}, 2},
+		{"raced without a conflict", nil, func(srv *xrplsaletest.Server) { srv.AddWebhooks(winner) },
+			"wh_winner", nil, []string{"GET /webhooks", "POST /webhooks", "GET /webhooks", "DELETE /webhooks/wh_00000001"}, 1},
+		{"raced with a conflict", nil, func(srv *xrplsaletest.Server) {
+			srv.AddWebhooks(winner)
+			srv.FailNext(http.MethodPost, "/webhooks", http.StatusConflict)
+		}, "wh_winner", nil, []string{"GET /webhooks", "POST /webhooks", "GET /webhooks"}, 1},
+		{"conflict with nothing listed", nil, func(srv *xrplsaletest.Server) {
+			srv.FailNext(http.MethodPost, "/webhooks", http.StatusConflict)
+		}, "", xrplsale.ErrConflict, []string{"GET /webhooks", "POST /webhooks", "GET /webhooks"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := xrplsaletest.NewServer()
+			defer srv.Close()
+			srv.AddWebhooks(tt.existing...)
+			client := xrplsaletest.NewClient(srv)
+			var mu sync.Mutex
+			var sent []string
+			client.OnRequest(func(_ context.Context, info *xrplsale.RequestInfo) error {
+				if tt.race != nil && info.Method == http.MethodPost {
+					tt.race(srv)
+				}
+				return nil
+			})
+			client.OnResponse(func(_ context.Context, info *xrplsale.ResponseInfo) {
+				mu.Lock()
+				sent = append(sent, info.Method+" "+info.Endpoint)
+				mu.Unlock()
+			})
+			
+			webhook, err := client.Webhooks.EnsureRegistered(context.Background(), req, xrplsale.WithNoRetry())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EnsureRegistered() = %v, want %v", err, tt.wantErr)
+			}
+			if !equalStrings(sent, tt.wantSent) {
+				t.Fatalf("sent %q, want %q", sent, tt.wantSent)
+			}
+			if got := len(srv.Webhooks()); got != tt.wantLeft {
+				t.Fatalf("%d webhooks registered, want %d", got, tt.wantLeft)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if webhook.URL != url || !equalStrings(webhook.Events, req.Events) || (tt.wantID != "" && webhook.ID != tt.wantID) {
+				t.Fatalf("EnsureRegistered() = %+v, want %s", webhook, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestEnsureRegisteredConcurrent(t *testing.T) {
+	const callers, rounds = 10, 20
+	req := xrplsale.RegisterWebhookRequest{URL: "https://a.example.com/hook", Events: []string{"investment.created"}}
+	for round := 0; round < rounds; round++ {
+		// The fake server accepts duplicate URLs, so only listing after
+		// registering keeps the callers from leaving several behind
+		srv := xrplsaletest.NewServer()
+		client := xrplsaletest.NewClient(srv)
+		start := make(chan struct{})
+		ids := make([]string, callers)
+		errs := make([]error, callers)
+		var wg sync.WaitGroup
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				webhook, err := client.Webhooks.EnsureRegistered(context.Background(), req)
+				if err == nil {
+					ids[i] = webhook.ID
+				}
+				errs[i] = err
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+		srv.Close()
+		
+		webhooks := srv.Webhooks()
+		if len(webhooks) != 1 {
+			t.Fatalf("round %d: %d webhooks registered, want 1", round, len(webhooks))
+		}
+		for i := range ids {
+			if errs[i] != nil {
+				t.Fatalf("round %d: caller %d: %v", round, i, errs[i])
+			}
+			if ids[i] != webhooks[0].ID {
+				t.Fatalf("round %d: caller %d got %s, want %s", round, i, ids[i], webhooks[0].ID)
+			}
+		}
+	}
+}
+
+// equalStrings reports whether a and b hold the same strings in order,
+// nil and empty being equal
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
\ No newline at end of file
@@ -0,0 +1,50 @@
+package xrplsale
+
+import (
+	"fmt"
+	"mime"
+)
+
+// WireFormat is the encoding the client asks the API to send responses in
+type WireFormat string
+
+const (
+	// WireFormatJSON is the default
+	WireFormatJSON WireFormat = "json"
+
+	// WireFormatMessagePack asks for MessagePack responses, which decode
+	// with less CPU than JSON. Responses the API still sends as JSON are
+	// decoded as JSON.
+	WireFormatMessagePack WireFormat = "msgpack"
+)
+
+// MessagePackContentType is the media type of MessagePack bodies
+const MessagePackContentType = "application/msgpack"
+
+// acceptHeader returns the Accept header requesting f
+func (f WireFormat) acceptHeader() (string, error) {
+	switch f {
+	case "", WireFormatJSON:
+		return "application/json", nil
+	case WireFormatMessagePack:
+		return MessagePackContentType + ", application/json;q=0.9", nil
+	}
+	return "", fmt.Errorf("unknown wire format %q", string(f))
+}
+
+// isMessagePack reports whether a Content-Type header names MessagePack,
+// under any of the media types in use for it
+func isMessagePack(contentType string) bool {
+	if contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case MessagePackContentType, "application/x-msgpack", "application/vnd.msgpack":
+		return true
+	}
+	return false
+}
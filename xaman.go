@@ -0,0 +1,119 @@
+package xrplsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultXamanPollInterval is how often WaitForXamanSignIn polls by default
+const DefaultXamanPollInterval = 2 * time.Second
+
+// XamanStatus is the state of a Xaman sign-in
+type XamanStatus string
+
+// Xaman sign-in statuses
+const (
+	// XamanPending: the payload was created and is waiting to be scanned
+	XamanPending XamanStatus = "pending"
+	
+	// XamanOpened: the user opened the payload in Xaman but has not answered
+	XamanOpened XamanStatus = "opened"
+	
+	XamanSigned   XamanStatus = "signed"
+	XamanRejected XamanStatus = "rejected"
+	XamanExpired  XamanStatus = "expired"
+)
+
+var (
+	// ErrXamanRejected is returned by WaitForXamanSignIn when the user
+	// declined the sign-in in Xaman
+	ErrXamanRejected = errors.New("xaman sign-in rejected")
+	
+	// ErrXamanExpired is returned by WaitForXamanSignIn when the payload
+	// expired before the user answered
+	ErrXamanExpired = errors.New("xaman sign-in expired")
+)
+
+// XamanSignInRequest is a Xaman sign-in payload to present to the user
+type XamanSignInRequest struct {
+	UUID string `json:"uuid"`
+	
+	// QRURL is an image of the QR code to scan with Xaman
+	QRURL string `json:"qr_url"`
+	
+	// DeeplinkURL opens the payload in Xaman on the user's phone
+	DeeplinkURL string `json:"deeplink_url"`
+	
+	ExpiresAt *Timestamp `json:"expires_at,omitempty"`
+}
+
+// XamanSignInStatus is the state of a Xaman sign-in
+type XamanSignInStatus struct {
+	UUID   string      `json:"uuid"`
+	Status XamanStatus `json:"status"`
+	
+	// WalletAddress is the account that signed; set once Status is XamanSigned
+	WalletAddress string `json:"wallet_address,omitempty"`
+	
+	ExpiresAt *Timestamp `json:"expires_at,omitempty"`
+}
+
+// CreateXamanSignIn starts a sign-in through the Xaman wallet app. Show the
+// QR code or deeplink to the user, then call WaitForXamanSignIn.
+func (as *AuthService) CreateXamanSignIn(ctx context.Context, reqOpts ...RequestOption) (*XamanSignInRequest, error) {
+	var signIn XamanSignInRequest
+	err := as.client.Post(ctx, "/auth/xaman", nil, &signIn, reqOpts...)
+	return &signIn, err
+}
+
+// GetXamanSignInStatus retrieves the state of a Xaman sign-in
+func (as *AuthService) GetXamanSignInStatus(ctx context.Context, uuid string, reqOpts ...RequestOption) (*XamanSignInStatus, error) {
+	var status XamanSignInStatus
+	err := as.client.Get(ctx, fmt.Sprintf("/auth/xaman/%s", uuid), nil, &status, reqOpts...)
+	return &status, err
+}
+
+// CompleteXamanSignIn exchanges a signed Xaman sign-in for tokens, which
+// the client then uses like those of Authenticate
+func (as *AuthService) CompleteXamanSignIn(ctx context.Context, uuid string, reqOpts ...RequestOption) (*AuthResponse, error) {
+	var response AuthResponse
+	err := as.client.Post(ctx, fmt.Sprintf("/auth/xaman/%s/complete", uuid), nil, &response, reqOpts...)
+	if err == nil {
+		err = as.signedIn(ctx, &response)
+	}
+	return &response, err
+}
+
+// WaitForXamanSignIn polls a Xaman sign-in every pollInterval (zero for
+// DefaultXamanPollInterval) until the user answers, then completes it. It
+// returns ErrXamanRejected or ErrXamanExpired when the sign-in cannot
+// succeed, and ctx's error when ctx ends first.
+func (as *AuthService) WaitForXamanSignIn(ctx context.Context, uuid string, pollInterval time.Duration, reqOpts ...RequestOption) (*AuthResponse, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultXamanPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := as.GetXamanSignInStatus(ctx, uuid, reqOpts...)
+		if err != nil {
+			return nil, err
+		}
+		switch status.Status {
+		case XamanSigned:
+			return as.CompleteXamanSignIn(ctx, uuid, reqOpts...)
+		case XamanRejected:
+			return nil, fmt.Errorf("%w: %s", ErrXamanRejected, uuid)
+		case XamanExpired:
+			return nil, fmt.Errorf("%w: %s", ErrXamanExpired, uuid)
+		}
+		
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
\ No newline at end of file
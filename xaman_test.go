@@ -0,0 +1,93 @@
+package xrplsale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestWaitForXamanSignIn(t *testing.T) {
+	const poll = 5 * time.Millisecond
+	tests := []struct {
+		name string
+		// answer is the user's answer in Xaman, given after a few polls
+		answer     func(srv *xrplsaletest.Server, uuid string)
+		uuid       string
+		timeout    time.Duration
+		wantErr    error
+		wantStatus int
+	}{
+		{"signed", func(srv *xrplsaletest.Server, uuid string) { srv.SignXaman(uuid, fixtures.Address()) }, "", 0, nil, 0},
+		{"rejected", func(srv *xrplsaletest.Server, uuid string) { srv.RejectXaman(uuid) }, "", 0, xrplsale.ErrXamanRejected, 0},
+		{"never answered", nil, "", 100 * time.Millisecond, context.DeadlineExceeded, 0},
+		{"unknown sign-in", nil, "xaman_unknown", 0, nil, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, hits := newCountingClient(t)
+			signIn, err := client.Auth.CreateXamanSignIn(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signIn.UUID == "" || signIn.QRURL == "" || signIn.DeeplinkURL == "" {
+				t.Fatalf("CreateXamanSignIn() = %+v, want a UUID, QR code and deeplink", signIn)
+			}
+			uuid := signIn.UUID
+			if tt.uuid != "" {
+				uuid = tt.uuid
+			}
+			if tt.answer != nil {
+				go func() {
+					time.Sleep(5 * poll)
+					tt.answer(srv, uuid)
+				}()
+			}
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			
+			response, err := client.Auth.WaitForXamanSignIn(ctx, uuid, poll)
+			var apiErr *xrplsale.APIError
+			switch {
+			case tt.wantStatus != 0:
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("WaitForXamanSignIn() = %v, want status %d", err, tt.wantStatus)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("WaitForXamanSignIn() = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil || response.Token == "":
+				t.Fatalf("WaitForXamanSignIn() = %+v, %v", response, err)
+			}
+			
+			completed := hits.count(http.MethodPost, "/auth/xaman/"+uuid+"/complete")
+			if signedIn := tt.wantErr == nil && tt.wantStatus == 0; signedIn != (completed == 1) {
+				t.Fatalf("sign-in completed %d times, want it completed %v", completed, signedIn)
+			}
+			if tt.answer != nil && hits.count(http.MethodGet, "/auth/xaman/"+uuid) < 2 {
+				t.Errorf("answer seen without polling")
+			}
+			_, err = client.Auth.GetProfile(context.Background())
+			if signedIn := err == nil; signedIn != (completed == 1) {
+				t.Errorf("GetProfile() after the sign-in = %v", err)
+			}
+			if completed == 0 {
+				return
+			}
+			// A payload signs in once
+			if _, err := client.Auth.CompleteXamanSignIn(context.Background(), uuid); err == nil {
+				t.Error("completing the sign-in again succeeded")
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,883 @@
+package xrplsaletest
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// handler serves a route; params holds the values of its {name} segments
+type handler func(s *Server, w http.ResponseWriter, r *http.Request, params []string)
+
+// route maps a method and path pattern to its handler
+type route struct {
+	method  string
+	pattern string
+	handle  handler
+}
+
+// routes lists every route the server implements
+var routes = []route{
+	{http.MethodGet, "/health", (*Server).health},
+	
+	{http.MethodGet, "/projects", (*Server).listProjects},
+	{http.MethodPost, "/projects", (*Server).createProject},
+	{http.MethodGet, "/projects/{id}", (*Server).getProject},
+	{http.MethodPatch, "/projects/{id}", (*Server).updateProject},
+	{http.MethodPost, "/projects/{id}/launch", (*Server).launchProject},
+	{http.MethodGet, "/projects/{id}/investments", (*Server).listProjectInvestments},
+	{http.MethodGet, "/projects/{id}/stats", (*Server).projectStats},
+	
+	{http.MethodPost, "/investments", (*Server).createInvestment},
+	{http.MethodGet, "/investments/{id}", (*Server).getInvestment},
+	
+	{http.MethodPost, "/auth/challenge", (*Server).authChallenge},
+	{http.MethodPost, "/auth/wallet", (*Server).authWallet},
+	{http.MethodPost, "/auth/refresh", (*Server).authRefresh},
+	{http.MethodPost, "/auth/token", (*Server).authToken},
+	{http.MethodPost, "/auth/logout", (*Server).authLogout},
+	{http.MethodGet, "/auth/profile", (*Server).authProfile},
+	{http.MethodGet, "/auth/session", (*Server).verifySession},
+	{http.MethodPost, "/auth/stream-token", (*Server).createStreamToken},
+	{http.MethodGet, "/auth/sessions", (*Server).listSessions},
+	{http.MethodDelete, "/auth/sessions", (*Server).revokeAllSessions},
+	{http.MethodDelete, "/auth/sessions/{id}", (*Server).revokeSession},
+	{http.MethodPost, "/auth/xaman", (*Server).createXaman},
+	{http.MethodGet, "/auth/xaman/{uuid}", (*Server).getXaman},
+	{http.MethodPost, "/auth/xaman/{uuid}/complete", (*Server).completeXaman},
+	
+	{http.MethodGet, "/webhooks", (*Server).listWebhooks},
+	{http.MethodPost, "/webhooks", (*Server).registerWebhook},
+	{http.MethodGet, "/webhooks/{id}", (*Server).getWebhook},
+	{http.MethodPatch, "/webhooks/{id}", (*Server).updateWebhook},
+	{http.MethodDelete, "/webhooks/{id}", (*Server).deleteWebhook},
+	{http.MethodPost, "/webhooks/{id}/test", (*Server).testWebhook},
+}
+
+// session is a wallet signed in through /auth/wallet, or a service account
+// through /auth/token. A session keeps its id across refreshes.
+type session struct {
+	id            string
+	created       time.Time
+	walletAddress string
+	refreshToken  string
+	expires       time.Time
+}
+
+// xamanPayload is a sign-in started through /auth/xaman
+type xamanPayload struct {
+	status        xrplsale.XamanStatus
+	walletAddress string
+	expires       time.Time
+}
+
+// statusOf describes the payload with the given UUID
+func (p *xamanPayload) statusOf(uuid string) xrplsale.XamanSignInStatus {
+	status := p.status
+	if status != xrplsale.XamanSigned && status != xrplsale.XamanRejected && !time.Now().Before(p.expires) {
+		status = xrplsale.XamanExpired
+	}
+	return xrplsale.XamanSignInStatus{
+		UUID:          uuid,
+		Status:        status,
+		WalletAddress: p.walletAddress,
+		ExpiresAt:     &xrplsale.Timestamp{Time: p.expires},
+	}
+}
+
+// serveHTTP dispatches a request to its route. HEAD is served as GET.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.Contains(r.Header.Get("Accept"), xrplsale.MessagePackContentType) {
+		w = messagePackWriter{w}
+	}
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	if f := s.takeFailure(method, r.URL.Path); f != nil {
+		for key, values := range f.Header {
+			w.Header()[key] = values
+		}
+		writeError(w, f.Status, f.Code, f.Message)
+		return
+	}
+	if r.URL.Path != "/health" && r.Header.Get("X-API-Key") != APIKey {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "invalid API key")
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/auth/") && s.expiredToken(bearerToken(r)) {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "auth token expired")
+		return
+	}
+	
+	known := false
+	for _, rt := range routes {
+		params, ok := match(rt.pattern, r.URL.Path)
+		if !ok {
+			continue
+		}
+		if rt.method == method {
+			rt.handle(s, w, r, params)
+			return
+		}
+		known = true
+	}
+	if known {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", fmt.Sprintf("%s is not allowed on %s", r.Method, r.URL.Path))
+		return
+	}
+	writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request, _ []string) {
+	writeJSON(w, http.StatusOK, xrplsale.PingResult{
+		Status:      "ok",
+		APIVersion:  xrplsale.SupportedAPIVersion,
+		ServerTime:  now(),
+		Environment: "test",
+	})
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, _ []string) {
+	status := xrplsale.ProjectStatus(r.URL.Query().Get("status"))
+	slug := r.URL.Query().Get("slug")
+	query := r.URL.Query().Get("q")
+	s.mu.Lock()
+	var projects []xrplsale.Project
+	for _, project := range s.projects {
+		if (status == "" || project.Status == status) && (slug == "" || project.Slug == slug) {
+			projects = append(projects, *project)
+		}
+	}
+	s.mu.Unlock()
+	if query != "" {
+		projects = search(projects, query)
+	}
+	writePage(w, r, projects, func(p xrplsale.Project) string { return p.ID })
+}
+
+// search keeps the projects whose name, token symbol or description
+// contains query, ignoring case, scored by where it matched and sorted by
+// descending score
+func search(projects []xrplsale.Project, query string) []xrplsale.Project {
+	query = strings.ToLower(query)
+	var matches []xrplsale.Project
+	for _, p := range projects {
+		p.Score = 0
+		if strings.Contains(strings.ToLower(p.Name), query) {
+			p.Score += 3
+		}
+		if strings.Contains(strings.ToLower(p.TokenSymbol), query) {
+			p.Score += 2
+		}
+		if strings.Contains(strings.ToLower(p.Description), query) {
+			p.Score++
+		}
+		if p.Score > 0 {
+			matches = append(matches, p)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, _ []string) {
+	var project xrplsale.Project
+	if !decodeBody(w, r, &project) {
+		return
+	}
+	if project.Name == "" {
+		writeValidationError(w, "name", "is required")
+		return
+	}
+	s.mu.Lock()
+	project.ID = s.newID("proj")
+	project.Status = xrplsale.StatusDraft
+	project.CreatedAt = now()
+	s.projects = append(s.projects, &project)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, project)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := find(s.projects, params[0], func(p *xrplsale.Project) string { return p.ID })
+	if project == nil {
+		writeNotFound(w, "project", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := find(s.projects, params[0], func(p *xrplsale.Project) string { return p.ID })
+	if project == nil {
+		writeNotFound(w, "project", params[0])
+		return
+	}
+	updated := *project
+	if !mergeBody(w, r, &updated) {
+		return
+	}
+	updated.ID = project.ID
+	*project = updated
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) launchProject(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := find(s.projects, params[0], func(p *xrplsale.Project) string { return p.ID })
+	if project == nil {
+		writeNotFound(w, "project", params[0])
+		return
+	}
+	if project.Status != xrplsale.StatusDraft {
+		writeError(w, http.StatusConflict, "conflict", fmt.Sprintf("project %s is %s, not draft", project.ID, project.Status))
+		return
+	}
+	project.Status = xrplsale.StatusActive
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) listProjectInvestments(w http.ResponseWriter, r *http.Request, params []string) {
+	streamed := r.Header.Get("Accept") == xrplsale.NDJSONContentType
+	status := r.URL.Query().Get("status")
+	s.mu.Lock()
+	var investments []xrplsale.Investment
+	for _, investment := range s.investments {
+		if investment.ProjectID == params[0] && (!streamed || status == "" || investment.Status == status) {
+			investments = append(investments, *investment)
+		}
+	}
+	s.mu.Unlock()
+	if streamed {
+		writeNDJSON(w, investments)
+		return
+	}
+	writePage(w, r, investments, func(i xrplsale.Investment) string { return i.ID })
+}
+
+// projectStats totals every investment recorded for a project, whatever
+// its status
+func (s *Server) projectStats(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if find(s.projects, params[0], func(p *xrplsale.Project) string { return p.ID }) == nil {
+		writeNotFound(w, "project", params[0])
+		return
+	}
+	stats := xrplsale.ProjectStats{ProjectID: params[0]}
+	investors := make(map[string]bool)
+	for _, investment := range s.investments {
+		if investment.ProjectID != params[0] {
+			continue
+		}
+		stats.TotalRaisedXRP = stats.TotalRaisedXRP.Add(investment.AmountXRP)
+		stats.TokensSold = stats.TokensSold.Add(investment.TokenAmount)
+		stats.InvestmentCount++
+		investors[investment.InvestorAccount] = true
+	}
+	stats.InvestorCount = len(investors)
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (s *Server) createInvestment(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req xrplsale.CreateInvestmentRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	amount, err := xrplsale.ParseAmount(req.AmountXRP)
+	if err != nil || amount.Sign() <= 0 {
+		writeValidationError(w, "amount_xrp", "must be a positive amount")
+		return
+	}
+	if req.InvestorAccount == "" {
+		writeValidationError(w, "investor_account", "is required")
+		return
+	}
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := find(s.projects, req.ProjectID, func(p *xrplsale.Project) string { return p.ID })
+	if project == nil {
+		writeNotFound(w, "project", req.ProjectID)
+		return
+	}
+	investment := &xrplsale.Investment{
+		ID:              s.newID("inv"),
+		ProjectID:       project.ID,
+		InvestorAccount: req.InvestorAccount,
+		AmountXRP:       amount,
+		Status:          "pending",
+		CreatedAt:       now(),
+	}
+	if len(project.Tiers) > 0 {
+		tier := project.Tiers[0]
+		investment.Tier = tier.Tier
+		investment.TokenAmount = tokensFor(amount, tier.PricePerToken)
+	}
+	s.investments = append(s.investments, investment)
+	writeJSON(w, http.StatusCreated, investment)
+}
+
+func (s *Server) getInvestment(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	investment := find(s.investments, params[0], func(i *xrplsale.Investment) string { return i.ID })
+	if investment == nil {
+		writeNotFound(w, "investment", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, investment)
+}
+
+// authChallenge issues a challenge. Any non-empty signature of it is
+// accepted by /auth/wallet.
+func (s *Server) authChallenge(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req struct {
+		WalletAddress string `json:"wallet_address"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.WalletAddress == "" {
+		writeValidationError(w, "wallet_address", "is required")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"challenge": "Sign in to XRPL.Sale as " + req.WalletAddress,
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+func (s *Server) authWallet(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req struct {
+		WalletAddress string `json:"wallet_address"`
+		Signature     string `json:"signature"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.WalletAddress == "" || req.Signature == "" {
+		writeError(w, http.StatusUnauthorized, "invalid_signature", "wallet_address and signature are required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.signIn(req.WalletAddress, ""))
+}
+
+func (s *Server) authRefresh(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token, sess := range s.sessions {
+		if req.RefreshToken != "" && sess.refreshToken == req.RefreshToken {
+			// Refresh tokens are single use, as in the API
+			delete(s.sessions, token)
+			writeJSON(w, http.StatusOK, s.signIn(sess.walletAddress, sess.id))
+			return
+		}
+	}
+	writeError(w, http.StatusUnauthorized, "invalid_refresh_token", "refresh token is invalid or already used")
+}
+
+// authToken exchanges the credentials of a service account added with
+// AddServiceAccount for an auth token
+func (s *Server) authToken(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req struct {
+		GrantType    string `json:"grant_type"`
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.GrantType != "client_credentials" {
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type", "grant_type must be client_credentials")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secret, ok := s.accounts[req.ClientID]
+	if !ok || req.ClientSecret == "" || req.ClientSecret != secret {
+		writeError(w, http.StatusUnauthorized, "invalid_client", "client_id or client_secret is invalid")
+		return
+	}
+	response := s.signIn(req.ClientID, "")
+	s.sessions[response.Token].refreshToken = ""
+	response.RefreshToken = ""
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) authLogout(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, bearerToken(r))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) authProfile(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.mu.Lock()
+	sess := s.caller(w, r)
+	s.mu.Unlock()
+	if sess == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"wallet_address": sess.walletAddress,
+	})
+}
+
+func (s *Server) createXaman(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payload := &xamanPayload{
+		status:  xrplsale.XamanPending,
+		expires: time.Now().Add(XamanPayloadTTL),
+	}
+	uuid := s.newID("xaman")
+	s.xaman[uuid] = payload
+	writeJSON(w, http.StatusCreated, xrplsale.XamanSignInRequest{
+		UUID:        uuid,
+		QRURL:       s.URL + "/xaman/" + uuid + ".png",
+		DeeplinkURL: "https://xumm.app/sign/" + uuid,
+		ExpiresAt:   &xrplsale.Timestamp{Time: payload.expires},
+	})
+}
+
+func (s *Server) getXaman(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payload := s.xaman[params[0]]
+	if payload == nil {
+		writeNotFound(w, "xaman sign-in", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, payload.statusOf(params[0]))
+}
+
+func (s *Server) completeXaman(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payload := s.xaman[params[0]]
+	if payload == nil {
+		writeNotFound(w, "xaman sign-in", params[0])
+		return
+	}
+	if status := payload.statusOf(params[0]).Status; status != xrplsale.XamanSigned {
+		writeError(w, http.StatusConflict, "xaman_not_signed", fmt.Sprintf("xaman sign-in is %s", status))
+		return
+	}
+	// A payload signs in once
+	delete(s.xaman, params[0])
+	writeJSON(w, http.StatusOK, s.signIn(payload.walletAddress, ""))
+}
+
+func (s *Server) verifySession(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.caller(w, r)
+	if sess == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, xrplsale.SessionStatus{
+		Valid:         true,
+		WalletAddress: sess.walletAddress,
+		SessionID:     sess.id,
+		ExpiresAt:     xrplsale.Timestamp{Time: sess.expires},
+	})
+}
+
+func (s *Server) listSessions(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	caller := s.caller(w, r)
+	if caller == nil {
+		return
+	}
+	sessions := []xrplsale.Session{}
+	for _, sess := range s.sessions {
+		if sess.walletAddress == caller.walletAddress {
+			sessions = append(sessions, xrplsale.Session{
+				ID:         sess.id,
+				CreatedAt:  xrplsale.Timestamp{Time: sess.created},
+				LastSeenAt: xrplsale.Timestamp{Time: sess.created},
+				ExpiresAt:  xrplsale.Timestamp{Time: sess.expires},
+				Current:    sess == caller,
+			})
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID < sessions[j].ID })
+	writeJSON(w, http.StatusOK, map[string]interface{}{"sessions": sessions})
+}
+
+func (s *Server) revokeSession(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	caller := s.caller(w, r)
+	if caller == nil {
+		return
+	}
+	for token, sess := range s.sessions {
+		if sess.id == params[0] && sess.walletAddress == caller.walletAddress {
+			delete(s.sessions, token)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeNotFound(w, "session", params[0])
+}
+
+func (s *Server) revokeAllSessions(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	caller := s.caller(w, r)
+	if caller == nil {
+		return
+	}
+	exceptCurrent := r.URL.Query().Get("except_current") == "true"
+	for token, sess := range s.sessions {
+		if sess.walletAddress == caller.walletAddress && !(exceptCurrent && sess == caller) {
+			delete(s.sessions, token)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// createStreamToken issues a stream token to a signed-in caller. The server
+// has no WebSocket; the URL only points at where it would be.
+func (s *Server) createStreamToken(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.caller(w, r) == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"token":      s.newID("stream"),
+		"url":        "ws" + strings.TrimPrefix(s.URL, "http") + "/stream",
+		"expires_in": int(StreamTokenTTL / time.Second),
+	})
+}
+
+// caller returns the session of the request's auth token, or responds 401
+// and returns nil when it has none. The caller holds s.mu.
+func (s *Server) caller(w http.ResponseWriter, r *http.Request) *session {
+	sess := s.sessions[bearerToken(r)]
+	if sess == nil || !time.Now().Before(sess.expires) {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "missing or expired auth token")
+		return nil
+	}
+	return sess
+}
+
+// expiredToken reports whether token was issued by the server and has
+// expired. The /auth routes check tokens themselves.
+func (s *Server) expiredToken(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.sessions[token]
+	return sess != nil && !time.Now().Before(sess.expires)
+}
+
+// signIn starts a session for walletAddress, or issues new tokens for the
+// session with ID id when it is not empty. The caller holds s.mu.
+func (s *Server) signIn(walletAddress, id string) *xrplsale.AuthResponse {
+	token := s.newID("tok")
+	sess := &session{
+		id:            id,
+		walletAddress: walletAddress,
+		refreshToken:  s.newID("ref"),
+		created:       time.Now(),
+		expires:       time.Now().Add(s.tokenTTL),
+	}
+	if id == "" {
+		sess.id = s.newID("sess")
+	}
+	s.sessions[token] = sess
+	return &xrplsale.AuthResponse{
+		Token:        token,
+		RefreshToken: sess.refreshToken,
+		ExpiresIn:    int((s.tokenTTL + time.Second - 1) / time.Second),
+	}
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.mu.Lock()
+	webhooks := values(s.webhooks)
+	s.mu.Unlock()
+	writePage(w, r, webhooks, func(wh xrplsale.Webhook) string { return wh.ID })
+}
+
+func (s *Server) registerWebhook(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req xrplsale.RegisterWebhookRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.URL == "" {
+		writeValidationError(w, "url", "is required")
+		return
+	}
+	if len(req.Events) == 0 {
+		writeValidationError(w, "events", "must not be empty")
+		return
+	}
+	s.mu.Lock()
+	webhook := &xrplsale.Webhook{
+		ID:          s.newID("wh"),
+		URL:         req.URL,
+		Events:      req.Events,
+		Description: req.Description,
+		Active:      true,
+		CreatedAt:   now(),
+	}
+	s.webhooks = append(s.webhooks, webhook)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, webhook)
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	webhook := find(s.webhooks, params[0], func(wh *xrplsale.Webhook) string { return wh.ID })
+	if webhook == nil {
+		writeNotFound(w, "webhook", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	webhook := find(s.webhooks, params[0], func(wh *xrplsale.Webhook) string { return wh.ID })
+	if webhook == nil {
+		writeNotFound(w, "webhook", params[0])
+		return
+	}
+	updated := *webhook
+	if !mergeBody(w, r, &updated) {
+		return
+	}
+	updated.ID = webhook.ID
+	*webhook = updated
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, webhook := range s.webhooks {
+		if webhook.ID == params[0] {
+			s.webhooks = append(s.webhooks[:i], s.webhooks[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeNotFound(w, "webhook", params[0])
+}
+
+func (s *Server) testWebhook(w http.ResponseWriter, r *http.Request, params []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if find(s.webhooks, params[0], func(wh *xrplsale.Webhook) string { return wh.ID }) == nil {
+		writeNotFound(w, "webhook", params[0])
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// find returns the record with id, or nil
+func find[T any](items []*T, id string, idOf func(*T) string) *T {
+	for _, item := range items {
+		if idOf(item) == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// writePage writes the page of items selected by the page and limit query
+// parameters. With sort_by=id, items are sorted by ID and after_id skips
+// those up to the cursor, as for Iterator's WithOrderByID.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T, idOf func(T) string) {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultPageSize
+	}
+	
+	if query.Get("sort_by") == "id" {
+		sort.SliceStable(items, func(i, j int) bool { return idOf(items[i]) < idOf(items[j]) })
+		if after := query.Get("after_id"); after != "" {
+			n := sort.Search(len(items), func(i int) bool { return idOf(items[i]) > after })
+			items = items[n:]
+		}
+	}
+	
+	resp := xrplsale.PaginatedResponse[T]{
+		Data: []T{},
+		Pagination: xrplsale.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      len(items),
+			TotalPages: (len(items) + limit - 1) / limit,
+		},
+	}
+	if start := (page - 1) * limit; start < len(items) {
+		resp.Data = items[start:min(start+limit, len(items))]
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// writeNDJSON writes items as a streamed NDJSON response, one per line
+func writeNDJSON[T any](w http.ResponseWriter, items []T) {
+	w.Header().Set("Content-Type", xrplsale.NDJSONContentType)
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if enc.Encode(item) != nil {
+			return
+		}
+	}
+}
+
+// tokensFor returns the tokens amount buys at price, rounded to 6 decimals
+func tokensFor(amount, price xrplsale.Amount) xrplsale.Amount {
+	if price.Sign() <= 0 {
+		return xrplsale.Amount{}
+	}
+	tokens, _ := xrplsale.ParseAmount(new(big.Rat).Quo(amount.Rat(), price.Rat()).FloatString(6))
+	return tokens
+}
+
+// now returns the current time as the API reports it
+func now() xrplsale.Timestamp {
+	return xrplsale.Timestamp{Time: time.Now().UTC().Truncate(time.Second)}
+}
+
+// bearerToken returns the auth token of a request
+func bearerToken(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// readBody reads a request body, decompressing it if the client gzipped it
+func readBody(r *http.Request) ([]byte, error) {
+	body := io.Reader(r.Body)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		body = zr
+	}
+	return io.ReadAll(body)
+}
+
+// decodeBody decodes a JSON request body into v, writing a 400 and
+// reporting false if it is malformed
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, err := readBody(r)
+	if err == nil {
+		err = json.Unmarshal(body, v)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", "malformed JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// mergeBody applies the fields of a JSON request body to v, keeping the
+// fields it does not mention
+func mergeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	current, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+		return false
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(current, &fields); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+		return false
+	}
+	if !decodeBody(w, r, &fields) {
+		return false
+	}
+	merged, err := json.Marshal(fields)
+	if err == nil {
+		err = json.Unmarshal(merged, v)
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", err.Error())
+		return false
+	}
+	return true
+}
+
+// messagePackWriter marks the response to a client that asked for MessagePack
+type messagePackWriter struct {
+	http.ResponseWriter
+}
+
+// writeJSON writes v as a JSON response, or as MessagePack to a client that
+// asked for it
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	if _, ok := w.(messagePackWriter); ok {
+		body, err := xrplsale.MarshalMessagePack(v)
+		if err == nil {
+			w.Header().Set("Content-Type", xrplsale.MessagePackContentType)
+			w.WriteHeader(status)
+			w.Write(body)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an API error response
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, xrplsale.APIError{
+		Code:    code,
+		Message: message,
+	})
+}
+
+// writeNotFound writes a 404 for a missing record
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("%s %s not found", kind, id))
+}
+
+// writeValidationError writes a 422 for an invalid request field
+func writeValidationError(w http.ResponseWriter, field, message string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"message": "validation failed",
+		"code":    "validation_error",
+		"errors":  []xrplsale.FieldError{{Field: field, Message: message}},
+	})
+}
\ No newline at end of file
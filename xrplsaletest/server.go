@@ -0,0 +1,310 @@
+// Package xrplsaletest provides an in-memory fake of the XRPL.Sale API for
+// tests of code built on the SDK. The fake serves the main project,
+// investment, auth and webhook routes from in-memory stores, encodes
+// responses with the SDK's own types, as MessagePack to clients asking for
+// it, and can be told to fail any route.
+//
+//	srv := xrplsaletest.NewServer()
+//	defer srv.Close()
+//	srv.AddProjects(fixtures.Project())
+//	client := xrplsaletest.NewClient(srv)
+package xrplsaletest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	xrplsale "github.com/xrplsale/go-sdk"
+)
+
+// APIKey is the only API key the server accepts
+const APIKey = "xrplsaletest_key"
+
+// DefaultTokenTTL is the lifetime of the auth tokens the server issues
+const DefaultTokenTTL = time.Hour
+
+// StreamTokenTTL is the lifetime of the stream tokens the server issues
+const StreamTokenTTL = 5 * time.Minute
+
+// XamanPayloadTTL is how long a Xaman sign-in waits for the user
+const XamanPayloadTTL = 5 * time.Minute
+
+// DefaultPageSize is the page size of list routes called without a limit
+const DefaultPageSize = 20
+
+// Failure is an error response injected for a route
+type Failure struct {
+	// Status is the HTTP status to respond with
+	Status int
+	
+	// Code and Message fill the API error body; Message defaults to the
+	// status text
+	Code    string
+	Message string
+	
+	// Header is added to the response, e.g. Retry-After for a 429
+	Header http.Header
+	
+	// Times is the number of requests to fail; zero fails every request
+	// until ClearFailures is called
+	Times int
+}
+
+// injectedFailure is a Failure waiting for a matching request
+type injectedFailure struct {
+	method  string
+	pattern string
+	Failure
+}
+
+// Server is a fake XRPL.Sale API. Its methods are safe for concurrent use
+// with the requests it serves.
+type Server struct {
+	*httptest.Server
+	
+	mu          sync.Mutex
+	nextID      int
+	projects    []*xrplsale.Project
+	investments []*xrplsale.Investment
+	webhooks    []*xrplsale.Webhook
+	sessions    map[string]*session
+	xaman       map[string]*xamanPayload
+	accounts    map[string]string
+	tokenTTL    time.Duration
+	failures    []*injectedFailure
+}
+
+// NewServer starts a server with empty stores. The caller must Close it.
+func NewServer() *Server {
+	s := &Server{
+		sessions: make(map[string]*session),
+		xaman:    make(map[string]*xamanPayload),
+		accounts: make(map[string]string),
+		tokenTTL: DefaultTokenTTL,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewClient returns a client for srv, authenticated with APIKey. Retries
+// wait a millisecond instead of a second; configure may change any other
+// setting before the client is created.
+func NewClient(srv *Server, configure ...func(*xrplsale.Config)) *xrplsale.Client {
+	config := &xrplsale.Config{
+		APIKey:        APIKey,
+		BaseURL:       srv.URL,
+		RetryWaitTime: time.Millisecond,
+	}
+	for _, fn := range configure {
+		fn(config)
+	}
+	return xrplsale.NewClientWithConfig(config)
+}
+
+// AddProjects seeds projects, e.g. from the fixtures package. Projects
+// without an ID are assigned one.
+func (s *Server) AddProjects(projects ...*xrplsale.Project) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, project := range projects {
+		p := *project
+		if p.ID == "" {
+			p.ID = s.newID("proj")
+		}
+		s.projects = append(s.projects, &p)
+	}
+}
+
+// AddInvestments seeds investments, e.g. from fixtures.Investments.
+// Investments without an ID are assigned one; their projects need not exist.
+func (s *Server) AddInvestments(investments ...xrplsale.Investment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, investment := range investments {
+		inv := investment
+		if inv.ID == "" {
+			inv.ID = s.newID("inv")
+		}
+		s.investments = append(s.investments, &inv)
+	}
+}
+
+// AddWebhooks seeds webhooks. Webhooks without an ID are assigned one.
+func (s *Server) AddWebhooks(webhooks ...*xrplsale.Webhook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, webhook := range webhooks {
+		wh := *webhook
+		if wh.ID == "" {
+			wh.ID = s.newID("wh")
+		}
+		s.webhooks = append(s.webhooks, &wh)
+	}
+}
+
+// Projects returns a copy of every stored project, in creation order
+func (s *Server) Projects() []xrplsale.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return values(s.projects)
+}
+
+// Investments returns a copy of every stored investment, in creation order
+func (s *Server) Investments() []xrplsale.Investment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return values(s.investments)
+}
+
+// Webhooks returns a copy of every stored webhook, in creation order
+func (s *Server) Webhooks() []xrplsale.Webhook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return values(s.webhooks)
+}
+
+// Fail makes requests matching method and pattern fail with f. Patterns are
+// paths whose {name} segments match any value, e.g. "/projects/{id}"; the
+// method "*" matches any method. Failures are checked before
+// authentication, in the order they were added.
+func (s *Server) Fail(method, pattern string, f Failure) {
+	if f.Message == "" {
+		f.Message = http.StatusText(f.Status)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &injectedFailure{
+		method:  strings.ToUpper(method),
+		pattern: pattern,
+		Failure: f,
+	})
+}
+
+// FailNext makes the next request matching method and pattern fail with
+// status
+func (s *Server) FailNext(method, pattern string, status int) {
+	s.Fail(method, pattern, Failure{Status: status, Times: 1})
+}
+
+// SetTokenTTL sets the lifetime of auth tokens issued from now on, e.g. a
+// few seconds to test token refresh. Expired tokens are rejected with 401.
+func (s *Server) SetTokenTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenTTL = ttl
+}
+
+// ExpireTokens makes every auth token issued so far expire, so requests
+// with them get 401, e.g. to test re-authentication
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sess := range s.sessions {
+		sess.expires = time.Now()
+	}
+}
+
+// AddServiceAccount registers a service account that /auth/token issues
+// tokens to. Its tokens come without a refresh token.
+func (s *Server) AddServiceAccount(clientID, clientSecret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts[clientID] = clientSecret
+}
+
+// SignXaman answers the Xaman sign-in with the given UUID as if the user
+// had signed it with walletAddress. It reports whether the sign-in exists.
+func (s *Server) SignXaman(uuid, walletAddress string) bool {
+	return s.answerXaman(uuid, xrplsale.XamanSigned, walletAddress)
+}
+
+// RejectXaman answers the Xaman sign-in with the given UUID as if the user
+// had declined it. It reports whether the sign-in exists.
+func (s *Server) RejectXaman(uuid string) bool {
+	return s.answerXaman(uuid, xrplsale.XamanRejected, "")
+}
+
+// answerXaman sets the status of a Xaman sign-in
+func (s *Server) answerXaman(uuid string, status xrplsale.XamanStatus, walletAddress string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payload := s.xaman[uuid]
+	if payload == nil {
+		return false
+	}
+	payload.status, payload.walletAddress = status, walletAddress
+	return true
+}
+
+// ClearFailures removes every injected failure
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = nil
+}
+
+// takeFailure returns the first failure injected for a request, using up
+// one of its Times
+func (s *Server) takeFailure(method, path string) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.failures {
+		if f.method != "*" && f.method != method {
+			continue
+		}
+		if _, ok := match(f.pattern, path); !ok {
+			continue
+		}
+		failure := f.Failure
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+		return &failure
+	}
+	return nil
+}
+
+// newID returns a new ID with prefix. IDs sort in creation order.
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s_%08d", prefix, s.nextID)
+}
+
+// values copies the records of a store
+func values[T any](items []*T) []T {
+	out := make([]T, len(items))
+	for i, item := range items {
+		out[i] = *item
+	}
+	return out
+}
+
+// match reports whether path matches pattern, returning the values of its
+// {name} segments in order
+func match(pattern, path string) ([]string, bool) {
+	want := strings.Split(strings.Trim(pattern, "/"), "/")
+	got := strings.Split(strings.Trim(path, "/"), "/")
+	if len(want) != len(got) {
+		return nil, false
+	}
+	var params []string
+	for i, segment := range want {
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			if got[i] == "" {
+				return nil, false
+			}
+			params = append(params, got[i])
+		case segment != got[i]:
+			return nil, false
+		}
+	}
+	return params, true
+}
\ No newline at end of file
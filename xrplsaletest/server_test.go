@@ -0,0 +1,32 @@
+package xrplsaletest_test
+
+import (
+	"testing"
+
+	"github.com/xrplsale/go-sdk/fixtures"
+	"github.com/xrplsale/go-sdk/xrplsaletest"
+)
+
+func TestAddInvestmentsKeepsEach(t *testing.T) {
+	srv := xrplsaletest.NewServer()
+	defer srv.Close()
+	project := fixtures.Project()
+	seeded := fixtures.Investments(project, 3)
+	seeded[2].ID = ""
+	srv.AddInvestments(seeded...)
+	
+	got := srv.Investments()
+	if len(got) != 3 {
+		t.Fatalf("Investments() holds %d investments, want 3", len(got))
+	}
+	seen := map[string]bool{}
+	for i, inv := range got {
+		if inv.ID == "" || seen[inv.ID] {
+			t.Fatalf("investment %d has ID %q, want a distinct one", i, inv.ID)
+		}
+		seen[inv.ID] = true
+		if i < 2 && inv.ID != seeded[i].ID {
+			t.Errorf("investment %d = %s, want %s", i, inv.ID, seeded[i].ID)
+		}
+	}
+}
\ No newline at end of file